@@ -0,0 +1,40 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// WatchActionProgress returns a watcher for the progress messages
+// logged by the action with the given id. Each change is a json
+// encoded params.ActionMessage; the first holds all messages logged
+// so far.
+func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("WatchActionProgress")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewActionTag(actionId).String()}},
+	}
+	err := c.facade.FacadeCall("WatchActionsProgress", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
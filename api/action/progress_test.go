@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type progressSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&progressSuite{})
+
+func (s *progressSuite) TestWatchActionProgressError(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Action")
+		c.Check(request, gc.Equals, "WatchActionsProgress")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "action-f47ac10b-58cc-4372-a567-0e02b2c3d479"}},
+		})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: `action "f47ac10b-58cc-4372-a567-0e02b2c3d479" not found`},
+			}},
+		}
+		return nil
+	}, 4)
+	_, err := client.WatchActionProgress("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	c.Assert(err, gc.ErrorMatches, `action "f47ac10b-58cc-4372-a567-0e02b2c3d479" not found`)
+}
+
+func (s *progressSuite) TestWatchActionProgressNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	}, 3)
+	_, err := client.WatchActionProgress("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,30 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddActionRollouts starts running actions across the units of
+// applications, returning each rollout or an error.
+func (c *Client) AddActionRollouts(arg params.AddActionRollouts) (params.ActionRolloutResults, error) {
+	return c.actionRollouts("AddActionRollouts", arg)
+}
+
+// ActionRollouts returns the progress of the given action rollouts.
+func (c *Client) ActionRollouts(arg params.ActionRolloutIds) (params.ActionRolloutResults, error) {
+	return c.actionRollouts("ActionRollouts", arg)
+}
+
+func (c *Client) actionRollouts(method string, arg interface{}) (params.ActionRolloutResults, error) {
+	results := params.ActionRolloutResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action rollouts")
+	}
+	err := c.facade.FacadeCall(method, arg, &results)
+	return results, err
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type rolloutSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&rolloutSuite{})
+
+func (s *rolloutSuite) TestAddActionRollouts(c *gc.C) {
+	args := params.AddActionRollouts{Rollouts: []params.AddActionRollout{{
+		Application: "application-mysql",
+		Name:        "backup",
+		BatchSize:   2,
+	}}}
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Action")
+		c.Check(request, gc.Equals, "AddActionRollouts")
+		c.Check(arg, jc.DeepEquals, args)
+		*(result.(*params.ActionRolloutResults)) = params.ActionRolloutResults{
+			Results: []params.ActionRolloutResult{{Rollout: &params.ActionRollout{Id: "0"}}},
+		}
+		return nil
+	}, 4)
+	results, err := client.AddActionRollouts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Rollout.Id, gc.Equals, "0")
+}
+
+func (s *rolloutSuite) TestActionRollouts(c *gc.C) {
+	args := params.ActionRolloutIds{Ids: []string{"0"}}
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ActionRollouts")
+		c.Check(arg, jc.DeepEquals, args)
+		*(result.(*params.ActionRolloutResults)) = params.ActionRolloutResults{
+			Results: []params.ActionRolloutResult{{Rollout: &params.ActionRollout{Id: "0", Status: "running"}}},
+		}
+		return nil
+	}, 4)
+	results, err := client.ActionRollouts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Rollout.Status, gc.Equals, "running")
+}
+
+func (s *rolloutSuite) TestActionRolloutsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	}, 3)
+	_, err := client.ActionRollouts(params.ActionRolloutIds{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddActionSchedules adds schedules on which the controller enqueues
+// actions, at a future time or repeatedly, returning the added
+// schedule or an error for each.
+func (c *Client) AddActionSchedules(arg params.AddActionSchedules) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action schedules")
+	}
+	err := c.facade.FacadeCall("AddActionSchedules", arg, &results)
+	return results, err
+}
+
+// ListActionSchedules returns all the model's action schedules.
+func (c *Client) ListActionSchedules() (params.ActionSchedules, error) {
+	result := params.ActionSchedules{}
+	if c.BestAPIVersion() < 4 {
+		return result, errors.NotSupportedf("action schedules")
+	}
+	err := c.facade.FacadeCall("ListActionSchedules", nil, &result)
+	return result, err
+}
+
+// PauseActionSchedules stops the given schedules enqueueing actions
+// until they are resumed.
+func (c *Client) PauseActionSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	return c.updateActionSchedules("PauseActionSchedules", arg)
+}
+
+// ResumeActionSchedules restarts the given paused schedules.
+func (c *Client) ResumeActionSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	return c.updateActionSchedules("ResumeActionSchedules", arg)
+}
+
+// RemoveActionSchedules removes the given schedules.
+func (c *Client) RemoveActionSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	return c.updateActionSchedules("RemoveActionSchedules", arg)
+}
+
+func (c *Client) updateActionSchedules(method string, arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action schedules")
+	}
+	err := c.facade.FacadeCall(method, arg, &results)
+	return results, err
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type scheduleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func newClient(f basetesting.APICallerFunc, version int) *action.Client {
+	return action.NewClient(basetesting.BestVersionCaller{APICallerFunc: f, BestVersion: version})
+}
+
+func (s *scheduleSuite) TestAddActionSchedules(c *gc.C) {
+	args := params.AddActionSchedules{Schedules: []params.AddActionSchedule{{
+		Receiver: "application-mysql",
+		Name:     "backup",
+		Schedule: "@daily",
+	}}}
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Action")
+		c.Check(request, gc.Equals, "AddActionSchedules")
+		c.Check(arg, jc.DeepEquals, args)
+		*(result.(*params.ActionScheduleResults)) = params.ActionScheduleResults{
+			Results: []params.ActionScheduleResult{{Schedule: &params.ActionSchedule{Id: "0"}}},
+		}
+		return nil
+	}, 4)
+	results, err := client.AddActionSchedules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Schedule.Id, gc.Equals, "0")
+}
+
+func (s *scheduleSuite) TestListActionSchedules(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ListActionSchedules")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ActionSchedules)) = params.ActionSchedules{
+			Schedules: []params.ActionSchedule{{Id: "0"}, {Id: "1"}},
+		}
+		return nil
+	}, 4)
+	result, err := client.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Schedules, gc.HasLen, 2)
+}
+
+func (s *scheduleSuite) TestUpdateActionSchedules(c *gc.C) {
+	var calls []string
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		calls = append(calls, request)
+		c.Check(arg, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"0"}})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+		return nil
+	}, 4)
+	ids := params.ActionScheduleIds{Ids: []string{"0"}}
+	_, err := client.PauseActionSchedules(ids)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.ResumeActionSchedules(ids)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.RemoveActionSchedules(ids)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(calls, jc.DeepEquals, []string{
+		"PauseActionSchedules", "ResumeActionSchedules", "RemoveActionSchedules",
+	})
+}
+
+func (s *scheduleSuite) TestNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	}, 3)
+	_, err := client.AddActionSchedules(params.AddActionSchedules{})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ListActionSchedules()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.RemoveActionSchedules(params.ActionScheduleIds{})
+	c.Check(err, gc.ErrorMatches, "action schedules not supported")
+}
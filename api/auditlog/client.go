@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides the client for the AuditLog facade, used
+// to query the controller's audit log.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the audit log API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the audit log api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Conversations returns the audited conversations matching the query,
+// oldest first.
+func (c *Client) Conversations(query params.AuditLogQuery) ([]params.AuditConversation, error) {
+	var result params.AuditConversationsResult
+	if err := c.facade.FacadeCall("Conversations", query, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Conversations, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) TestConversations(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Conversations")
+			c.Check(a, jc.DeepEquals, params.AuditLogQuery{
+				Who:        "bob",
+				ErrorsOnly: true,
+			})
+			if results, ok := result.(*params.AuditConversationsResult); ok {
+				results.Conversations = []params.AuditConversation{{
+					ConversationID: "abc",
+					Who:            "bob",
+				}}
+			}
+			return nil
+		})
+
+	client := auditlog.NewClient(apiCaller)
+	conversations, err := client.Conversations(params.AuditLogQuery{
+		Who:        "bob",
+		ErrorsOnly: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, jc.DeepEquals, []params.AuditConversation{{
+		ConversationID: "abc",
+		Who:            "bob",
+	}})
+}
+
+func (s *AuditLogSuite) TestConversationsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("facade failure")
+		})
+
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Conversations(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "facade failure")
+}
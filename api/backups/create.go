@@ -11,13 +11,16 @@ import (
 
 // Create sends a request to create a backup of juju's state.  It
 // returns the metadata associated with the resulting backup and a
-// filename for download.
-func (c *Client) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+// filename for download. If encryption is not nil, the backup
+// archive is encrypted with it; this needs Backups facade version 3,
+// as earlier versions ignore it.
+func (c *Client) Create(notes string, keepCopy, noDownload bool, encryption *params.BackupsEncryption) (*params.BackupsMetadataResult, error) {
 	var result params.BackupsMetadataResult
 	args := params.BackupsCreateArgs{
 		Notes:      notes,
 		KeepCopy:   keepCopy,
 		NoDownload: noDownload,
+		Encryption: encryption,
 	}
 
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
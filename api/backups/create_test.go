@@ -29,6 +29,7 @@ func (s *createSuite) TestCreate(c *gc.C) {
 			c.Check(p.Notes, gc.Equals, "important")
 			c.Check(p.KeepCopy, jc.IsFalse)
 			c.Check(p.NoDownload, jc.IsFalse)
+			c.Check(p.Encryption, gc.IsNil)
 
 			if result, ok := resp.(*params.BackupsMetadataResult); ok {
 				*result = apiserverbackups.CreateResult(s.Meta, "test-filename")
@@ -41,9 +42,29 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	)
 	defer cleanup()
 
-	result, err := s.client.Create("important", false, false)
+	result, err := s.client.Create("important", false, false, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Log(result)
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateEncrypted(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			p := paramsIn.(params.BackupsCreateArgs)
+			c.Check(p.Encryption, jc.DeepEquals, &params.BackupsEncryption{Passphrase: "secret"})
+
+			result := resp.(*params.BackupsMetadataResult)
+			*result = apiserverbackups.CreateResult(s.Meta, "test-filename")
+			result.Encrypted = true
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.Create("", false, false, &params.BackupsEncryption{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Encrypted, jc.IsTrue)
+}
@@ -85,6 +85,7 @@ func (args DebugLogParams) URLQuery() url.Values {
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
+	ModelUUID string
 	Entity    string
 	Timestamp time.Time
 	Severity  string
@@ -122,6 +123,7 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				return
 			}
 			messages <- LogMessage{
+				ModelUUID: msg.ModelUUID,
 				Entity:    msg.Entity,
 				Timestamp: msg.Timestamp,
 				Severity:  msg.Severity,
@@ -13,6 +13,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -73,6 +75,29 @@ func (e *ModelWatcher) LogForwardConfig() (*syslog.RawConfig, bool, error) {
 	return cfg, ok, nil
 }
 
+// HTTPLogForwardConfig returns the current HTTP log forward configuration.
+func (e *ModelWatcher) HTTPLogForwardConfig() (*httpjson.RawConfig, bool, error) {
+	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
+	// For now, we'll piggyback off the ModelConfig API.
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	cfg, ok := modelConfig.LogFwdHTTP()
+	return cfg, ok, nil
+}
+
+// LogForwardFilter returns the filter applied to forwarded log records.
+func (e *ModelWatcher) LogForwardFilter() (logfwd.Filter, error) {
+	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
+	// For now, we'll piggyback off the ModelConfig API.
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return logfwd.Filter{}, err
+	}
+	return modelConfig.LogFwdFilter(), nil
+}
+
 // UpdateStatusHookInterval returns the current update status hook interval.
 func (e *ModelWatcher) UpdateStatusHookInterval() (time.Duration, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
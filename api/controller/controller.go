@@ -301,24 +301,10 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 		return "", errors.Annotatef(err, "client-side validation failed")
 	}
 
-	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	args, err := migrationArgs(spec)
 	if err != nil {
 		return "", errors.Annotatef(err, "client-side validation failed")
 	}
-
-	args := params.InitiateMigrationArgs{
-		Specs: []params.MigrationSpec{{
-			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
-			TargetInfo: params.MigrationTargetInfo{
-				ControllerTag: names.NewControllerTag(spec.TargetControllerUUID).String(),
-				Addrs:         spec.TargetAddrs,
-				CACert:        spec.TargetCACert,
-				AuthTag:       names.NewUserTag(spec.TargetUser).String(),
-				Password:      spec.TargetPassword,
-				Macaroons:     macsJSON,
-			},
-		}},
-	}
 	response := params.InitiateMigrationResults{}
 	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
 		return "", errors.Trace(err)
@@ -333,6 +319,207 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 	return result.MigrationId, nil
 }
 
+// MigrationBatchResult reports the result of starting the migration of
+// a model in a batch.
+type MigrationBatchResult struct {
+	// ModelUUID identifies the model.
+	ModelUUID string
+
+	// MigrationId identifies the migration started for the model.
+	// It is empty if the migration has been queued.
+	MigrationId string
+
+	// Error is set if the model's migration couldn't be started.
+	Error error
+}
+
+// InitiateMigrations starts the migrations of the specified models as a
+// batch, with at most maxConcurrent in progress at once. The controller
+// starts the queued migrations as earlier ones finish. The specs must
+// share the same target controller, and no migrations are started
+// unless every model passes the prechecks. The batch's ID is returned,
+// along with a result for each spec, in the same order.
+func (c *Client) InitiateMigrations(specs []MigrationSpec, maxConcurrent int) (string, []MigrationBatchResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return "", nil, errors.Errorf("this controller version doesn't support batch migrations")
+	}
+	args := params.InitiateMigrationArgs{MaxConcurrent: maxConcurrent}
+	for _, spec := range specs {
+		if err := spec.Validate(); err != nil {
+			return "", nil, errors.Annotatef(err, "client-side validation failed")
+		}
+		specArgs, err := migrationArgs(spec)
+		if err != nil {
+			return "", nil, errors.Annotatef(err, "client-side validation failed")
+		}
+		args.Specs = append(args.Specs, specArgs.Specs...)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if len(response.Results) != len(specs) {
+		return "", nil, errors.New("unexpected number of results returned")
+	}
+	results := make([]MigrationBatchResult, len(specs))
+	for i, result := range response.Results {
+		results[i] = MigrationBatchResult{
+			ModelUUID:   specs[i].ModelUUID,
+			MigrationId: result.MigrationId,
+		}
+		if result.Error != nil {
+			results[i].Error = result.Error
+		}
+	}
+	return response.BatchId, results, nil
+}
+
+// MigrationBatchStatus reports the models in a migration batch whose
+// migrations haven't been started yet, and those whose migrations
+// couldn't be started.
+type MigrationBatchStatus struct {
+	// Queued holds the UUIDs of the models whose migrations haven't
+	// been started yet.
+	Queued []string
+
+	// Failed holds the reasons the migrations of some models
+	// couldn't be started, keyed by model UUID.
+	Failed map[string]error
+}
+
+// MigrationBatchStatus returns the status of the identified migration
+// batch.
+func (c *Client) MigrationBatchStatus(batchID string) (MigrationBatchStatus, error) {
+	var empty MigrationBatchStatus
+	if c.BestAPIVersion() < 8 {
+		return empty, errors.Errorf("this controller version doesn't support batch migrations")
+	}
+	var result params.MigrationBatchStatus
+	args := params.MigrationBatchArg{BatchId: batchID}
+	if err := c.facade.FacadeCall("MigrationBatchStatus", args, &result); err != nil {
+		return empty, errors.Trace(err)
+	}
+	status := MigrationBatchStatus{Failed: make(map[string]error)}
+	for _, tag := range result.Queued {
+		modelTag, err := names.ParseModelTag(tag)
+		if err != nil {
+			return empty, errors.Trace(err)
+		}
+		status.Queued = append(status.Queued, modelTag.Id())
+	}
+	for _, failed := range result.Failed {
+		modelTag, err := names.ParseModelTag(failed.ModelTag)
+		if err != nil {
+			return empty, errors.Trace(err)
+		}
+		if failed.Error != nil {
+			status.Failed[modelTag.Id()] = failed.Error
+		}
+	}
+	return status, nil
+}
+
+// MigrationDryRunResult reports what a migration of a model would run
+// into, and what it would upload to the target controller.
+type MigrationDryRunResult struct {
+	// ModelUUID identifies the model checked.
+	ModelUUID string
+
+	// Error is set if the checks couldn't be run for the model.
+	Error error
+
+	// SourceProblems holds the problems found by the prechecks on
+	// the model and the source controller.
+	SourceProblems []string
+
+	// TargetProblems holds the problems found by the prechecks on
+	// the target controller, and by a trial import of the model
+	// there.
+	TargetProblems []string
+
+	// Charms, Tools and Resources hold the binaries the migration
+	// would upload.
+	Charms    []string
+	Tools     []string
+	Resources []string
+}
+
+// DryRunMigration checks whether the specified model could be
+// migrated, without starting a migration. Every problem found is
+// reported, rather than just the first.
+func (c *Client) DryRunMigration(spec MigrationSpec) (MigrationDryRunResult, error) {
+	results, err := c.DryRunMigrations([]MigrationSpec{spec})
+	if err != nil {
+		return MigrationDryRunResult{}, errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return MigrationDryRunResult{}, errors.Trace(results[0].Error)
+	}
+	return results[0], nil
+}
+
+// DryRunMigrations checks whether the specified models could be
+// migrated together, without starting any migrations. A result is
+// returned for each spec, in the same order.
+func (c *Client) DryRunMigrations(specs []MigrationSpec) ([]MigrationDryRunResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.Errorf("this controller version doesn't support migration dry runs")
+	}
+	var args params.InitiateMigrationArgs
+	for _, spec := range specs {
+		if err := spec.Validate(); err != nil {
+			return nil, errors.Annotatef(err, "client-side validation failed")
+		}
+		specArgs, err := migrationArgs(spec)
+		if err != nil {
+			return nil, errors.Annotatef(err, "client-side validation failed")
+		}
+		args.Specs = append(args.Specs, specArgs.Specs...)
+	}
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("DryRunMigration", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != len(specs) {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	results := make([]MigrationDryRunResult, len(specs))
+	for i, result := range response.Results {
+		results[i] = MigrationDryRunResult{
+			ModelUUID:      specs[i].ModelUUID,
+			SourceProblems: result.SourceProblems,
+			TargetProblems: result.TargetProblems,
+			Charms:         result.Charms,
+			Tools:          result.Tools,
+			Resources:      result.Resources,
+		}
+		if result.Error != nil {
+			results[i].Error = result.Error
+		}
+	}
+	return results, nil
+}
+
+func migrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
+	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	if err != nil {
+		return params.InitiateMigrationArgs{}, errors.Trace(err)
+	}
+	return params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: names.NewControllerTag(spec.TargetControllerUUID).String(),
+				Addrs:         spec.TargetAddrs,
+				CACert:        spec.TargetCACert,
+				AuthTag:       names.NewUserTag(spec.TargetUser).String(),
+				Password:      spec.TargetPassword,
+				Macaroons:     macsJSON,
+			},
+		}},
+	}, nil
+}
+
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
 	if len(macs) == 0 {
 		return "", nil
@@ -170,6 +170,163 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func makeDryRunMigrationClient(results params.MigrationDryRunResults, bestVersion int) (
+	*controller.Client, *jujutesting.Stub,
+) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationDryRunResults)
+			*out = results
+			return nil
+		},
+		BestVersion: bestVersion,
+	}
+	client := controller.NewClient(apiCaller)
+	return client, &stub
+}
+
+func (s *Suite) TestDryRunMigration(c *gc.C) {
+	client, stub := makeDryRunMigrationClient(params.MigrationDryRunResults{
+		Results: []params.MigrationDryRunResult{{
+			SourceProblems: []string{"machine 0 is dying"},
+			Charms:         []string{"cs:xenial/mysql-1"},
+			Tools:          []string{"2.5.0-xenial-amd64"},
+			Resources:      []string{"mysql/data"},
+		}},
+	}, 8)
+	spec := makeSpec()
+	result, err := client.DryRunMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, controller.MigrationDryRunResult{
+		ModelUUID:      spec.ModelUUID,
+		SourceProblems: []string{"machine 0 is dying"},
+		Charms:         []string{"cs:xenial/mysql-1"},
+		Tools:          []string{"2.5.0-xenial-amd64"},
+		Resources:      []string{"mysql/data"},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.DryRunMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestDryRunMigrationError(c *gc.C) {
+	client, _ := makeDryRunMigrationClient(params.MigrationDryRunResults{
+		Results: []params.MigrationDryRunResult{{
+			Error: common.ServerError(errors.New("boom")),
+		}},
+	}, 8)
+	_, err := client.DryRunMigration(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestDryRunMigrations(c *gc.C) {
+	client, stub := makeDryRunMigrationClient(params.MigrationDryRunResults{
+		Results: []params.MigrationDryRunResult{{
+			TargetProblems: []string{"model named \"foo\" already exists"},
+		}, {
+			Error: common.ServerError(errors.New("boom")),
+		}},
+	}, 8)
+	spec1 := makeSpec()
+	spec2 := makeSpec()
+	results, err := client.DryRunMigrations([]controller.MigrationSpec{spec1, spec2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results[0], jc.DeepEquals, controller.MigrationDryRunResult{
+		ModelUUID:      spec1.ModelUUID,
+		TargetProblems: []string{"model named \"foo\" already exists"},
+	})
+	c.Check(results[1].ModelUUID, gc.Equals, spec2.ModelUUID)
+	c.Check(results[1].Error, gc.ErrorMatches, "boom")
+
+	args1, args2 := specToArgs(spec1), specToArgs(spec2)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.DryRunMigration", []interface{}{params.InitiateMigrationArgs{
+			Specs: append(args1.Specs, args2.Specs...),
+		}}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrations(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.InitiateMigrationResults)) = params.InitiateMigrationResults{
+				BatchId: "1",
+				Results: []params.InitiateMigrationResult{{
+					MigrationId: "id",
+				}, {}},
+			}
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := controller.NewClient(apiCaller)
+	spec1 := makeSpec()
+	spec2 := makeSpec()
+	batchID, results, err := client.InitiateMigrations([]controller.MigrationSpec{spec1, spec2}, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(batchID, gc.Equals, "1")
+	c.Check(results, jc.DeepEquals, []controller.MigrationBatchResult{
+		{ModelUUID: spec1.ModelUUID, MigrationId: "id"},
+		{ModelUUID: spec2.ModelUUID},
+	})
+
+	args1, args2 := specToArgs(spec1), specToArgs(spec2)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{params.InitiateMigrationArgs{
+			Specs:         append(args1.Specs, args2.Specs...),
+			MaxConcurrent: 1,
+		}}},
+	})
+}
+
+func (s *Suite) TestMigrationBatchStatus(c *gc.C) {
+	modelUUID1 := randomUUID()
+	modelUUID2 := randomUUID()
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.MigrationBatchStatus)) = params.MigrationBatchStatus{
+				Queued: []string{names.NewModelTag(modelUUID1).String()},
+				Failed: []params.InitiateMigrationResult{{
+					ModelTag: names.NewModelTag(modelUUID2).String(),
+					Error:    common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := controller.NewClient(apiCaller)
+	status, err := client.MigrationBatchStatus("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Queued, jc.DeepEquals, []string{modelUUID1})
+	c.Assert(status.Failed, gc.HasLen, 1)
+	c.Check(status.Failed[modelUUID2], gc.ErrorMatches, "boom")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationBatchStatus", []interface{}{params.MigrationBatchArg{BatchId: "1"}}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrationsNotSupported(c *gc.C) {
+	client, stub := makeDryRunMigrationClient(params.MigrationDryRunResults{}, 7)
+	_, _, err := client.InitiateMigrations([]controller.MigrationSpec{makeSpec()}, 1)
+	c.Check(err, gc.ErrorMatches, "this controller version doesn't support batch migrations")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestDryRunMigrationNotSupported(c *gc.C) {
+	client, stub := makeDryRunMigrationClient(params.MigrationDryRunResults{}, 7)
+	_, err := client.DryRunMigration(makeSpec())
+	c.Check(err, gc.ErrorMatches, "this controller version doesn't support migration dry runs")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
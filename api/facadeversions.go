@@ -11,7 +11,7 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       3,
+	"Action":                       4,
 	"ActionPruner":                 1,
 	"Agent":                        2,
 	"AgentTools":                   1,
@@ -21,7 +21,8 @@ var facadeVersions = map[string]int{
 	"Application":                  9,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
-	"Backups":                      2,
+	"AuditLog":                     1,
+	"Backups":                      3,
 	"Block":                        2,
 	"Bundle":                       2,
 	"CAASAgent":                    1,
@@ -34,7 +35,7 @@ var facadeVersions = map[string]int{
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        3,
-	"Controller":                   7,
+	"Controller":                   8,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
@@ -68,10 +69,10 @@ var facadeVersions = map[string]int{
 	"MetricsDebug":                 2,
 	"MetricsManager":               1,
 	"MigrationFlag":                1,
-	"MigrationMaster":              1,
+	"MigrationMaster":              2,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
 	"ModelConfig":                  2,
 	"ModelGeneration":              1,
 	"ModelManager":                 5,
@@ -101,7 +102,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       9,
+	"Uniter":                       10,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
 	"UserManager":                  2,
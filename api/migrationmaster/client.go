@@ -211,6 +211,17 @@ func (c *Client) Reap() error {
 	return c.caller.FacadeCall("Reap", nil, nil)
 }
 
+// StartQueuedMigrations starts the queued migrations in the batch the
+// finished migration was part of, if any. Controllers which don't
+// support the call can't have migration batches, so there's nothing to
+// start.
+func (c *Client) StartQueuedMigrations() error {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil
+	}
+	return c.caller.FacadeCall("StartQueuedMigrations", nil, nil)
+}
+
 // WatchMinionReports returns a watcher which reports when a migration
 // minion has made a report for the current migration phase.
 func (c *Client) WatchMinionReports() (watcher.NotifyWatcher, error) {
@@ -394,6 +394,36 @@ func (s *ClientSuite) TestReap(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestStartQueuedMigrations(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.StartQueuedMigrations()
+	c.Check(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.StartQueuedMigrations", []interface{}{"", nil}},
+	})
+}
+
+func (s *ClientSuite) TestStartQueuedMigrationsV1(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatal("unexpected call")
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.StartQueuedMigrations()
+	c.Check(err, jc.ErrorIsNil)
+}
+
 func (s *ClientSuite) TestReapError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("blam")
@@ -43,14 +43,37 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
+	args := migrationModelInfo(model)
+	return c.caller.FacadeCall("Prechecks", args, nil)
+}
+
+// DryRun asks the target controller whether the model could be
+// migrated to it, without changing anything. It returns every problem
+// the target controller found, including any with importing the
+// serialized model.
+func (c *Client) DryRun(model coremigration.ModelInfo, bytes []byte) ([]string, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("migration dry run by target controller")
+	}
+	args := params.MigrationDryRunArgs{
+		Model: migrationModelInfo(model),
+		Bytes: bytes,
+	}
+	var result params.MigrationDryRunProblems
+	if err := c.caller.FacadeCall("DryRun", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Problems, nil
+}
+
+func migrationModelInfo(model coremigration.ModelInfo) params.MigrationModelInfo {
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
 	}
-	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
 // Import takes a serialized model and imports it into the target
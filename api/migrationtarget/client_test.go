@@ -77,6 +77,54 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			*(result.(*params.MigrationDryRunProblems)) = params.MigrationDryRunProblems{
+				Problems: []string{"machine 0 is dying"},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	problems, err := client.DryRun(coremigration.ModelInfo{
+		UUID:                   "uuid",
+		Owner:                  ownerTag,
+		Name:                   "name",
+		AgentVersion:           vers,
+		ControllerAgentVersion: vers,
+	}, []byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(problems, jc.DeepEquals, []string{"machine 0 is dying"})
+
+	expectedArg := params.MigrationDryRunArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   "uuid",
+			Name:                   "name",
+			OwnerTag:               ownerTag.String(),
+			AgentVersion:           vers,
+			ControllerAgentVersion: vers,
+		},
+		Bytes: []byte("foo"),
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.DryRun", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestDryRunNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.DryRun(coremigration.ModelInfo{}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
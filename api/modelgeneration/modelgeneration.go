@@ -4,6 +4,8 @@
 package modelgeneration
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -103,6 +105,57 @@ func (c *Client) HasNextGeneration(modelUUID string) (bool, error) {
 	return result.Result, nil
 }
 
+// DiffGeneration returns how each application with changes in the
+// model's "next" generation differs from the "current" generation.
+func (c *Client) DiffGeneration(modelUUID string) ([]params.GenerationApplicationDiff, error) {
+	var result params.GenerationDiffResult
+	err := c.facade.FacadeCall("DiffGeneration", argForModel(modelUUID), &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Applications, nil
+}
+
+// PromoteGeneration asks the controller to move units into the
+// model's "next" generation in batches of the given percentage of each
+// application's units, waiting the soak time between batches, and to
+// make the generation current once every unit is in it. The
+// generation is cancelled if a unit in it becomes unhealthy.
+func (c *Client) PromoteGeneration(modelUUID string, percent int, soakTime time.Duration, requireActive bool) error {
+	arg := params.PromoteGenerationArg{
+		Model:         argForModel(modelUUID),
+		Percent:       percent,
+		SoakTime:      soakTime,
+		RequireActive: requireActive,
+	}
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("PromoteGeneration", arg, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
+// GenerationPromotion returns the automatic promotion of the model's
+// "next" generation, or of its most recently completed generation.
+func (c *Client) GenerationPromotion(modelUUID string) (params.GenerationPromotion, error) {
+	var result params.GenerationPromotionResult
+	err := c.facade.FacadeCall("GenerationPromotion", argForModel(modelUUID), &result)
+	if err != nil {
+		return params.GenerationPromotion{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.GenerationPromotion{}, errors.Trace(result.Error)
+	}
+	return *result.Result, nil
+}
+
 func argForModel(modelUUID string) params.Entity {
 	return params.Entity{Tag: names.NewModelTag(modelUUID).String()}
 }
@@ -4,6 +4,8 @@
 package modelgeneration_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	jc "github.com/juju/testing/checkers"
 	"github.com/pkg/errors"
@@ -14,6 +16,7 @@ import (
 	"github.com/juju/juju/api/modelgeneration"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 )
 
 type modelGenerationSuite struct {
@@ -168,3 +171,104 @@ func (s *modelGenerationSuite) TestHasNextGeneration(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 	c.Check(has, jc.IsTrue)
 }
+
+func (s *modelGenerationSuite) TestDiffGeneration(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	diff := []params.GenerationApplicationDiff{{
+		Application:     "mysql",
+		CurrentCharmURL: "cs:mysql-1",
+		Config: []params.GenerationConfigChange{
+			{Key: "tuning", Current: "safest", Next: "fast"},
+		},
+		Units: map[string]model.GenerationVersion{"mysql/0": model.GenerationNext},
+	}}
+	resultSource := params.GenerationDiffResult{Applications: diff}
+	arg := params.Entity{Tag: s.tag.String()}
+
+	s.fCaller.EXPECT().FacadeCall("DiffGeneration", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	result, err := api.DiffGeneration(s.tag.Id())
+	c.Assert(err, gc.IsNil)
+	c.Check(result, jc.DeepEquals, diff)
+}
+
+func (s *modelGenerationSuite) TestDiffGenerationError(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.GenerationDiffResult{Error: common.ServerError(errors.New("diff go boom"))}
+	arg := params.Entity{Tag: s.tag.String()}
+
+	s.fCaller.EXPECT().FacadeCall("DiffGeneration", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	_, err := api.DiffGeneration(s.tag.Id())
+	c.Assert(err, gc.ErrorMatches, "diff go boom")
+}
+
+func (s *modelGenerationSuite) TestPromoteGeneration(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.ErrorResult{}
+	arg := params.PromoteGenerationArg{
+		Model:         params.Entity{Tag: s.tag.String()},
+		Percent:       25,
+		SoakTime:      5 * time.Minute,
+		RequireActive: true,
+	}
+
+	s.fCaller.EXPECT().FacadeCall("PromoteGeneration", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.PromoteGeneration(s.tag.Id(), 25, 5*time.Minute, true)
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *modelGenerationSuite) TestPromoteGenerationError(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.ErrorResult{Error: common.ServerError(errors.New("promote go boom"))}
+	arg := params.PromoteGenerationArg{
+		Model:   params.Entity{Tag: s.tag.String()},
+		Percent: 50,
+	}
+
+	s.fCaller.EXPECT().FacadeCall("PromoteGeneration", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.PromoteGeneration(s.tag.Id(), 50, 0, false)
+	c.Assert(err, gc.ErrorMatches, "promote go boom")
+}
+
+func (s *modelGenerationSuite) TestGenerationPromotion(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	promotion := params.GenerationPromotion{
+		Percent:  50,
+		SoakTime: time.Minute,
+		Status:   "running",
+	}
+	resultSource := params.GenerationPromotionResult{Result: &promotion}
+	arg := params.Entity{Tag: s.tag.String()}
+
+	s.fCaller.EXPECT().FacadeCall("GenerationPromotion", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	result, err := api.GenerationPromotion(s.tag.Id())
+	c.Assert(err, gc.IsNil)
+	c.Check(result, jc.DeepEquals, promotion)
+}
+
+func (s *modelGenerationSuite) TestGenerationPromotionError(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.GenerationPromotionResult{Error: common.ServerError(errors.New("show go boom"))}
+	arg := params.Entity{Tag: s.tag.String()}
+
+	s.fCaller.EXPECT().FacadeCall("GenerationPromotion", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	_, err := api.GenerationPromotion(s.tag.Id())
+	c.Assert(err, gc.ErrorMatches, "show go boom")
+}
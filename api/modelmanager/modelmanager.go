@@ -316,6 +316,34 @@ func (c *Client) DumpModel(model names.ModelTag, simplified bool) (map[string]in
 		return c.dumpModelV2(model)
 	}
 
+	serialized, err := c.dumpModel(model, simplified)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Parse back into a map.
+	var asMap map[string]interface{}
+	err = yaml.Unmarshal([]byte(serialized), &asMap)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return asMap, nil
+}
+
+// ExportModel returns the full serialized description of the model,
+// as it would be sent to the target controller by a migration.
+func (c *Client) ExportModel(model names.ModelTag) ([]byte, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return nil, errors.NotSupportedf("exporting models with ModelManager v%d", bestVer)
+	}
+	serialized, err := c.dumpModel(model, false)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []byte(serialized), nil
+}
+
+func (c *Client) dumpModel(model names.ModelTag, simplified bool) (string, error) {
 	var results params.StringResults
 	entities := params.DumpModelRequest{
 		Entities:   []params.Entity{{Tag: model.String()}},
@@ -324,23 +352,16 @@ func (c *Client) DumpModel(model names.ModelTag, simplified bool) (map[string]in
 
 	err := c.facade.FacadeCall("DumpModels", entities, &results)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return "", errors.Trace(err)
 	}
 	if count := len(results.Results); count != 1 {
-		return nil, errors.Errorf("unexpected result count: %d", count)
+		return "", errors.Errorf("unexpected result count: %d", count)
 	}
 	result := results.Results[0]
 	if result.Error != nil {
-		return nil, result.Error
+		return "", result.Error
 	}
-	// Parse back into a map.
-	var asMap map[string]interface{}
-	err = yaml.Unmarshal([]byte(result.Result), &asMap)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	return asMap, nil
+	return result.Result, nil
 }
 
 func (c *Client) dumpModelV2(model names.ModelTag) (map[string]interface{}, error) {
@@ -299,6 +299,33 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// LogActionMessage records a progress message for a running action.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if st.facade.BestAPIVersion() < 10 {
+		return errors.NotImplementedf("LogActionMessage() (need V10+)")
+	}
+	var outcome params.ErrorResults
+
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: tag.String(), Value: message},
+		},
+	}
+
+	err := st.facade.FacadeCall("LogActionsMessages", args, &outcome)
+	if err != nil {
+		return err
+	}
+	if len(outcome.Results) != 1 {
+		return fmt.Errorf("expected 1 result, got %d", len(outcome.Results))
+	}
+	result := outcome.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
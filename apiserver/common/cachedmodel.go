@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/cache"
+)
+
+// CachedModel returns the facade context's model from the controller's
+// model cache, if the named feature is enabled in the controller config.
+// A nil model is returned if the feature is not enabled, or if the model
+// is not yet in the cache, so callers should fall back to the database.
+func CachedModel(ctx facade.Context, featureFlag string) (*cache.Model, error) {
+	if ctx.Controller() == nil {
+		return nil, nil
+	}
+	st := ctx.State()
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !controllerConfig.Features().Contains(featureFlag) {
+		return nil, nil
+	}
+	model, err := ctx.Controller().Model(st.ModelUUID())
+	if errors.IsNotFound(err) {
+		logger.Debugf("model %q not yet in the cache", st.ModelUUID())
+		return nil, nil
+	}
+	return model, errors.Trace(err)
+}
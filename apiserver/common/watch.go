@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
@@ -21,6 +22,7 @@ import (
 // various facades.
 type AgentEntityWatcher struct {
 	st          state.EntityFinder
+	model       *cache.Model
 	resources   facade.Resources
 	getCanWatch GetAuthFunc
 }
@@ -36,7 +38,47 @@ func NewAgentEntityWatcher(st state.EntityFinder, resources facade.Resources, ge
 	}
 }
 
+// NewCachedAgentEntityWatcher returns a new AgentEntityWatcher that
+// watches machines, units and applications using the model cache.
+// Entities that are not in the cache, or if the model is nil, are
+// watched using the database.
+func NewCachedAgentEntityWatcher(st state.EntityFinder, model *cache.Model, resources facade.Resources, getCanWatch GetAuthFunc) *AgentEntityWatcher {
+	return &AgentEntityWatcher{
+		st:          st,
+		model:       model,
+		resources:   resources,
+		getCanWatch: getCanWatch,
+	}
+}
+
+// watchCachedEntity returns a watcher for the entity from the model
+// cache. A NotFound error is returned if the entity is not cached.
+func (a *AgentEntityWatcher) watchCachedEntity(tag names.Tag) (cache.NotifyWatcher, error) {
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		return a.model.WatchMachine(tag.Id())
+	case names.UnitTag:
+		return a.model.WatchUnit(tag.Id())
+	case names.ApplicationTag:
+		return a.model.WatchApplication(tag.Id())
+	}
+	return nil, errors.NotFoundf("cached %s", tag)
+}
+
 func (a *AgentEntityWatcher) watchEntity(tag names.Tag) (string, error) {
+	if a.model != nil {
+		watch, err := a.watchCachedEntity(tag)
+		if err == nil {
+			// Consume the initial event, as below.
+			if _, ok := <-watch.Changes(); ok {
+				return a.resources.Register(watch), nil
+			}
+			return "", errors.Errorf("cache watcher for %s closed", tag)
+		}
+		if !errors.IsNotFound(err) {
+			return "", errors.Trace(err)
+		}
+	}
 	entity0, err := a.st.FindEntity(tag)
 	if err != nil {
 		return "", err
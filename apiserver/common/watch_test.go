@@ -5,16 +5,20 @@ package common_test
 
 import (
 	"fmt"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type agentEntityWatcherSuite struct{}
@@ -62,6 +66,60 @@ func (*agentEntityWatcherSuite) TestWatch(c *gc.C) {
 	})
 }
 
+func (*agentEntityWatcherSuite) TestWatchCached(c *gc.C) {
+	changes := make(chan interface{})
+	processed := make(chan interface{})
+	controller, err := cache.NewController(cache.ControllerConfig{
+		Changes: changes,
+		Notify:  func(change interface{}) { processed <- change },
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, controller)
+	for _, change := range []interface{}{
+		cache.ModelChange{ModelUUID: "model-uuid", Name: "model"},
+		cache.UnitChange{ModelUUID: "model-uuid", Name: "x/1", Application: "x"},
+	} {
+		changes <- change
+		select {
+		case <-processed:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("change %#v not processed", change)
+		}
+	}
+	model, err := controller.Model("model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Unit x/1 is served from the cache; x/2 is not cached,
+	// so falls back to the database.
+	st := &fakeState{
+		entities: map[names.Tag]entityWithError{
+			u("x/2"): &fakeAgentEntityWatcher{},
+		},
+	}
+	getCanWatch := func() (common.AuthFunc, error) {
+		return func(tag names.Tag) bool {
+			return tag != u("x/3")
+		}, nil
+	}
+	resources := common.NewResources()
+	defer resources.StopAll()
+	a := common.NewCachedAgentEntityWatcher(st, model, resources, getCanWatch)
+	result, err := a.Watch(params.Entities{[]params.Entity{
+		{"unit-x-1"}, {"unit-x-2"}, {"unit-x-3"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{"1", nil},
+			{"2", nil},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(resources.Count(), gc.Equals, 2)
+	_, ok := resources.Get("1").(cache.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+}
+
 func (*agentEntityWatcherSuite) TestWatchError(c *gc.C) {
 	getCanWatch := func() (common.AuthFunc, error) {
 		return nil, fmt.Errorf("pow")
@@ -78,6 +78,7 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 
 func formatLogRecord(r *state.LogRecord) *params.LogMessage {
 	return &params.LogMessage{
+		ModelUUID: r.ModelUUID,
 		Entity:    r.Entity.String(),
 		Timestamp: r.Time,
 		Severity:  r.Level.String(),
@@ -128,6 +128,27 @@ func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestFormatLogRecord(c *gc.C) {
+	rec := &state.LogRecord{
+		Time:      time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Entity:    names.NewMachineTag("99"),
+		Module:    "some.where",
+		Location:  "code.go:42",
+		Level:     loggo.INFO,
+		Message:   "stuff happened",
+	}
+	c.Assert(formatLogRecord(rec), jc.DeepEquals, &params.LogMessage{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Entity:    "machine-99",
+		Timestamp: time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		Severity:  "INFO",
+		Module:    "some.where",
+		Location:  "code.go:42",
+		Message:   "stuff happened",
+	})
+}
+
 func (s *debugLogDBIntSuite) TestRequestStopsWhenTailerStops(c *gc.C) {
 	tailer := newFakeLogTailer()
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
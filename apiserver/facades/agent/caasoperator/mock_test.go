@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/caasoperator"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	_ "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
@@ -131,6 +132,7 @@ type mockApplication struct {
 	unitsWatcher *statetesting.MockStringsWatcher
 	appChanges   chan struct{}
 	watcher      *statetesting.MockNotifyWatcher
+	config       application.ConfigAttributes
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -178,6 +180,14 @@ func (a *mockApplication) AllUnits() ([]caasoperator.Unit, error) {
 	return []caasoperator.Unit{&mockUnit{}}, nil
 }
 
+func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
+	a.MethodCall(a, "ApplicationConfig")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.config, nil
+}
+
 func (a *mockApplication) AgentTools() (*tools.Tools, error) {
 	return nil, errors.NotImplementedf("AgentTools")
 }
@@ -199,10 +199,15 @@ func (f *Facade) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResults,
 			results.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		if _, err := caasProvider.ParsePodSpec(arg.Value); err != nil {
+		spec, err := caasProvider.ParsePodSpec(arg.Value)
+		if err != nil {
 			results.Results[i].Error = common.ServerError(errors.New("invalid pod spec"))
 			continue
 		}
+		if err := f.checkPodSpecTrust(tag, spec); err != nil {
+			results.Results[i].Error = common.ServerError(errors.Annotate(err, "invalid pod spec"))
+			continue
+		}
 		results.Results[i].Error = common.ServerError(
 			f.model.SetPodSpec(tag, arg.Value),
 		)
@@ -210,6 +215,20 @@ func (f *Facade) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResults,
 	return results, nil
 }
 
+// checkPodSpecTrust returns an error if the pod spec asks for
+// privileges the application has not been trusted with.
+func (f *Facade) checkPodSpecTrust(tag names.ApplicationTag, spec *caas.PodSpec) error {
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return spec.ValidateTrust(config.GetBool(caas.JujuTrustKey, false))
+}
+
 // WatchUnits starts a StringsWatcher to watch changes to the
 // lifecycle states of units for the specified applications in
 // this model.
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/caasoperator"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/status"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -257,11 +258,38 @@ containers:
 		}},
 	})
 
-	s.st.CheckCallNames(c, "Model")
+	s.st.CheckCallNames(c, "Model", "Application", "Application")
 	s.st.model.CheckCallNames(c, "SetPodSpec", "SetPodSpec")
 	s.st.model.CheckCall(c, 0, "SetPodSpec", names.NewApplicationTag("gitlab"), validSpecStr)
 }
 
+func (s *CAASOperatorSuite) TestSetPodSpecGlobalServiceAccountRequiresTrust(c *gc.C) {
+	specStr := `
+serviceAccount:
+  global: true
+  rules:
+    - resources: ["pods"]
+      verbs: ["get"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	args := params.SetPodSpecParams{
+		Specs: []params.EntityString{{Tag: "application-gitlab", Value: specStr}},
+	}
+	results, err := s.facade.SetPodSpec(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "invalid pod spec: global service account for application without trust not valid")
+	s.st.model.CheckNoCalls(c)
+
+	s.st.app.config = application.ConfigAttributes{"trust": true}
+	results, err = s.facade.SetPodSpec(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.st.model.CheckCall(c, 0, "SetPodSpec", names.NewApplicationTag("gitlab"), specStr)
+}
+
 func (s *CAASOperatorSuite) TestModel(c *gc.C) {
 	result, err := s.facade.CurrentModel()
 	c.Assert(err, jc.ErrorIsNil)
@@ -14,6 +14,8 @@ import (
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 )
@@ -35,8 +37,23 @@ type MachinerAPI struct {
 	getCanRead   common.GetAuthFunc
 }
 
+// NewFacade provides the signature required for facade registration.
+// Machine watchers are served from the model cache when the controller
+// has the cached-watchers feature enabled.
+func NewFacade(ctx facade.Context) (*MachinerAPI, error) {
+	model, err := common.CachedModel(ctx, feature.CachedWatchers)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newMachinerAPI(ctx.State(), model, ctx.Resources(), ctx.Auth())
+}
+
 // NewMachinerAPI creates a new instance of the Machiner API.
 func NewMachinerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*MachinerAPI, error) {
+	return newMachinerAPI(st, nil, resources, authorizer)
+}
+
+func newMachinerAPI(st *state.State, model *cache.Model, resources facade.Resources, authorizer facade.Authorizer) (*MachinerAPI, error) {
 	if !authorizer.AuthMachineAgent() {
 		return nil, common.ErrPerm
 	}
@@ -50,7 +67,7 @@ func NewMachinerAPI(st *state.State, resources facade.Resources, authorizer faca
 		LifeGetter:         common.NewLifeGetter(st, getCanRead),
 		StatusSetter:       common.NewStatusSetter(st, getCanModify),
 		DeadEnsurer:        common.NewDeadEnsurer(st, getCanModify),
-		AgentEntityWatcher: common.NewAgentEntityWatcher(st, resources, getCanRead),
+		AgentEntityWatcher: common.NewCachedAgentEntityWatcher(st, model, resources, getCanRead),
 		APIAddresser:       common.NewAPIAddresser(st, resources),
 		NetworkConfigAPI:   networkingcommon.NewNetworkConfigAPI(st, state.CallContext(st), getCanModify),
 		st:                 st,
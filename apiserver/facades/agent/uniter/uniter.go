@@ -24,9 +24,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -60,6 +62,10 @@ type UniterAPI struct {
 	accessMachine     common.GetAuthFunc
 	*StorageAPI
 
+	// cachedModel is used to serve relation watchers when the
+	// cached-watchers feature is enabled; it is nil otherwise.
+	cachedModel *cache.Model
+
 	// A cloud spec can only be accessed for the model of the unit or
 	// application that is authorised for this API facade.
 	// We do not need to use an AuthFunc, because we do not need to pass a tag.
@@ -67,11 +73,17 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV9 adds WatchConfigSettingsHash, WatchTrustConfigSettingsHash
+// and WatchUnitAddressesHash.
+type UniterAPIV9 struct {
+	UniterAPI
+}
+
 // UniterAPIV8 adds SetContainerSpec, GoalStates, CloudSpec,
 // WatchTrustConfigSettings, WatchActionNotifications,
 // UpgradeSeriesStatus, SetUpgradeSeriesStatus.
 type UniterAPIV8 struct {
-	UniterAPI
+	UniterAPIV9
 }
 
 // UniterAPIV7 adds CMR support to NetworkInfo.
@@ -217,6 +229,10 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	cachedModel, err := common.CachedModel(context, feature.CachedWatchers)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	storageAccessor, err := getStorageState(st)
 	storageAPI, err := newStorageAPI(
@@ -238,7 +254,7 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	return &UniterAPI{
 		LifeGetter:                 common.NewLifeGetter(st, accessUnitOrApplication),
 		DeadEnsurer:                common.NewDeadEnsurer(st, accessUnit),
-		AgentEntityWatcher:         common.NewAgentEntityWatcher(st, resources, accessUnitOrApplication),
+		AgentEntityWatcher:         common.NewCachedAgentEntityWatcher(st, cachedModel, resources, accessUnitOrApplication),
 		APIAddresser:               common.NewAPIAddresser(st, resources),
 		ModelWatcher:               common.NewModelWatcher(m, resources, authorizer),
 		RebootRequester:            common.NewRebootRequester(st, accessMachine),
@@ -261,17 +277,29 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 		accessCloudSpec:   accessCloudSpec,
 		cloudSpec:         cloudSpec,
 		StorageAPI:        storageAPI,
+		cachedModel:       cachedModel,
+	}, nil
+}
+
+// NewUniterAPIV9 creates an instance of the V9 uniter API.
+func NewUniterAPIV9(context facade.Context) (*UniterAPIV9, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV9{
+		UniterAPI: *uniterAPI,
 	}, nil
 }
 
 // NewUniterAPIV8 creates an instance of the V8 uniter API.
 func NewUniterAPIV8(context facade.Context) (*UniterAPIV8, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV9(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV8{
-		UniterAPI: *uniterAPI,
+		UniterAPIV9: *uniterAPI,
 	}, nil
 }
 
@@ -1144,6 +1172,23 @@ func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.Er
 	return common.FinishActions(args, actionFn), nil
 }
 
+// LogActionsMessages records the progress messages logged by running
+// actions.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -2181,6 +2226,31 @@ func (u *UniterAPI) WatchUnitRelations(args params.Entities) (params.StringsWatc
 	return result, nil
 }
 
+// watchCachedApplicationRelations watches the application's relations
+// using the model cache. False is returned if the cache is not in use,
+// or the application is not yet cached, in which case the database
+// should be watched instead.
+func (u *UniterAPI) watchCachedApplicationRelations(appName string) (params.StringsWatchResult, bool, error) {
+	nothing := params.StringsWatchResult{}
+	if u.cachedModel == nil {
+		return nothing, false, nil
+	}
+	if _, err := u.cachedModel.Application(appName); errors.IsNotFound(err) {
+		return nothing, false, nil
+	} else if err != nil {
+		return nothing, true, errors.Trace(err)
+	}
+	watch := u.cachedModel.WatchApplicationRelations(appName)
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: u.resources.Register(watch),
+			Changes:          changes,
+		}, true, nil
+	}
+	return nothing, true, errors.Errorf("cache watcher for application %q relations closed", appName)
+}
+
 func (u *UniterAPI) watchOneUnitRelations(tag names.UnitTag) (params.StringsWatchResult, error) {
 	nothing := params.StringsWatchResult{}
 	unit, err := u.getUnit(tag)
@@ -2206,6 +2276,8 @@ func (u *UniterAPI) watchOneUnitRelations(tag names.UnitTag) (params.StringsWatc
 		if err != nil {
 			return nothing, errors.Trace(err)
 		}
+	} else if result, ok, err := u.watchCachedApplicationRelations(app.Name()); ok {
+		return result, errors.Trace(err)
 	} else {
 		watch = app.WatchRelations()
 	}
@@ -2412,6 +2484,9 @@ func (u *UniterAPIV4) watchOneApplicationRelations(tag names.ApplicationTag) (pa
 	if err != nil {
 		return nothing, err
 	}
+	if result, ok, err := u.watchCachedApplicationRelations(application.Name()); ok {
+		return result, errors.Trace(err)
+	}
 	watch := application.WatchRelations()
 	// Consume the initial event and forward it to the result.
 	if changes, ok := <-watch.Changes(); ok {
@@ -2502,7 +2577,12 @@ func (u *UniterAPI) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResult
 			results.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		if _, err := cassProvider.ParsePodSpec(arg.Value); err != nil {
+		spec, err := cassProvider.ParsePodSpec(arg.Value)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(errors.Annotate(err, "invalid pod spec"))
+			continue
+		}
+		if err := u.checkPodSpecTrust(tag, spec); err != nil {
 			results.Results[i].Error = common.ServerError(errors.Annotate(err, "invalid pod spec"))
 			continue
 		}
@@ -2518,6 +2598,20 @@ func (u *UniterAPI) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResult
 	return results, nil
 }
 
+// checkPodSpecTrust returns an error if the pod spec asks for
+// privileges the application has not been trusted with.
+func (u *UniterAPI) checkPodSpecTrust(tag names.ApplicationTag, spec *caas.PodSpec) error {
+	app, err := u.st.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return spec.ValidateTrust(config.GetBool(application.TrustConfigOptionName, false))
+}
+
 // CloudSpec returns the cloud spec used by the model in which the
 // authenticated unit or application resides.
 // A check is made beforehand to ensure that the request is made by an entity
@@ -2767,6 +2861,13 @@ func (u *UniterAPIV8) WatchTrustConfigSettingsHash(_, _ struct{}) {}
 // WatchUnitAddressesHash isn't on the v8 API.
 func (u *UniterAPIV8) WatchUnitAddressesHash(_, _ struct{}) {}
 
+// Mask LogActionsMessages from the v9 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// LogActionsMessages isn't on the v9 API.
+func (u *UniterAPIV9) LogActionsMessages(_, _ struct{}) {}
+
 func (u *UniterAPI) watchHashes(args params.Entities, getWatcher func(u *state.Unit) (state.StringsWatcher, error)) (params.StringsWatchResults, error) {
 	result := params.StringsWatchResults{
 		Results: make([]params.StringsWatchResult, len(args.Entities)),
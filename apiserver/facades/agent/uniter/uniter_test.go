@@ -26,11 +26,13 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/cache"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -1287,6 +1289,51 @@ func (s *uniterSuite) TestWatchUnitRelations(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *uniterSuite) TestWatchUnitRelationsCached(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"features": []string{feature.CachedWatchers},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForCachedApplication(c, s.wordpress.Name())
+
+	uniterAPI, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:             s.State,
+		Resources_:         s.resources,
+		Auth_:              s.authorizer,
+		LeadershipChecker_: s.State.LeadershipChecker(),
+		Controller_:        s.Controller,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}}
+	result, err := uniterAPI.WatchUnitRelations(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Changes, gc.HasLen, 0)
+
+	// The watcher is served from the model cache.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get(result.Results[0].StringsWatcherId)
+	defer statetesting.AssertStop(c, resource)
+	_, ok := resource.(cache.StringsWatcher)
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *uniterSuite) waitForCachedApplication(c *gc.C, appName string) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.State.StartSync()
+		model, err := s.Controller.Model(s.State.ModelUUID())
+		if err != nil {
+			continue
+		}
+		if _, err := model.Application(appName); err == nil {
+			return
+		}
+	}
+	c.Fatalf("application %q not added to the model cache", appName)
+}
+
 func (s *uniterSuite) TestWatchSubordinateUnitRelations(c *gc.C) {
 	// The logging charm is subordinate (and the info endpoint is scope=container).
 	loggingCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
@@ -1793,6 +1840,39 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	running, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{Messages: []params.EntityString{
+		{Tag: running.ActionTag().String(), Value: "dumping table users"},
+		{Tag: pending.ActionTag().String(), Value: "too soon"},
+		{Tag: other.ActionTag().String(), Value: "not mine"},
+		{Tag: "foo", Value: "bad tag"},
+	}}
+	res, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 4)
+	c.Check(res.Results[0].Error, gc.IsNil)
+	c.Check(res.Results[1].Error, gc.ErrorMatches, `cannot log message for action ".*": action is not running`)
+	c.Check(res.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Check(res.Results[3].Error, gc.ErrorMatches, `"foo" is not a valid tag`)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := model.Action(running.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Check(messages[0].Message, gc.Equals, "dumping table users")
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
@@ -3198,6 +3278,33 @@ func (s *uniterSuite) TestSetPodSpec(c *gc.C) {
 	c.Assert(spec, gc.Equals, podSpec)
 }
 
+var globalServiceAccountPodSpec = podSpec + `
+serviceAccount:
+  global: true
+  rules:
+    - apiGroups: [""]
+      resources: ["pods"]
+      verbs: ["get", "list"]
+`[1:]
+
+func (s *uniterSuite) TestSetPodSpecGlobalServiceAccountRequiresTrust(c *gc.C) {
+	u, cm, app, _ := s.setupCAASModel(c)
+	err := u.SetPodSpec(app.Name(), globalServiceAccountPodSpec)
+	c.Assert(err, gc.ErrorMatches, "invalid pod spec: global service account for application without trust not valid")
+
+	conf := map[string]interface{}{application.TrustConfigOptionName: true}
+	fields := map[string]environschema.Attr{application.TrustConfigOptionName: {Type: environschema.Tbool}}
+	defaults := map[string]interface{}{application.TrustConfigOptionName: false}
+	err = app.UpdateApplicationConfig(conf, nil, fields, defaults)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.SetPodSpec(app.Name(), globalServiceAccountPodSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	spec, err := cm.PodSpec(app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, gc.Equals, globalServiceAccountPodSpec)
+}
+
 type unitMetricBatchesSuite struct {
 	uniterSuiteBase
 	*commontesting.ModelWatcherTest
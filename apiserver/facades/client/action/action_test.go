@@ -129,8 +129,8 @@ func (s *actionSuite) TestActions(c *gc.C) {
 		Actions: []params.Action{
 			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{}},
 			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{}},
-			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{"foo": 1, "bar": "please"}},
-			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{"baz": true}},
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "juju-run", Parameters: map[string]interface{}{"command": "please", "timeout": 1.5}},
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "juju-run", Parameters: map[string]interface{}{"command": "ls", "timeout": 10.0}},
 		}}
 
 	r, err := s.action.Enqueue(arg)
@@ -231,7 +231,7 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 
 	// Add Actions.
 	expectedName := "fakeaction"
-	expectedParameters := map[string]interface{}{"kan jy nie": "verstaand"}
+	expectedParameters := map[string]interface{}{}
 	arg := params.Actions{
 		Actions: []params.Action{
 			// No receiver.
@@ -288,6 +288,47 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueValidatesParameters(c *gc.C) {
+	dummyUnit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.dummy})
+	arg := params.Actions{
+		Actions: []params.Action{
+			// Defaults are inserted.
+			{Receiver: dummyUnit.Tag().String(), Name: "snapshot"},
+			// Every problem is reported.
+			{Receiver: dummyUnit.Tag().String(), Name: "snapshot", Parameters: map[string]interface{}{
+				"outfile": 42,
+				"extra":   "x",
+			}},
+			// Charms without parameters accept none.
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{
+				"kan jy nie": "verstaand",
+			}},
+		},
+	}
+	res, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Check(res.Results[0].Action.Parameters, jc.DeepEquals, map[string]interface{}{
+		"outfile": "foo.bz2",
+	})
+
+	c.Check(res.Results[1].Error, gc.ErrorMatches,
+		`invalid parameters for action "snapshot": .*outfile.*; unknown parameter "extra"`)
+	c.Check(res.Results[1].Action, gc.IsNil)
+
+	c.Check(res.Results[2].Error, gc.ErrorMatches,
+		`invalid parameters for action "fakeaction": unknown parameter "kan jy nie"`)
+
+	actions, err := dummyUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Check(actions[0].Parameters(), jc.DeepEquals, map[string]interface{}{
+		"outfile": "foo.bz2",
+	})
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
@@ -0,0 +1,58 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/watcher"
+)
+
+// WatchActionsProgress isn't on the v3 API.
+func (*APIv3) WatchActionsProgress(_, _ struct{}) {}
+
+// WatchActionsProgress returns a watcher for the progress messages
+// logged by each of the given actions. Each change is a json encoded
+// params.ActionMessage; the first holds all messages logged so far.
+func (a *ActionAPI) WatchActionsProgress(actions params.Entities) (params.StringsWatchResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(actions.Entities)),
+	}
+	for i, arg := range actions.Entities {
+		result, err := a.watchActionProgress(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (a *ActionAPI) watchActionProgress(tag string) (params.StringsWatchResult, error) {
+	actionTag, err := names.ParseActionTag(tag)
+	if err != nil {
+		return params.StringsWatchResult{}, errors.Trace(err)
+	}
+	if _, err := a.model.ActionByTag(actionTag); err != nil {
+		return params.StringsWatchResult{}, errors.Trace(err)
+	}
+	w := a.model.WatchActionLogs(actionTag.Id())
+	// Consume the initial event, which holds the messages logged so far.
+	changes, ok := <-w.Changes()
+	if !ok {
+		return params.StringsWatchResult{}, watcher.EnsureErr(w)
+	}
+	return params.StringsWatchResult{
+		StringsWatcherId: a.resources.Register(w),
+		Changes:          changes,
+	}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"encoding/json"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func (s *actionSuite) TestWatchActionsProgress(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("dumping table users")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.WatchActionsProgress(params.Entities{Entities: []params.Entity{
+		{Tag: action.ActionTag().String()},
+		{Tag: "action-f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, gc.HasLen, 1)
+	var msg params.ActionMessage
+	err = json.Unmarshal([]byte(result.Changes[0]), &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg.Message, gc.Equals, "dumping table users")
+
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `action "f47ac10b-58cc-4372-a567-0e02b2c3d479" not found`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid action tag`)
+
+	// The watcher is registered, and reports later messages.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1").(state.StringsWatcher)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+	err = action.Log("dumping table posts")
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case changes := <-w.Changes():
+		c.Assert(changes, gc.HasLen, 1)
+		err = json.Unmarshal([]byte(changes[0]), &msg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(msg.Message, gc.Equals, "dumping table posts")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for action progress")
+	}
+}
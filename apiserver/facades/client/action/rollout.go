@@ -0,0 +1,136 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddActionRollouts isn't on the v3 API.
+func (*APIv3) AddActionRollouts(_, _ struct{}) {}
+
+// ActionRollouts isn't on the v3 API.
+func (*APIv3) ActionRollouts(_, _ struct{}) {}
+
+// AddActionRollouts starts running actions across the units of
+// applications. The controller enqueues each rollout's actions as the
+// rollout's limits allow.
+func (a *ActionAPI) AddActionRollouts(args params.AddActionRollouts) (params.ActionRolloutResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionRolloutResults{
+		Results: make([]params.ActionRolloutResult, len(args.Rollouts)),
+	}
+	for i, arg := range args.Rollouts {
+		rollout, err := a.addActionRollout(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Rollout = rollout
+	}
+	return results, nil
+}
+
+func (a *ActionAPI) addActionRollout(arg params.AddActionRollout) (*params.ActionRollout, error) {
+	appTag, err := names.ParseApplicationTag(arg.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rollout, err := a.model.AddActionRollout(state.ActionRolloutArgs{
+		Application:   appTag.Id(),
+		Name:          arg.Name,
+		Parameters:    arg.Parameters,
+		Targets:       state.ActionRolloutTargets(arg.Targets),
+		MaxParallel:   arg.MaxParallel,
+		BatchSize:     arg.BatchSize,
+		StopOnFailure: arg.StopOnFailure,
+		CreatedBy:     a.authorizer.GetAuthTag().Id(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.makeActionRollout(rollout)
+}
+
+// ActionRollouts returns the progress of the given action rollouts,
+// including the status of the action on each unit.
+func (a *ActionAPI) ActionRollouts(args params.ActionRolloutIds) (params.ActionRolloutResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionRolloutResults{
+		Results: make([]params.ActionRolloutResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		rollout, err := a.model.ActionRollout(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result, err := a.makeActionRollout(rollout)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Rollout = result
+	}
+	return results, nil
+}
+
+func (a *ActionAPI) makeActionRollout(rollout *state.ActionRollout) (*params.ActionRollout, error) {
+	result := &params.ActionRollout{
+		Id:            rollout.Id(),
+		Application:   names.NewApplicationTag(rollout.Application()).String(),
+		Name:          rollout.Name(),
+		Parameters:    rollout.Parameters(),
+		MaxParallel:   rollout.MaxParallel(),
+		BatchSize:     rollout.BatchSize(),
+		StopOnFailure: rollout.StopOnFailure(),
+		Status:        string(rollout.Status()),
+		Units:         make([]params.ActionRolloutUnit, len(rollout.Units())),
+		Created:       rollout.Created(),
+		CreatedBy:     rollout.CreatedBy(),
+	}
+	if finished := rollout.Finished(); !finished.IsZero() {
+		result.Finished = &finished
+	}
+
+	actionIds := rollout.ActionIds()
+	for i, unitName := range rollout.Units() {
+		unit := &result.Units[i]
+		unit.Unit = names.NewUnitTag(unitName).String()
+		if i >= len(actionIds) {
+			unit.Status = "waiting"
+			if rollout.Status() != state.RolloutRunning {
+				unit.Status = "skipped"
+			}
+			continue
+		}
+		if actionIds[i] == "" {
+			unit.Status = "skipped"
+			unit.Message = "unit was removed"
+			continue
+		}
+		action, err := a.model.Action(actionIds[i])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unit.Action = action.ActionTag().String()
+		unit.Status = string(action.Status())
+		_, unit.Message = action.Results()
+	}
+	return result, nil
+}
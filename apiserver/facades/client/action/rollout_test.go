@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *actionSuite) TestAddActionRollouts(c *gc.C) {
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.wordpress})
+	results, err := s.action.AddActionRollouts(params.AddActionRollouts{
+		Rollouts: []params.AddActionRollout{{
+			Application:   s.wordpress.Tag().String(),
+			Name:          "fakeaction",
+			MaxParallel:   1,
+			StopOnFailure: true,
+		}, {
+			Application: s.wordpressUnit.Tag().String(),
+			Name:        "fakeaction",
+		}, {
+			Application: s.mysql.Tag().String(),
+			Name:        "missing",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	first := results.Results[0]
+	c.Assert(first.Error, gc.IsNil)
+	c.Check(first.Rollout.Id, gc.Equals, "0")
+	c.Check(first.Rollout.Application, gc.Equals, "application-wordpress")
+	c.Check(first.Rollout.MaxParallel, gc.Equals, 1)
+	c.Check(first.Rollout.StopOnFailure, jc.IsTrue)
+	c.Check(first.Rollout.Status, gc.Equals, "running")
+	c.Check(first.Rollout.CreatedBy, gc.Equals, s.AdminUserTag(c).Id())
+	c.Check(first.Rollout.Units, jc.DeepEquals, []params.ActionRolloutUnit{
+		{Unit: "unit-wordpress-0", Status: "waiting"},
+		{Unit: "unit-wordpress-1", Status: "waiting"},
+	})
+
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid application tag`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `cannot add action rollout: action "missing" not defined for application "mysql"`)
+}
+
+func (s *actionSuite) TestActionRollouts(c *gc.C) {
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.wordpress})
+	rollout, err := s.Model.AddActionRollout(state.ActionRolloutArgs{
+		Application:   "wordpress",
+		Name:          "fakeaction",
+		MaxParallel:   1,
+		StopOnFailure: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rollout.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.Model.Action(rollout.ActionIds()[0])
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Finish(state.ActionResults{Status: state.ActionFailed, Message: "oops"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rollout.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.ActionRollouts(params.ActionRolloutIds{Ids: []string{"0", "42"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	result := results.Results[0].Rollout
+	c.Check(result.Status, gc.Equals, "stopped")
+	c.Check(result.Finished, gc.NotNil)
+	c.Check(result.Units, jc.DeepEquals, []params.ActionRolloutUnit{
+		{Unit: "unit-wordpress-0", Action: action.ActionTag().String(), Status: "failed", Message: "oops"},
+		{Unit: "unit-wordpress-1", Status: "skipped"},
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `action rollout "42" not found`)
+}
+
+func (s *actionSuite) TestBlockAddActionRollouts(c *gc.C) {
+	s.BlockAllChanges(c, "AddActionRollouts")
+	_, err := s.action.AddActionRollouts(params.AddActionRollouts{})
+	s.AssertBlocked(c, err, "AddActionRollouts")
+}
@@ -0,0 +1,180 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddActionSchedules isn't on the v3 API.
+func (*APIv3) AddActionSchedules(_, _ struct{}) {}
+
+// ListActionSchedules isn't on the v3 API.
+func (*APIv3) ListActionSchedules(_, _ struct{}) {}
+
+// PauseActionSchedules isn't on the v3 API.
+func (*APIv3) PauseActionSchedules(_, _ struct{}) {}
+
+// ResumeActionSchedules isn't on the v3 API.
+func (*APIv3) ResumeActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the v3 API.
+func (*APIv3) RemoveActionSchedules(_, _ struct{}) {}
+
+// scheduleReceiver converts the receiver of an action schedule given
+// to the API into the unit name, application name or
+// "<application>/leader" stored in state.
+func scheduleReceiver(receiver string) (string, error) {
+	if strings.HasSuffix(receiver, "/leader") {
+		return receiver, nil
+	}
+	tag, err := names.ParseTag(receiver)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.UnitTag, names.ApplicationTag:
+		return tag.Id(), nil
+	}
+	return "", errors.NotValidf("action receiver %q", receiver)
+}
+
+// apiReceiver converts the receiver of an action schedule in state to
+// the form returned by the API.
+func apiReceiver(receiver string) string {
+	switch {
+	case strings.HasSuffix(receiver, "/leader"):
+		return receiver
+	case names.IsValidUnit(receiver):
+		return names.NewUnitTag(receiver).String()
+	default:
+		return names.NewApplicationTag(receiver).String()
+	}
+}
+
+func makeActionSchedule(sched *state.ActionSchedule) params.ActionSchedule {
+	optionalTime := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+	return params.ActionSchedule{
+		Id:         sched.Id(),
+		Receiver:   apiReceiver(sched.Receiver()),
+		Name:       sched.Name(),
+		Parameters: sched.Parameters(),
+		Schedule:   sched.Schedule(),
+		Paused:     sched.Paused(),
+		NextRun:    optionalTime(sched.NextRun()),
+		LastRun:    optionalTime(sched.LastRun()),
+		LastError:  sched.LastError(),
+		Created:    sched.Created(),
+		CreatedBy:  sched.CreatedBy(),
+	}
+}
+
+// AddActionSchedules adds schedules on which the controller enqueues
+// actions, at a future time or repeatedly.
+func (a *ActionAPI) AddActionSchedules(args params.AddActionSchedules) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		receiver, err := scheduleReceiver(arg.Receiver)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		schedArgs := state.ActionScheduleArgs{
+			Receiver:   receiver,
+			Name:       arg.Name,
+			Parameters: arg.Parameters,
+			Schedule:   arg.Schedule,
+			CreatedBy:  a.authorizer.GetAuthTag().Id(),
+		}
+		if arg.At != nil {
+			schedArgs.At = *arg.At
+		}
+		sched, err := a.model.AddActionSchedule(schedArgs)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := makeActionSchedule(sched)
+		results.Results[i].Schedule = &result
+	}
+	return results, nil
+}
+
+// ListActionSchedules returns all the model's action schedules.
+func (a *ActionAPI) ListActionSchedules() (params.ActionSchedules, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	schedules, err := a.model.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	result := params.ActionSchedules{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, sched := range schedules {
+		result.Schedules[i] = makeActionSchedule(sched)
+	}
+	return result, nil
+}
+
+// PauseActionSchedules stops the given schedules enqueueing actions
+// until they are resumed.
+func (a *ActionAPI) PauseActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	return a.updateActionSchedules(args, (*state.ActionSchedule).Pause)
+}
+
+// ResumeActionSchedules restarts the given paused schedules.
+func (a *ActionAPI) ResumeActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	return a.updateActionSchedules(args, (*state.ActionSchedule).Resume)
+}
+
+// RemoveActionSchedules removes the given schedules. Actions they have
+// already enqueued are not affected.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	return a.updateActionSchedules(args, (*state.ActionSchedule).Remove)
+}
+
+func (a *ActionAPI) updateActionSchedules(args params.ActionScheduleIds, update func(*state.ActionSchedule) error) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		sched, err := a.model.ActionSchedule(id)
+		if err == nil {
+			err = update(sched)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
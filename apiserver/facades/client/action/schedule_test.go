@@ -0,0 +1,127 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) addSchedules(c *gc.C, args ...params.AddActionSchedule) params.ActionScheduleResults {
+	results, err := s.action.AddActionSchedules(params.AddActionSchedules{Schedules: args})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, len(args))
+	return results
+}
+
+func (s *actionSuite) TestAddActionSchedules(c *gc.C) {
+	at := time.Now().Add(time.Hour).Round(time.Second).UTC()
+	results := s.addSchedules(c, params.AddActionSchedule{
+		Receiver: s.wordpress.Tag().String(),
+		Name:     "fakeaction",
+		Schedule: "@daily",
+	}, params.AddActionSchedule{
+		Receiver: "mysql/leader",
+		Name:     "fakeaction",
+		At:       &at,
+	}, params.AddActionSchedule{
+		Receiver: s.machine0.Tag().String(),
+		Name:     "fakeaction",
+		Schedule: "@daily",
+	}, params.AddActionSchedule{
+		Receiver: s.mysqlUnit.Tag().String(),
+		Name:     "missing",
+		Schedule: "@daily",
+	})
+
+	first := results.Results[0]
+	c.Assert(first.Error, gc.IsNil)
+	c.Check(first.Schedule.Id, gc.Equals, "0")
+	c.Check(first.Schedule.Receiver, gc.Equals, "application-wordpress")
+	c.Check(first.Schedule.Schedule, gc.Equals, "@daily")
+	c.Check(first.Schedule.NextRun, gc.NotNil)
+	c.Check(first.Schedule.LastRun, gc.IsNil)
+	c.Check(first.Schedule.CreatedBy, gc.Equals, s.AdminUserTag(c).Id())
+
+	second := results.Results[1]
+	c.Assert(second.Error, gc.IsNil)
+	c.Check(second.Schedule.Receiver, gc.Equals, "mysql/leader")
+	c.Check(second.Schedule.NextRun.Equal(at), jc.IsTrue)
+
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `action receiver "machine-0" not valid`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `cannot add action schedule: action "missing" not defined for application "mysql"`)
+}
+
+func (s *actionSuite) TestAddActionSchedulesValidatesParameters(c *gc.C) {
+	results := s.addSchedules(c, params.AddActionSchedule{
+		Receiver: s.dummy.Tag().String(),
+		Name:     "snapshot",
+		Schedule: "@daily",
+	}, params.AddActionSchedule{
+		Receiver:   "dummy/leader",
+		Name:       "snapshot",
+		Parameters: map[string]interface{}{"extra": true},
+		Schedule:   "@daily",
+	})
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Schedule.Parameters, jc.DeepEquals, map[string]interface{}{
+		"outfile": "foo.bz2",
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches,
+		`cannot add action schedule: invalid parameters for action "snapshot": unknown parameter "extra"`)
+}
+
+func (s *actionSuite) TestListActionSchedules(c *gc.C) {
+	s.addSchedules(c, params.AddActionSchedule{
+		Receiver: s.wordpressUnit.Tag().String(),
+		Name:     "fakeaction",
+		Schedule: "@daily",
+	})
+	result, err := s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Schedules, gc.HasLen, 1)
+	c.Check(result.Schedules[0].Receiver, gc.Equals, "unit-wordpress-0")
+	c.Check(result.Schedules[0].Name, gc.Equals, "fakeaction")
+	c.Check(result.Schedules[0].Paused, jc.IsFalse)
+}
+
+func (s *actionSuite) TestPauseResumeRemoveActionSchedules(c *gc.C) {
+	s.addSchedules(c, params.AddActionSchedule{
+		Receiver: s.wordpress.Tag().String(),
+		Name:     "fakeaction",
+		Schedule: "@daily",
+	})
+	ids := params.ActionScheduleIds{Ids: []string{"0", "42"}}
+	checkResults := func(results params.ErrorResults, err error) {
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results.Results, gc.HasLen, 2)
+		c.Check(results.Results[0].Error, gc.IsNil)
+		c.Check(results.Results[1].Error, gc.ErrorMatches, `action schedule "42" not found`)
+	}
+
+	checkResults(s.action.PauseActionSchedules(ids))
+	schedules, err := s.Model.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules[0].Paused(), jc.IsTrue)
+
+	checkResults(s.action.ResumeActionSchedules(ids))
+	schedules, err = s.Model.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules[0].Paused(), jc.IsFalse)
+
+	checkResults(s.action.RemoveActionSchedules(ids))
+	schedules, err = s.Model.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules, gc.HasLen, 0)
+}
+
+func (s *actionSuite) TestBlockAddActionSchedules(c *gc.C) {
+	s.BlockAllChanges(c, "AddActionSchedules")
+	_, err := s.action.AddActionSchedules(params.AddActionSchedules{})
+	s.AssertBlocked(c, err, "AddActionSchedules")
+}
@@ -738,7 +738,7 @@ func (api *APIBase) Update(args params.ApplicationUpdate) error {
 			return errors.Trace(err)
 		}
 	}
-	app, err := api.backend.Application(args.ApplicationName)
+	app, err := api.generationApplication(args.ApplicationName, args.Generation)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -843,7 +843,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 			return errors.Trace(err)
 		}
 	}
-	application, err := api.backend.Application(args.ApplicationName)
+	application, err := api.generationApplication(args.ApplicationName, args.Generation)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -1006,7 +1006,7 @@ func (api *APIBase) Set(p params.ApplicationSet) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	app, err := api.backend.Application(p.ApplicationName)
+	app, err := api.generationApplication(p.ApplicationName, p.Generation)
 	if err != nil {
 		return err
 	}
@@ -1032,7 +1032,7 @@ func (api *APIBase) Unset(p params.ApplicationUnset) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	app, err := api.backend.Application(p.ApplicationName)
+	app, err := api.generationApplication(p.ApplicationName, p.Generation)
 	if err != nil {
 		return err
 	}
@@ -1494,6 +1494,9 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := api.checkCanScale(appTag); err != nil {
+			return nil, errors.Trace(err)
+		}
 		var info params.ScaleApplicationInfo
 		if arg.ScaleChange != 0 {
 			newScale, err := app.ChangeScale(arg.ScaleChange)
@@ -1521,6 +1524,26 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 	return params.ScaleApplicationResults{results}, nil
 }
 
+// checkCanScale returns an error if the application's pod spec
+// doesn't allow the number of its units to be chosen. A daemon
+// deployment runs a unit on every node in the cluster.
+func (api *APIBase) checkCanScale(appTag names.ApplicationTag) error {
+	specStr, err := api.backend.PodSpec(appTag)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	spec, err := api.caasBroker.Provider().ParsePodSpec(specStr)
+	if err != nil {
+		return errors.Annotate(err, "parsing pod spec")
+	}
+	if spec.DeploymentType == caas.DeploymentDaemon {
+		return errors.NewNotSupported(nil, fmt.Sprintf("application %q runs a unit on every node so cannot be scaled", appTag.Id()))
+	}
+	return nil
+}
+
 // GetConstraints returns the constraints for a given application.
 func (api *APIBase) GetConstraints(args params.Entities) (params.ApplicationGetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
@@ -2005,7 +2028,7 @@ func (api *APIBase) SetApplicationsConfig(args params.ApplicationConfigSetArgs)
 }
 
 func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error {
-	app, err := api.backend.Application(arg.ApplicationName)
+	app, err := api.generationApplication(arg.ApplicationName, arg.Generation)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -2062,7 +2085,7 @@ func (api *APIBase) UnsetApplicationsConfig(args params.ApplicationConfigUnsetAr
 }
 
 func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
-	app, err := api.backend.Application(arg.ApplicationName)
+	app, err := api.generationApplication(arg.ApplicationName, arg.Generation)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/network"
@@ -729,6 +730,26 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 	app.CheckCall(c, 0, "Scale", 5)
 }
 
+func (s *ApplicationSuite) TestScaleApplicationsCAASModelDaemon(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.podSpecs = map[string]string{"postgresql": `
+deploymentType: daemon
+containers:
+  - name: postgresql
+    image: postgresql/latest
+`[1:]}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `application "postgresql" runs a unit on every node so cannot be scaled`)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotSupported)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleChange(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	s.backend.applications["postgresql"].scale = 2
@@ -1313,6 +1334,24 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	app.CheckCall(c, 2, "UpdateCharmConfig", charm.Settings{"stringOption": "stringVal"})
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigNextGeneration(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.generation = &mockGeneration{}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "stringVal"},
+			Generation:      model.GenerationNext,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application", "NextGeneration")
+	s.backend.applications["postgresql"].CheckCallNames(c, "Charm")
+	s.backend.generation.CheckCalls(c, []testing.StubCall{
+		{"UpdateCharmConfig", []interface{}{"postgresql", charm.Settings{"stringOption": "stringVal"}}},
+	})
+}
+
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
 	_, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
@@ -43,6 +43,8 @@ type Backend interface {
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
+	NextGeneration() (Generation, error)
+	PodSpec(names.ApplicationTag) (string, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -88,6 +90,15 @@ type Application interface {
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
 }
 
+// Generation defines a subset of the functionality provided by the
+// state.Generation type, as required by the application facade. For
+// details on the methods, see the methods on state.Generation with
+// the same names.
+type Generation interface {
+	UpdateCharmConfig(string, charm.Settings) error
+	SetCharmURL(string, *charm.URL) error
+}
+
 // Charm defines a subset of the functionality provided by the
 // state.Charm type, as required by the application facade. For
 // details on the methods, see the methods on state.Charm with
@@ -285,6 +296,26 @@ func (s stateShim) Charm(curl *charm.URL) (Charm, error) {
 	return stateCharmShim{ch}, nil
 }
 
+func (s stateShim) NextGeneration() (Generation, error) {
+	gen, err := s.State.NextGeneration()
+	if err != nil {
+		return nil, err
+	}
+	return gen, nil
+}
+
+func (s stateShim) PodSpec(tag names.ApplicationTag) (string, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return "", err
+	}
+	cm, err := m.CAASModel()
+	if err != nil {
+		return "", err
+	}
+	return cm.PodSpec(tag)
+}
+
 func (s stateShim) EndpointsRelation(eps ...state.Endpoint) (Relation, error) {
 	r, err := s.State.EndpointsRelation(eps...)
 	if err != nil {
@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+)
+
+// generationApplication returns the named application, as changed in
+// the given generation. Changes to the charm and charm config of the
+// application in the next generation are recorded in the generation,
+// and applied to the application when the generation completes.
+func (api *APIBase) generationApplication(name string, generation model.GenerationVersion) (Application, error) {
+	app, err := api.backend.Application(name)
+	if err != nil {
+		return nil, err
+	}
+	if generation != model.GenerationNext {
+		return app, nil
+	}
+	gen, err := api.backend.NextGeneration()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting next generation")
+	}
+	return &nextGenerationApplication{
+		Application: app,
+		name:        name,
+		generation:  gen,
+	}, nil
+}
+
+// nextGenerationApplication records changes to an application's charm
+// and charm config in the model's next generation.
+type nextGenerationApplication struct {
+	Application
+	name       string
+	generation Generation
+}
+
+// UpdateCharmConfig is part of the Application interface.
+func (a *nextGenerationApplication) UpdateCharmConfig(changes charm.Settings) error {
+	return errors.Trace(a.generation.UpdateCharmConfig(a.name, changes))
+}
+
+// SetCharmProfile is part of the Application interface. The profile
+// is set when the generation completes.
+func (a *nextGenerationApplication) SetCharmProfile(string) error {
+	return nil
+}
+
+// SetCharm is part of the Application interface. Only the charm is
+// recorded in the generation, so other changes are refused.
+func (a *nextGenerationApplication) SetCharm(cfg state.SetCharmConfig) error {
+	if len(cfg.ConfigSettings) > 0 || len(cfg.ResourceIDs) > 0 || len(cfg.StorageConstraints) > 0 {
+		return errors.NotSupportedf("changing config, resources or storage with the charm in the next generation")
+	}
+	if cfg.Force || cfg.ForceSeries || cfg.ForceUnits {
+		return errors.NotSupportedf("forcing a charm upgrade in the next generation")
+	}
+	return errors.Trace(a.generation.SetCharmURL(a.name, cfg.Charm.URL()))
+}
@@ -329,6 +329,8 @@ type mockBackend struct {
 	storageInstanceFilesystems map[string]*mockFilesystem
 	controllers                map[string]crossmodel.ControllerInfo
 	machines                   map[string]*mockMachine
+	generation                 *mockGeneration
+	podSpecs                   map[string]string
 }
 
 type mockFilesystemAccess struct {
@@ -336,6 +338,28 @@ type mockFilesystemAccess struct {
 	*mockBackend
 }
 
+func (m *mockBackend) NextGeneration() (application.Generation, error) {
+	m.MethodCall(m, "NextGeneration")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.generation, nil
+}
+
+type mockGeneration struct {
+	jtesting.Stub
+}
+
+func (g *mockGeneration) UpdateCharmConfig(appName string, changes charm.Settings) error {
+	g.MethodCall(g, "UpdateCharmConfig", appName, changes)
+	return g.NextErr()
+}
+
+func (g *mockGeneration) SetCharmURL(appName string, curl *charm.URL) error {
+	g.MethodCall(g, "SetCharmURL", appName, curl)
+	return g.NextErr()
+}
+
 func (m *mockBackend) VolumeAccess() storagecommon.VolumeAccess {
 	return nil
 }
@@ -352,6 +376,18 @@ func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, err
 	return nil, false, nil
 }
 
+func (m *mockBackend) PodSpec(tag names.ApplicationTag) (string, error) {
+	m.MethodCall(m, "PodSpec", tag)
+	if err := m.NextErr(); err != nil {
+		return "", err
+	}
+	spec, ok := m.podSpecs[tag.Id()]
+	if !ok {
+		return "", errors.NotFoundf("pod spec for %s", names.ReadableString(tag))
+	}
+	return spec, nil
+}
+
 func (m *mockBackend) Charm(curl *charm.URL) (application.Charm, error) {
 	m.MethodCall(m, "Charm", curl)
 	if err := m.NextErr(); err != nil {
@@ -837,6 +873,14 @@ type mockCaasBroker struct {
 	storageClassName string
 }
 
+func (m *mockCaasBroker) Provider() caas.ContainerEnvironProvider {
+	p, err := environs.Provider("kubernetes")
+	if err != nil {
+		panic(err)
+	}
+	return p.(caas.ContainerEnvironProvider)
+}
+
 func (m *mockCaasBroker) GetStorageClassName(labels ...string) (string, error) {
 	m.MethodCall(m, "GetStorageClassName", labels)
 	if err := m.NextErr(); err != nil {
@@ -0,0 +1,122 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides the API for querying the records in the
+// controller's audit log.
+package auditlog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/permission"
+)
+
+// Backend defines the state methods used by the AuditLog facade.
+type Backend interface {
+	AuditConversations(auditlog.Filter) ([]auditlog.ConversationLog, error)
+}
+
+// API provides the AuditLog facade APIs for v1.
+type API struct {
+	backend Backend
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(ctx.Auth(), st.ControllerTag(), st)
+}
+
+// NewAPI returns a new AuditLog API facade reading the audit log
+// shared by the controller machines. Only controller superusers may
+// read the audit log.
+func NewAPI(authorizer facade.Authorizer, controllerTag names.ControllerTag, backend Backend) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// Conversations returns the conversations in the audit log that match
+// the query, oldest first. The conversations handled by every
+// controller machine are returned.
+func (api *API) Conversations(args params.AuditLogQuery) (params.AuditConversationsResult, error) {
+	filter := auditlog.Filter{
+		Who:        args.Who,
+		ModelUUID:  args.ModelUUID,
+		Methods:    args.Methods,
+		ErrorsOnly: args.ErrorsOnly,
+		Limit:      args.Limit,
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	conversations, err := api.backend.AuditConversations(filter)
+	if err != nil {
+		return params.AuditConversationsResult{}, errors.Trace(err)
+	}
+	result := params.AuditConversationsResult{
+		Conversations: make([]params.AuditConversation, len(conversations)),
+	}
+	for i, c := range conversations {
+		result.Conversations[i] = params.AuditConversation{
+			ConversationID: c.ConversationID,
+			ConnectionID:   c.ConnectionID,
+			Who:            c.Who,
+			What:           c.What,
+			When:           parseTime(c.When),
+			ModelName:      c.ModelName,
+			ModelUUID:      c.ModelUUID,
+			Calls:          make([]params.AuditCall, len(c.Calls)),
+		}
+		for j, call := range c.Calls {
+			result.Conversations[i].Calls[j] = auditCall(call)
+		}
+	}
+	return result, nil
+}
+
+func auditCall(call auditlog.Call) params.AuditCall {
+	result := params.AuditCall{
+		RequestID: call.RequestID,
+		When:      parseTime(call.When),
+		Facade:    call.Facade,
+		Method:    call.Method,
+		Version:   call.Version,
+		Args:      call.Args,
+	}
+	for _, e := range call.Errors {
+		if e == nil {
+			continue
+		}
+		result.Errors = append(result.Errors, params.AuditError{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return result
+}
+
+// parseTime parses the audit record time, which is always written in
+// RFC 3339 format. An unparseable time is returned as the zero time.
+func parseTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
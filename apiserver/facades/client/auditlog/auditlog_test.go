@@ -0,0 +1,136 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	admin := names.NewUserTag("admin")
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      admin,
+		AdminTag: admin,
+	}
+	s.backend = &fakeBackend{records: []coreauditlog.Record{{
+		Conversation: &coreauditlog.Conversation{
+			Who:            "bob",
+			What:           "juju deploy mysql",
+			When:           "2018-11-01T10:00:00Z",
+			ModelName:      "admin/default",
+			ModelUUID:      coretesting.ModelTag.Id(),
+			ConversationID: "abc",
+			ConnectionID:   "1",
+		},
+	}, {
+		Request: &coreauditlog.Request{
+			ConversationID: "abc",
+			ConnectionID:   "1",
+			RequestID:      7,
+			When:           "2018-11-01T10:00:01Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        8,
+		},
+	}, {
+		Errors: &coreauditlog.ResponseErrors{
+			ConversationID: "abc",
+			ConnectionID:   "1",
+			RequestID:      7,
+			When:           "2018-11-01T10:00:02Z",
+			Errors:         []*coreauditlog.Error{{Message: "boom", Code: "bad"}},
+		},
+	}}}
+}
+
+func (s *auditLogSuite) newAPI(c *gc.C) *auditlog.API {
+	api, err := auditlog.NewAPI(s.authorizer, coretesting.ControllerTag, s.backend)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *auditLogSuite) TestNonSuperuserDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := auditlog.NewAPI(s.authorizer, coretesting.ControllerTag, s.backend)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestAgentDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.authorizer, coretesting.ControllerTag, s.backend)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestConversations(c *gc.C) {
+	result, err := s.newAPI(c).Conversations(params.AuditLogQuery{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AuditConversationsResult{
+		Conversations: []params.AuditConversation{{
+			ConversationID: "abc",
+			ConnectionID:   "1",
+			Who:            "bob",
+			What:           "juju deploy mysql",
+			When:           time.Date(2018, 11, 1, 10, 0, 0, 0, time.UTC),
+			ModelName:      "admin/default",
+			ModelUUID:      coretesting.ModelTag.Id(),
+			Calls: []params.AuditCall{{
+				RequestID: 7,
+				When:      time.Date(2018, 11, 1, 10, 0, 1, 0, time.UTC),
+				Facade:    "Application",
+				Method:    "Deploy",
+				Version:   8,
+				Errors:    []params.AuditError{{Message: "boom", Code: "bad"}},
+			}},
+		}},
+	})
+}
+
+func (s *auditLogSuite) TestConversationsFiltered(c *gc.C) {
+	from := time.Date(2018, 11, 1, 11, 0, 0, 0, time.UTC)
+	result, err := s.newAPI(c).Conversations(params.AuditLogQuery{From: &from})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Conversations, gc.HasLen, 0)
+	c.Assert(s.backend.filter.From, gc.Equals, from)
+
+	result, err = s.newAPI(c).Conversations(params.AuditLogQuery{
+		Who:        "bob",
+		Methods:    []string{"Application.Deploy"},
+		ErrorsOnly: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Conversations, gc.HasLen, 1)
+}
+
+// fakeBackend selects conversations from the records it holds.
+type fakeBackend struct {
+	records []coreauditlog.Record
+	filter  coreauditlog.Filter
+}
+
+func (b *fakeBackend) AuditConversations(filter coreauditlog.Filter) ([]coreauditlog.ConversationLog, error) {
+	b.filter = filter
+	return coreauditlog.SelectConversations(b.records, filter), nil
+}
@@ -35,6 +35,7 @@ type Backend interface {
 	ModelConfig() (*config.Config, error)
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (state.StateServingInfo, error)
+	BackupS3SecretKey() (string, error)
 	RestoreInfo() *state.RestoreInfo
 }
 
@@ -52,6 +53,21 @@ type APIv2 struct {
 	*API
 }
 
+// APIv3 serves backup-specific API methods for version 3.
+type APIv3 struct {
+	*APIv2
+}
+
+// NewAPIv3 creates a new instance of the Backups API facade for
+// version 3.
+func NewAPIv3(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewAPIv2(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
 func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
 	api, err := NewAPI(backend, resources, authorizer)
 	if err != nil {
@@ -142,12 +158,15 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	if meta.Stored() != nil {
 		result.Stored = *(meta.Stored())
 	}
+	result.StorageTarget = meta.StorageTarget
+	result.Failure = meta.Failure
 
 	result.Started = meta.Started
 	if meta.Finished != nil {
 		result.Finished = *meta.Finished
 	}
 	result.Notes = meta.Notes
+	result.Encrypted = meta.Encrypted
 
 	result.Model = meta.Origin.Model
 	result.Machine = meta.Origin.Machine
@@ -183,6 +202,8 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Encrypted = result.Encrypted
+	meta.Failure = result.Failure
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
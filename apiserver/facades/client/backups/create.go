@@ -30,7 +30,26 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 	return result, nil
 }
 
+// Create is the API method that requests juju to create a new backup
+// of its state.  It returns the metadata for that backup.
+//
+// NOTE this provides backwards compatibility for facade version 2,
+// which cannot encrypt the backup archive.
 func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	if args.Encryption != nil {
+		return params.BackupsMetadataResult{}, errors.NotSupportedf("backup encryption in this facade version")
+	}
+	return a.create(args)
+}
+
+// Create is the API method that requests juju to create a new backup
+// of its state, encrypting the archive if asked to.  It returns the
+// metadata for that backup.
+func (a *APIv3) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	return a.create(args)
+}
+
+func (a *API) create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
 	backupsMethods, closer := newBackups(a.backend)
 	defer closer.Close()
 
@@ -71,7 +90,15 @@ func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataRes
 	}
 	meta.Notes = args.Notes
 
-	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload)
+	var encryption *backups.Encryption
+	if args.Encryption != nil {
+		encryption = &backups.Encryption{
+			PublicKey:  args.Encryption.PublicKey,
+			Passphrase: args.Encryption.Passphrase,
+		}
+	}
+
+	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload, encryption)
 	if err != nil {
 		return result, errors.Trace(err)
 	}
@@ -4,12 +4,14 @@
 package backups_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -54,3 +56,30 @@ func (s *backupsSuite) TestCreateError(c *gc.C) {
 	c.Logf("%v", err)
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+	api, err := backups.NewAPIv3(&stateShim{s.State, s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.BackupsCreateArgs{
+		Encryption: &params.BackupsEncryption{Passphrase: "secret"},
+	}
+
+	_, err = api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.EncryptionArg, jc.DeepEquals, &statebackups.Encryption{Passphrase: "secret"})
+}
+
+func (s *backupsSuite) TestCreateEncryptedNotSupported(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		Encryption: &params.BackupsEncryption{Passphrase: "secret"},
+	}
+
+	_, err := s.api.Create(args)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
@@ -38,6 +38,17 @@ func (s *backupsSuite) TestInfoMissingFile(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestInfoStorageTarget(c *gc.C) {
+	s.meta.StorageTarget = "s3"
+	s.setBackups(c, s.meta, "")
+	args := params.BackupsInfoArgs{
+		ID: "some-id",
+	}
+	result, err := s.api.Info(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.StorageTarget, gc.Equals, "s3")
+}
+
 func (s *backupsSuite) TestInfoError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	args := params.BackupsInfoArgs{
@@ -17,12 +17,14 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/manual/sshprovisioner"
 	"github.com/juju/juju/environs/manual/winrmprovisioner"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
@@ -39,6 +41,10 @@ type API struct {
 	resources     facade.Resources
 	presence      facade.Presence
 
+	// cachedModel is set when the FullStatus call should be answered
+	// from the in-memory model cache rather than the database.
+	cachedModel *cache.Model
+
 	client *Client
 	// statusSetter provides common methods for updating an entity's provisioning status.
 	statusSetter *common.StatusSetter
@@ -158,7 +164,7 @@ func newFacade(ctx facade.Context) (*Client, error) {
 		return nil, errors.Trace(err)
 	}
 
-	return NewClient(
+	client, err := NewClient(
 		&stateShim{st, model},
 		&poolShim{ctx.StatePool()},
 		&modelconfig.ModelConfigAPIV1{modelConfigAPI},
@@ -171,6 +177,25 @@ func newFacade(ctx facade.Context) (*Client, error) {
 		blockChecker,
 		state.CallContext(st),
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client.api.cachedModel, err = cachedStatusModel(ctx, model); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+// cachedStatusModel returns the cached model to use for status requests
+// if the controller has the cached-status feature enabled. Only IAAS
+// models are supported. If the model has not been loaded into the cache
+// yet, nil is returned and status is read from the database.
+func cachedStatusModel(ctx facade.Context, model *state.Model) (*cache.Model, error) {
+	if model.Type() != state.ModelTypeIAAS {
+		return nil, nil
+	}
+	cachedModel, err := common.CachedModel(ctx, feature.CachedStatus)
+	return cachedModel, errors.Trace(err)
 }
 
 // NewClient creates a new instance of the Client Facade.
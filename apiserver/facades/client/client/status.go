@@ -172,6 +172,17 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err := c.checkCanRead(); err != nil {
 		return params.FullStatus{}, err
 	}
+	if c.api.cachedModel != nil && len(args.Patterns) == 0 {
+		m, err := c.api.stateAccessor.Model()
+		if err != nil {
+			return params.FullStatus{}, errors.Annotate(err, "cannot get model")
+		}
+		// The cache doesn't track agent presence, so the cached
+		// status is only used when the pubsub presence is available.
+		if c.api.presence.ModelPresence(m.UUID()) != nil {
+			return c.cachedFullStatus()
+		}
+	}
 
 	var noStatus params.FullStatus
 	var context statusContext
@@ -836,47 +847,7 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo
 			}
 			status.IPAddresses = append(status.IPAddresses, mAddr.Value)
 		}
-		status.NetworkInterfaces = make(map[string]params.NetworkInterface, len(linkLayerDevices))
-		for _, llDev := range linkLayerDevices {
-			device := llDev.Name()
-			ips := []string{}
-			gw := []string{}
-			ns := []string{}
-			sp := make(set.Strings)
-			for _, ipAddress := range ipAddresses {
-				if ipAddress.DeviceName() != device {
-					continue
-				}
-				ips = append(ips, ipAddress.Value())
-				// We don't expect to find more than one
-				// ipAddress on a device with a list of
-				// nameservers, but append in any case.
-				if len(ipAddress.DNSServers()) > 0 {
-					ns = append(ns, ipAddress.DNSServers()...)
-				}
-				// There should only be one gateway per device
-				// (per machine, in fact, as we don't store
-				// metrics). If we find more than one we should
-				// show them all.
-				if ipAddress.GatewayAddress() != "" {
-					gw = append(gw, ipAddress.GatewayAddress())
-				}
-				// There should only be one space per address,
-				// but it's technically possible to have more
-				// than one address on an interface. If we find
-				// that happens, we need to show all spaces, to
-				// be safe.
-				sp = spaces[device]
-			}
-			status.NetworkInterfaces[device] = params.NetworkInterface{
-				IPAddresses:    ips,
-				MACAddress:     llDev.MACAddress(),
-				Gateway:        strings.Join(gw, " "),
-				DNSNameservers: ns,
-				Space:          strings.Join(sp.Values(), " "),
-				IsUp:           llDev.IsUp(),
-			}
-		}
+		status.NetworkInterfaces = machineNetworkInterfaces(ipAddresses, spaces, linkLayerDevices)
 		logger.Tracef("NetworkInterfaces: %+v", status.NetworkInterfaces)
 	} else {
 		if errors.IsNotProvisioned(err) {
@@ -885,15 +856,7 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo
 			status.InstanceId = "error"
 		}
 	}
-	// TODO: preload all constraints.
-	constraints, err := machine.Constraints()
-	if err != nil {
-		if !errors.IsNotFound(err) {
-			status.Constraints = "error"
-		}
-	} else {
-		status.Constraints = constraints.String()
-	}
+	status.Constraints = machineConstraints(machine)
 	// TODO: preload all hardware characteristics.
 	hc, err := machine.HardwareCharacteristics()
 	if err != nil {
@@ -904,25 +867,90 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo
 		status.Hardware = hc.String()
 	}
 	status.Containers = make(map[string]params.MachineStatus)
+	status.LXDProfiles = machineLXDProfiles(machine, appStatusInfo.lxdProfiles)
+	return
+}
+
+// machineNetworkInterfaces returns the network interfaces of a machine,
+// keyed on device name, from its addresses, spaces and devices.
+func machineNetworkInterfaces(
+	ipAddresses []*state.Address, spaces map[string]set.Strings, linkLayerDevices []*state.LinkLayerDevice,
+) map[string]params.NetworkInterface {
+	interfaces := make(map[string]params.NetworkInterface, len(linkLayerDevices))
+	for _, llDev := range linkLayerDevices {
+		device := llDev.Name()
+		ips := []string{}
+		gw := []string{}
+		ns := []string{}
+		sp := make(set.Strings)
+		for _, ipAddress := range ipAddresses {
+			if ipAddress.DeviceName() != device {
+				continue
+			}
+			ips = append(ips, ipAddress.Value())
+			// We don't expect to find more than one
+			// ipAddress on a device with a list of
+			// nameservers, but append in any case.
+			if len(ipAddress.DNSServers()) > 0 {
+				ns = append(ns, ipAddress.DNSServers()...)
+			}
+			// There should only be one gateway per device
+			// (per machine, in fact, as we don't store
+			// metrics). If we find more than one we should
+			// show them all.
+			if ipAddress.GatewayAddress() != "" {
+				gw = append(gw, ipAddress.GatewayAddress())
+			}
+			// There should only be one space per address,
+			// but it's technically possible to have more
+			// than one address on an interface. If we find
+			// that happens, we need to show all spaces, to
+			// be safe.
+			sp = spaces[device]
+		}
+		interfaces[device] = params.NetworkInterface{
+			IPAddresses:    ips,
+			MACAddress:     llDev.MACAddress(),
+			Gateway:        strings.Join(gw, " "),
+			DNSNameservers: ns,
+			Space:          strings.Join(sp.Values(), " "),
+			IsUp:           llDev.IsUp(),
+		}
+	}
+	return interfaces
+}
+
+// machineConstraints returns the constraints of a machine for status.
+func machineConstraints(machine *state.Machine) string {
+	// TODO: preload all constraints.
+	constraints, err := machine.Constraints()
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return "error"
+		}
+		return ""
+	}
+	return constraints.String()
+}
 
+// machineLXDProfiles returns the charm LXD profiles applied to a machine.
+func machineLXDProfiles(machine *state.Machine, profiles map[string]*charm.LXDProfile) map[string]params.LXDProfile {
 	lxdProfiles := make(map[string]params.LXDProfile)
 	charmProfiles, err := machine.CharmProfiles()
-	if err == nil {
-		for _, v := range charmProfiles {
-			if profile, ok := appStatusInfo.lxdProfiles[v]; ok {
-				lxdProfiles[v] = params.LXDProfile{
-					Config:      profile.Config,
-					Description: profile.Description,
-					Devices:     profile.Devices,
-				}
+	if err != nil {
+		logger.Tracef("error fetching lxd profiles for %s: %q", machine.String(), err.Error())
+		return lxdProfiles
+	}
+	for _, v := range charmProfiles {
+		if profile, ok := profiles[v]; ok {
+			lxdProfiles[v] = params.LXDProfile{
+				Config:      profile.Config,
+				Description: profile.Description,
+				Devices:     profile.Devices,
 			}
 		}
-	} else {
-		logger.Tracef("error fetching lxd profiles for %s: %q", machine.String(), err.Error())
 	}
-	status.LXDProfiles = lxdProfiles
-
-	return
+	return lxdProfiles
 }
 
 func (context *statusContext) processRelations() []params.RelationStatus {
@@ -1048,7 +1076,7 @@ func (context *statusContext) processApplication(application *state.Application)
 	metrics := applicationCharm.Metrics()
 	planRequired := metrics != nil && metrics.Plan != nil && metrics.Plan.Required
 	if planRequired || len(application.MetricCredentials()) > 0 {
-		processedStatus.MeterStatuses = context.processUnitMeterStatuses(units)
+		processedStatus.MeterStatuses = processUnitMeterStatuses(units)
 	}
 
 	// TODO(caas) - there's no way for a CAAS charm to set workload version yet
@@ -1093,15 +1121,31 @@ func (context *statusContext) processApplication(application *state.Application)
 			// Container zero is the primary.
 			processedStatus.WorkloadVersion = fmt.Sprintf("%v", spec.Containers[0].Image)
 		}
+		var hostnames []string
 		serviceInfo, err := application.ServiceInfo()
 		if err == nil {
 			processedStatus.ProviderId = serviceInfo.ProviderId()
 			if len(serviceInfo.Addresses()) > 0 {
 				processedStatus.PublicAddress = serviceInfo.Addresses()[0].Value
 			}
+			hostnames = serviceInfo.Hostnames()
 		} else {
 			logger.Debugf("no service details for %v: %v", application.Name(), err)
 		}
+		// An exposed application is reached through an ingress
+		// resource, at the hostnames assigned by its load balancer
+		// or else at the configured external hostname.
+		if application.IsExposed() {
+			if len(hostnames) > 0 {
+				processedStatus.ExternalHostname = strings.Join(hostnames, ",")
+			} else {
+				appConfig, err := application.ApplicationConfig()
+				if err != nil {
+					return params.ApplicationStatus{Err: common.ServerError(err)}
+				}
+				processedStatus.ExternalHostname = appConfig.GetString(caas.JujuExternalHostNameKey, "")
+			}
+		}
 		processedStatus.Scale = application.GetScale()
 		processedStatus.Placement = application.GetPlacement()
 	}
@@ -1183,7 +1227,7 @@ func isColorStatus(code state.MeterStatusCode) bool {
 	return code == state.MeterGreen || code == state.MeterAmber || code == state.MeterRed
 }
 
-func (context *statusContext) processUnitMeterStatuses(units map[string]*state.Unit) map[string]params.MeterStatus {
+func processUnitMeterStatuses(units map[string]*state.Unit) map[string]params.MeterStatus {
 	unitsMap := make(map[string]params.MeterStatus)
 	for _, unit := range units {
 		meterStatus, err := unit.GetMeterStatus()
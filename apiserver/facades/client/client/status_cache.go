@@ -0,0 +1,657 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/lxdprofile"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// cachedStatusContext holds the values used to build the full status of
+// a model from the in-memory model cache.
+//
+// The machines, applications, units and relations of the model, along
+// with their statuses, come from the cache. The database is only read
+// for the details the cache does not hold:
+//   - the model section, which needs the cloud, SLA and tools details
+//     of the model;
+//   - remote applications and offers, as the cache does not track
+//     cross model entities;
+//   - the application leaders, which are held by the lease store;
+//   - the meter statuses of the units of metered applications, which
+//     depend on the state of the model's metrics manager;
+//   - the controller timestamp.
+type cachedStatusContext struct {
+	presence common.ModelPresenceContext
+
+	// applications: application name -> application
+	applications map[string]cache.ApplicationChange
+
+	// units: application name -> unit name -> unit
+	units map[string]map[string]cache.UnitChange
+
+	// subordinates: principal unit name -> subordinate unit names
+	subordinates map[string][]string
+
+	// machines: top-level machine id -> list of machines nested in
+	// this machine, sorted so that parents come before their containers.
+	machines map[string][]cache.MachineChange
+
+	// relations: application name -> relations
+	relations    map[string][]cache.RelationChange
+	allRelations []cache.RelationChange
+
+	// lxdProfiles: LXD profile name -> charm LXD profile
+	lxdProfiles map[string]*cache.LXDProfile
+
+	// consumerRemoteApplications: application name -> application
+	consumerRemoteApplications map[string]*state.RemoteApplication
+
+	// offers: offer name -> offer
+	offers map[string]offerStatus
+
+	// meterStatuses: application name -> unit name -> meter status
+	meterStatuses map[string]map[string]params.MeterStatus
+
+	leaders map[string]string
+}
+
+// cachedFullStatus returns the unfiltered full status of the model using
+// the entities held in the model cache.
+func (c *Client) cachedFullStatus() (params.FullStatus, error) {
+	var noStatus params.FullStatus
+
+	m, err := c.api.stateAccessor.Model()
+	if err != nil {
+		return noStatus, errors.Annotate(err, "cannot get model")
+	}
+	context := newCachedStatusContext(c.api.cachedModel, m.Name())
+	context.presence.Presence = c.api.presence.ModelPresence(m.UUID())
+
+	remoteApplications, err := c.api.stateAccessor.AllRemoteApplications()
+	if err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch remote applications")
+	}
+	consumerProxies := make(set.Strings)
+	for _, app := range remoteApplications {
+		if app.IsConsumerProxy() {
+			consumerProxies.Add(app.Name())
+		}
+		if _, ok := app.URL(); ok {
+			context.consumerRemoteApplications[app.Name()] = app
+		}
+	}
+	context.loadRelations(c.api.cachedModel, consumerProxies)
+
+	// Only admins can see offer details.
+	if err := c.checkIsAdmin(); err == nil {
+		if context.offers, err = fetchCachedOffers(c.api.stateAccessor, context.applications); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch application offers")
+		}
+	}
+	if err := context.loadMeterStatuses(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch meter statuses")
+	}
+	if len(context.applications) > 0 {
+		if context.leaders, err = c.api.stateAccessor.ApplicationLeaders(); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch leaders")
+		}
+	}
+	controllerTimestamp, err := c.api.stateAccessor.ControllerTimestamp()
+	if err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch controller timestamp")
+	}
+
+	// Reuse the regular status context for the cross model
+	// parts of the status, which the cache doesn't track.
+	legacy := statusContext{
+		consumerRemoteApplications: context.consumerRemoteApplications,
+		offers:                     context.offers,
+		relations:                  make(map[string][]*state.Relation),
+	}
+	remoteApplicationsStatus := make(map[string]params.RemoteApplicationStatus)
+	for name, app := range context.consumerRemoteApplications {
+		remoteStatus := legacy.processRemoteApplication(app)
+		if remoteStatus.Err == nil {
+			remoteStatus.Relations, _ = context.relatedApplications(name, nil)
+		}
+		remoteApplicationsStatus[name] = remoteStatus
+	}
+
+	modelStatus, err := c.modelStatus()
+	if err != nil {
+		return noStatus, errors.Annotate(err, "cannot determine model status")
+	}
+	return params.FullStatus{
+		Model:               modelStatus,
+		Machines:            context.processMachines(),
+		Applications:        context.processApplications(),
+		RemoteApplications:  remoteApplicationsStatus,
+		Offers:              legacy.processOffers(),
+		Relations:           context.processRelations(),
+		ControllerTimestamp: controllerTimestamp,
+	}, nil
+}
+
+func newCachedStatusContext(model *cache.Model, modelName string) *cachedStatusContext {
+	context := &cachedStatusContext{
+		applications:               make(map[string]cache.ApplicationChange),
+		units:                      make(map[string]map[string]cache.UnitChange),
+		subordinates:               make(map[string][]string),
+		machines:                   make(map[string][]cache.MachineChange),
+		relations:                  make(map[string][]cache.RelationChange),
+		lxdProfiles:                make(map[string]*cache.LXDProfile),
+		consumerRemoteApplications: make(map[string]*state.RemoteApplication),
+		meterStatuses:              make(map[string]map[string]params.MeterStatus),
+	}
+	for name, app := range model.Applications() {
+		details := app.Details()
+		context.applications[name] = details
+		if details.CharmLXDProfile == nil {
+			continue
+		}
+		curl, err := charm.ParseURL(details.CharmURL)
+		if err != nil {
+			continue
+		}
+		context.lxdProfiles[lxdprofile.Name(modelName, name, curl.Revision)] = details.CharmLXDProfile
+	}
+	for name, unit := range model.Units() {
+		details := unit.Details()
+		appUnits, found := context.units[details.Application]
+		if !found {
+			appUnits = make(map[string]cache.UnitChange)
+			context.units[details.Application] = appUnits
+		}
+		appUnits[name] = details
+		if details.Principal != "" {
+			context.subordinates[details.Principal] = append(context.subordinates[details.Principal], name)
+		}
+	}
+
+	var machines []cache.MachineChange
+	for _, machine := range model.Machines() {
+		machines = append(machines, machine.Details())
+	}
+	// Sort on nesting depth first so that parents are always processed
+	// before the containers they host.
+	sort.Slice(machines, func(i, j int) bool {
+		di, dj := strings.Count(machines[i].Id, "/"), strings.Count(machines[j].Id, "/")
+		if di != dj {
+			return di < dj
+		}
+		return machines[i].Id < machines[j].Id
+	})
+	for _, machine := range machines {
+		topLevel := strings.Split(machine.Id, "/")[0]
+		context.machines[topLevel] = append(context.machines[topLevel], machine)
+	}
+	return context
+}
+
+// loadRelations records the cached relations, excluding any relations
+// where either end is a remote application on the offering side.
+func (context *cachedStatusContext) loadRelations(model *cache.Model, consumerProxies set.Strings) {
+	for _, relation := range model.Relations() {
+		details := relation.Details()
+		isRemote := false
+		for _, ep := range details.Endpoints {
+			if consumerProxies.Contains(ep.Application) {
+				isRemote = true
+				break
+			}
+		}
+		if isRemote {
+			continue
+		}
+		context.allRelations = append(context.allRelations, details)
+		for _, ep := range details.Endpoints {
+			context.relations[ep.Application] = append(context.relations[ep.Application], details)
+		}
+	}
+	sort.Slice(context.allRelations, func(i, j int) bool {
+		return context.allRelations[i].Id < context.allRelations[j].Id
+	})
+}
+
+// loadMeterStatuses records the meter statuses of the units of
+// applications that are metered.
+func (context *cachedStatusContext) loadMeterStatuses(st Backend) error {
+	for name, app := range context.applications {
+		if !app.MetricsPlanRequired && !app.HasMetricCredentials {
+			continue
+		}
+		application, err := st.Application(name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		units, err := application.AllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		unitMap := make(map[string]*state.Unit)
+		for _, unit := range units {
+			unitMap[unit.Name()] = unit
+		}
+		context.meterStatuses[name] = processUnitMeterStatuses(unitMap)
+	}
+	return nil
+}
+
+// fetchCachedOffers returns a map from offer name to offer status,
+// using the cached applications to determine the charm URLs.
+func fetchCachedOffers(st Backend, applications map[string]cache.ApplicationChange) (map[string]offerStatus, error) {
+	offersMap := make(map[string]offerStatus)
+	offers, err := st.AllApplicationOffers()
+	if err != nil {
+		return nil, err
+	}
+	for _, offer := range offers {
+		app, ok := applications[offer.ApplicationName]
+		if !ok {
+			continue
+		}
+		offerInfo := offerStatus{
+			ApplicationOffer: crossmodel.ApplicationOffer{
+				OfferName:       offer.OfferName,
+				OfferUUID:       offer.OfferUUID,
+				ApplicationName: offer.ApplicationName,
+				Endpoints:       offer.Endpoints,
+			},
+			charmURL: app.CharmURL,
+		}
+		rc, err := st.RemoteConnectionStatus(offer.OfferUUID)
+		if err != nil && !errors.IsNotFound(err) {
+			offerInfo.err = err
+			continue
+		} else if err == nil {
+			offerInfo.totalConnectedCount = rc.TotalConnectionCount()
+			offerInfo.activeConnectedCount = rc.ActiveConnectionCount()
+		}
+		offersMap[offer.OfferName] = offerInfo
+	}
+	return offersMap, nil
+}
+
+func (context *cachedStatusContext) processMachines() map[string]params.MachineStatus {
+	machinesMap := make(map[string]params.MachineStatus)
+	processed := make(map[string]params.MachineStatus)
+	for id, machines := range context.machines {
+		// Element 0 is the top-level machine.
+		hostStatus := context.makeMachineStatus(machines[0])
+		machinesMap[id] = hostStatus
+		processed[id] = hostStatus
+
+		for _, machine := range machines[1:] {
+			parent, ok := processed[state.ParentId(machine.Id)]
+			if !ok {
+				logger.Errorf("programmer error, please file a bug, reference this whole log line: %q, %q", id, machine.Id)
+				continue
+			}
+			status := context.makeMachineStatus(machine)
+			parent.Containers[machine.Id] = status
+			processed[machine.Id] = status
+		}
+	}
+	return machinesMap
+}
+
+func (context *cachedStatusContext) makeMachineStatus(machine cache.MachineChange) (status params.MachineStatus) {
+	status.Id = machine.Id
+	status.AgentStatus = context.processMachine(machine)
+	status.Series = machine.Series
+	status.Jobs = make([]multiwatcher.MachineJob, len(machine.Jobs))
+	for i, job := range machine.Jobs {
+		status.Jobs[i] = multiwatcher.MachineJob(job)
+	}
+	status.WantsVote = machine.WantsVote
+	status.HasVote = machine.HasVote
+	populateStatusFromStatusInfoAndErr(&status.InstanceStatus, machine.InstanceStatus, nil)
+
+	if machine.InstanceId != "" {
+		status.InstanceId = machine.InstanceId
+		status.DisplayName = machine.DisplayName
+		addresses := make([]network.Address, len(machine.Addresses))
+		for i, addr := range machine.Addresses {
+			addresses[i] = network.Address{
+				Value: addr.Value,
+				Type:  network.AddressType(addr.Type),
+				Scope: network.Scope(addr.Scope),
+			}
+		}
+		if addr, ok := network.SelectPublicAddress(addresses); ok {
+			status.DNSName = addr.Value
+		}
+		for _, addr := range addresses {
+			switch addr.Scope {
+			case network.ScopeMachineLocal, network.ScopeLinkLocal:
+				continue
+			}
+			status.IPAddresses = append(status.IPAddresses, addr.Value)
+		}
+		status.NetworkInterfaces = make(map[string]params.NetworkInterface, len(machine.NetworkInterfaces))
+		for _, iface := range machine.NetworkInterfaces {
+			status.NetworkInterfaces[iface.DeviceName] = params.NetworkInterface{
+				IPAddresses:    append([]string{}, iface.IPAddresses...),
+				MACAddress:     iface.MACAddress,
+				Gateway:        strings.Join(iface.Gateways, " "),
+				DNSNameservers: append([]string{}, iface.DNSNameservers...),
+				Space:          strings.Join(iface.Spaces, " "),
+				IsUp:           iface.IsUp,
+			}
+		}
+	} else {
+		status.InstanceId = "pending"
+	}
+	if machine.HardwareCharacteristics != nil {
+		status.Hardware = machine.HardwareCharacteristics.String()
+	}
+	status.Constraints = machine.Constraints.String()
+	status.Containers = make(map[string]params.MachineStatus)
+	status.LXDProfiles = make(map[string]params.LXDProfile)
+	for _, name := range machine.CharmProfiles {
+		if profile, ok := context.lxdProfiles[name]; ok {
+			status.LXDProfiles[name] = params.LXDProfile{
+				Config:      profile.Config,
+				Description: profile.Description,
+				Devices:     profile.Devices,
+			}
+		}
+	}
+	return
+}
+
+// processMachine retrieves version and status information for the given machine.
+func (context *cachedStatusContext) processMachine(machine cache.MachineChange) (out params.DetailedStatus) {
+	wrapped := &cachedMachine{machine, context}
+	statusInfo, err := context.presence.MachineStatus(wrapped)
+	populateStatusFromStatusInfoAndErr(&out, statusInfo, err)
+
+	out.Life = processCachedLife(machine.Life)
+	out.Version = machine.AgentVersion
+	return
+}
+
+func (context *cachedStatusContext) processApplications() map[string]params.ApplicationStatus {
+	applicationsMap := make(map[string]params.ApplicationStatus)
+	for name, app := range context.applications {
+		applicationsMap[name] = context.processApplication(app)
+	}
+	return applicationsMap
+}
+
+func (context *cachedStatusContext) processApplication(application cache.ApplicationChange) params.ApplicationStatus {
+	curl, err := charm.ParseURL(application.CharmURL)
+	if err != nil {
+		return params.ApplicationStatus{Err: common.ServerError(err)}
+	}
+	units := context.units[application.Name]
+
+	var processedStatus = params.ApplicationStatus{
+		Charm:        application.CharmURL,
+		CharmVersion: application.CharmVersion,
+		Series:       application.Series,
+		Exposed:      application.Exposed,
+		Life:         processCachedLife(application.Life),
+	}
+	if processedStatus.Series == "" {
+		processedStatus.Series = curl.Series
+	}
+
+	// The latest store revision is only of interest for
+	// applications that have units to upgrade.
+	if len(units) > 0 && application.LatestCharmURL != "" {
+		latest, err := charm.ParseURL(application.LatestCharmURL)
+		if err == nil && *latest.WithRevision(-1) == *curl.WithRevision(-1) && latest.Revision > curl.Revision {
+			processedStatus.CanUpgradeTo = application.LatestCharmURL
+		}
+	}
+
+	processedStatus.Relations, processedStatus.SubordinateTo = context.relatedApplications(application.Name, &application)
+	if !application.Subordinate {
+		processedStatus.Units = make(map[string]params.UnitStatus)
+		for name, unit := range units {
+			if unit.Principal == "" {
+				processedStatus.Units[name] = context.processUnit(unit, application.CharmURL)
+			}
+		}
+	}
+	processedStatus.Status.Status = application.Status.Status.String()
+	processedStatus.Status.Info = application.Status.Message
+	processedStatus.Status.Data = application.Status.Data
+	processedStatus.Status.Since = application.Status.Since
+
+	processedStatus.MeterStatuses = context.meterStatuses[application.Name]
+	if len(units) > 0 {
+		processedStatus.WorkloadVersion = application.WorkloadVersion
+	}
+	processedStatus.EndpointBindings = application.EndpointBindings
+	return processedStatus
+}
+
+func (context *cachedStatusContext) processUnit(unit cache.UnitChange, applicationCharm string) params.UnitStatus {
+	var result params.UnitStatus
+	result.PublicAddress = unit.PublicAddress
+	for _, pr := range unit.PortRanges {
+		portRange := corenetwork.PortRange{
+			FromPort: pr.FromPort,
+			ToPort:   pr.ToPort,
+			Protocol: pr.Protocol,
+		}
+		result.OpenedPorts = append(result.OpenedPorts, portRange.String())
+	}
+	if unit.Principal == "" {
+		result.Machine = unit.MachineId
+	}
+	if applicationCharm != "" && unit.CharmURL != "" && unit.CharmURL != applicationCharm {
+		result.Charm = unit.CharmURL
+	}
+	result.WorkloadVersion = unit.WorkloadVersion
+
+	wrapped := &cachedUnit{unit, context}
+	agent, workload := context.presence.UnitStatus(wrapped)
+	populateStatusFromStatusInfoAndErr(&result.AgentStatus, agent.Status, agent.Err)
+	populateStatusFromStatusInfoAndErr(&result.WorkloadStatus, workload.Status, workload.Err)
+	result.AgentStatus.Version = unit.AgentVersion
+
+	if subUnits := context.subordinates[unit.Name]; len(subUnits) > 0 {
+		result.Subordinates = make(map[string]params.UnitStatus)
+		for _, name := range subUnits {
+			appName := strings.Split(name, "/")[0]
+			if subUnit, ok := context.units[appName][name]; ok {
+				result.Subordinates[name] = context.processUnit(subUnit, applicationCharm)
+			}
+		}
+	}
+	if leader := context.leaders[unit.Application]; leader == unit.Name {
+		result.Leader = true
+	}
+	return result
+}
+
+// relatedApplications returns the applications related to the named
+// application, keyed on relation name. If the application details are
+// supplied, the names of any subordinate applications related to it
+// are also returned.
+func (context *cachedStatusContext) relatedApplications(appName string, application *cache.ApplicationChange) (related map[string][]string, subord []string) {
+	subordSet := make(set.Strings)
+	related = make(map[string][]string)
+	for _, relation := range context.relations[appName] {
+		var relationName string
+		for _, ep := range relation.Endpoints {
+			if ep.Application == appName {
+				relationName = ep.Name
+			}
+		}
+		for _, ep := range relation.Endpoints {
+			// Peer relations have the application on both ends.
+			if ep.Application == appName && ep.Role != string(charm.RolePeer) {
+				continue
+			}
+			if application != nil && context.isSubordinate(ep, *application) {
+				subordSet.Add(ep.Application)
+			}
+			related[relationName] = append(related[relationName], ep.Application)
+		}
+	}
+	for relationName, applicationNames := range related {
+		sn := set.NewStrings(applicationNames...)
+		related[relationName] = sn.SortedValues()
+	}
+	return related, subordSet.SortedValues()
+}
+
+// isSubordinate returns whether the endpoint is the container scoped
+// end of a subordinate application.
+func (context *cachedStatusContext) isSubordinate(ep cache.RelationEndpoint, application cache.ApplicationChange) bool {
+	return ep.Scope == string(charm.ScopeContainer) && application.Subordinate
+}
+
+func (context *cachedStatusContext) processRelations() []params.RelationStatus {
+	var out []params.RelationStatus
+	for _, relation := range context.allRelations {
+		var eps []params.EndpointStatus
+		var scope, relationInterface string
+		for _, ep := range relation.Endpoints {
+			subordinate := false
+			if app, ok := context.applications[ep.Application]; ok {
+				subordinate = context.isSubordinate(ep, app)
+			}
+			eps = append(eps, params.EndpointStatus{
+				ApplicationName: ep.Application,
+				Name:            ep.Name,
+				Role:            ep.Role,
+				Subordinate:     subordinate,
+			})
+			// these should match on both sides so use the last
+			relationInterface = ep.Interface
+			scope = ep.Scope
+		}
+		relStatus := params.RelationStatus{
+			Id:        relation.Id,
+			Key:       relation.Key,
+			Interface: relationInterface,
+			Scope:     scope,
+			Endpoints: eps,
+		}
+		populateStatusFromStatusInfoAndErr(&relStatus.Status, relation.Status, nil)
+		out = append(out, relStatus)
+	}
+	return out
+}
+
+// cachedUnit implements common.UnitStatusGetter using the cached unit
+// details.
+type cachedUnit struct {
+	details cache.UnitChange
+	context *cachedStatusContext
+}
+
+// AgentStatus implements UnitStatusGetter. The cache records an agent
+// in error as the workload status, so in that case the agent is
+// reported as idle, as it is for the database status values.
+func (u *cachedUnit) AgentStatus() (status.StatusInfo, error) {
+	if u.details.WorkloadStatus.Status == status.Error {
+		return status.StatusInfo{
+			Status: status.Idle,
+			Data:   map[string]interface{}{},
+			Since:  u.details.WorkloadStatus.Since,
+		}, nil
+	}
+	return u.details.AgentStatus, nil
+}
+
+// Status implements UnitStatusGetter.
+func (u *cachedUnit) Status() (status.StatusInfo, error) {
+	return u.details.WorkloadStatus, nil
+}
+
+// AgentPresence implements UnitStatusGetter. The cached status is
+// only used with the pubsub presence, which takes precedence over
+// the agent presence of the unit.
+func (u *cachedUnit) AgentPresence() (bool, error) {
+	return false, errors.NotSupportedf("agent presence for cached unit %q", u.details.Name)
+}
+
+// ShouldBeAssigned implements UnitStatusGetter. Only IAAS models
+// use the cache for status, and their units are always assigned.
+func (u *cachedUnit) ShouldBeAssigned() bool {
+	return true
+}
+
+// Name implements UnitStatusGetter.
+func (u *cachedUnit) Name() string {
+	return u.details.Name
+}
+
+// Life implements UnitStatusGetter.
+func (u *cachedUnit) Life() state.Life {
+	return stateLife(u.details.Life)
+}
+
+// cachedMachine implements common.MachineStatusGetter using the cached
+// machine details.
+type cachedMachine struct {
+	details cache.MachineChange
+	context *cachedStatusContext
+}
+
+// Status implements MachineStatusGetter.
+func (m *cachedMachine) Status() (status.StatusInfo, error) {
+	return m.details.AgentStatus, nil
+}
+
+// AgentPresence implements MachineStatusGetter. The cached status is
+// only used with the pubsub presence, which takes precedence over the
+// agent presence of the machine.
+func (m *cachedMachine) AgentPresence() (bool, error) {
+	return false, errors.NotSupportedf("agent presence for cached machine %q", m.details.Id)
+}
+
+// Id implements MachineStatusGetter.
+func (m *cachedMachine) Id() string {
+	return m.details.Id
+}
+
+// Life implements MachineStatusGetter.
+func (m *cachedMachine) Life() state.Life {
+	return stateLife(m.details.Life)
+}
+
+func stateLife(value life.Value) state.Life {
+	switch value {
+	case life.Dying:
+		return state.Dying
+	case life.Dead:
+		return state.Dead
+	default:
+		return state.Alive
+	}
+}
+
+func processCachedLife(value life.Value) string {
+	if value == life.Alive || value == "" {
+		// alive is the usual state so omit it by default.
+		return ""
+	}
+	return string(value)
+}
@@ -13,14 +13,21 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/client"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater/testing"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/feature"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -66,6 +73,53 @@ func (s *statusSuite) TestFullStatus(c *gc.C) {
 	c.Check(resultMachine.LXDProfiles, gc.HasLen, 0)
 }
 
+func (s *statusSuite) exposedCAASApplicationStatus(c *gc.C, hostnames []string) params.ApplicationStatus {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	fields, err := caas.ConfigSchema(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	app := f.MakeApplication(c, &factory.ApplicationParams{
+		Name:                    "gitlab",
+		Charm:                   ch,
+		ApplicationConfig:       map[string]interface{}{caas.JujuExternalHostNameKey: "exthost"},
+		ApplicationConfigFields: fields,
+	})
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateCloudService("id", []network.Address{{Value: "10.0.0.1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetServiceHostnames(hostnames)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := client.NewFacade(facadetest.Context{
+		State_:     st,
+		StatePool_: s.StatePool,
+		Auth_: apiservertesting.FakeAuthorizer{
+			Tag:        s.AdminUserTag(c),
+			Controller: true,
+		},
+		Resources_: common.NewResources(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := api.FullStatus(params.StatusParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, ok := status.Applications["gitlab"]
+	c.Assert(ok, jc.IsTrue)
+	return appStatus
+}
+
+func (s *statusSuite) TestFullStatusCAASExternalHostname(c *gc.C) {
+	appStatus := s.exposedCAASApplicationStatus(c, []string{"gitlab.example.com", "10.0.0.2"})
+	c.Check(appStatus.ExternalHostname, gc.Equals, "gitlab.example.com,10.0.0.2")
+}
+
+func (s *statusSuite) TestFullStatusCAASConfiguredExternalHostname(c *gc.C) {
+	appStatus := s.exposedCAASApplicationStatus(c, nil)
+	c.Check(appStatus.ExternalHostname, gc.Equals, "exthost")
+}
+
 func (s *statusSuite) TestUnsupportedNoModelMeterStatus(c *gc.C) {
 	s.addMachine(c)
 	c.Assert(s.State.SetSLA("unsupported", "test-user", []byte("")), jc.ErrorIsNil)
@@ -94,6 +148,108 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+type cachedStatusSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&cachedStatusSuite{})
+
+func (s *cachedStatusSuite) SetUpTest(c *gc.C) {
+	// The feature needs to be in the controller config
+	// before the API server starts.
+	s.ControllerConfigAttrs = map[string]interface{}{
+		"features": []string{feature.CachedStatus},
+	}
+	s.baseSuite.SetUpTest(c)
+}
+
+func (s *cachedStatusSuite) waitForCachedUnit(c *gc.C, unitName string) {
+	model, err := s.Controller.Model(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.State.StartSync()
+		if _, err := model.Unit(unitName); err == nil {
+			return
+		}
+	}
+	c.Fatalf("unit %q not added to the model cache", unitName)
+}
+
+func (s *cachedStatusSuite) TestFullStatus(c *gc.C) {
+	u := s.Factory.MakeUnit(c, nil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForCachedUnit(c, u.Name())
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Model.Name, gc.Equals, "controller")
+	c.Check(status.Machines, gc.HasLen, 1)
+	_, ok := status.Machines[machineId]
+	c.Check(ok, jc.IsTrue)
+
+	app, ok := status.Applications[u.ApplicationName()]
+	c.Assert(ok, jc.IsTrue)
+	unit, ok := app.Units[u.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(unit.Machine, gc.Equals, machineId)
+}
+
+func (s *cachedStatusSuite) TestFullStatusCachedDetails(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	machineId := machine.Id()
+	u := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	s.waitForCachedUnit(c, u.Name())
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	machineStatus, ok := status.Machines[machineId]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(machineStatus.Constraints, gc.Equals, "mem=4096M")
+	c.Check(machineStatus.LXDProfiles, gc.HasLen, 0)
+
+	app, err := u.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, ok := status.Applications[u.ApplicationName()]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(appStatus.Series, gc.Equals, app.Series())
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appStatus.EndpointBindings, jc.DeepEquals, bindings)
+	c.Check(appStatus.Status.Status, gc.Not(gc.Equals), "")
+}
+
+type cachedStatusOldPresenceSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&cachedStatusOldPresenceSuite{})
+
+func (s *cachedStatusOldPresenceSuite) SetUpTest(c *gc.C) {
+	s.ControllerConfigAttrs = map[string]interface{}{
+		"features": []string{feature.CachedStatus, feature.OldPresence},
+	}
+	s.baseSuite.SetUpTest(c)
+}
+
+func (s *cachedStatusOldPresenceSuite) TestFullStatus(c *gc.C) {
+	u := s.Factory.MakeUnit(c, nil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	app, ok := status.Applications[u.ApplicationName()]
+	c.Assert(ok, jc.IsTrue)
+	unit, ok := app.Units[u.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(unit.AgentStatus.Err, gc.IsNil)
+	c.Check(unit.WorkloadStatus.Err, gc.IsNil)
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
@@ -7,8 +7,12 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/juju/collections/set"
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/txn"
@@ -46,10 +50,17 @@ type ControllerAPI struct {
 	hub        facade.Hub
 }
 
+// ControllerAPIv7 provides the v7 Controller API. The only difference
+// between this and v8 is that v7 doesn't have the DryRunMigration and
+// MigrationBatchStatus methods, or batch migrations.
+type ControllerAPIv7 struct {
+	*ControllerAPI
+}
+
 // ControllerAPIv6 provides the v6 Controller API. The only difference
 // between this and v7 is that v6 doesn't have the IdentityProviderURL method.
 type ControllerAPIv6 struct {
-	*ControllerAPI
+	*ControllerAPIv7
 }
 
 // ControllerAPIv5 provides the v5 Controller API. The only difference
@@ -70,8 +81,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv7 creates a new ControllerAPIv7.
-func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -89,6 +100,15 @@ func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
+	v8, err := NewControllerAPIv8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv7{v8}, nil
+}
+
 // NewControllerAPIv6 creates a new ControllerAPIv6.
 func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
 	v7, err := NewControllerAPIv7(ctx)
@@ -474,7 +494,8 @@ func (c *ControllerAPI) GetControllerAccess(req params.Entities) (params.UserAcc
 }
 
 // InitiateMigration attempts to begin the migration of one or
-// more models to other controllers.
+// more models to other controllers. If MaxConcurrent is set, the
+// models are migrated as a batch; see initiateMigrationBatch.
 func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs) (
 	params.InitiateMigrationResults, error,
 ) {
@@ -484,6 +505,9 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 	if err := c.checkHasAdmin(); err != nil {
 		return out, errors.Trace(err)
 	}
+	if reqArgs.MaxConcurrent > 0 {
+		return c.initiateMigrationBatch(reqArgs.Specs, reqArgs.MaxConcurrent)
+	}
 
 	for i, spec := range reqArgs.Specs {
 		result := &out.Results[i]
@@ -499,63 +523,322 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	modelTag, targetInfo, err := c.parseMigrationSpec(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer hostedState.Release()
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// initiateMigrationBatch migrates the models to a single target
+// controller, with at most maxConcurrent of the migrations in progress
+// at once. Every model is checked first, and no migrations are started
+// unless they all pass. The migrations which can't be started straight
+// away are queued, and are started by the migrationmaster workers as
+// earlier migrations in the batch finish.
+func (c *ControllerAPI) initiateMigrationBatch(specs []params.MigrationSpec, maxConcurrent int) (
+	params.InitiateMigrationResults, error,
+) {
+	out := params.InitiateMigrationResults{
+		Results: make([]params.InitiateMigrationResult, len(specs)),
+	}
+	modelUUIDs := make([]string, len(specs))
+	targets := make([]coremigration.TargetInfo, len(specs))
+	batch := set.NewStrings()
+	var targetTag names.ControllerTag
+	for i, spec := range specs {
+		out.Results[i].ModelTag = spec.ModelTag
+		modelTag, targetInfo, err := c.parseMigrationSpec(spec)
+		if err == nil {
+			if targetTag.Id() == "" {
+				targetTag = targetInfo.ControllerTag
+			} else if targetInfo.ControllerTag != targetTag {
+				err = errors.New("models in a batch must be migrated to the same controller")
+			}
+		}
+		if err != nil {
+			out.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		modelUUIDs[i] = modelTag.Id()
+		targets[i] = targetInfo
+		batch.Add(modelTag.Id())
+	}
+
+	failed := false
+	for i := range specs {
+		if out.Results[i].Error == nil {
+			if err := c.checkBatchMigration(modelUUIDs[i], &targets[i], batch); err != nil {
+				out.Results[i].Error = common.ServerError(err)
+			}
+		}
+		if out.Results[i].Error != nil {
+			failed = true
+		}
+	}
+	if failed {
+		return out, nil
+	}
+
+	migrations, err := state.NewController(c.statePool).AddMigrationBatch(modelUUIDs, maxConcurrent)
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+	out.BatchId = migrations.Id()
+	started, err := migrations.StartQueued(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targets[0],
+	})
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+	indexes := make(map[string]int)
+	for i, modelUUID := range modelUUIDs {
+		indexes[modelUUID] = i
+	}
+	for _, mig := range started {
+		out.Results[indexes[mig.ModelUUID()]].MigrationId = mig.Id()
+	}
+	for modelUUID, reason := range migrations.Failed() {
+		out.Results[indexes[modelUUID]].Error = common.ServerError(errors.New(reason))
+	}
+	return out, nil
+}
+
+// checkBatchMigration runs the prechecks for the migration of a model
+// in a batch, and checks that it has no cross-model relations.
+func (c *ControllerAPI) checkBatchMigration(modelUUID string, targetInfo *coremigration.TargetInfo, batch set.Strings) error {
+	hostedState, err := c.statePool.Get(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	defer hostedState.Release()
 
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), targetInfo, c.presence); err != nil {
+		return errors.Trace(err)
+	}
+	problems, err := c.crossModelProblems(hostedState.State, batch)
+	if err != nil {
+		return errors.Annotate(err, "checking cross model relations")
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// MigrationBatchStatus reports the models in a migration batch whose
+// migrations haven't been started yet, and those whose migrations
+// couldn't be started.
+func (c *ControllerAPI) MigrationBatchStatus(arg params.MigrationBatchArg) (params.MigrationBatchStatus, error) {
+	var out params.MigrationBatchStatus
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+	migrations, err := state.NewController(c.statePool).MigrationBatch(arg.BatchId)
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+	out.Queued = []string{}
+	for _, modelUUID := range migrations.Queued() {
+		out.Queued = append(out.Queued, names.NewModelTag(modelUUID).String())
+	}
+	out.Failed = []params.InitiateMigrationResult{}
+	failed := migrations.Failed()
+	var failedUUIDs []string
+	for modelUUID := range failed {
+		failedUUIDs = append(failedUUIDs, modelUUID)
+	}
+	sort.Strings(failedUUIDs)
+	for _, modelUUID := range failedUUIDs {
+		out.Failed = append(out.Failed, params.InitiateMigrationResult{
+			ModelTag: names.NewModelTag(modelUUID).String(),
+			Error:    common.ServerError(errors.New(failed[modelUUID])),
+		})
+	}
+	return out, nil
+}
+
+// MigrationBatchStatus isn't on the v7 API.
+func (c *ControllerAPIv7) MigrationBatchStatus(_, _ struct{}) {}
+
+// DryRunMigration checks whether one or more models could be migrated
+// to other controllers, without starting the migrations. Rather than
+// stopping at the first problem, as InitiateMigration does, it reports
+// every problem found on the source and target controllers, along with
+// the binaries each migration would upload.
+func (c *ControllerAPI) DryRunMigration(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	batch := set.NewStrings()
+	for _, spec := range reqArgs.Specs {
+		if modelTag, err := names.ParseModelTag(spec.ModelTag); err == nil {
+			batch.Add(modelTag.Id())
+		}
+	}
+	for i, spec := range reqArgs.Specs {
+		result, err := c.dryRunOneMigration(spec, batch)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		result.ModelTag = spec.ModelTag
+		out.Results[i] = result
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) dryRunOneMigration(spec params.MigrationSpec, batch set.Strings) (params.MigrationDryRunResult, error) {
+	modelTag, targetInfo, err := c.parseMigrationSpec(spec)
+	if err != nil {
+		return params.MigrationDryRunResult{}, errors.Trace(err)
+	}
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return params.MigrationDryRunResult{}, errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	result, err := runMigrationDryRun(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	problems, err := c.crossModelProblems(hostedState.State, batch)
+	if err != nil {
+		return result, errors.Annotate(err, "checking cross model relations")
+	}
+	result.SourceProblems = append(result.SourceProblems, problems...)
+	return result, nil
+}
+
+// crossModelProblems reports the model's cross-model relations, which
+// would stop the migration. Relations with models which aren't in the
+// batch being migrated would be broken. Relations between models in
+// the same batch are still reported: the target controller can't
+// import remote applications, and offers and their consumers aren't
+// re-pointed to the target controller, so those relations can't be
+// migrated yet either.
+func (c *ControllerAPI) crossModelProblems(st *state.State, batch set.Strings) ([]string, error) {
+	remoteApps, err := st.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var problems []string
+	for _, app := range remoteApps {
+		problems = append(problems, fmt.Sprintf(
+			"application %s is related to model %s, %s",
+			app.Name(), c.modelName(app.SourceModel().Id()),
+			crossModelReason(batch, app.SourceModel().Id())))
+	}
+
+	offers, err := state.NewApplicationOffers(st).AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, offer := range offers {
+		conns, err := st.OfferConnections(offer.OfferUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, conn := range conns {
+			problems = append(problems, fmt.Sprintf(
+				"offer %s is consumed by model %s, %s",
+				offer.OfferName, c.modelName(conn.SourceModelUUID()),
+				crossModelReason(batch, conn.SourceModelUUID())))
+		}
+	}
+	return problems, nil
+}
+
+// crossModelReason explains why a cross-model relation with the
+// identified model stops a migration.
+func crossModelReason(batch set.Strings, modelUUID string) string {
+	if batch.Contains(modelUUID) {
+		return "and cross-model relations can't be migrated, even between models migrated together"
+	}
+	return "which isn't being migrated with it"
+}
+
+// modelName returns the qualified name of the identified model if it's
+// hosted by this controller, or its UUID otherwise.
+func (c *ControllerAPI) modelName(uuid string) string {
+	model, ph, err := c.statePool.GetModel(uuid)
+	if err != nil {
+		return uuid
+	}
+	defer ph.Release()
+	return fmt.Sprintf("%s/%s", model.Owner().Id(), model.Name())
+}
+
+// DryRunMigration isn't on the v7 API.
+func (c *ControllerAPIv7) DryRunMigration(_, _ struct{}) {}
+
+// parseMigrationSpec checks that the model to migrate exists and
+// returns its tag, along with the target controller details from the
+// spec.
+func (c *ControllerAPI) parseMigrationSpec(spec params.MigrationSpec) (names.ModelTag, coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return modelTag, empty, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return modelTag, empty, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return modelTag, empty, errors.NotFoundf("model")
+	}
+
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return modelTag, empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return modelTag, empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return modelTag, empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	return modelTag, coremigration.TargetInfo{
 		ControllerTag: controllerTag,
 		Addrs:         specTarget.Addrs,
 		CACert:        specTarget.CACert,
 		AuthTag:       authTag,
 		Password:      specTarget.Password,
 		Macaroons:     macs,
-	}
-
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
-	if err != nil {
-		return "", errors.Trace(err)
-	}
-	return mig.Id(), nil
+	}, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -658,21 +941,87 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 		return errors.Trace(err)
 	}
 	client := migrationtarget.NewClient(conn)
-	if targetInfo.CACert == "" {
-		targetInfo.CACert, err = client.CACert()
-		if err != nil {
-			if !params.IsCodeNotImplemented(err) {
-				return errors.Annotatef(err, "cannot retrieve CA certificate")
-			}
-			// If the call's not implemented, it indicates an earlier version
-			// of the controller, which we can't migrate to.
-			return errors.New("controller API version is too old")
-		}
+	if err := fillTargetCACert(client, targetInfo); err != nil {
+		return errors.Trace(err)
 	}
 	err = client.Prechecks(modelInfo)
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationDryRun runs all the prechecks on the source and target
+// controllers, and a trial import of the model on the target, and
+// reports every problem found along with the binaries a migration
+// would upload. An error is only returned when the checks can't be
+// run at all.
+var runMigrationDryRun = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) (params.MigrationDryRunResult, error) {
+	var result params.MigrationDryRunResult
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return result, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	for _, problem := range migration.SourcePrecheckAll(backend, modelPresence, controllerPresence) {
+		result.SourceProblems = append(result.SourceProblems, problem.Error())
+	}
+
+	model, err := st.Export()
+	if err != nil {
+		return result, errors.Annotate(err, "exporting model")
+	}
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return result, errors.Annotate(err, "serializing model")
+	}
+	binaries := coremigration.ModelBinaries(model)
+	result.Charms = binaries.Charms
+	for _, tools := range binaries.Tools {
+		result.Tools = append(result.Tools, tools.String())
+	}
+	result.Resources = binaries.Resources
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return result, errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	client := migrationtarget.NewClient(conn)
+	if err := fillTargetCACert(client, targetInfo); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.TargetProblems, err = client.DryRun(modelInfo, bytes)
+	if errors.IsNotSupported(err) {
+		return result, errors.New("target controller doesn't support migration dry runs")
+	}
+	return result, errors.Annotate(err, "target dry run failed")
+}
+
+// fillTargetCACert sets the target controller's CA certificate in
+// targetInfo, retrieving it from the target if it wasn't specified.
+func fillTargetCACert(client *migrationtarget.Client, targetInfo *coremigration.TargetInfo) error {
+	if targetInfo.CACert != "" {
+		return nil
+	}
+	var err error
+	targetInfo.CACert, err = client.CACert()
+	if err != nil {
+		if !params.IsCodeNotImplemented(err) {
+			return errors.Annotatef(err, "cannot retrieve CA certificate")
+		}
+		// If the call's not implemented, it indicates an earlier version
+		// of the controller, which we can't migrate to.
+		return errors.New("controller API version is too old")
+	}
+	return nil
+}
+
 func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
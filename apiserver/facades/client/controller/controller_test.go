@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -64,7 +66,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
 
-	controller, err := controller.NewControllerAPIv7(
+	controller, err := controller.NewControllerAPIv8(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -571,6 +573,242 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestDryRunMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, params.MigrationDryRunResult{
+		SourceProblems: []string{"machine 0 is dying"},
+		TargetProblems: []string{"model named \"foo\" already exists"},
+		Charms:         []string{"cs:xenial/mysql-1"},
+		Tools:          []string{"2.5.0-xenial-amd64"},
+	}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}, {
+			ModelTag: randomModelTag(),
+		}},
+	}
+	out, err := s.controller.DryRunMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationDryRunResult{
+		ModelTag:       m.ModelTag().String(),
+		SourceProblems: []string{"machine 0 is dying"},
+		TargetProblems: []string{"model named \"foo\" already exists"},
+		Charms:         []string{"cs:xenial/mysql-1"},
+		Tools:          []string{"2.5.0-xenial-amd64"},
+	})
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// Nothing is migrated.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestDryRunMigrationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, params.MigrationDryRunResult{
+		SourceProblems: []string{"machine 0 is dying"},
+	}, errors.New("connect to target controller: boom"))
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				AuthTag:       names.NewUserTag("admin1").String(),
+			},
+		}},
+	}
+	out, err := s.controller.DryRunMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "connect to target controller: boom")
+	// Problems found before the error are still reported.
+	c.Check(out.Results[0].SourceProblems, jc.DeepEquals, []string{"machine 0 is dying"})
+}
+
+func (s *controllerSuite) TestDryRunMigrationCrossModelRelations(c *gc.C) {
+	st1 := s.Factory.MakeModel(c, nil)
+	defer st1.Close()
+	model1, err := st1.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	st2 := s.Factory.MakeModel(c, nil)
+	defer st2.Close()
+	model2, err := st2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The first model consumes an offer from the second.
+	f := factory.NewFactory(st2, s.StatePool)
+	mysql := f.MakeApplication(c, &factory.ApplicationParams{
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	offer, err := state.NewApplicationOffers(st2).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: mysql.Name(),
+		Owner:           "admin",
+		Endpoints:       map[string]string{"server": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st1.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "mysql",
+		OfferUUID:   offer.OfferUUID,
+		SourceModel: model2.ModelTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st2.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: model1.UUID(),
+		Username:        "admin",
+		OfferUUID:       offer.OfferUUID,
+		RelationId:      1,
+		RelationKey:     "wordpress:db mysql:server",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, params.MigrationDryRunResult{}, nil)
+	spec := func(m *state.Model) params.MigrationSpec {
+		return params.MigrationSpec{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				AuthTag:       names.NewUserTag("admin1").String(),
+			},
+		}
+	}
+
+	// The relation would be broken by migrating one model without
+	// the other.
+	out, err := s.controller.DryRunMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{spec(model1)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].SourceProblems, jc.DeepEquals, []string{
+		fmt.Sprintf("application mysql is related to model %s/%s, which isn't being migrated with it",
+			model2.Owner().Id(), model2.Name()),
+	})
+
+	// The relation can't be migrated, even when both models are
+	// migrated together.
+	out, err = s.controller.DryRunMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{spec(model1), spec(model2)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].SourceProblems, jc.DeepEquals, []string{
+		fmt.Sprintf("application mysql is related to model %s/%s, and cross-model relations can't be migrated, even between models migrated together",
+			model2.Owner().Id(), model2.Name()),
+	})
+	c.Check(out.Results[1].Error, gc.IsNil)
+	c.Check(out.Results[1].SourceProblems, jc.DeepEquals, []string{
+		fmt.Sprintf("offer hosted-mysql is consumed by model %s/%s, and cross-model relations can't be migrated, even between models migrated together",
+			model1.Owner().Id(), model1.Name()),
+	})
+}
+
+func (s *controllerSuite) TestInitiateMigrationBatch(c *gc.C) {
+	var models []*state.Model
+	for i := 0; i < 3; i++ {
+		st := s.Factory.MakeModel(c, nil)
+		defer st.Close()
+		m, err := st.Model()
+		c.Assert(err, jc.ErrorIsNil)
+		models = append(models, m)
+	}
+	controller.SetPrecheckResult(s, nil)
+
+	targetTag := randomControllerTag()
+	args := params.InitiateMigrationArgs{MaxConcurrent: 2}
+	for _, m := range models {
+		args.Specs = append(args.Specs, params.MigrationSpec{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: targetTag,
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		})
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 3)
+	c.Check(out.BatchId, gc.Not(gc.Equals), "")
+
+	// Only two migrations are started, and the third is queued.
+	for i, m := range models[:2] {
+		c.Check(out.Results[i].Error, gc.IsNil)
+		c.Check(out.Results[i].MigrationId, gc.Equals, m.UUID()+":0")
+	}
+	c.Check(out.Results[2].Error, gc.IsNil)
+	c.Check(out.Results[2].MigrationId, gc.Equals, "")
+
+	status, err := s.controller.MigrationBatchStatus(params.MigrationBatchArg{BatchId: out.BatchId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, params.MigrationBatchStatus{
+		Queued: []string{models[2].ModelTag().String()},
+		Failed: []params.InitiateMigrationResult{},
+	})
+}
+
+func (s *controllerSuite) TestInitiateMigrationBatchPrecheckFail(c *gc.C) {
+	st1 := s.Factory.MakeModel(c, nil)
+	defer st1.Close()
+	st2 := s.Factory.MakeModel(c, nil)
+	defer st2.Close()
+	controller.SetPrecheckResult(s, errors.New("boom"))
+
+	args := params.InitiateMigrationArgs{MaxConcurrent: 1}
+	for _, st := range []*state.State{st1, st2} {
+		args.Specs = append(args.Specs, params.MigrationSpec{
+			ModelTag: names.NewModelTag(st.ModelUUID()).String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		})
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.BatchId, gc.Equals, "")
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "models in a batch must be migrated to the same controller")
+
+	// Nothing is migrated.
+	for _, st := range []*state.State{st1, st2} {
+		active, err := st.IsMigrationActive()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(active, jc.IsFalse)
+	}
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
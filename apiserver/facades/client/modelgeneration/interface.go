@@ -7,12 +7,16 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
 )
 
 // ModelGeneration defines the methods exported by the model generation API facade.
 type ModelGeneration interface {
 	AddGeneration() (params.ErrorResult, error)
 	AdvanceGeneration(args params.Entities) (params.ErrorResults, error)
+	DiffGeneration(arg params.Entity) (params.GenerationDiffResult, error)
+	GenerationPromotion(arg params.Entity) (params.GenerationPromotionResult, error)
+	PromoteGeneration(arg params.PromoteGenerationArg) (params.ErrorResult, error)
 	SwitchGeneration(arg params.GenerationVersionArg) (params.ErrorResult, error)
 }
 
@@ -27,6 +31,7 @@ type ModelGenerationState interface {
 type GenerationModel interface {
 	AddGeneration() error
 	NextGeneration() (Generation, error)
+	LatestGeneration() (Generation, error)
 	HasNextGeneration() (bool, error)
 }
 
@@ -37,5 +42,8 @@ type Generation interface {
 	AssignUnit(string) error
 	MakeCurrent() error
 	AutoComplete() (bool, error)
+	Diff() ([]state.GenerationApplicationDiff, error)
+	StartPromotion(state.GenerationPromotionPolicy) error
+	Promotion() (state.GenerationPromotion, bool)
 	Refresh() error
 }
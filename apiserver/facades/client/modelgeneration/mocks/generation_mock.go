@@ -6,6 +6,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	state "github.com/juju/juju/state"
 	reflect "reflect"
 )
 
@@ -69,6 +70,19 @@ func (mr *MockGenerationMockRecorder) AutoComplete() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoComplete", reflect.TypeOf((*MockGeneration)(nil).AutoComplete))
 }
 
+// Diff mocks base method
+func (m *MockGeneration) Diff() ([]state.GenerationApplicationDiff, error) {
+	ret := m.ctrl.Call(m, "Diff")
+	ret0, _ := ret[0].([]state.GenerationApplicationDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff
+func (mr *MockGenerationMockRecorder) Diff() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockGeneration)(nil).Diff))
+}
+
 // MakeCurrent mocks base method
 func (m *MockGeneration) MakeCurrent() error {
 	ret := m.ctrl.Call(m, "MakeCurrent")
@@ -81,6 +95,19 @@ func (mr *MockGenerationMockRecorder) MakeCurrent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeCurrent", reflect.TypeOf((*MockGeneration)(nil).MakeCurrent))
 }
 
+// Promotion mocks base method
+func (m *MockGeneration) Promotion() (state.GenerationPromotion, bool) {
+	ret := m.ctrl.Call(m, "Promotion")
+	ret0, _ := ret[0].(state.GenerationPromotion)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Promotion indicates an expected call of Promotion
+func (mr *MockGenerationMockRecorder) Promotion() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Promotion", reflect.TypeOf((*MockGeneration)(nil).Promotion))
+}
+
 // Refresh mocks base method
 func (m *MockGeneration) Refresh() error {
 	ret := m.ctrl.Call(m, "Refresh")
@@ -92,3 +119,15 @@ func (m *MockGeneration) Refresh() error {
 func (mr *MockGenerationMockRecorder) Refresh() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockGeneration)(nil).Refresh))
 }
+
+// StartPromotion mocks base method
+func (m *MockGeneration) StartPromotion(arg0 state.GenerationPromotionPolicy) error {
+	ret := m.ctrl.Call(m, "StartPromotion", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartPromotion indicates an expected call of StartPromotion
+func (mr *MockGenerationMockRecorder) StartPromotion(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartPromotion", reflect.TypeOf((*MockGeneration)(nil).StartPromotion), arg0)
+}
@@ -58,6 +58,19 @@ func (mr *MockGenerationModelMockRecorder) HasNextGeneration() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNextGeneration", reflect.TypeOf((*MockGenerationModel)(nil).HasNextGeneration))
 }
 
+// LatestGeneration mocks base method
+func (m *MockGenerationModel) LatestGeneration() (modelgeneration.Generation, error) {
+	ret := m.ctrl.Call(m, "LatestGeneration")
+	ret0, _ := ret[0].(modelgeneration.Generation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestGeneration indicates an expected call of LatestGeneration
+func (mr *MockGenerationModelMockRecorder) LatestGeneration() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestGeneration", reflect.TypeOf((*MockGenerationModel)(nil).LatestGeneration))
+}
+
 // NextGeneration mocks base method
 func (m *MockGenerationModel) NextGeneration() (modelgeneration.Generation, error) {
 	ret := m.ctrl.Call(m, "NextGeneration")
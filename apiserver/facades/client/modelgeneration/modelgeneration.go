@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.modelgeneration")
@@ -180,3 +181,112 @@ func (m *ModelGenerationAPI) CancelGeneration(arg params.Entity) (params.ErrorRe
 	result.Error = common.ServerError(generation.MakeCurrent())
 	return result, nil
 }
+
+// DiffGeneration returns how each application with changes in the
+// "next" generation differs from the "current" generation, including
+// the generation that each of its units is on.
+func (m *ModelGenerationAPI) DiffGeneration(arg params.Entity) (params.GenerationDiffResult, error) {
+	result := params.GenerationDiffResult{}
+	modelTag, err := names.ParseModelTag(arg.Tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	isModelAdmin, err := m.hasAdminAccess(modelTag)
+	if !isModelAdmin && !m.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	generation, err := m.model.NextGeneration()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	diffs, err := generation.Diff()
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	result.Applications = make([]params.GenerationApplicationDiff, len(diffs))
+	for i, diff := range diffs {
+		config := make([]params.GenerationConfigChange, len(diff.Config))
+		for j, change := range diff.Config {
+			config[j] = params.GenerationConfigChange{
+				Key:     change.Key,
+				Current: change.Current,
+				Next:    change.Next,
+			}
+		}
+		result.Applications[i] = params.GenerationApplicationDiff{
+			Application:     diff.Application,
+			CurrentCharmURL: diff.CurrentCharmURL,
+			NextCharmURL:    diff.NextCharmURL,
+			Config:          config,
+			Units:           diff.Units,
+		}
+	}
+	return result, nil
+}
+
+// PromoteGeneration asks the controller to move the units of the
+// applications with changes in the "next" generation into it in
+// batches, and to make it current once they all are. If a unit in the
+// generation becomes unhealthy, the controller moves every unit back
+// and cancels the generation.
+func (m *ModelGenerationAPI) PromoteGeneration(arg params.PromoteGenerationArg) (params.ErrorResult, error) {
+	result := params.ErrorResult{}
+	modelTag, err := names.ParseModelTag(arg.Model.Tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	isModelAdmin, err := m.hasAdminAccess(modelTag)
+	if !isModelAdmin && !m.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	generation, err := m.model.NextGeneration()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Error = common.ServerError(generation.StartPromotion(state.GenerationPromotionPolicy{
+		Percent:       arg.Percent,
+		SoakTime:      arg.SoakTime,
+		RequireActive: arg.RequireActive,
+	}))
+	return result, nil
+}
+
+// GenerationPromotion returns the automatic promotion of the "next"
+// generation, or of the most recently completed generation if there is
+// no "next" generation.
+func (m *ModelGenerationAPI) GenerationPromotion(arg params.Entity) (params.GenerationPromotionResult, error) {
+	result := params.GenerationPromotionResult{}
+	modelTag, err := names.ParseModelTag(arg.Tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	isModelAdmin, err := m.hasAdminAccess(modelTag)
+	if !isModelAdmin && !m.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	generation, err := m.model.LatestGeneration()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	promotion, ok := generation.Promotion()
+	if !ok {
+		result.Error = common.ServerError(errors.NotFoundf("generation promotion"))
+		return result, nil
+	}
+	result.Result = &params.GenerationPromotion{
+		Percent:       promotion.Percent,
+		SoakTime:      promotion.SoakTime,
+		RequireActive: promotion.RequireActive,
+		Status:        string(promotion.Status),
+		Message:       promotion.Message,
+	}
+	if !promotion.BatchStarted.IsZero() {
+		batchStarted := promotion.BatchStarted
+		result.Result.BatchStarted = &batchStarted
+	}
+	return result, nil
+}
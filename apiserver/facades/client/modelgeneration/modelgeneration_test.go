@@ -4,6 +4,8 @@
 package modelgeneration_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +16,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration/mocks"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
 )
 
 var _ = gc.Suite(&modelGenerationSuite{})
@@ -151,6 +155,130 @@ func (s *modelGenerationSuite) TestCancelGenerationCanNotMakeCurrent(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: &params.Error{Message: errMsg}})
 }
 
+func (s *modelGenerationSuite) TestDiffGeneration(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, mockModel *mocks.MockGenerationModel) {
+		mockGeneration := mocks.NewMockGeneration(ctrl)
+		gExp := mockGeneration.EXPECT()
+		gExp.Diff().Return([]state.GenerationApplicationDiff{{
+			Application:     "mysql",
+			CurrentCharmURL: "cs:mysql-1",
+			NextCharmURL:    "cs:mysql-2",
+			Config: []state.GenerationConfigChange{
+				{Key: "tuning", Current: "safest", Next: "fast"},
+			},
+			Units: map[string]model.GenerationVersion{
+				"mysql/0": model.GenerationNext,
+				"mysql/1": model.GenerationCurrent,
+			},
+		}}, nil)
+
+		mExp := mockModel.EXPECT()
+		mExp.NextGeneration().Return(mockGeneration, nil)
+	}).Finish()
+
+	result, err := s.api.DiffGeneration(params.Entity{Tag: names.NewModelTag(s.modelUUID).String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.GenerationDiffResult{
+		Applications: []params.GenerationApplicationDiff{{
+			Application:     "mysql",
+			CurrentCharmURL: "cs:mysql-1",
+			NextCharmURL:    "cs:mysql-2",
+			Config: []params.GenerationConfigChange{
+				{Key: "tuning", Current: "safest", Next: "fast"},
+			},
+			Units: map[string]model.GenerationVersion{
+				"mysql/0": model.GenerationNext,
+				"mysql/1": model.GenerationCurrent,
+			},
+		}},
+	})
+}
+
+func (s *modelGenerationSuite) TestDiffGenerationNoNextGeneration(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(_ *gomock.Controller, mockModel *mocks.MockGenerationModel) {
+		mockModel.EXPECT().NextGeneration().Return(nil, errors.NotFoundf("next generation"))
+	}).Finish()
+
+	_, err := s.api.DiffGeneration(params.Entity{Tag: names.NewModelTag(s.modelUUID).String()})
+	c.Assert(err, gc.ErrorMatches, "next generation not found")
+}
+
+func (s *modelGenerationSuite) TestPromoteGeneration(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, mockModel *mocks.MockGenerationModel) {
+		mockGeneration := mocks.NewMockGeneration(ctrl)
+		mockGeneration.EXPECT().StartPromotion(state.GenerationPromotionPolicy{
+			Percent:       25,
+			SoakTime:      10 * time.Minute,
+			RequireActive: true,
+		}).Return(nil)
+		mockModel.EXPECT().NextGeneration().Return(mockGeneration, nil)
+	}).Finish()
+
+	result, err := s.api.PromoteGeneration(params.PromoteGenerationArg{
+		Model:         params.Entity{Tag: names.NewModelTag(s.modelUUID).String()},
+		Percent:       25,
+		SoakTime:      10 * time.Minute,
+		RequireActive: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{})
+}
+
+func (s *modelGenerationSuite) TestPromoteGenerationInvalid(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, mockModel *mocks.MockGenerationModel) {
+		mockGeneration := mocks.NewMockGeneration(ctrl)
+		mockGeneration.EXPECT().StartPromotion(gomock.Any()).Return(errors.NotValidf("promotion percentage 0"))
+		mockModel.EXPECT().NextGeneration().Return(mockGeneration, nil)
+	}).Finish()
+
+	result, err := s.api.PromoteGeneration(params.PromoteGenerationArg{
+		Model: params.Entity{Tag: names.NewModelTag(s.modelUUID).String()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "promotion percentage 0 not valid")
+}
+
+func (s *modelGenerationSuite) TestGenerationPromotion(c *gc.C) {
+	batchStarted := time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, mockModel *mocks.MockGenerationModel) {
+		mockGeneration := mocks.NewMockGeneration(ctrl)
+		mockGeneration.EXPECT().Promotion().Return(state.GenerationPromotion{
+			GenerationPromotionPolicy: state.GenerationPromotionPolicy{
+				Percent:  50,
+				SoakTime: time.Minute,
+			},
+			Status:       state.PromotionRolledBack,
+			BatchStarted: batchStarted,
+			Message:      "units unhealthy: mysql/0",
+		}, true)
+		mockModel.EXPECT().LatestGeneration().Return(mockGeneration, nil)
+	}).Finish()
+
+	result, err := s.api.GenerationPromotion(params.Entity{Tag: names.NewModelTag(s.modelUUID).String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.GenerationPromotionResult{
+		Result: &params.GenerationPromotion{
+			Percent:      50,
+			SoakTime:     time.Minute,
+			Status:       "rolled-back",
+			BatchStarted: &batchStarted,
+			Message:      "units unhealthy: mysql/0",
+		},
+	})
+}
+
+func (s *modelGenerationSuite) TestGenerationPromotionNotStarted(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, mockModel *mocks.MockGenerationModel) {
+		mockGeneration := mocks.NewMockGeneration(ctrl)
+		mockGeneration.EXPECT().Promotion().Return(state.GenerationPromotion{}, false)
+		mockModel.EXPECT().LatestGeneration().Return(mockGeneration, nil)
+	}).Finish()
+
+	result, err := s.api.GenerationPromotion(params.Entity{Tag: names.NewModelTag(s.modelUUID).String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "generation promotion not found")
+}
+
 type setupFunc func(*gomock.Controller, *mocks.MockGenerationModel)
 
 func (s *modelGenerationSuite) setupModelGenerationAPI(c *gc.C, fn setupFunc) *gomock.Controller {
@@ -24,3 +24,7 @@ type generationModelShim struct {
 func (g *generationModelShim) NextGeneration() (Generation, error) {
 	return g.Model.NextGeneration()
 }
+
+func (g *generationModelShim) LatestGeneration() (Generation, error) {
+	return g.Model.LatestGeneration()
+}
@@ -114,6 +114,7 @@ type mockApplication struct {
 	ops        *state.UpdateUnitsOperation
 	providerId string
 	addresses  []network.Address
+	hostnames  []string
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -178,6 +179,11 @@ func (m *mockApplication) UpdateCloudService(providerId string, addreses []netwo
 	return nil
 }
 
+func (m *mockApplication) SetServiceHostnames(hostnames []string) error {
+	m.hostnames = hostnames
+	return nil
+}
+
 var addOp = &state.AddUnitOperation{}
 
 func (m *mockApplication) AddOperation(props state.UnitUpdateProperties) *state.AddUnitOperation {
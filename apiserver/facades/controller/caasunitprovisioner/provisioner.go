@@ -524,6 +524,12 @@ func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 			Status: status.Idle,
 		}
 		containerStatus = status.Running
+	case status.Waiting:
+		// A pod has started but isn't yet ready to serve requests.
+		agentStatus = &status.StatusInfo{
+			Status: status.Idle,
+		}
+		containerStatus = status.Waiting
 	case status.Error:
 		agentStatus = &status.StatusInfo{
 			Status:  status.Error,
@@ -1181,6 +1187,10 @@ func (a *Facade) UpdateApplicationsService(args params.UpdateApplicationServiceA
 		}
 		if err := app.UpdateCloudService(appUpdate.ProviderId, params.NetworkAddresses(appUpdate.Addresses...)); err != nil {
 			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := app.SetServiceHostnames(appUpdate.Hostnames); err != nil {
+			result.Results[i].Error = common.ServerError(err)
 		}
 	}
 	return result, nil
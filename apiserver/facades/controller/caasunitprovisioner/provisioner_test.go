@@ -364,6 +364,36 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnits(c *gc.C) {
 	})
 }
 
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsNotReady(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+	}
+
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "uuid", Address: "address", Ports: []string{"port"},
+			Status: "waiting", Info: "containers with unready status: [gitlab]"},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+		},
+	})
+	s.st.application.units[0].(*mockUnit).CheckCallNames(c, "Life", "UpdateOperation")
+	s.st.application.units[0].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId: strPtr("uuid"),
+		Address:    strPtr("address"), Ports: &[]string{"port"},
+		CloudContainerStatus: &status.StatusInfo{Status: status.Waiting, Message: "containers with unready status: [gitlab]"},
+		AgentStatus:          &status.StatusInfo{Status: status.Idle},
+	})
+}
+
 func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsNotAlive(c *gc.C) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", life: state.Alive},
@@ -529,7 +559,12 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsWithStorage(c *gc.C) {
 func (s *CAASProvisionerSuite) TestUpdateApplicationsService(c *gc.C) {
 	results, err := s.facade.UpdateApplicationsService(params.UpdateApplicationServiceArgs{
 		Args: []params.UpdateApplicationServiceArg{
-			{ApplicationTag: "application-gitlab", ProviderId: "id", Addresses: []params.Address{{Value: "10.0.0.1"}}},
+			{
+				ApplicationTag: "application-gitlab",
+				ProviderId:     "id",
+				Addresses:      []params.Address{{Value: "10.0.0.1"}},
+				Hostnames:      []string{"gitlab.example.com"},
+			},
 			{ApplicationTag: "unit-gitlab-0"},
 		},
 	})
@@ -541,6 +576,7 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsService(c *gc.C) {
 	})
 	c.Assert(s.st.application.providerId, gc.Equals, "id")
 	c.Assert(s.st.application.addresses, jc.DeepEquals, []network.Address{{Value: "10.0.0.1"}})
+	c.Assert(s.st.application.hostnames, jc.DeepEquals, []string{"gitlab.example.com"})
 }
 
 func (s *CAASProvisionerSuite) TestSetOperatorStatus(c *gc.C) {
@@ -21,6 +21,14 @@ type Backend interface {
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
+	MigrationBatch(id string) (MigrationBatch, error)
 
 	migration.StateExporter
 }
+
+// MigrationBatch defines the migration batch functionality required
+// by the migrationmaster facade.
+type MigrationBatch interface {
+	Finished(modelUUID string) error
+	StartQueued(spec state.MigrationSpec) ([]state.ModelMigration, error)
+}
@@ -19,6 +19,7 @@ import (
 	coremigration "github.com/juju/juju/core/migration"
 	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
@@ -33,6 +34,12 @@ type API struct {
 	presence        facade.Presence
 }
 
+// APIV1 implements the API for version 1 of the facade, which has no
+// StartQueuedMigrations method.
+type APIV1 struct {
+	*API
+}
+
 // NewAPI creates a new API server endpoint for the model migration
 // master worker.
 func NewAPI(
@@ -156,6 +163,46 @@ func (api *API) SetPhase(args params.SetMigrationPhaseArgs) error {
 	return errors.Annotate(err, "failed to set phase")
 }
 
+// StartQueuedMigrations is called once the latest model migration has
+// finished. If the migration was part of a batch, it starts as many of
+// the batch's queued migrations as its concurrency limit now allows.
+func (api *API) StartQueuedMigrations() error {
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	if mig.Batch() == "" {
+		return nil
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return errors.Annotate(err, "retrieving phase")
+	}
+	if !phase.IsTerminal() {
+		return errors.Errorf("migration is still in progress (phase %s)", phase)
+	}
+	target, err := mig.TargetInfo()
+	if err != nil {
+		return errors.Annotate(err, "retrieving target info")
+	}
+
+	batch, err := api.backend.MigrationBatch(mig.Batch())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := batch.Finished(mig.ModelUUID()); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = batch.StartQueued(state.MigrationSpec{
+		InitiatedBy: names.NewUserTag(mig.InitiatedBy()),
+		TargetInfo:  *target,
+	})
+	return errors.Annotate(err, "starting queued migrations")
+}
+
+// StartQueuedMigrations isn't on the v1 API.
+func (*APIV1) StartQueuedMigrations(_, _ struct{}) {}
+
 // Prechecks performs pre-migration checks on the model and
 // (source) controller.
 func (api *API) Prechecks() error {
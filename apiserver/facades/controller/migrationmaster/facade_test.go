@@ -136,6 +136,44 @@ func (s *Suite) TestSetPhase(c *gc.C) {
 	c.Assert(s.backend.migration.phaseSet, gc.Equals, coremigration.ABORT)
 }
 
+func (s *Suite) TestStartQueuedMigrations(c *gc.C) {
+	s.backend.migration.phase = coremigration.DONE
+	s.backend.migration.batch = "1"
+	s.backend.batch = &stubBatch{stub: s.stub}
+	api := s.mustMakeAPI(c)
+
+	err := api.StartQueuedMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	target, err := s.backend.migration.TargetInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "LatestMigration", "MigrationBatch", "Finished", "StartQueued")
+	s.stub.CheckCall(c, 1, "MigrationBatch", "1")
+	s.stub.CheckCall(c, 2, "Finished", modelUUID)
+	spec := s.stub.Calls()[3].Args[0].(state.MigrationSpec)
+	c.Check(spec.InitiatedBy, gc.Equals, names.NewUserTag("admin"))
+	c.Check(spec.TargetInfo.ControllerTag, gc.Equals, target.ControllerTag)
+	c.Check(spec.TargetInfo.Addrs, jc.DeepEquals, target.Addrs)
+}
+
+func (s *Suite) TestStartQueuedMigrationsNotInBatch(c *gc.C) {
+	s.backend.migration.phase = coremigration.DONE
+	api := s.mustMakeAPI(c)
+
+	err := api.StartQueuedMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "LatestMigration")
+}
+
+func (s *Suite) TestStartQueuedMigrationsStillRunning(c *gc.C) {
+	s.backend.migration.batch = "1"
+	api := s.mustMakeAPI(c)
+
+	err := api.StartQueuedMigrations()
+	c.Assert(err, gc.ErrorMatches, `migration is still in progress \(phase IMPORT\)`)
+	s.stub.CheckCallNames(c, "LatestMigration")
+}
+
 func (s *Suite) TestSetPhaseNoMigration(c *gc.C) {
 	s.backend.getErr = errors.New("boom")
 	api := s.mustMakeAPI(c)
@@ -442,6 +480,7 @@ type stubBackend struct {
 	removeErr error
 	migration *stubMigration
 	model     description.Model
+	batch     *stubBatch
 }
 
 func (b *stubBackend) WatchForMigration() state.NotifyWatcher {
@@ -483,6 +522,25 @@ func (b *stubBackend) Export() (description.Model, error) {
 	return b.model, nil
 }
 
+func (b *stubBackend) MigrationBatch(id string) (migrationmaster.MigrationBatch, error) {
+	b.stub.AddCall("MigrationBatch", id)
+	return b.batch, nil
+}
+
+type stubBatch struct {
+	stub *testing.Stub
+}
+
+func (b *stubBatch) Finished(modelUUID string) error {
+	b.stub.AddCall("Finished", modelUUID)
+	return nil
+}
+
+func (b *stubBatch) StartQueued(spec state.MigrationSpec) ([]state.ModelMigration, error) {
+	b.stub.AddCall("StartQueued", spec)
+	return nil, nil
+}
+
 type stubMigration struct {
 	state.ModelMigration
 
@@ -493,6 +551,8 @@ type stubMigration struct {
 	messageSet      string
 	minionReports   *state.MinionReports
 	externalControl bool
+	phase           coremigration.Phase
+	batch           string
 }
 
 func (m *stubMigration) Id() string {
@@ -500,9 +560,20 @@ func (m *stubMigration) Id() string {
 }
 
 func (m *stubMigration) Phase() (coremigration.Phase, error) {
+	if m.phase != coremigration.UNKNOWN {
+		return m.phase, nil
+	}
 	return coremigration.IMPORT, nil
 }
 
+func (m *stubMigration) Batch() string {
+	return m.batch
+}
+
+func (m *stubMigration) InitiatedBy() string {
+	return "admin"
+}
+
 func (m *stubMigration) PhaseChangedTime() time.Time {
 	return time.Date(2016, 6, 22, 16, 38, 0, 0, time.UTC)
 }
@@ -13,6 +13,15 @@ import (
 	"github.com/juju/juju/state"
 )
 
+// NewFacadeV1 is used for API registration of version 1 of the facade.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewFacade exists to provide the required signature for API
 // registration, converting st to backend.
 func NewFacade(ctx facade.Context) (*API, error) {
@@ -22,7 +31,7 @@ func NewFacade(ctx facade.Context) (*API, error) {
 		return nil, errors.Annotate(err, "creating precheck backend")
 	}
 	return NewAPI(
-		&backendShim{ctx.State()},
+		&backendShim{ctx.State(), ctx.StatePool()},
 		precheckBackend,
 		migration.PoolShim(ctx.StatePool()),
 		ctx.Resources(),
@@ -35,6 +44,16 @@ func NewFacade(ctx facade.Context) (*API, error) {
 // untested, but is simple enough to be verified by inspection.
 type backendShim struct {
 	*state.State
+	pool *state.StatePool
+}
+
+// MigrationBatch implements Backend.
+func (s *backendShim) MigrationBatch(id string) (MigrationBatch, error) {
+	batch, err := state.NewController(s.pool).MigrationBatch(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return batch, nil
 }
 
 // ModelName implements Backend.
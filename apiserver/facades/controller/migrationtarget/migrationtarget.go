@@ -4,6 +4,7 @@
 package migrationtarget
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -82,13 +83,82 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 	return nil
 }
 
+// APIV1 implements the API for version 1 of the facade, which has no
+// DryRun method.
+type APIV1 struct {
+	*API
+}
+
+// NewFacadeV1 is used for API registration of version 1 of the facade.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
+// DryRun isn't on the v1 API.
+func (*APIV1) DryRun(_, _ struct{}) {}
+
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	modelInfo, err := migrationModelInfo(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	backend, controllerState, err := api.precheckBackend()
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return migration.TargetPrecheck(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		api.presence.ModelPresence(controllerState.ModelUUID()),
+	)
+}
+
+// DryRun checks whether a model could be migrated to the target
+// controller, without changing anything. All the prechecks are run,
+// reporting every problem found rather than just the first, and if
+// they pass the serialized model is imported and removed again.
+func (api *API) DryRun(args params.MigrationDryRunArgs) (params.MigrationDryRunProblems, error) {
+	var result params.MigrationDryRunProblems
+	modelInfo, err := migrationModelInfo(args.Model)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	backend, controllerState, err := api.precheckBackend()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	problems := migration.TargetPrecheckAll(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		api.presence.ModelPresence(controllerState.ModelUUID()),
+	)
+	for _, problem := range problems {
+		result.Problems = append(result.Problems, problem.Error())
+	}
+	// The import checks repeat some of the prechecks (the model
+	// may already exist, for example), so they're only run once
+	// everything else looks good.
+	if len(result.Problems) > 0 {
+		return result, nil
+	}
+	controller := state.NewController(api.pool)
+	if err := migration.DryRunImport(controller, args.Bytes); err != nil {
+		result.Problems = append(result.Problems, fmt.Sprintf("importing model description: %v", err))
+	}
+	return result, nil
+}
+
+// precheckBackend returns the backend used to run prechecks against
+// the target controller, along with the controller model's state.
+func (api *API) precheckBackend() (migration.PrecheckBackend, *state.State, error) {
 	controllerState := api.pool.SystemState()
 	// NOTE (thumper): it isn't clear to me why api.state would be different
 	// from the controllerState as I had thought that the Precheck call was
@@ -96,20 +166,23 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	// controllerState.
 	backend, err := migration.PrecheckShim(api.state, controllerState)
 	if err != nil {
-		return errors.Annotate(err, "creating backend")
+		return nil, nil, errors.Annotate(err, "creating backend")
 	}
-	return migration.TargetPrecheck(
-		backend,
-		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
-		api.presence.ModelPresence(controllerState.ModelUUID()),
-	)
+	return backend, controllerState, nil
+}
+
+func migrationModelInfo(model params.MigrationModelInfo) (coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	return coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+	}, nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
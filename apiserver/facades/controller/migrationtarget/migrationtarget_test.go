@@ -4,6 +4,7 @@
 package migrationtarget_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"time"
 
@@ -84,6 +85,17 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 		Auth_:      s.authorizer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+
+	factory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
@@ -141,6 +153,65 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) dryRunArgs(c *gc.C) (string, params.MigrationDryRunArgs) {
+	uuid, bytes := s.makeExportedModel(c)
+	return uuid, params.MigrationDryRunArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   uuid,
+			Name:                   "some-model",
+			OwnerTag:               s.Owner.String(),
+			AgentVersion:           s.controllerVersion(c),
+			ControllerAgentVersion: s.controllerVersion(c),
+		},
+		Bytes: bytes,
+	}
+}
+
+func (s *Suite) TestDryRun(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, args := s.dryRunArgs(c)
+	result, err := api.DryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Problems, gc.HasLen, 0)
+
+	// The trial import leaves nothing behind.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
+}
+
+func (s *Suite) TestDryRunPrechecksFail(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, args := s.dryRunArgs(c)
+	args.Model.AgentVersion.Minor++
+	args.Model.Name = s.Model.Name()
+	result, err := api.DryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		fmt.Sprintf("model has higher version than target controller (%s > %s)",
+			args.Model.AgentVersion, s.controllerVersion(c)),
+		fmt.Sprintf("model named %q already exists", s.Model.Name()),
+	})
+}
+
+func (s *Suite) TestDryRunImportFails(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, args := s.dryRunArgs(c)
+	args.Bytes = []byte("not a model")
+	result, err := api.DryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 1)
+	c.Check(result.Problems[0], gc.Matches, "importing model description: yaml: unmarshal errors:\n.*")
+}
+
+func (s *Suite) TestDryRunBadOwner(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, args := s.dryRunArgs(c)
+	args.Model.OwnerTag = "bad"
+	_, err := api.DryRun(args)
+	c.Assert(err, gc.ErrorMatches, `"bad" is not a valid tag`)
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
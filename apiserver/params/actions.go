@@ -54,9 +54,22 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage is a progress message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// ActionMessageParams holds the progress messages to log for some
+// running actions; each tag is an action tag.
+type ActionMessageParams struct {
+	Messages []EntityString `json:"messages"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -143,3 +156,133 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// AddActionSchedules holds action schedules to add to a model.
+type AddActionSchedules struct {
+	Schedules []AddActionSchedule `json:"schedules"`
+}
+
+// AddActionSchedule describes an action to be enqueued at a future
+// time, once or repeatedly. Exactly one of Schedule and At is set.
+type AddActionSchedule struct {
+	// Receiver is the tag of a unit or application, or
+	// "<application>/leader".
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Schedule   string                 `json:"schedule,omitempty"`
+	At         *time.Time             `json:"at,omitempty"`
+}
+
+// ActionSchedule describes an action that is enqueued at a future
+// time, once or repeatedly.
+type ActionSchedule struct {
+	Id         string                 `json:"id"`
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Schedule   string                 `json:"schedule,omitempty"`
+	Paused     bool                   `json:"paused"`
+	NextRun    *time.Time             `json:"next-run,omitempty"`
+	LastRun    *time.Time             `json:"last-run,omitempty"`
+	LastError  string                 `json:"last-error,omitempty"`
+	Created    time.Time              `json:"created"`
+	CreatedBy  string                 `json:"created-by"`
+}
+
+// ActionScheduleResults holds the results of adding action schedules.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// ActionScheduleResult holds an added action schedule or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionSchedules holds the action schedules of a model.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleIds identifies action schedules in a model.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
+
+// AddActionRollouts holds actions to run across the units of
+// applications.
+type AddActionRollouts struct {
+	Rollouts []AddActionRollout `json:"rollouts"`
+}
+
+// AddActionRollout describes an action to run across the units of an
+// application, a few at a time.
+type AddActionRollout struct {
+	// Application is the tag of the application.
+	Application string                 `json:"application"`
+	Name        string                 `json:"name"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+
+	// Targets is "all", "leader" or "non-leaders"; it defaults to
+	// "all".
+	Targets string `json:"targets,omitempty"`
+
+	// MaxParallel limits the number of unfinished actions, and
+	// BatchSize the number of units in each batch. Zero means no
+	// limit.
+	MaxParallel   int  `json:"max-parallel,omitempty"`
+	BatchSize     int  `json:"batch-size,omitempty"`
+	StopOnFailure bool `json:"stop-on-failure,omitempty"`
+}
+
+// ActionRollout describes the progress of an action running across
+// the units of an application.
+type ActionRollout struct {
+	Id            string                 `json:"id"`
+	Application   string                 `json:"application"`
+	Name          string                 `json:"name"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty"`
+	MaxParallel   int                    `json:"max-parallel,omitempty"`
+	BatchSize     int                    `json:"batch-size,omitempty"`
+	StopOnFailure bool                   `json:"stop-on-failure,omitempty"`
+
+	// Status is "running", "completed", "failed" or "stopped".
+	Status    string              `json:"status"`
+	Units     []ActionRolloutUnit `json:"units"`
+	Created   time.Time           `json:"created"`
+	CreatedBy string              `json:"created-by"`
+	Finished  *time.Time          `json:"finished,omitempty"`
+}
+
+// ActionRolloutUnit describes the action run on a unit by a rollout.
+type ActionRolloutUnit struct {
+	// Unit is the tag of the unit, and Action the tag of the action
+	// enqueued on it, if there is one yet.
+	Unit   string `json:"unit"`
+	Action string `json:"action,omitempty"`
+
+	// Status is the status of the action, or "waiting" if it is yet
+	// to be enqueued, or "skipped" if the rollout stopped before
+	// enqueueing it or the unit was removed.
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// ActionRolloutResults holds the results of adding or getting action
+// rollouts.
+type ActionRolloutResults struct {
+	Results []ActionRolloutResult `json:"results"`
+}
+
+// ActionRolloutResult holds an action rollout or an error.
+type ActionRolloutResult struct {
+	Rollout *ActionRollout `json:"rollout,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
+}
+
+// ActionRolloutIds identifies action rollouts in a model.
+type ActionRolloutIds struct {
+	Ids []string `json:"ids"`
+}
@@ -269,6 +269,7 @@ type UpdateApplicationServiceArg struct {
 	ApplicationTag string    `json:"application-tag"`
 	ProviderId     string    `json:"provider-id"`
 	Addresses      []Address `json:"addresses"`
+	Hostnames      []string  `json:"hostnames,omitempty"`
 }
 
 // ApplicationDestroy holds the parameters for making the deprecated
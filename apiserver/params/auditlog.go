@@ -0,0 +1,68 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogQuery holds the arguments for a call to the Conversations
+// method of the AuditLog facade. Zero-valued fields match every
+// conversation.
+type AuditLogQuery struct {
+	// Who matches the user that started the conversation.
+	Who string `json:"who,omitempty"`
+
+	// ModelUUID matches the model the conversation was with.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
+	// Methods matches conversations calling any of the methods,
+	// given as "Facade.Method" or just "Facade".
+	Methods []string `json:"methods,omitempty"`
+
+	// From and To bound the time the conversations started.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// ErrorsOnly matches conversations where a call returned errors.
+	ErrorsOnly bool `json:"errors-only,omitempty"`
+
+	// Limit is the maximum number of conversations to return; the
+	// most recent are kept.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditConversationsResult holds the conversations returned by
+// the Conversations method of the AuditLog facade, oldest first.
+type AuditConversationsResult struct {
+	Conversations []AuditConversation `json:"conversations"`
+}
+
+// AuditConversation holds an audited conversation: a command run by
+// a client, and the API calls it made.
+type AuditConversation struct {
+	ConversationID string      `json:"conversation-id"`
+	ConnectionID   string      `json:"connection-id"`
+	Who            string      `json:"who"`
+	What           string      `json:"what"`
+	When           time.Time   `json:"when"`
+	ModelName      string      `json:"model-name"`
+	ModelUUID      string      `json:"model-uuid"`
+	Calls          []AuditCall `json:"calls"`
+}
+
+// AuditCall holds an audited API call and any errors it returned.
+type AuditCall struct {
+	RequestID uint64       `json:"request-id"`
+	When      time.Time    `json:"when"`
+	Facade    string       `json:"facade"`
+	Method    string       `json:"method"`
+	Version   int          `json:"version"`
+	Args      string       `json:"args,omitempty"`
+	Errors    []AuditError `json:"errors,omitempty"`
+}
+
+// AuditError holds an error returned from an audited API call.
+type AuditError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
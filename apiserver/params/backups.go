@@ -14,6 +14,17 @@ type BackupsCreateArgs struct {
 	Notes      string `json:"notes"`
 	KeepCopy   bool   `json:"keep-copy"`
 	NoDownload bool   `json:"no-download"`
+
+	// Encryption, if set, holds the key material used to encrypt
+	// the backup archive. Only supported by facade version 3.
+	Encryption *BackupsEncryption `json:"encryption,omitempty"`
+}
+
+// BackupsEncryption holds either an ASCII-armored OpenPGP public key
+// or a passphrase with which to encrypt a backup archive.
+type BackupsEncryption struct {
+	PublicKey  string `json:"public-key,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -70,6 +81,18 @@ type BackupsMetadataResult struct {
 	Version  version.Number `json:"version"`
 	Series   string         `json:"series"`
 
+	// Encrypted is true if the archive must be decrypted before
+	// it can be restored.
+	Encrypted bool `json:"encrypted,omitempty"`
+
+	// StorageTarget is the backup storage target holding the
+	// archive, such as "controller", "local" or "s3".
+	StorageTarget string `json:"storage-target,omitempty"`
+
+	// Failure is set if creating the backup failed, in which case
+	// there is no archive.
+	Failure string `json:"failure,omitempty"`
+
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 	Filename     string `json:"filename"`
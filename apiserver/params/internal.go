@@ -800,6 +800,7 @@ type GUIVersionRequest struct {
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
+	ModelUUID string    `json:"model-uuid,omitempty"`
 	Entity    string    `json:"tag"`
 	Timestamp time.Time `json:"ts"`
 	Severity  string    `json:"sev"`
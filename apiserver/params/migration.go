@@ -18,6 +18,12 @@ const MigrationModelHTTPHeader = "X-Juju-Migration-Model-UUID"
 // more model migrations.
 type InitiateMigrationArgs struct {
 	Specs []MigrationSpec `json:"specs"`
+
+	// MaxConcurrent, if set, makes the controller migrate the models
+	// as a batch, starting at most this many migrations at once and
+	// the rest as earlier ones finish. The specs must then share the
+	// same target controller.
+	MaxConcurrent int `json:"max-concurrent,omitempty"`
 }
 
 // MigrationSpec holds the details required to start the migration of
@@ -42,16 +48,68 @@ type MigrationTargetInfo struct {
 // more attempts to start model migrations.
 type InitiateMigrationResults struct {
 	Results []InitiateMigrationResult `json:"results"`
+
+	// BatchId identifies the migration batch started, if the
+	// migrations were requested as a batch.
+	BatchId string `json:"batch-id,omitempty"`
 }
 
 // InitiateMigrationResult is used to return the result of one model
-// migration initiation attempt.
+// migration initiation attempt. MigrationId is empty for migrations
+// queued in a batch.
 type InitiateMigrationResult struct {
 	ModelTag    string `json:"model-tag"`
 	Error       *Error `json:"error,omitempty"`
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationBatchArg identifies a migration batch.
+type MigrationBatchArg struct {
+	BatchId string `json:"batch-id"`
+}
+
+// MigrationBatchStatus reports the models in a migration batch whose
+// migrations haven't been started yet, and those whose migrations
+// couldn't be started.
+type MigrationBatchStatus struct {
+	Queued []string                  `json:"queued"`
+	Failed []InitiateMigrationResult `json:"failed"`
+}
+
+// MigrationDryRunResults is used to return the reports of one or more
+// migration dry runs.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult reports what a migration of a single model
+// would run into, without the migration being started. Problems found
+// by the prechecks on either controller, and by a trial import of the
+// model on the target, are all reported, along with the binaries the
+// migration would upload.
+type MigrationDryRunResult struct {
+	ModelTag       string   `json:"model-tag"`
+	Error          *Error   `json:"error,omitempty"`
+	SourceProblems []string `json:"source-problems,omitempty"`
+	TargetProblems []string `json:"target-problems,omitempty"`
+	Charms         []string `json:"charms,omitempty"`
+	Tools          []string `json:"tools,omitempty"`
+	Resources      []string `json:"resources,omitempty"`
+}
+
+// MigrationDryRunArgs holds the details a target controller needs to
+// check whether a model could be migrated to it.
+type MigrationDryRunArgs struct {
+	Model MigrationModelInfo `json:"model"`
+	Bytes []byte             `json:"bytes"`
+}
+
+// MigrationDryRunProblems holds the problems a target controller found
+// during a migration dry run.
+type MigrationDryRunProblems struct {
+	Problems []string `json:"problems,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
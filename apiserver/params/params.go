@@ -1113,3 +1113,80 @@ type AdvanceGenerationResult struct {
 	// represented here.
 	CompleteResult BoolResult `json:"complete-result,omitempty"`
 }
+
+// GenerationDiffResult contains the result of a call to DiffGeneration.
+type GenerationDiffResult struct {
+	Applications []GenerationApplicationDiff `json:"applications"`
+	Error        *Error                      `json:"error,omitempty"`
+}
+
+// GenerationApplicationDiff describes how an application with changes
+// in the "next" generation differs from the "current" generation.
+type GenerationApplicationDiff struct {
+	Application string `json:"application"`
+
+	// CurrentCharmURL is the URL of the application's charm.
+	CurrentCharmURL string `json:"current-charm-url"`
+
+	// NextCharmURL is the URL of the charm the application is upgraded
+	// to in the next generation, if it is.
+	NextCharmURL string `json:"next-charm-url,omitempty"`
+
+	// Config holds the charm config settings that differ.
+	Config []GenerationConfigChange `json:"config,omitempty"`
+
+	// Units holds the generation that each of the application's units
+	// is on.
+	Units map[string]model.GenerationVersion `json:"units"`
+}
+
+// GenerationConfigChange describes a charm config setting with a
+// different value in the "next" generation.
+type GenerationConfigChange struct {
+	Key     string      `json:"key"`
+	Current interface{} `json:"current,omitempty"`
+	Next    interface{} `json:"next,omitempty"`
+}
+
+// PromoteGenerationArg contains a Model Entity and the policy with
+// which the controller promotes its 'next' generation.
+type PromoteGenerationArg struct {
+	Model Entity `json:"model"`
+
+	// Percent is the percentage of each application's units moved
+	// into the generation in each batch.
+	Percent int `json:"percent"`
+
+	// SoakTime is how long each batch must stay healthy before the
+	// next batch is moved.
+	SoakTime time.Duration `json:"soak-time"`
+
+	// RequireActive, if true, requires the workloads of the units
+	// in the generation to be active by the end of each soak time.
+	RequireActive bool `json:"require-active,omitempty"`
+}
+
+// GenerationPromotionResult contains the result of a call to
+// GenerationPromotion.
+type GenerationPromotionResult struct {
+	Result *GenerationPromotion `json:"result,omitempty"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// GenerationPromotion describes the automatic promotion of the 'next'
+// generation.
+type GenerationPromotion struct {
+	Percent       int           `json:"percent"`
+	SoakTime      time.Duration `json:"soak-time"`
+	RequireActive bool          `json:"require-active,omitempty"`
+
+	// Status is "running", "completed" or "rolled-back".
+	Status string `json:"status"`
+
+	// BatchStarted is when the latest batch of units was moved into
+	// the generation, if any has been.
+	BatchStarted *time.Time `json:"batch-started,omitempty"`
+
+	// Message describes why the promotion was rolled back.
+	Message string `json:"message,omitempty"`
+}
@@ -120,6 +120,7 @@ var marshalTestCases = []struct {
 			ModelUUID: "uuid",
 			Key:       "Benji",
 			Id:        4711,
+			Life:      "alive",
 			Endpoints: []multiwatcher.Endpoint{
 				{
 					ApplicationName: "logging",
@@ -144,7 +145,7 @@ var marshalTestCases = []struct {
 			},
 		},
 	},
-	json: `["relation","change",{"model-uuid": "uuid", "key":"Benji", "id": 4711, "endpoints": [{"application-name":"logging", "relation":{"name":"logging-directory", "role":"requirer", "interface":"logging", "optional":false, "limit":1, "scope":"container"}}, {"application-name":"wordpress", "relation":{"name":"logging-dir", "role":"provider", "interface":"logging", "optional":false, "limit":0, "scope":"container"}}]}]`,
+	json: `["relation","change",{"model-uuid": "uuid", "key":"Benji", "id": 4711, "life": "alive", "endpoints": [{"application-name":"logging", "relation":{"name":"logging-directory", "role":"requirer", "interface":"logging", "optional":false, "limit":1, "scope":"container"}}, {"application-name":"wordpress", "relation":{"name":"logging-dir", "role":"provider", "interface":"logging", "optional":false, "limit":0, "scope":"container"}}]}]`,
 }, {
 	about: "AnnotationInfo Delta",
 	value: multiwatcher.Delta{
@@ -167,7 +168,7 @@ var marshalTestCases = []struct {
 			Key:       "Benji",
 		},
 	},
-	json: `["relation","remove",{"model-uuid": "uuid", "key":"Benji", "id": 0, "endpoints": null}]`,
+	json: `["relation","remove",{"model-uuid": "uuid", "key":"Benji", "id": 0, "life": "", "endpoints": null}]`,
 }}
 
 func (s *MarshalSuite) TestDeltaMarshalJSON(c *gc.C) {
@@ -147,10 +147,11 @@ type ApplicationStatus struct {
 	EndpointBindings map[string]string      `json:"endpoint-bindings"`
 
 	// The following are for CAAS models.
-	Scale         int    `json:"int,omitempty"`
-	Placement     string `json:"string,omitempty"`
-	ProviderId    string `json:"provider-id,omitempty"`
-	PublicAddress string `json:"public-address"`
+	Scale            int    `json:"int,omitempty"`
+	Placement        string `json:"string,omitempty"`
+	ProviderId       string `json:"provider-id,omitempty"`
+	PublicAddress    string `json:"public-address"`
+	ExternalHostname string `json:"external-hostname,omitempty"`
 }
 
 // RemoteApplicationStatus holds status info about a remote application.
@@ -11,9 +11,11 @@ import (
 	"strconv"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 )
@@ -66,10 +68,15 @@ func (h *modelRestHandler) ServeGet(w http.ResponseWriter, r *http.Request) erro
 func (h *modelRestHandler) processGet(r *http.Request, w http.ResponseWriter, st *state.State) error {
 	query := r.URL.Query()
 	entity := query.Get(":entity")
-	// TODO(wallyworld) - support more than just "remote-application"
 	switch entity {
 	case "remote-application":
 		return h.processRemoteApplication(r, w, st)
+	case "application":
+		return h.processApplication(r, w, st)
+	case "unit":
+		return h.processUnit(r, w, st)
+	case "machine":
+		return h.processMachine(r, w, st)
 	default:
 		return errors.NotSupportedf("entity %v", entity)
 	}
@@ -84,7 +91,6 @@ func (h *modelRestHandler) processRemoteApplication(r *http.Request, w http.Resp
 		return errors.Trace(err)
 	}
 	attribute := query.Get(":attribute")
-	// TODO(wallyworld) - support more than just "icon"
 	if attribute != "icon" {
 		return errors.NotSupportedf("attribute %v on entity %v", attribute, name)
 	}
@@ -111,10 +117,98 @@ func (h *modelRestHandler) processRemoteApplication(r *http.Request, w http.Resp
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return errors.Trace(h.sendCharmIcon(w, sourceSt.State, ch))
+}
 
-	store := storage.NewStorage(sourceSt.ModelUUID(), sourceSt.MongoSession())
-	// Use the storage to retrieve and save the charm archive.
-	charmPath, err := common.ReadCharmFromStorage(store, h.dataDir, ch.StoragePath())
+// processApplication handles a request for attributes on applications.
+func (h *modelRestHandler) processApplication(r *http.Request, w http.ResponseWriter, st *state.State) error {
+	query := r.URL.Query()
+	name := query.Get(":name")
+	app, err := st.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch attribute := query.Get(":attribute"); attribute {
+	case "icon":
+		return errors.Trace(h.sendCharmIcon(w, st, ch))
+	case "metadata":
+		return errors.Trace(sendStatusAndJSON(w, http.StatusOK, convertCharmMeta(ch.Meta())))
+	case "readme":
+		return errors.Trace(h.sendCharmReadme(w, st, ch))
+	default:
+		return errors.NotSupportedf("attribute %v on entity %v", attribute, name)
+	}
+}
+
+// processUnit handles a request for attributes on units.
+func (h *modelRestHandler) processUnit(r *http.Request, w http.ResponseWriter, st *state.State) error {
+	query := r.URL.Query()
+	name := query.Get(":name")
+	unit, err := st.Unit(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch attribute := query.Get(":attribute"); attribute {
+	case "status":
+		info, err := unit.Status()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(sendStatusAndJSON(w, http.StatusOK, params.EntityStatus{
+			Status: info.Status,
+			Info:   info.Message,
+			Data:   info.Data,
+			Since:  info.Since,
+		}))
+	case "workload-version":
+		version, err := unit.WorkloadVersion()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(sendStatusAndJSON(w, http.StatusOK, params.EntityWorkloadVersion{
+			Tag:             unit.Tag().String(),
+			WorkloadVersion: version,
+		}))
+	default:
+		return errors.NotSupportedf("attribute %v on entity %v", attribute, name)
+	}
+}
+
+// processMachine handles a request for attributes on machines.
+func (h *modelRestHandler) processMachine(r *http.Request, w http.ResponseWriter, st *state.State) error {
+	query := r.URL.Query()
+	name := query.Get(":name")
+	machine, err := st.Machine(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch attribute := query.Get(":attribute"); attribute {
+	case "hardware-characteristics":
+		hc, err := machine.HardwareCharacteristics()
+		if errors.IsNotFound(err) {
+			// The machine has not been provisioned yet.
+			hc = &instance.HardwareCharacteristics{}
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(sendStatusAndJSON(w, http.StatusOK, hc))
+	default:
+		return errors.NotSupportedf("attribute %v on entity %v", attribute, name)
+	}
+}
+
+// charmReadmeNames holds the archive entries searched, in order, for a
+// charm's README.
+var charmReadmeNames = []string{"README.md", "README", "README.txt", "README.rst"}
+
+// sendCharmIcon sends the icon of the given charm, or the default icon
+// if the charm has none.
+func (h *modelRestHandler) sendCharmIcon(w http.ResponseWriter, st *state.State, ch *state.Charm) error {
+	charmPath, err := h.readCharm(st, ch)
 	if errors.IsNotFound(err) {
 		return h.byteSender(w, ".svg", []byte(common.DefaultCharmIcon))
 	}
@@ -131,6 +225,70 @@ func (h *modelRestHandler) processRemoteApplication(r *http.Request, w http.Resp
 	return h.byteSender(w, ".svg", iconContents)
 }
 
+// sendCharmReadme sends the README of the given charm as plain text.
+func (h *modelRestHandler) sendCharmReadme(w http.ResponseWriter, st *state.State, ch *state.Charm) error {
+	charmPath, err := h.readCharm(st, ch)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range charmReadmeNames {
+		contents, err := common.CharmArchiveEntry(charmPath, name, false)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return h.byteSender(w, ".txt", contents)
+	}
+	return errors.NotFoundf("README for charm %q", ch.URL())
+}
+
+// readCharm retrieves the archive of the given charm from the model's
+// storage, returning the local path it was saved to.
+func (h *modelRestHandler) readCharm(st *state.State, ch *state.Charm) (string, error) {
+	store := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	charmPath, err := common.ReadCharmFromStorage(store, h.dataDir, ch.StoragePath())
+	return charmPath, errors.Trace(err)
+}
+
+// convertCharmMeta converts charm metadata into the summary
+// served by the ReST endpoint.
+func convertCharmMeta(meta *charm.Meta) *params.CharmMeta {
+	return &params.CharmMeta{
+		Name:           meta.Name,
+		Summary:        meta.Summary,
+		Description:    meta.Description,
+		Subordinate:    meta.Subordinate,
+		Provides:       convertCharmRelations(meta.Provides),
+		Requires:       convertCharmRelations(meta.Requires),
+		Peers:          convertCharmRelations(meta.Peers),
+		Categories:     meta.Categories,
+		Tags:           meta.Tags,
+		Series:         meta.Series,
+		Terms:          meta.Terms,
+		MinJujuVersion: meta.MinJujuVersion.String(),
+	}
+}
+
+func convertCharmRelations(relations map[string]charm.Relation) map[string]params.CharmRelation {
+	if len(relations) == 0 {
+		return nil
+	}
+	result := make(map[string]params.CharmRelation)
+	for key, relation := range relations {
+		result[key] = params.CharmRelation{
+			Name:      relation.Name,
+			Role:      string(relation.Role),
+			Interface: relation.Interface,
+			Optional:  relation.Optional,
+			Limit:     relation.Limit,
+			Scope:     string(relation.Scope),
+		}
+	}
+	return result
+}
+
 func (h *modelRestHandler) byteSender(w http.ResponseWriter, ext string, contents []byte) error {
 	ctype := mime.TypeByExtension(ext)
 	if ctype != "" {
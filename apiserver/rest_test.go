@@ -4,6 +4,7 @@
 package apiserver_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -22,6 +23,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apitesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing/factory"
@@ -202,3 +205,125 @@ func (s *restSuite) TestGetRemoteApplicationIcon(c *gc.C) {
 		s.assertGetFileResponse(c, resp, test.expectBody, test.expectType)
 	}
 }
+
+// addUploadedApplication uploads the given charm directory and deploys
+// it as an application with the given name.
+func (s *restSuite) addUploadedApplication(c *gc.C, name string, dir *charm.CharmDir) *state.Application {
+	var buf bytes.Buffer
+	err := dir.ArchiveTo(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "POST",
+		URL:         s.charmsURI("series=quantal"),
+		ContentType: "application/zip",
+		Body:        &buf,
+	})
+	apitesting.AssertResponse(c, resp, http.StatusOK, "application/json")
+
+	curl, err := charm.ParseURL(fmt.Sprintf("local:quantal/%s-%d", dir.Meta().Name, dir.Revision()))
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  name,
+		Charm: ch,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return app
+}
+
+func (s *restSuite) getJSON(c *gc.C, query string, result interface{}) {
+	uri := s.restURI(s.State.ModelUUID(), query)
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{Method: "GET", URL: uri})
+	body := apitesting.AssertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	err := json.Unmarshal(body, result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+}
+
+func (s *restSuite) TestGetApplicationAttributes(c *gc.C) {
+	dir := testcharms.Repo.ClonedDir(c.MkDir(), "mysql")
+	err := ioutil.WriteFile(filepath.Join(dir.Path, "README.md"), []byte("# MySQL\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addUploadedApplication(c, "mysql", dir)
+
+	iconPath := filepath.Join(testcharms.Repo.CharmDirPath("mysql"), "icon.svg")
+	icon, err := ioutil.ReadFile(iconPath)
+	c.Assert(err, jc.ErrorIsNil)
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.restURI(s.State.ModelUUID(), "application/mysql/icon"),
+	})
+	s.assertGetFileResponse(c, resp, string(icon), mime.TypeByExtension(".svg"))
+
+	resp = s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.restURI(s.State.ModelUUID(), "application/mysql/readme"),
+	})
+	s.assertGetFileResponse(c, resp, "# MySQL\n", mime.TypeByExtension(".txt"))
+
+	var meta params.CharmMeta
+	s.getJSON(c, "application/mysql/metadata", &meta)
+	c.Assert(meta.Name, gc.Equals, "mysql")
+	c.Assert(meta.Summary, gc.Equals, dir.Meta().Summary)
+	c.Assert(meta.Provides["server"].Interface, gc.Equals, "mysql")
+}
+
+func (s *restSuite) TestGetApplicationReadmeNotFound(c *gc.C) {
+	s.addUploadedApplication(c, "mysql", testcharms.Repo.ClonedDir(c.MkDir(), "mysql"))
+	uri := s.restURI(s.State.ModelUUID(), "application/mysql/readme")
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{Method: "GET", URL: uri})
+	s.assertErrorResponse(
+		c, resp, http.StatusNotFound,
+		`cannot retrieve model data: README for charm "local:quantal/mysql-1" not found`,
+	)
+}
+
+func (s *restSuite) TestGetUnitAttributes(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Status: &status.StatusInfo{
+			Status:  status.Active,
+			Message: "ready",
+		},
+	})
+	err := unit.SetWorkloadVersion("5.7")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var unitStatus params.EntityStatus
+	s.getJSON(c, "unit/"+unit.Name()+"/status", &unitStatus)
+	c.Assert(unitStatus.Status, gc.Equals, status.Active)
+	c.Assert(unitStatus.Info, gc.Equals, "ready")
+
+	var version params.EntityWorkloadVersion
+	s.getJSON(c, "unit/"+unit.Name()+"/workload-version", &version)
+	c.Assert(version, jc.DeepEquals, params.EntityWorkloadVersion{
+		Tag:             unit.Tag().String(),
+		WorkloadVersion: "5.7",
+	})
+}
+
+func (s *restSuite) TestGetMachineHardwareCharacteristics(c *gc.C) {
+	arch := "amd64"
+	mem := uint64(4096)
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Characteristics: &instance.HardwareCharacteristics{
+			Arch: &arch,
+			Mem:  &mem,
+		},
+	})
+	var hc instance.HardwareCharacteristics
+	s.getJSON(c, "machine/"+machine.Id()+"/hardware-characteristics", &hc)
+	c.Assert(hc, jc.DeepEquals, instance.HardwareCharacteristics{
+		Arch: &arch,
+		Mem:  &mem,
+	})
+}
+
+func (s *restSuite) TestGetUnsupportedAttribute(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	uri := s.restURI(s.State.ModelUUID(), "machine/"+machine.Id()+"/secrets")
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{Method: "GET", URL: uri})
+	s.assertErrorResponse(
+		c, resp, http.StatusInternalServerError,
+		`cannot retrieve model data: attribute secrets on entity 0 not supported`,
+	)
+}
@@ -193,6 +193,10 @@ type NamespaceWatcher interface {
 type Service struct {
 	Id        string
 	Addresses []network.Address
+
+	// Hostnames are the external hostnames, or addresses, at
+	// which an exposed application is reached.
+	Hostnames []string
 }
 
 // FilesystemInfo represents information about a filesystem
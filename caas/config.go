@@ -24,6 +24,11 @@ const (
 
 	// JujuDefaultApplicationPath is the default value for juju-application-path.
 	JujuDefaultApplicationPath = "/"
+
+	// JujuTrustKey is the application setting that grants an application
+	// trust. Only trusted applications may ask for privileges beyond
+	// their model, such as global service account rules.
+	JujuTrustKey = "trust"
 )
 
 var configFields = environschema.Fields{
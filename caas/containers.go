@@ -4,8 +4,12 @@
 package caas
 
 import (
+	"strings"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // FileSet defines a set of files to mount
@@ -30,6 +34,102 @@ type ImageDetails struct {
 	Password  string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
+// SecretEnvVar defines an environment variable whose
+// value is read from a key of a secret.
+type SecretEnvVar struct {
+	Name       string `yaml:"name" json:"name"`
+	SecretName string `yaml:"secretName" json:"secretName"`
+	Key        string `yaml:"key" json:"key"`
+}
+
+// Secret defines a secret to be made available to
+// the workload containers.
+type Secret struct {
+	Name string `yaml:"name" json:"name"`
+	// Type defaults to an opaque secret if not specified.
+	Type string            `yaml:"type,omitempty" json:"type,omitempty"`
+	Data map[string]string `yaml:"data" json:"data"`
+}
+
+// PolicyRule defines a set of permissions granted
+// to a service account.
+type PolicyRule struct {
+	APIGroups     []string `yaml:"apiGroups,omitempty" json:"apiGroups,omitempty"`
+	Resources     []string `yaml:"resources,omitempty" json:"resources,omitempty"`
+	ResourceNames []string `yaml:"resourceNames,omitempty" json:"resourceNames,omitempty"`
+	Verbs         []string `yaml:"verbs" json:"verbs"`
+}
+
+// ServiceAccountSpec defines the service account the workload
+// pods run as, and the permissions it is granted.
+type ServiceAccountSpec struct {
+	AutomountServiceAccountToken *bool `yaml:"automountServiceAccountToken,omitempty" json:"automountServiceAccountToken,omitempty"`
+	// Global is true if the rules apply across the cluster
+	// rather than just within the model.
+	Global bool         `yaml:"global,omitempty" json:"global,omitempty"`
+	Rules  []PolicyRule `yaml:"rules" json:"rules"`
+}
+
+// HTTPGetAction defines an HTTP GET request used to probe a container.
+type HTTPGetAction struct {
+	Path   string             `yaml:"path,omitempty" json:"path,omitempty"`
+	Port   intstr.IntOrString `yaml:"port" json:"port"`
+	Host   string             `yaml:"host,omitempty" json:"host,omitempty"`
+	Scheme string             `yaml:"scheme,omitempty" json:"scheme,omitempty"`
+}
+
+// TCPSocketAction defines a TCP connection used to probe a container.
+type TCPSocketAction struct {
+	Port intstr.IntOrString `yaml:"port" json:"port"`
+	Host string             `yaml:"host,omitempty" json:"host,omitempty"`
+}
+
+// ExecAction defines a command run inside a container to probe it.
+type ExecAction struct {
+	Command []string `yaml:"command" json:"command"`
+}
+
+// Probe defines a health check performed against a container.
+// Exactly one of HTTPGet, TCPSocket or Exec must be specified.
+type Probe struct {
+	HTTPGet   *HTTPGetAction   `yaml:"httpGet,omitempty" json:"httpGet,omitempty"`
+	TCPSocket *TCPSocketAction `yaml:"tcpSocket,omitempty" json:"tcpSocket,omitempty"`
+	Exec      *ExecAction      `yaml:"exec,omitempty" json:"exec,omitempty"`
+
+	InitialDelaySeconds int32 `yaml:"initialDelaySeconds,omitempty" json:"initialDelaySeconds,omitempty"`
+	TimeoutSeconds      int32 `yaml:"timeoutSeconds,omitempty" json:"timeoutSeconds,omitempty"`
+	PeriodSeconds       int32 `yaml:"periodSeconds,omitempty" json:"periodSeconds,omitempty"`
+	SuccessThreshold    int32 `yaml:"successThreshold,omitempty" json:"successThreshold,omitempty"`
+	FailureThreshold    int32 `yaml:"failureThreshold,omitempty" json:"failureThreshold,omitempty"`
+}
+
+// ResourceRequirements defines the compute resources requested
+// by a container, and the limits on those it may consume.
+// Resources are keyed on name, eg cpu or memory, and the
+// values are quantities such as 500m or 1Gi.
+type ResourceRequirements struct {
+	Requests map[string]string `yaml:"requests,omitempty" json:"requests,omitempty"`
+	Limits   map[string]string `yaml:"limits,omitempty" json:"limits,omitempty"`
+}
+
+// Capabilities defines the Linux capabilities to add
+// to, or drop from, a container.
+type Capabilities struct {
+	Add  []string `yaml:"add,omitempty" json:"add,omitempty"`
+	Drop []string `yaml:"drop,omitempty" json:"drop,omitempty"`
+}
+
+// SecurityContext defines the privileges a container runs with.
+type SecurityContext struct {
+	RunAsUser                *int64        `yaml:"runAsUser,omitempty" json:"runAsUser,omitempty"`
+	RunAsGroup               *int64        `yaml:"runAsGroup,omitempty" json:"runAsGroup,omitempty"`
+	RunAsNonRoot             *bool         `yaml:"runAsNonRoot,omitempty" json:"runAsNonRoot,omitempty"`
+	Privileged               *bool         `yaml:"privileged,omitempty" json:"privileged,omitempty"`
+	AllowPrivilegeEscalation *bool         `yaml:"allowPrivilegeEscalation,omitempty" json:"allowPrivilegeEscalation,omitempty"`
+	ReadOnlyRootFilesystem   *bool         `yaml:"readOnlyRootFilesystem,omitempty" json:"readOnlyRootFilesystem,omitempty"`
+	Capabilities             *Capabilities `yaml:"capabilities,omitempty" json:"capabilities,omitempty"`
+}
+
 // ProviderContainer defines a provider specific container.
 type ProviderContainer interface {
 	Validate() error
@@ -51,16 +151,101 @@ type ContainerSpec struct {
 	Config map[string]interface{} `yaml:"config,omitempty"`
 	Files  []FileSet              `yaml:"files,omitempty"`
 
+	// EnvFromSecrets holds the names of secrets whose
+	// keys are all exposed as environment variables.
+	EnvFromSecrets []string       `yaml:"envFromSecrets,omitempty"`
+	SecretEnv      []SecretEnvVar `yaml:"secretEnv,omitempty"`
+
+	LivenessProbe   *Probe               `yaml:"livenessProbe,omitempty"`
+	ReadinessProbe  *Probe               `yaml:"readinessProbe,omitempty"`
+	Resources       ResourceRequirements `yaml:"resources,omitempty"`
+	SecurityContext *SecurityContext     `yaml:"securityContext,omitempty"`
+
 	// ProviderContainer defines config which is specific to a substrate, eg k8s
 	ProviderContainer `yaml:"-"`
 }
 
+// DeploymentType defines how the pods of an application are run.
+type DeploymentType string
+
+const (
+	// DeploymentStateless runs a set of interchangeable pods.
+	DeploymentStateless DeploymentType = "stateless"
+
+	// DeploymentStateful runs pods with stable identities,
+	// as required when the application uses storage.
+	DeploymentStateful DeploymentType = "stateful"
+
+	// DeploymentDaemon runs a pod on each node of the cluster.
+	DeploymentDaemon DeploymentType = "daemon"
+
+	// DeploymentJob runs pods to completion, either
+	// once or repeatedly on a schedule.
+	DeploymentJob DeploymentType = "job"
+)
+
+// Validate returns an error if the deployment type is not valid.
+func (dt DeploymentType) Validate() error {
+	switch dt {
+	case "", DeploymentStateless, DeploymentStateful, DeploymentDaemon, DeploymentJob:
+		return nil
+	}
+	return errors.NotValidf("deployment type %q", dt)
+}
+
+// JobSpec defines how the pods of a job deployment are run.
+type JobSpec struct {
+	// Schedule is the cron schedule on which the job is run.
+	// If empty, the job is run once. Each scheduled run has new
+	// units, and the units of all but the last successful and last
+	// failed runs are removed.
+	Schedule              string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	BackoffLimit          *int32 `yaml:"backoffLimit,omitempty" json:"backoffLimit,omitempty"`
+	ActiveDeadlineSeconds *int64 `yaml:"activeDeadlineSeconds,omitempty" json:"activeDeadlineSeconds,omitempty"`
+}
+
+// IngressSpec defines how requests from outside the cluster are
+// routed to an exposed application. Any values set here take
+// precedence over those in the application config.
+type IngressSpec struct {
+	Class       string            `yaml:"class,omitempty" json:"class,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+	TLS         *IngressTLS       `yaml:"tls,omitempty" json:"tls,omitempty"`
+	Paths       []IngressPath     `yaml:"paths,omitempty" json:"paths,omitempty"`
+}
+
+// IngressTLS defines the certificate used to terminate TLS at the
+// ingress, either from an existing secret or from a certificate
+// issued by cert-manager.
+type IngressTLS struct {
+	SecretName    string `yaml:"secretName,omitempty" json:"secretName,omitempty"`
+	Issuer        string `yaml:"issuer,omitempty" json:"issuer,omitempty"`
+	ClusterIssuer string `yaml:"clusterIssuer,omitempty" json:"clusterIssuer,omitempty"`
+}
+
+// IngressPath routes requests for a path to a port of the
+// application's service. If no port is specified, the
+// first service port is used.
+type IngressPath struct {
+	Path string `yaml:"path" json:"path"`
+	Port int    `yaml:"port,omitempty" json:"port,omitempty"`
+}
+
 // PodSpec defines the data values used to configure
 // a pod on the CAAS substrate.
 type PodSpec struct {
 	Containers                []ContainerSpec            `yaml:"-"`
 	OmitServiceFrontend       bool                       `yaml:"omitServiceFrontend"`
 	CustomResourceDefinitions []CustomResourceDefinition `yaml:"customResourceDefinition,omitempty"`
+	Secrets                   []Secret                   `yaml:"secrets,omitempty"`
+	ServiceAccount            *ServiceAccountSpec        `yaml:"serviceAccount,omitempty"`
+
+	// DeploymentType defaults to a stateless deployment, or a
+	// stateful one if the application has storage.
+	DeploymentType DeploymentType `yaml:"deploymentType,omitempty"`
+	Job            *JobSpec       `yaml:"job,omitempty"`
+
+	Ingress *IngressSpec `yaml:"ingress,omitempty"`
 
 	// ProviderPod defines config which is specific to a substrate, eg k8s
 	ProviderPod `yaml:"-"`
@@ -69,6 +254,11 @@ type PodSpec struct {
 // ProviderPod defines a provider specific pod.
 type ProviderPod interface {
 	Validate() error
+
+	// ValidateTrust returns an error if the provider specific pod
+	// settings ask for privileges that are only granted to trusted
+	// applications.
+	ValidateTrust() error
 }
 
 // CustomResourceDefinitionValidation defines the custom resource definition validation schema.
@@ -102,18 +292,279 @@ func (crd *CustomResourceDefinition) Validate() error {
 	return nil
 }
 
+// Validate returns an error if the probe is not valid.
+func (p *Probe) Validate() error {
+	handlers := 0
+	if p.HTTPGet != nil {
+		handlers++
+		if isZeroPort(p.HTTPGet.Port) {
+			return errors.NotValidf("http probe with no port")
+		}
+		switch p.HTTPGet.Scheme {
+		case "", "HTTP", "HTTPS":
+		default:
+			return errors.NotValidf("http probe scheme %q", p.HTTPGet.Scheme)
+		}
+	}
+	if p.TCPSocket != nil {
+		handlers++
+		if isZeroPort(p.TCPSocket.Port) {
+			return errors.NotValidf("tcp probe with no port")
+		}
+	}
+	if p.Exec != nil {
+		handlers++
+		if len(p.Exec.Command) == 0 {
+			return errors.NotValidf("exec probe with no command")
+		}
+	}
+	if handlers != 1 {
+		return errors.NotValidf("probe without exactly one of httpGet, tcpSocket or exec")
+	}
+	if p.InitialDelaySeconds < 0 || p.TimeoutSeconds < 0 || p.PeriodSeconds < 0 ||
+		p.SuccessThreshold < 0 || p.FailureThreshold < 0 {
+		return errors.NotValidf("negative probe timing")
+	}
+	return nil
+}
+
+func isZeroPort(port intstr.IntOrString) bool {
+	if port.Type == intstr.String {
+		return port.StrVal == ""
+	}
+	return port.IntVal <= 0
+}
+
+// Validate returns an error if the resource requirements are not valid.
+func (r *ResourceRequirements) Validate() error {
+	for _, resources := range []map[string]string{r.Requests, r.Limits} {
+		for name, value := range resources {
+			if name == "" {
+				return errors.New("resource name is missing")
+			}
+			if value == "" {
+				return errors.Errorf("resource quantity is missing for %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the security context is not valid.
+func (sc *SecurityContext) Validate() error {
+	if sc.Privileged != nil && *sc.Privileged &&
+		sc.AllowPrivilegeEscalation != nil && !*sc.AllowPrivilegeEscalation {
+		return errors.NotValidf("privileged container without privilege escalation")
+	}
+	if sc.RunAsNonRoot != nil && *sc.RunAsNonRoot && sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		return errors.NotValidf("non root container running as root user")
+	}
+	return nil
+}
+
+// Validate returns an error if the ingress spec is not valid.
+func (spec *IngressSpec) Validate() error {
+	if spec.TLS != nil {
+		if err := spec.TLS.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	paths := set.NewStrings()
+	for _, p := range spec.Paths {
+		if !strings.HasPrefix(p.Path, "/") {
+			return errors.NotValidf("ingress path %q", p.Path)
+		}
+		if paths.Contains(p.Path) {
+			return errors.NotValidf("duplicate ingress path %q", p.Path)
+		}
+		paths.Add(p.Path)
+		if p.Port < 0 {
+			return errors.NotValidf("port %d for ingress path %q", p.Port, p.Path)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the ingress TLS config is not valid.
+func (tls *IngressTLS) Validate() error {
+	if tls.Issuer != "" && tls.ClusterIssuer != "" {
+		return errors.NotValidf("specifying both issuer and clusterIssuer")
+	}
+	if tls.SecretName == "" && tls.Issuer == "" && tls.ClusterIssuer == "" {
+		return errors.New("ingress TLS requires a secret name or certificate issuer")
+	}
+	return nil
+}
+
+// clusterScopedResources are the resources that don't belong to a
+// namespace, so can only be granted by cluster-wide rules.
+var clusterScopedResources = set.NewStrings(
+	"nodes",
+	"namespaces",
+	"persistentvolumes",
+	"storageclasses",
+	"clusterroles",
+	"clusterrolebindings",
+	"customresourcedefinitions",
+	"certificatesigningrequests",
+	"podsecuritypolicies",
+	"priorityclasses",
+	"mutatingwebhookconfigurations",
+	"validatingwebhookconfigurations",
+	"apiservices",
+)
+
+// validateTrust returns an error if the security context gives the
+// container privileges over the node it runs on.
+func (sc *SecurityContext) validateTrust() error {
+	switch {
+	case sc.Privileged != nil && *sc.Privileged:
+		return errors.NotValidf("privileged container for application without trust")
+	case sc.AllowPrivilegeEscalation != nil && *sc.AllowPrivilegeEscalation:
+		return errors.NotValidf("allowing privilege escalation for application without trust")
+	case sc.Capabilities != nil && len(sc.Capabilities.Add) > 0:
+		return errors.NotValidf("adding capabilities for application without trust")
+	case sc.RunAsUser != nil && *sc.RunAsUser == 0:
+		return errors.NotValidf("running as root user for application without trust")
+	}
+	return nil
+}
+
+// Validate returns an error if the service account is not valid.
+// The rules of a service account that isn't global are limited to
+// the model's namespace, so may not name cluster-scoped resources.
+func (sa *ServiceAccountSpec) Validate() error {
+	if len(sa.Rules) == 0 {
+		return errors.NotValidf("service account with no rules")
+	}
+	for _, r := range sa.Rules {
+		if len(r.Verbs) == 0 {
+			return errors.NotValidf("service account rule with no verbs")
+		}
+		if sa.Global {
+			continue
+		}
+		for _, resource := range r.Resources {
+			if clusterScopedResources.Contains(resource) {
+				return errors.NotValidf("rule for cluster-scoped resource %q in service account that is not global", resource)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTrust returns an error if the service account's rules reach
+// beyond the application's own resources in the model's namespace.
+func (sa *ServiceAccountSpec) validateTrust() error {
+	if sa.Global {
+		return errors.NotValidf("global service account for application without trust")
+	}
+	for _, r := range sa.Rules {
+		for _, resource := range r.Resources {
+			if trustedResources.Contains(resource) {
+				return errors.NotValidf("service account rule for %q resources for application without trust", resource)
+			}
+		}
+	}
+	return nil
+}
+
+// trustedResources are the namespaced resources that may only be
+// granted to trusted applications, as they give access to the secrets
+// of the other applications in the model.
+var trustedResources = set.NewStrings("secrets", "*")
+
+// ValidateTrust returns an error if the spec asks for privileges that
+// are only granted to applications the operator has trusted. The rules
+// of a global service account apply across the cluster, outside the
+// model, rules for secrets expose those of the model's other
+// applications, and the workload privileges checked by
+// ValidateWorkloadTrust give a container control of its node, so all
+// require trust.
+func (spec *PodSpec) ValidateTrust(trusted bool) error {
+	if trusted {
+		return nil
+	}
+	if spec.ServiceAccount != nil {
+		if err := spec.ServiceAccount.validateTrust(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(spec.ValidateWorkloadTrust(trusted))
+}
+
+// ValidateWorkloadTrust returns an error if the spec's pods ask for
+// privileges over the node they run on that are only granted to
+// trusted applications: privileged containers, privilege escalation,
+// added capabilities, running as root, or provider specific settings
+// such as sharing the node's namespaces. Pods that do so must not be
+// left running once an application's trust is removed.
+func (spec *PodSpec) ValidateWorkloadTrust(trusted bool) error {
+	if trusted {
+		return nil
+	}
+	for _, c := range spec.Containers {
+		if c.SecurityContext == nil {
+			continue
+		}
+		if err := c.SecurityContext.validateTrust(); err != nil {
+			return errors.Annotatef(err, "security context for %q", c.Name)
+		}
+	}
+	if spec.ProviderPod != nil {
+		return errors.Trace(spec.ProviderPod.ValidateTrust())
+	}
+	return nil
+}
+
 // Validate returns an error if the spec is not valid.
 func (spec *PodSpec) Validate() error {
+	secretNames := set.NewStrings()
+	for _, s := range spec.Secrets {
+		if s.Name == "" {
+			return errors.New("secret name is missing")
+		}
+		if secretNames.Contains(s.Name) {
+			return errors.NotValidf("duplicate secret %q", s.Name)
+		}
+		secretNames.Add(s.Name)
+	}
 	for _, c := range spec.Containers {
 		if err := c.Validate(); err != nil {
 			return errors.Trace(err)
 		}
+		for _, name := range c.EnvFromSecrets {
+			if !secretNames.Contains(name) {
+				return errors.NotFoundf("secret %q for container %q", name, c.Name)
+			}
+		}
+		for _, env := range c.SecretEnv {
+			if !secretNames.Contains(env.SecretName) {
+				return errors.NotFoundf("secret %q for container %q", env.SecretName, c.Name)
+			}
+		}
 	}
 	for _, crd := range spec.CustomResourceDefinitions {
 		if err := crd.Validate(); err != nil {
 			return errors.Trace(err)
 		}
 	}
+	if spec.ServiceAccount != nil {
+		if err := spec.ServiceAccount.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := spec.DeploymentType.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Job != nil && spec.DeploymentType != DeploymentJob {
+		return errors.NotValidf("job settings for %q deployment", spec.DeploymentType)
+	}
+	if spec.Ingress != nil {
+		if err := spec.Ingress.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if spec.ProviderPod != nil {
 		return spec.ProviderPod.Validate()
 	}
@@ -136,6 +587,32 @@ func (spec *ContainerSpec) Validate() error {
 			return errors.Errorf("mount path is missing for file set %q", fs.Name)
 		}
 	}
+	for _, env := range spec.SecretEnv {
+		if env.Name == "" {
+			return errors.New("secret environment variable name is missing")
+		}
+		if env.SecretName == "" || env.Key == "" {
+			return errors.Errorf("secret name and key are required for environment variable %q", env.Name)
+		}
+	}
+	if spec.LivenessProbe != nil {
+		if err := spec.LivenessProbe.Validate(); err != nil {
+			return errors.Annotatef(err, "liveness probe for %q", spec.Name)
+		}
+	}
+	if spec.ReadinessProbe != nil {
+		if err := spec.ReadinessProbe.Validate(); err != nil {
+			return errors.Annotatef(err, "readiness probe for %q", spec.Name)
+		}
+	}
+	if err := spec.Resources.Validate(); err != nil {
+		return errors.Annotatef(err, "resources for %q", spec.Name)
+	}
+	if spec.SecurityContext != nil {
+		if err := spec.SecurityContext.Validate(); err != nil {
+			return errors.Annotatef(err, "security context for %q", spec.Name)
+		}
+	}
 	if spec.ProviderContainer != nil {
 		return spec.ProviderContainer.Validate()
 	}
@@ -43,6 +43,9 @@ type BaseSuite struct {
 	mockSecrets                *mocks.MockSecretInterface
 	mockDeployments            *mocks.MockDeploymentInterface
 	mockStatefulSets           *mocks.MockStatefulSetInterface
+	mockDaemonSets             *mocks.MockDaemonSetInterface
+	mockJobs                   *mocks.MockJobInterface
+	mockCronJobs               *mocks.MockCronJobInterface
 	mockPods                   *mocks.MockPodInterface
 	mockServices               *mocks.MockServiceInterface
 	mockConfigMaps             *mocks.MockConfigMapInterface
@@ -52,6 +55,13 @@ type BaseSuite struct {
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
 	mockNodes                  *mocks.MockNodeInterface
+	mockServiceAccounts        *mocks.MockServiceAccountInterface
+
+	mockRbacV1              *mocks.MockRbacV1Interface
+	mockRoles               *mocks.MockRoleInterface
+	mockRoleBindings        *mocks.MockRoleBindingInterface
+	mockClusterRoles        *mocks.MockClusterRoleInterface
+	mockClusterRoleBindings *mocks.MockClusterRoleBindingInterface
 
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
@@ -121,6 +131,9 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockNodes = mocks.NewMockNodeInterface(ctrl)
 	mockCoreV1.EXPECT().Nodes().AnyTimes().Return(s.mockNodes)
 
+	s.mockServiceAccounts = mocks.NewMockServiceAccountInterface(ctrl)
+	mockCoreV1.EXPECT().ServiceAccounts(testNamespace).AnyTimes().Return(s.mockServiceAccounts)
+
 	s.mockApps = mocks.NewMockAppsV1Interface(ctrl)
 	s.mockExtensions = mocks.NewMockExtensionsV1beta1Interface(ctrl)
 	s.mockStatefulSets = mocks.NewMockStatefulSetInterface(ctrl)
@@ -130,13 +143,35 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.k8sClient.EXPECT().AppsV1().AnyTimes().Return(s.mockApps)
 	s.mockApps.EXPECT().StatefulSets(testNamespace).AnyTimes().Return(s.mockStatefulSets)
 	s.mockApps.EXPECT().Deployments(testNamespace).AnyTimes().Return(s.mockDeployments)
+	s.mockDaemonSets = mocks.NewMockDaemonSetInterface(ctrl)
+	s.mockApps.EXPECT().DaemonSets(testNamespace).AnyTimes().Return(s.mockDaemonSets)
 	s.mockExtensions.EXPECT().Ingresses(testNamespace).AnyTimes().Return(s.mockIngressInterface)
 
+	mockBatchV1 := mocks.NewMockBatchV1Interface(ctrl)
+	mockBatchV1beta1 := mocks.NewMockBatchV1beta1Interface(ctrl)
+	s.mockJobs = mocks.NewMockJobInterface(ctrl)
+	s.mockCronJobs = mocks.NewMockCronJobInterface(ctrl)
+	s.k8sClient.EXPECT().BatchV1().AnyTimes().Return(mockBatchV1)
+	s.k8sClient.EXPECT().BatchV1beta1().AnyTimes().Return(mockBatchV1beta1)
+	mockBatchV1.EXPECT().Jobs(testNamespace).AnyTimes().Return(s.mockJobs)
+	mockBatchV1beta1.EXPECT().CronJobs(testNamespace).AnyTimes().Return(s.mockCronJobs)
+
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
 	s.mockStorageClass = mocks.NewMockStorageClassInterface(ctrl)
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
 	s.mockStorage.EXPECT().StorageClasses().AnyTimes().Return(s.mockStorageClass)
 
+	s.mockRbacV1 = mocks.NewMockRbacV1Interface(ctrl)
+	s.mockRoles = mocks.NewMockRoleInterface(ctrl)
+	s.mockRoleBindings = mocks.NewMockRoleBindingInterface(ctrl)
+	s.mockClusterRoles = mocks.NewMockClusterRoleInterface(ctrl)
+	s.mockClusterRoleBindings = mocks.NewMockClusterRoleBindingInterface(ctrl)
+	s.k8sClient.EXPECT().RbacV1().AnyTimes().Return(s.mockRbacV1)
+	s.mockRbacV1.EXPECT().Roles(testNamespace).AnyTimes().Return(s.mockRoles)
+	s.mockRbacV1.EXPECT().RoleBindings(testNamespace).AnyTimes().Return(s.mockRoleBindings)
+	s.mockRbacV1.EXPECT().ClusterRoles().AnyTimes().Return(s.mockClusterRoles)
+	s.mockRbacV1.EXPECT().ClusterRoleBindings().AnyTimes().Return(s.mockClusterRoleBindings)
+
 	s.mockApiextensionsClient = mocks.NewMockApiExtensionsClientInterface(ctrl)
 	s.mockApiextensionsV1 = mocks.NewMockApiextensionsV1beta1Interface(ctrl)
 	s.mockCustomResourceDefinition = mocks.NewMockCustomResourceDefinitionInterface(ctrl)
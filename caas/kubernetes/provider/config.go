@@ -28,6 +28,11 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	ingressAnnotationsKey      = "kubernetes-ingress-annotations"
+	ingressTLSSecretKey        = "kubernetes-ingress-tls-secret"
+	ingressTLSIssuerKey        = "kubernetes-ingress-tls-issuer"
+	ingressTLSClusterIssuerKey = "kubernetes-ingress-tls-cluster-issuer"
 )
 
 var configFields = environschema.Fields{
@@ -86,11 +91,32 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	ingressAnnotationsKey: {
+		Description: "a space separated set of annotations to add to the ingress resource",
+		Type:        environschema.Tattrs,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSSecretKey: {
+		Description: "the secret holding the TLS certificate used by the ingress resource",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSIssuerKey: {
+		Description: "the cert-manager issuer used to obtain a TLS certificate for the ingress resource",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSClusterIssuerKey: {
+		Description: "the cert-manager cluster issuer used to obtain a TLS certificate for the ingress resource",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
 	serviceTypeConfigKey:     defaultServiceType,
 	serviceAnnotationsKey:    schema.Omit,
+	ingressAnnotationsKey:    schema.Omit,
 	ingressClassKey:          defaultIngressClass,
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
@@ -21,6 +21,7 @@ var (
 	NewKubernetesWatcher     = newKubernetesWatcher
 	CompileK8sCloudCheckers  = compileK8sCloudCheckers
 	CloudSpecToK8sRestConfig = cloudSpecToK8sRestConfig
+	MergeConstraint          = mergeConstraint
 )
 
 type KubernetesWatcher = kubernetesWatcher
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"regexp"
@@ -22,8 +23,11 @@ import (
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	core "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8sstorage "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -61,6 +65,14 @@ const (
 	labelApplication = "juju-application"
 	labelModel       = "juju-model"
 
+	// annotationIngressSpec records the ingress settings from the
+	// pod spec on the application's service, for use when the
+	// application is exposed.
+	annotationIngressSpec = "juju.io/ingress-spec"
+
+	certManagerIssuerAnnotation        = "certmanager.k8s.io/issuer"
+	certManagerClusterIssuerAnnotation = "certmanager.k8s.io/cluster-issuer"
+
 	defaultOperatorStorageClassName = "juju-operator-storage"
 
 	gpuAffinityNodeSelectorKey = "gpu"
@@ -90,10 +102,14 @@ type kubernetesClient struct {
 // To regenerate the mocks for the kubernetes Client used by this broker,
 // run "go generate" from the package directory.
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
-//go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
+//go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DaemonSetInterface,DeploymentInterface,StatefulSetInterface
 //go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
+//go:generate mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,ClusterRoleBindingInterface,ClusterRoleInterface,RoleBindingInterface,RoleInterface
+//go:generate mockgen -package mocks -destination mocks/serviceaccount_mock.go k8s.io/client-go/kubernetes/typed/core/v1 ServiceAccountInterface
+//go:generate mockgen -package mocks -destination mocks/batchv1_mock.go k8s.io/client-go/kubernetes/typed/batch/v1 BatchV1Interface,JobInterface
+//go:generate mockgen -package mocks -destination mocks/batchv1beta1_mock.go k8s.io/client-go/kubernetes/typed/batch/v1beta1 BatchV1beta1Interface,CronJobInterface
 
 // NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
 type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error)
@@ -268,6 +284,25 @@ func (k *kubernetesClient) Destroy(callbacks context.ProviderCallContext) error
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return errors.Annotate(err, "deleting model storage classes")
 	}
+
+	// Likewise for any cluster roles granted to workload service accounts.
+	rbac := k.RbacV1()
+	err = rbac.ClusterRoleBindings().DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: modelSelector,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotate(err, "deleting model cluster role bindings")
+	}
+	err = rbac.ClusterRoles().DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: modelSelector,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotate(err, "deleting model cluster roles")
+	}
 	for {
 		select {
 		case <-callbacks.Dying():
@@ -395,6 +430,245 @@ func (k *kubernetesClient) deleteSecret(imageSecretName string) error {
 	return errors.Trace(err)
 }
 
+// ensureWorkloadSecret ensures a secret declared in the pod spec exists
+// for use by the workload containers.
+func (k *kubernetesClient) ensureWorkloadSecret(secretName string, secret *caas.Secret, resourceTags map[string]string) error {
+	secretType := core.SecretTypeOpaque
+	if secret.Type != "" {
+		secretType = core.SecretType(secret.Type)
+	}
+	newSecret := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      secretName,
+			Namespace: k.namespace,
+			Labels:    resourceTags},
+		Type: secretType,
+		Data: make(map[string][]byte, len(secret.Data)),
+	}
+	for key, value := range secret.Data {
+		newSecret.Data[key] = []byte(value)
+	}
+
+	secrets := k.CoreV1().Secrets(k.namespace)
+	_, err := secrets.Update(newSecret)
+	if k8serrors.IsNotFound(err) {
+		_, err = secrets.Create(newSecret)
+	}
+	return errors.Trace(err)
+}
+
+// pruneSecrets deletes any secrets belonging to the
+// application which are not in the supplied set.
+func (k *kubernetesClient) pruneSecrets(appName string, keep set.Strings) error {
+	secrets := k.CoreV1().Secrets(k.namespace)
+	secretList, err := secrets.List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, s := range secretList.Items {
+		if keep.Contains(s.Name) {
+			continue
+		}
+		logger.Debugf("deleting unused secret %q for %v", s.Name, appName)
+		if err := k.deleteSecret(s.Name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// ensureServiceAccount ensures the workload service account and
+// its role and role binding exist, as specified. If no service account
+// is specified, any existing one is deleted. The role is created in
+// the model's namespace unless the service account is global, which
+// the caller must have checked is allowed.
+func (k *kubernetesClient) ensureServiceAccount(deploymentName string, spec *caas.ServiceAccountSpec, resourceTags map[string]string) error {
+	if spec == nil {
+		return k.deleteServiceAccount(deploymentName)
+	}
+
+	sa := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: k.namespace,
+			Labels:    resourceTags,
+		},
+		AutomountServiceAccountToken: spec.AutomountServiceAccountToken,
+	}
+	serviceAccounts := k.CoreV1().ServiceAccounts(k.namespace)
+	_, err := serviceAccounts.Update(sa)
+	if k8serrors.IsNotFound(err) {
+		_, err = serviceAccounts.Create(sa)
+	}
+	if err != nil {
+		return errors.Annotate(err, "ensuring service account")
+	}
+
+	rules := make([]rbacv1.PolicyRule, len(spec.Rules))
+	for i, r := range spec.Rules {
+		rules[i] = rbacv1.PolicyRule{
+			APIGroups:     r.APIGroups,
+			Resources:     r.Resources,
+			ResourceNames: r.ResourceNames,
+			Verbs:         r.Verbs,
+		}
+	}
+	subjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      deploymentName,
+		Namespace: k.namespace,
+	}}
+
+	if !spec.Global {
+		if err := k.deleteClusterRole(deploymentName); err != nil {
+			return errors.Trace(err)
+		}
+		meta := v1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: k.namespace,
+			Labels:    resourceTags,
+		}
+		if err := k.ensureRole(&rbacv1.Role{ObjectMeta: meta, Rules: rules}); err != nil {
+			return errors.Annotate(err, "ensuring role")
+		}
+		err := k.ensureRoleBinding(&rbacv1.RoleBinding{
+			ObjectMeta: meta,
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     deploymentName,
+			},
+			Subjects: subjects,
+		})
+		return errors.Annotate(err, "ensuring role binding")
+	}
+
+	if err := k.deleteRole(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	// Cluster roles live outside the namespace so are
+	// qualified and labelled with the model.
+	name := qualifiedClusterRoleName(k.namespace, deploymentName)
+	labels := map[string]string{labelModel: k.namespace}
+	for key, value := range resourceTags {
+		labels[key] = value
+	}
+	meta := v1.ObjectMeta{
+		Name:   name,
+		Labels: labels,
+	}
+	if err := k.ensureClusterRole(&rbacv1.ClusterRole{ObjectMeta: meta, Rules: rules}); err != nil {
+		return errors.Annotate(err, "ensuring cluster role")
+	}
+	err = k.ensureClusterRoleBinding(&rbacv1.ClusterRoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+		Subjects: subjects,
+	})
+	return errors.Annotate(err, "ensuring cluster role binding")
+}
+
+func (k *kubernetesClient) ensureRole(spec *rbacv1.Role) error {
+	roles := k.RbacV1().Roles(k.namespace)
+	_, err := roles.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRoleBinding(spec *rbacv1.RoleBinding) error {
+	bindings := k.RbacV1().RoleBindings(k.namespace)
+	_, err := bindings.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = bindings.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureClusterRole(spec *rbacv1.ClusterRole) error {
+	roles := k.RbacV1().ClusterRoles()
+	_, err := roles.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureClusterRoleBinding(spec *rbacv1.ClusterRoleBinding) error {
+	bindings := k.RbacV1().ClusterRoleBindings()
+	_, err := bindings.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = bindings.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+// deleteRole deletes the namespaced role and role
+// binding for the workload service account.
+func (k *kubernetesClient) deleteRole(deploymentName string) error {
+	opts := &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}
+	err := k.RbacV1().RoleBindings(k.namespace).Delete(deploymentName, opts)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	err = k.RbacV1().Roles(k.namespace).Delete(deploymentName, opts)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// deleteClusterRole deletes the cluster role and cluster
+// role binding for the workload service account.
+func (k *kubernetesClient) deleteClusterRole(deploymentName string) error {
+	opts := &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}
+	name := qualifiedClusterRoleName(k.namespace, deploymentName)
+	err := k.RbacV1().ClusterRoleBindings().Delete(name, opts)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	err = k.RbacV1().ClusterRoles().Delete(name, opts)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// revokeServiceAccountRoles deletes the roles granted to the workload
+// service account, leaving the account itself.
+func (k *kubernetesClient) revokeServiceAccountRoles(deploymentName string) error {
+	if err := k.deleteRole(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteClusterRole(deploymentName))
+}
+
+// deleteServiceAccount deletes the workload service
+// account along with any roles granted to it.
+func (k *kubernetesClient) deleteServiceAccount(deploymentName string) error {
+	if err := k.revokeServiceAccountRoles(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	err := k.CoreV1().ServiceAccounts(k.namespace).Delete(deploymentName, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 // OperatorExists returns true if the operator for the specified
 // application exists.
 func (k *kubernetesClient) OperatorExists(appName string) (bool, error) {
@@ -806,6 +1080,23 @@ func (k *kubernetesClient) Service(appName string) (*caas.Service, error) {
 			Scope: network.ScopePublic,
 		})
 	}
+	// An exposed application is reached through the load
+	// balancer of its ingress resource.
+	ingress, err := k.ExtensionsV1beta1().Ingresses(k.namespace).Get(service.Name, v1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if err == nil {
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			hostname := lb.Hostname
+			if hostname == "" {
+				hostname = lb.IP
+			}
+			if hostname != "" {
+				result.Hostnames = append(result.Hostnames, hostname)
+			}
+		}
+	}
 	return &result, nil
 }
 
@@ -823,6 +1114,18 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteDeployment(deploymentName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deleteDaemonSet(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteJob(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteCronJob(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteServiceAccount(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
 	pods := k.CoreV1().Pods(k.namespace)
 	podsList, err := pods.List(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -926,6 +1229,25 @@ func (k *kubernetesClient) EnsureService(
 	if params.PodSpec.OmitServiceFrontend && len(params.Filesystems) == 0 {
 		return errors.Errorf("kubernetes service is required when using storage")
 	}
+	deploymentType := params.PodSpec.DeploymentType
+	if len(params.Filesystems) > 0 && deploymentType != "" && deploymentType != caas.DeploymentStateful {
+		return errors.NotSupportedf("storage for %q deployment", deploymentType)
+	}
+	// Trust may have been revoked since the pod spec was set, in which
+	// case the roles granted to the workload are revoked too, and any
+	// pods running with privileges over their node are stopped.
+	trusted := config.GetBool(caas.JujuTrustKey, false)
+	if err := params.PodSpec.ValidateTrust(trusted); err != nil {
+		if err := k.revokeServiceAccountRoles(deploymentName); err != nil {
+			return errors.Annotate(err, "revoking roles of untrusted application")
+		}
+		if params.PodSpec.ValidateWorkloadTrust(trusted) != nil {
+			if err := k.deleteAllPods(appName, deploymentName); err != nil {
+				return errors.Annotate(err, "stopping pods of untrusted application")
+			}
+		}
+		return errors.Trace(err)
+	}
 
 	var cleanups []func()
 	defer func() {
@@ -969,6 +1291,7 @@ func (k *kubernetesClient) EnsureService(
 		resourceTags[k] = v
 	}
 	resourceTags[labelApplication] = appName
+	secretNames := set.NewStrings()
 	for _, c := range params.PodSpec.Containers {
 		if c.ImageDetails.Password == "" {
 			continue
@@ -977,35 +1300,63 @@ func (k *kubernetesClient) EnsureService(
 		if err := k.ensureSecret(imageSecretName, appName, &c.ImageDetails, resourceTags); err != nil {
 			return errors.Annotatef(err, "creating secrets for container: %s", c.Name)
 		}
+		secretNames.Add(imageSecretName)
 		cleanups = append(cleanups, func() { k.deleteSecret(imageSecretName) })
 	}
-
-	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
-	// Defensively check to see if a stateful set is already used.
-	useStatefulSet := len(params.Filesystems) > 0
-	if !useStatefulSet {
-		statefulsets := k.AppsV1().StatefulSets(k.namespace)
-		_, err := statefulsets.Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
-		if err != nil && !k8serrors.IsNotFound(err) {
-			return errors.Trace(err)
-		}
-		useStatefulSet = err == nil
-		if useStatefulSet {
-			logger.Debugf("no updated filesystems but already using stateful set for %v", appName)
+	for _, s := range params.PodSpec.Secrets {
+		secretName := workloadSecretName(deploymentName, s.Name)
+		if err := k.ensureWorkloadSecret(secretName, &s, resourceTags); err != nil {
+			return errors.Annotatef(err, "creating secret %q", s.Name)
 		}
+		secretNames.Add(secretName)
+	}
+	if err := k.ensureServiceAccount(deploymentName, params.PodSpec.ServiceAccount, resourceTags); err != nil {
+		return errors.Annotatef(err, "configuring service account for %v", appName)
 	}
 
 	numPods := int32(numUnits)
-	if useStatefulSet {
-		if err := k.configureStatefulSet(appName, deploymentName, resourceTags, unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
-			return errors.Annotate(err, "creating or updating StatefulSet")
+	switch deploymentType {
+	case caas.DeploymentDaemon:
+		// A daemon set runs a pod on each node, regardless of the number of units.
+		if err := k.configureDaemonSet(appName, deploymentName, resourceTags, unitSpec, params.PodSpec.Containers); err != nil {
+			return errors.Annotate(err, "creating or updating DaemonSet")
+		}
+		cleanups = append(cleanups, func() { k.deleteDaemonSet(deploymentName) })
+	case caas.DeploymentJob:
+		if err := k.configureJob(appName, deploymentName, resourceTags, unitSpec, params.PodSpec.Containers, &numPods, params.PodSpec.Job); err != nil {
+			return errors.Annotate(err, "creating or updating Job")
+		}
+		cleanups = append(cleanups, func() {
+			k.deleteJob(deploymentName)
+			k.deleteCronJob(deploymentName)
+		})
+	default:
+		// Add a deployment controller or stateful set configured to create the specified number of units/pods.
+		// Defensively check to see if a stateful set is already used.
+		useStatefulSet := deploymentType == caas.DeploymentStateful || len(params.Filesystems) > 0
+		if !useStatefulSet {
+			statefulsets := k.AppsV1().StatefulSets(k.namespace)
+			_, err := statefulsets.Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+			useStatefulSet = err == nil
+			if useStatefulSet {
+				logger.Debugf("no updated filesystems but already using stateful set for %v", appName)
+			}
 		}
-		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
-	} else {
-		if err := k.configureDeployment(appName, deploymentName, resourceTags, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
-			return errors.Annotate(err, "creating or updating DeploymentController")
+
+		if useStatefulSet {
+			if err := k.configureStatefulSet(appName, deploymentName, resourceTags, unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
+				return errors.Annotate(err, "creating or updating StatefulSet")
+			}
+			cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
+		} else {
+			if err := k.configureDeployment(appName, deploymentName, resourceTags, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
+				return errors.Annotate(err, "creating or updating DeploymentController")
+			}
+			cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 		}
-		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	}
 
 	var ports []core.ContainerPort
@@ -1018,9 +1369,17 @@ func (k *kubernetesClient) EnsureService(
 		}
 	}
 	if !params.PodSpec.OmitServiceFrontend {
-		if err := k.configureService(appName, deploymentName, ports, resourceTags, config); err != nil {
+		if err := k.configureService(appName, deploymentName, ports, resourceTags, params.PodSpec.Ingress, config); err != nil {
 			return errors.Annotatef(err, "creating or updating service for %v", appName)
 		}
+		if err := k.updateIngress(appName, deploymentName, config); err != nil {
+			return errors.Annotatef(err, "updating ingress for %v", appName)
+		}
+	}
+	// The pods no longer refer to any secrets removed from
+	// the pod spec so they can now be deleted.
+	if err := k.pruneSecrets(appName, secretNames); err != nil {
+		return errors.Annotatef(err, "deleting unused secrets for %v", appName)
 	}
 	return nil
 }
@@ -1040,15 +1399,24 @@ func (k *kubernetesClient) deleteAllPods(appName, deploymentName string) error {
 
 	deployments := k.AppsV1().Deployments(k.namespace)
 	deployment, err := deployments.Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
-	if k8serrors.IsNotFound(err) {
-		return nil
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
 	}
-	if err != nil {
+	if err == nil {
+		deployment.Spec.Replicas = &zero
+		_, err = deployments.Update(deployment)
 		return errors.Trace(err)
 	}
-	deployment.Spec.Replicas = &zero
-	_, err = deployments.Update(deployment)
-	return errors.Trace(err)
+
+	// Daemon sets and jobs can't be scaled down
+	// to zero so they are deleted instead.
+	if err := k.deleteDaemonSet(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteJob(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteCronJob(deploymentName))
 }
 
 func (k *kubernetesClient) configureStorage(
@@ -1303,6 +1671,187 @@ func (k *kubernetesClient) deleteStatefulSet(name string) error {
 	return errors.Trace(err)
 }
 
+func (k *kubernetesClient) configureDaemonSet(
+	appName, deploymentName string, labels map[string]string, unitSpec *unitSpec, containers []caas.ContainerSpec,
+) error {
+	logger.Debugf("creating/updating daemon set for %s", appName)
+
+	// Add the specified file to the pod spec.
+	cfgName := func(fileSetName string) string {
+		return applicationConfigMapName(deploymentName, fileSetName)
+	}
+	podSpec := unitSpec.Pod
+	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+		return errors.Trace(err)
+	}
+
+	daemonSet := &apps.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName,
+			Labels: labels},
+		Spec: apps.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: deploymentName + "-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	return k.ensureDaemonSet(daemonSet)
+}
+
+func (k *kubernetesClient) ensureDaemonSet(spec *apps.DaemonSet) error {
+	daemonSets := k.AppsV1().DaemonSets(k.namespace)
+	_, err := daemonSets.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = daemonSets.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteDaemonSet(name string) error {
+	daemonSets := k.AppsV1().DaemonSets(k.namespace)
+	err := daemonSets.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// cronJobHistoryLimit is the number of finished runs of a cron job
+// whose pods are kept. Each run creates new pods, so new units; the
+// units of the last successful and the last failed run are kept so
+// their status can be seen, and those of older runs are removed when
+// their pods are deleted.
+var cronJobHistoryLimit = int32(1)
+
+// configureJob creates or updates a job which runs the specified number
+// of pods to completion, or a cron job if the job has a schedule.
+func (k *kubernetesClient) configureJob(
+	appName, deploymentName string, labels map[string]string, unitSpec *unitSpec,
+	containers []caas.ContainerSpec, parallelism *int32, job *caas.JobSpec,
+) error {
+	logger.Debugf("creating/updating job for %s", appName)
+
+	// Add the specified file to the pod spec.
+	cfgName := func(fileSetName string) string {
+		return applicationConfigMapName(deploymentName, fileSetName)
+	}
+	podSpec := unitSpec.Pod
+	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+		return errors.Trace(err)
+	}
+	// Job pods run to completion so must not be restarted once they succeed.
+	switch podSpec.RestartPolicy {
+	case "":
+		podSpec.RestartPolicy = core.RestartPolicyOnFailure
+	case core.RestartPolicyAlways:
+		return errors.NotValidf("restart policy %q for job", podSpec.RestartPolicy)
+	}
+	if job == nil {
+		job = &caas.JobSpec{}
+	}
+
+	jobSpec := batchv1.JobSpec{
+		Parallelism:           parallelism,
+		Completions:           parallelism,
+		BackoffLimit:          job.BackoffLimit,
+		ActiveDeadlineSeconds: job.ActiveDeadlineSeconds,
+		Template: core.PodTemplateSpec{
+			ObjectMeta: v1.ObjectMeta{
+				GenerateName: deploymentName + "-",
+				Labels:       labels,
+			},
+			Spec: podSpec,
+		},
+	}
+	if job.Schedule != "" {
+		cronJob := &batchv1beta1.CronJob{
+			ObjectMeta: v1.ObjectMeta{
+				Name:   deploymentName,
+				Labels: labels},
+			Spec: batchv1beta1.CronJobSpec{
+				Schedule:                   job.Schedule,
+				ConcurrencyPolicy:          batchv1beta1.ForbidConcurrent,
+				SuccessfulJobsHistoryLimit: &cronJobHistoryLimit,
+				FailedJobsHistoryLimit:     &cronJobHistoryLimit,
+				JobTemplate: batchv1beta1.JobTemplateSpec{
+					ObjectMeta: v1.ObjectMeta{
+						Labels: labels,
+					},
+					Spec: jobSpec,
+				},
+			},
+		}
+		return k.ensureCronJob(cronJob)
+	}
+	return k.ensureJob(&batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName,
+			Labels: labels},
+		Spec: jobSpec,
+	})
+}
+
+func (k *kubernetesClient) ensureJob(spec *batchv1.Job) error {
+	jobs := k.BatchV1().Jobs(k.namespace)
+	_, err := jobs.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = jobs.Create(spec)
+	}
+	if !k8serrors.IsInvalid(err) {
+		return errors.Trace(err)
+	}
+
+	// The job already exists and its pod template can't
+	// be changed, so all we can update is the parallelism.
+	existing, err := jobs.Get(spec.Name, v1.GetOptions{IncludeUninitialized: true})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	existing.Spec.Parallelism = spec.Spec.Parallelism
+	_, err = jobs.Update(existing)
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteJob(name string) error {
+	jobs := k.BatchV1().Jobs(k.namespace)
+	err := jobs.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureCronJob(spec *batchv1beta1.CronJob) error {
+	cronJobs := k.BatchV1beta1().CronJobs(k.namespace)
+	_, err := cronJobs.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = cronJobs.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteCronJob(name string) error {
+	cronJobs := k.BatchV1beta1().CronJobs(k.namespace)
+	err := cronJobs.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func (k *kubernetesClient) deleteVolumeClaims(appName string, p *core.Pod) ([]string, error) {
 	volumesByName := make(map[string]core.Volume)
 	for _, pv := range p.Spec.Volumes {
@@ -1335,7 +1884,7 @@ func (k *kubernetesClient) deleteVolumeClaims(appName string, p *core.Pod) ([]st
 
 func (k *kubernetesClient) configureService(
 	appName, deploymentName string, containerPorts []core.ContainerPort,
-	tags map[string]string, config application.ConfigAttributes,
+	tags map[string]string, ingress *caas.IngressSpec, config application.ConfigAttributes,
 ) error {
 	logger.Debugf("creating/updating service for %s", appName)
 
@@ -1362,6 +1911,16 @@ func (k *kubernetesClient) configureService(
 	if err != nil {
 		return errors.Annotatef(err, "unexpected annotations: %#v", config.Get(serviceAnnotationsKey, nil))
 	}
+	if ingress != nil {
+		ingressSpec, err := json.Marshal(ingress)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[annotationIngressSpec] = string(ingressSpec)
+	}
 	service := &core.Service{
 		ObjectMeta: v1.ObjectMeta{
 			Name:        deploymentName,
@@ -1426,6 +1985,15 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	if !strings.HasPrefix(httpPath, "/") {
 		httpPath = "/" + httpPath
 	}
+	configAnnotations, err := config.GetStringMap(ingressAnnotationsKey, nil)
+	if err != nil {
+		return errors.Annotatef(err, "unexpected annotations: %#v", config.Get(ingressAnnotationsKey, nil))
+	}
+	tls := &caas.IngressTLS{
+		SecretName:    config.GetString(ingressTLSSecretKey, ""),
+		Issuer:        config.GetString(ingressTLSIssuerKey, ""),
+		ClusterIssuer: config.GetString(ingressTLSClusterIssuerKey, ""),
+	}
 
 	deploymentName := k.deploymentName(appName)
 	svc, err := k.CoreV1().Services(k.namespace).Get(deploymentName, v1.GetOptions{})
@@ -1435,34 +2003,138 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	if len(svc.Spec.Ports) == 0 {
 		return errors.Errorf("cannot create ingress rule for service %q without a port", svc.Name)
 	}
+
+	// Any ingress settings from the pod spec override the application config.
+	var podIngress caas.IngressSpec
+	if data, ok := svc.Annotations[annotationIngressSpec]; ok {
+		if err := json.Unmarshal([]byte(data), &podIngress); err != nil {
+			return errors.Annotatef(err, "parsing ingress spec for %q", appName)
+		}
+	}
+	if podIngress.Class != "" {
+		ingressClass = podIngress.Class
+	}
+	if podIngress.TLS != nil {
+		tls = podIngress.TLS
+	}
+
+	annotations := map[string]string{
+		"ingress.kubernetes.io/rewrite-target":  "",
+		"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
+		"kubernetes.io/ingress.class":           ingressClass,
+		"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
+		"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
+	}
+	for key, value := range configAnnotations {
+		annotations[key] = value
+	}
+	for key, value := range podIngress.Annotations {
+		annotations[key] = value
+	}
+
+	defaultBackend := v1beta1.IngressBackend{
+		ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort}
+	paths := []v1beta1.HTTPIngressPath{{
+		Path:    httpPath,
+		Backend: defaultBackend,
+	}}
+	if len(podIngress.Paths) > 0 {
+		paths, err = ingressPaths(svc, podIngress.Paths, defaultBackend)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	spec := &v1beta1.Ingress{
 		ObjectMeta: v1.ObjectMeta{
-			Name:   deploymentName,
-			Labels: resourceTags,
-			Annotations: map[string]string{
-				"ingress.kubernetes.io/rewrite-target":  "",
-				"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
-				"kubernetes.io/ingress.class":           ingressClass,
-				"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
-				"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
-			},
+			Name:        deploymentName,
+			Labels:      resourceTags,
+			Annotations: annotations,
 		},
 		Spec: v1beta1.IngressSpec{
 			Rules: []v1beta1.IngressRule{{
 				Host: host,
 				IngressRuleValue: v1beta1.IngressRuleValue{
 					HTTP: &v1beta1.HTTPIngressRuleValue{
-						Paths: []v1beta1.HTTPIngressPath{{
-							Path: httpPath,
-							Backend: v1beta1.IngressBackend{
-								ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort},
-						}}},
-				}}},
+						Paths: paths,
+					}},
+			}},
 		},
 	}
+	if *tls != (caas.IngressTLS{}) {
+		if err := tls.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		configureIngressTLS(spec, deploymentName, host, tls)
+	}
 	return k.ensureIngress(spec)
 }
 
+// updateIngress reapplies the ingress settings of an exposed
+// application, so that changes to the ingress settings in the
+// pod spec take effect without the application being exposed
+// again.
+func (k *kubernetesClient) updateIngress(appName, deploymentName string, config application.ConfigAttributes) error {
+	ingress, err := k.ExtensionsV1beta1().Ingresses(k.namespace).Get(deploymentName, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return k.ExposeService(appName, ingress.Labels, config)
+}
+
+// ingressPaths returns the ingress paths routing requests to the
+// specified service ports, checking that each port is exposed
+// by the service.
+func ingressPaths(
+	svc *core.Service, paths []caas.IngressPath, defaultBackend v1beta1.IngressBackend,
+) ([]v1beta1.HTTPIngressPath, error) {
+	result := make([]v1beta1.HTTPIngressPath, len(paths))
+	for i, p := range paths {
+		backend := defaultBackend
+		if p.Port != 0 {
+			found := false
+			for _, port := range svc.Spec.Ports {
+				if int(port.Port) == p.Port {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, errors.NotFoundf("port %d for ingress path %q", p.Port, p.Path)
+			}
+			backend.ServicePort = intstr.FromInt(p.Port)
+		}
+		result[i] = v1beta1.HTTPIngressPath{
+			Path:    p.Path,
+			Backend: backend,
+		}
+	}
+	return result, nil
+}
+
+// configureIngressTLS sets up TLS termination for the ingress host.
+// If a cert-manager issuer is specified, the certificate is issued
+// into the named secret, or one named after the deployment.
+func configureIngressTLS(spec *v1beta1.Ingress, deploymentName, host string, tls *caas.IngressTLS) {
+	secretName := tls.SecretName
+	if secretName == "" {
+		secretName = deploymentName + "-tls"
+	}
+	if tls.Issuer != "" {
+		spec.Annotations[certManagerIssuerAnnotation] = tls.Issuer
+	}
+	if tls.ClusterIssuer != "" {
+		spec.Annotations[certManagerClusterIssuerAnnotation] = tls.ClusterIssuer
+	}
+	spec.Spec.TLS = []v1beta1.IngressTLS{{
+		Hosts:      []string{host},
+		SecretName: secretName,
+	}}
+}
+
 // UnexposeService removes external access to the specified service.
 func (k *kubernetesClient) UnexposeService(appName string) error {
 	logger.Debugf("deleting ingress resource for %s", appName)
@@ -1703,6 +2375,11 @@ func (k *kubernetesClient) Operator(appName string) (*caas.Operator, error) {
 func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (string, status.Status, time.Time, error) {
 	terminated := pod.DeletionTimestamp != nil
 	jujuStatus := k.jujuStatus(pod.Status.Phase, terminated)
+	if jujuStatus == status.Running && !podReady(pod) {
+		// The containers have started but aren't yet
+		// passing their readiness probes.
+		jujuStatus = status.Waiting
+	}
 	statusMessage := pod.Status.Message
 	since := now
 	if statusMessage == "" {
@@ -1736,6 +2413,18 @@ func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (string, st
 	return statusMessage, jujuStatus, since, nil
 }
 
+// podReady returns false if the pod reports that it's not ready
+// to serve requests, ie one of its containers is failing its
+// readiness probe.
+func podReady(pod core.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == core.PodReady {
+			return cond.Status == core.ConditionTrue
+		}
+	}
+	return true
+}
+
 func (k *kubernetesClient) jujuStatus(podPhase core.PodPhase, terminated bool) status.Status {
 	if terminated {
 		return status.Terminated
@@ -1743,6 +2432,9 @@ func (k *kubernetesClient) jujuStatus(podPhase core.PodPhase, terminated bool) s
 	switch podPhase {
 	case core.PodRunning:
 		return status.Running
+	case core.PodSucceeded:
+		// The pods of jobs run to completion and aren't restarted.
+		return status.Terminated
 	case core.PodFailed:
 		return status.Error
 	case core.PodPending:
@@ -1935,6 +2627,38 @@ func makeUnitSpec(appName, deploymentName string, podSpec *caas.PodSpec) (*unitS
 		if c.ImageDetails.Password != "" {
 			imageSecretNames = append(imageSecretNames, core.LocalObjectReference{Name: appSecretName(deploymentName, c.Name)})
 		}
+		for _, env := range c.SecretEnv {
+			unitSpec.Pod.Containers[i].Env = append(unitSpec.Pod.Containers[i].Env, core.EnvVar{
+				Name: env.Name,
+				ValueFrom: &core.EnvVarSource{
+					SecretKeyRef: &core.SecretKeySelector{
+						LocalObjectReference: core.LocalObjectReference{Name: workloadSecretName(deploymentName, env.SecretName)},
+						Key:                  env.Key,
+					},
+				},
+			})
+		}
+		for _, secretName := range c.EnvFromSecrets {
+			unitSpec.Pod.Containers[i].EnvFrom = append(unitSpec.Pod.Containers[i].EnvFrom, core.EnvFromSource{
+				SecretRef: &core.SecretEnvSource{
+					LocalObjectReference: core.LocalObjectReference{Name: workloadSecretName(deploymentName, secretName)},
+				},
+			})
+		}
+		if c.LivenessProbe != nil {
+			unitSpec.Pod.Containers[i].LivenessProbe = k8sProbe(c.LivenessProbe)
+		}
+		if c.ReadinessProbe != nil {
+			unitSpec.Pod.Containers[i].ReadinessProbe = k8sProbe(c.ReadinessProbe)
+		}
+		resources, err := k8sResourceRequirements(c.Resources)
+		if err != nil {
+			return nil, errors.Annotatef(err, "resources for %q", c.Name)
+		}
+		unitSpec.Pod.Containers[i].Resources = resources
+		if c.SecurityContext != nil {
+			unitSpec.Pod.Containers[i].SecurityContext = k8sSecurityContext(c.SecurityContext)
+		}
 
 		if c.ProviderContainer == nil {
 			continue
@@ -1944,12 +2668,6 @@ func makeUnitSpec(appName, deploymentName string, podSpec *caas.PodSpec) (*unitS
 			return nil, errors.Errorf("unexpected kubernetes container spec type %T", c.ProviderContainer)
 		}
 		unitSpec.Pod.Containers[i].ImagePullPolicy = spec.ImagePullPolicy
-		if spec.LivenessProbe != nil {
-			unitSpec.Pod.Containers[i].LivenessProbe = spec.LivenessProbe
-		}
-		if spec.ReadinessProbe != nil {
-			unitSpec.Pod.Containers[i].ReadinessProbe = spec.ReadinessProbe
-		}
 	}
 	unitSpec.Pod.ImagePullSecrets = imageSecretNames
 	if podSpec.ProviderPod != nil {
@@ -1971,6 +2689,12 @@ func makeUnitSpec(appName, deploymentName string, podSpec *caas.PodSpec) (*unitS
 		unitSpec.Pod.AutomountServiceAccountToken = spec.AutomountServiceAccountToken
 		unitSpec.Pod.ReadinessGates = spec.ReadinessGates
 	}
+	if sa := podSpec.ServiceAccount; sa != nil {
+		unitSpec.Pod.ServiceAccountName = deploymentName
+		if sa.AutomountServiceAccountToken != nil {
+			unitSpec.Pod.AutomountServiceAccountToken = sa.AutomountServiceAccountToken
+		}
+	}
 	return &unitSpec, nil
 }
 
@@ -2014,10 +2738,18 @@ func appSecretName(deploymentName, containerName string) string {
 	return deploymentName + "-" + containerName + "-secret"
 }
 
+func workloadSecretName(deploymentName, secretName string) string {
+	return deploymentName + "-" + secretName
+}
+
 func qualifiedStorageClassName(namespace, storageClass string) string {
 	return namespace + "-" + storageClass
 }
 
+func qualifiedClusterRoleName(namespace, deploymentName string) string {
+	return namespace + "-" + deploymentName
+}
+
 func mergeDeviceConstraints(device devices.KubernetesDeviceParams, resources *core.ResourceRequirements) error {
 	if resources.Limits == nil {
 		resources.Limits = core.ResourceList{}
@@ -2040,19 +2772,26 @@ func mergeDeviceConstraints(device devices.KubernetesDeviceParams, resources *co
 	return nil
 }
 
+// mergeConstraint sets the limit for the constrained resource. The
+// operator's constraint replaces any limit set by the charm, and any
+// request the charm made above the new limit is lowered to it.
 func mergeConstraint(constraint string, value string, resources *core.ResourceRequirements) error {
+	parsedValue, err := resource.ParseQuantity(value)
+	if err != nil {
+		return errors.Annotatef(err, "invalid constraint value %q for %v", value, constraint)
+	}
 	if resources.Limits == nil {
 		resources.Limits = core.ResourceList{}
 	}
 	resourceName := core.ResourceName(constraint)
 	if v, ok := resources.Limits[resourceName]; ok {
-		return errors.NotValidf("resource limit for %q has already been set to %v!", resourceName, v)
-	}
-	parsedValue, err := resource.ParseQuantity(value)
-	if err != nil {
-		return errors.Annotatef(err, "invalid constraint value %q for %v", value, constraint)
+		logger.Debugf("constraint %v overrides resource limit %v for %q", value, v.String(), resourceName)
 	}
 	resources.Limits[resourceName] = parsedValue
+	if request, ok := resources.Requests[resourceName]; ok && request.Cmp(parsedValue) > 0 {
+		logger.Debugf("lowering resource request %v for %q to constraint %v", request.String(), resourceName, value)
+		resources.Requests[resourceName] = parsedValue
+	}
 	return nil
 }
 
@@ -16,13 +16,20 @@ import (
 	"gopkg.in/juju/worker.v1/workertest"
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	core "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/watch"
 
@@ -33,6 +40,7 @@ import (
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 )
@@ -70,16 +78,24 @@ func (s *K8sSuite) TestMakeUnitSpecNoConfigConfig(c *gc.C) {
 			Name:  "test",
 			Ports: []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
 			Image: "juju/image",
+			ReadinessProbe: &caas.Probe{
+				InitialDelaySeconds: 10,
+				HTTPGet:             &caas.HTTPGetAction{Path: "/ready", Port: intstr.FromInt(80)},
+			},
+			LivenessProbe: &caas.Probe{
+				SuccessThreshold: 20,
+				TCPSocket:        &caas.TCPSocketAction{Port: intstr.FromInt(80)},
+			},
+			Resources: caas.ResourceRequirements{
+				Requests: map[string]string{"cpu": "250m", "memory": "64Mi"},
+				Limits:   map[string]string{"memory": "128Mi"},
+			},
+			SecurityContext: &caas.SecurityContext{
+				RunAsNonRoot: boolPtr(true),
+				Capabilities: &caas.Capabilities{Drop: []string{"ALL"}},
+			},
 			ProviderContainer: &provider.K8sContainerSpec{
 				ImagePullPolicy: core.PullAlways,
-				ReadinessProbe: &core.Probe{
-					InitialDelaySeconds: 10,
-					Handler:             core.Handler{HTTPGet: &core.HTTPGetAction{Path: "/ready"}},
-				},
-				LivenessProbe: &core.Probe{
-					SuccessThreshold: 20,
-					Handler:          core.Handler{HTTPGet: &core.HTTPGetAction{Path: "/liveready"}},
-				},
 			},
 		}, {
 			Name:  "test2",
@@ -117,11 +133,24 @@ func (s *K8sSuite) TestMakeUnitSpecNoConfigConfig(c *gc.C) {
 				ImagePullPolicy: core.PullAlways,
 				ReadinessProbe: &core.Probe{
 					InitialDelaySeconds: 10,
-					Handler:             core.Handler{HTTPGet: &core.HTTPGetAction{Path: "/ready"}},
+					Handler:             core.Handler{HTTPGet: &core.HTTPGetAction{Path: "/ready", Port: intstr.FromInt(80)}},
 				},
 				LivenessProbe: &core.Probe{
 					SuccessThreshold: 20,
-					Handler:          core.Handler{HTTPGet: &core.HTTPGetAction{Path: "/liveready"}},
+					Handler:          core.Handler{TCPSocket: &core.TCPSocketAction{Port: intstr.FromInt(80)}},
+				},
+				Resources: core.ResourceRequirements{
+					Requests: core.ResourceList{
+						core.ResourceCPU:    resource.MustParse("250m"),
+						core.ResourceMemory: resource.MustParse("64Mi"),
+					},
+					Limits: core.ResourceList{
+						core.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+				SecurityContext: &core.SecurityContext{
+					RunAsNonRoot: boolPtr(true),
+					Capabilities: &core.Capabilities{Drop: []core.Capability{"ALL"}},
 				},
 			}, {
 				Name:  "test2",
@@ -249,6 +278,51 @@ func (s *K8sSuite) TestMakeUnitSpecConfigPairs(c *gc.C) {
 	})
 }
 
+func (s *K8sSuite) TestMakeUnitSpecSecrets(c *gc.C) {
+	podSpec := caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:           "test",
+			Image:          "juju/image",
+			EnvFromSecrets: []string{"config"},
+			SecretEnv: []caas.SecretEnvVar{
+				{Name: "DB_PASSWORD", SecretName: "db", Key: "password"},
+			},
+		}},
+		Secrets: []caas.Secret{
+			{Name: "config", Data: map[string]string{"foo": "bar"}},
+			{Name: "db", Data: map[string]string{"password": "hunter2"}},
+		},
+		ServiceAccount: &caas.ServiceAccountSpec{
+			AutomountServiceAccountToken: boolPtr(false),
+			Rules:                        []caas.PolicyRule{{Verbs: []string{"get"}}},
+		},
+	}
+	spec, err := provider.MakeUnitSpec("app-name", "app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.PodSpec(spec), jc.DeepEquals, core.PodSpec{
+		ServiceAccountName:           "app-name",
+		AutomountServiceAccountToken: boolPtr(false),
+		Containers: []core.Container{{
+			Name:  "test",
+			Image: "juju/image",
+			Env: []core.EnvVar{{
+				Name: "DB_PASSWORD",
+				ValueFrom: &core.EnvVarSource{
+					SecretKeyRef: &core.SecretKeySelector{
+						LocalObjectReference: core.LocalObjectReference{Name: "app-name-db"},
+						Key:                  "password",
+					},
+				},
+			}},
+			EnvFrom: []core.EnvFromSource{{
+				SecretRef: &core.SecretEnvSource{
+					LocalObjectReference: core.LocalObjectReference{Name: "app-name-config"},
+				},
+			}},
+		}},
+	})
+}
+
 func (s *K8sSuite) TestOperatorPodConfig(c *gc.C) {
 	tags := map[string]string{
 		"juju-operator": "gitlab",
@@ -265,6 +339,34 @@ func (s *K8sSuite) TestOperatorPodConfig(c *gc.C) {
 	c.Assert(pod.Spec.Containers[0].VolumeMounts[0].MountPath, gc.Equals, "/var/lib/juju/agents/application-gitlab/template-agent.conf")
 }
 
+func (s *K8sSuite) TestMergeConstraint(c *gc.C) {
+	resources := core.ResourceRequirements{
+		Requests: core.ResourceList{
+			"cpu":    resource.MustParse("250m"),
+			"memory": resource.MustParse("256Mi"),
+		},
+		Limits: core.ResourceList{
+			"memory": resource.MustParse("512Mi"),
+		},
+	}
+	// The constraint replaces the charm's limit, and lowers the
+	// request so it doesn't exceed the limit.
+	err := provider.MergeConstraint("memory", "128Mi", &resources)
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.MergeConstraint("cpu", "500m", &resources)
+	c.Assert(err, jc.ErrorIsNil)
+
+	limits := resources.Limits
+	requests := resources.Requests
+	c.Check(limits.Memory().String(), gc.Equals, "128Mi")
+	c.Check(requests.Memory().String(), gc.Equals, "128Mi")
+	c.Check(limits.Cpu().String(), gc.Equals, "500m")
+	c.Check(requests.Cpu().String(), gc.Equals, "250m")
+
+	err = provider.MergeConstraint("cpu", "lots", &resources)
+	c.Check(err, gc.ErrorMatches, `invalid constraint value "lots" for cpu: .*`)
+}
+
 type K8sBrokerSuite struct {
 	BaseSuite
 }
@@ -478,6 +580,16 @@ func (s *K8sBrokerSuite) TestDestroy(c *gc.C) {
 			v1.ListOptions{LabelSelector: "juju-model==test"},
 		).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground),
+			v1.ListOptions{LabelSelector: "juju-model==test"},
+		).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground),
+			v1.ListOptions{LabelSelector: "juju-model==test"},
+		).Times(1).
+			Return(s.k8sNotFoundError()),
 		// still terminating.
 		s.mockNamespaces.EXPECT().Get("test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(ns, nil),
@@ -738,6 +850,22 @@ func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 			Return(s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockJobs.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockCronJobs.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).
 			Return(&core.PodList{Items: []core.Pod{}}, nil),
 		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
@@ -836,6 +964,16 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
@@ -848,6 +986,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(serviceArg).Times(1).
 			Return(nil, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
 	)
 
 	params := &caas.ServiceParams{
@@ -1090,6 +1232,16 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1104,6 +1256,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
 	)
 
 	params := &caas.ServiceParams{
@@ -1170,6 +1326,16 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
@@ -1182,6 +1348,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
 	)
 
 	params := &caas.ServiceParams{
@@ -1231,6 +1401,16 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1245,6 +1425,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
 	)
 
 	params := &caas.ServiceParams{
@@ -1300,6 +1484,16 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1314,6 +1508,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
 	)
 
 	params := &caas.ServiceParams{
@@ -1356,6 +1554,16 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithPlacement(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("test-juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().Get("juju-unit-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1370,6 +1578,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithPlacement(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
 			Return(nil, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
 	)
 
 	params := &caas.ServiceParams{
@@ -1393,6 +1605,937 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithPlacement(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+var secretsPodspec = &caas.PodSpec{
+	Containers: []caas.ContainerSpec{{
+		Name:           "test",
+		Ports:          []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+		Image:          "juju/image",
+		EnvFromSecrets: []string{"config"},
+	}},
+	Secrets: []caas.Secret{{
+		Name: "config",
+		Data: map[string]string{"password": "hunter2"},
+	}},
+	ServiceAccount: &caas.ServiceAccountSpec{
+		AutomountServiceAccountToken: boolPtr(true),
+		Rules: []caas.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list"},
+		}},
+	},
+}
+
+func (s *K8sBrokerSuite) assertEnsureServiceWithServiceAccount(c *gc.C, spec *caas.PodSpec, trusted bool, roleCalls ...*gomock.Call) {
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", spec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	numUnits := int32(2)
+	labels := map[string]string{"juju-application": "app-name"}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "app-name-",
+					Labels:       labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: labels},
+		Spec: core.ServiceSpec{
+			Selector: labels,
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+			},
+		},
+	}
+	secretArg := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "app-name-config",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Type: core.SecretTypeOpaque,
+		Data: map[string][]byte{"password": []byte("hunter2")},
+	}
+	serviceAccountArg := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		AutomountServiceAccountToken: boolPtr(true),
+	}
+
+	calls := []*gomock.Call{
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(secretArg).Times(1).
+			Return(nil, nil),
+		s.mockServiceAccounts.EXPECT().Update(serviceAccountArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Create(serviceAccountArg).Times(1).
+			Return(nil, nil),
+	}
+	calls = append(calls, roleCalls...)
+	calls = append(calls,
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		// The secret no longer in the pod spec is deleted.
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{Items: []core.Secret{
+				{ObjectMeta: v1.ObjectMeta{Name: "app-name-config"}},
+				{ObjectMeta: v1.ObjectMeta{Name: "app-name-old"}},
+			}}, nil),
+		s.mockSecrets.EXPECT().Delete("app-name-old", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+	)
+	gomock.InOrder(calls...)
+
+	params := &caas.ServiceParams{
+		PodSpec: spec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+		"trust":                   trusted,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithSecretsAndServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	meta := v1.ObjectMeta{
+		Name:      "app-name",
+		Namespace: "test",
+		Labels:    map[string]string{"juju-application": "app-name"},
+	}
+	roleArg := &rbacv1.Role{
+		ObjectMeta: meta,
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list"},
+		}},
+	}
+	roleBindingArg := &rbacv1.RoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "app-name",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      "app-name",
+			Namespace: "test",
+		}},
+	}
+	s.assertEnsureServiceWithServiceAccount(c, secretsPodspec, false,
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Update(roleArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Create(roleArg).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Update(roleBindingArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Create(roleBindingArg).Times(1).
+			Return(nil, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithGlobalServiceAccount(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := *secretsPodspec
+	serviceAccount := *spec.ServiceAccount
+	serviceAccount.Global = true
+	spec.ServiceAccount = &serviceAccount
+
+	meta := v1.ObjectMeta{
+		Name: "test-app-name",
+		Labels: map[string]string{
+			"juju-application": "app-name",
+			"juju-model":       "test",
+		},
+	}
+	clusterRoleArg := &rbacv1.ClusterRole{
+		ObjectMeta: meta,
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list"},
+		}},
+	}
+	clusterRoleBindingArg := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "test-app-name",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      "app-name",
+			Namespace: "test",
+		}},
+	}
+	s.assertEnsureServiceWithServiceAccount(c, &spec, true,
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Update(clusterRoleArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Create(clusterRoleArg).Times(1).
+			Return(nil, nil),
+		s.mockClusterRoleBindings.EXPECT().Update(clusterRoleBindingArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Create(clusterRoleBindingArg).Times(1).
+			Return(nil, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceGlobalServiceAccountRequiresTrust(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := *secretsPodspec
+	serviceAccount := *spec.ServiceAccount
+	serviceAccount.Global = true
+	spec.ServiceAccount = &serviceAccount
+
+	// Any roles granted while the application was trusted are revoked.
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &spec,
+	}
+	err := s.broker.EnsureService("app-name", func(appName string, settableStatus status.Status, info string, data map[string]interface{}) error {
+		return nil
+	}, params, 2, application.ConfigAttributes{
+		"trust": false,
+	})
+	c.Assert(err, gc.ErrorMatches, "global service account for application without trust not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServicePrivilegedContainerRequiresTrust(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := *basicPodspec
+	spec.Containers = []caas.ContainerSpec{basicPodspec.Containers[0]}
+	spec.Containers[0].SecurityContext = &caas.SecurityContext{Privileged: boolPtr(true)}
+
+	// The privileged pods started while the application was trusted
+	// are stopped.
+	two := int32(2)
+	dc := &apps.Deployment{ObjectMeta: v1.ObjectMeta{Name: "app-name"}, Spec: apps.DeploymentSpec{Replicas: &two}}
+	zero := int32(0)
+	emptyDc := *dc
+	emptyDc.Spec.Replicas = &zero
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(dc, nil),
+		s.mockDeployments.EXPECT().Update(&emptyDc).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &spec,
+	}
+	err := s.broker.EnsureService("app-name", func(appName string, settableStatus status.Status, info string, data map[string]interface{}) error {
+		return nil
+	}, params, 2, application.ConfigAttributes{
+		"trust": false,
+	})
+	c.Assert(err, gc.ErrorMatches, `security context for "test": privileged container for application without trust not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func workloadPodspec(deploymentType caas.DeploymentType, job *caas.JobSpec) *caas.PodSpec {
+	return &caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:  "test",
+			Ports: []caas.ContainerPort{{ContainerPort: 80, Protocol: "TCP"}},
+			Image: "juju/image",
+		}},
+		DeploymentType: deploymentType,
+		Job:            job,
+	}
+}
+
+func (s *K8sBrokerSuite) assertEnsureServiceWorkload(c *gc.C, spec *caas.PodSpec, workloadCalls ...*gomock.Call) {
+	labels := map[string]string{"juju-application": "app-name"}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: labels},
+		Spec: core.ServiceSpec{
+			Selector: labels,
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+			},
+		},
+	}
+
+	calls := []*gomock.Call{
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	}
+	calls = append(calls, workloadCalls...)
+	calls = append(calls,
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+	)
+	gomock.InOrder(calls...)
+
+	params := &caas.ServiceParams{
+		PodSpec: spec,
+	}
+	err := s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceDaemonSet(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := workloadPodspec(caas.DeploymentDaemon, nil)
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	labels := map[string]string{"juju-application": "app-name"}
+	daemonSetArg := &appsv1.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: labels},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "app-name-",
+					Labels:       labels,
+				},
+				Spec: provider.PodSpec(unitSpec),
+			},
+		},
+	}
+	s.assertEnsureServiceWorkload(c, spec,
+		s.mockDaemonSets.EXPECT().Update(daemonSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Create(daemonSetArg).Times(1).
+			Return(nil, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceUpdatesExposedIngress(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := workloadPodspec(caas.DeploymentDaemon, nil)
+	spec.Ingress = &caas.IngressSpec{Class: "traefik"}
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	labels := map[string]string{"juju-application": "app-name"}
+	daemonSetArg := &appsv1.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: labels},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "app-name-",
+					Labels:       labels,
+				},
+				Spec: provider.PodSpec(unitSpec),
+			},
+		},
+	}
+	annotations := map[string]string{"juju.io/ingress-spec": `{"class":"traefik"}`}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: core.ServiceSpec{
+			Selector: labels,
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+			},
+		},
+	}
+	existingIngress := &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: labels,
+		},
+	}
+	ingress := ingressArg(map[string]string{
+		"kubernetes.io/ingress.class": "traefik",
+	}, []extensionsv1beta1.HTTPIngressPath{{
+		Path: "/",
+		Backend: extensionsv1beta1.IngressBackend{
+			ServiceName: "app-name", ServicePort: intstr.FromInt(80)},
+	}})
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Update(daemonSetArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(existingIngress, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(exposedService(annotations), nil),
+		s.mockIngressInterface.EXPECT().Update(ingress).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.SecretList{}, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: spec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+		"juju-external-hostname":  "example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) jobSpecArg(c *gc.C, spec *caas.PodSpec) batchv1.JobSpec {
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", spec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+	podSpec.RestartPolicy = core.RestartPolicyOnFailure
+
+	numUnits := int32(2)
+	return batchv1.JobSpec{
+		Parallelism:  &numUnits,
+		Completions:  &numUnits,
+		BackoffLimit: spec.Job.BackoffLimit,
+		Template: core.PodTemplateSpec{
+			ObjectMeta: v1.ObjectMeta{
+				GenerateName: "app-name-",
+				Labels:       map[string]string{"juju-application": "app-name"},
+			},
+			Spec: podSpec,
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceJob(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := workloadPodspec(caas.DeploymentJob, &caas.JobSpec{BackoffLimit: int32Ptr(3)})
+	jobArg := &batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-application": "app-name"}},
+		Spec: s.jobSpecArg(c, spec),
+	}
+	s.assertEnsureServiceWorkload(c, spec,
+		s.mockJobs.EXPECT().Update(jobArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockJobs.EXPECT().Create(jobArg).Times(1).
+			Return(nil, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceJobUpdatesParallelism(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := workloadPodspec(caas.DeploymentJob, &caas.JobSpec{})
+	jobArg := &batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-application": "app-name"}},
+		Spec: s.jobSpecArg(c, spec),
+	}
+	one := int32(1)
+	existing := &batchv1.Job{
+		ObjectMeta: jobArg.ObjectMeta,
+		Spec:       batchv1.JobSpec{Parallelism: &one},
+	}
+	updated := *existing
+	updated.Spec.Parallelism = jobArg.Spec.Parallelism
+	s.assertEnsureServiceWorkload(c, spec,
+		s.mockJobs.EXPECT().Update(jobArg).Times(1).
+			Return(nil, k8serrors.NewInvalid(schema.GroupKind{}, "app-name", nil)),
+		s.mockJobs.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(existing, nil),
+		s.mockJobs.EXPECT().Update(&updated).Times(1).
+			Return(nil, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceCronJob(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := workloadPodspec(caas.DeploymentJob, &caas.JobSpec{Schedule: "*/5 * * * *"})
+	labels := map[string]string{"juju-application": "app-name"}
+	cronJobArg := &batchv1beta1.CronJob{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: labels},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:                   "*/5 * * * *",
+			ConcurrencyPolicy:          batchv1beta1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: int32Ptr(1),
+			FailedJobsHistoryLimit:     int32Ptr(1),
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: s.jobSpecArg(c, spec),
+			},
+		},
+	}
+	s.assertEnsureServiceWorkload(c, spec,
+		s.mockCronJobs.EXPECT().Update(cronJobArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCronJobs.EXPECT().Create(cronJobArg).Times(1).
+			Return(nil, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceJobInvalidRestartPolicy(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	spec := workloadPodspec(caas.DeploymentJob, nil)
+	spec.ProviderPod = &provider.K8sPodSpec{RestartPolicy: core.RestartPolicyAlways}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoleBindings.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockClusterRoles.EXPECT().Delete("test-app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: spec,
+	}
+	err := s.broker.EnsureService("app-name", func(appName string, settableStatus status.Status, info string, data map[string]interface{}) error {
+		return nil
+	}, params, 2, nil)
+	c.Assert(err, gc.ErrorMatches, `creating or updating Job: restart policy "Always" for job not valid`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceDaemonSetWithStorage(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(nil, s.k8sNotFoundError())
+
+	params := &caas.ServiceParams{
+		PodSpec: workloadPodspec(caas.DeploymentDaemon, nil),
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+		}},
+	}
+	err := s.broker.EnsureService("app-name", func(appName string, settableStatus status.Status, info string, data map[string]interface{}) error {
+		return nil
+	}, params, 2, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNoUnitsDaemonSet(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDaemonSets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockJobs.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockCronJobs.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{}
+	err := s.broker.EnsureService("app-name", nil, params, 0, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) assertExposeService(
+	c *gc.C, svc *core.Service, config application.ConfigAttributes, ingressArg *extensionsv1beta1.Ingress,
+) {
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(svc, nil),
+		s.mockIngressInterface.EXPECT().Update(ingressArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Create(ingressArg).Times(1).
+			Return(nil, nil),
+	)
+	err := s.broker.ExposeService("app-name", map[string]string{"juju-application": "app-name"}, config)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func exposedService(annotations map[string]string) *core.Service {
+	return &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Annotations: annotations,
+		},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, TargetPort: intstr.FromInt(8080), Protocol: "TCP"},
+			},
+		},
+	}
+}
+
+func ingressArg(annotations map[string]string, paths []extensionsv1beta1.HTTPIngressPath) *extensionsv1beta1.Ingress {
+	allAnnotations := map[string]string{
+		"ingress.kubernetes.io/rewrite-target":  "",
+		"ingress.kubernetes.io/ssl-redirect":    "false",
+		"kubernetes.io/ingress.class":           "nginx",
+		"kubernetes.io/ingress.allow-http":      "false",
+		"ingress.kubernetes.io/ssl-passthrough": "false",
+	}
+	for k, v := range annotations {
+		allAnnotations[k] = v
+	}
+	return &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-application": "app-name"},
+			Annotations: allAnnotations,
+		},
+		Spec: extensionsv1beta1.IngressSpec{
+			Rules: []extensionsv1beta1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+					HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+						Paths: paths,
+					},
+				},
+			}},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestExposeService(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	ingress := ingressArg(nil, []extensionsv1beta1.HTTPIngressPath{{
+		Path: "/",
+		Backend: extensionsv1beta1.IngressBackend{
+			ServiceName: "app-name", ServicePort: intstr.FromInt(80)},
+	}})
+	s.assertExposeService(c, exposedService(nil), application.ConfigAttributes{
+		"juju-external-hostname": "example.com",
+	}, ingress)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceWithConfigTLS(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	ingress := ingressArg(map[string]string{
+		"nginx.ingress.kubernetes.io/proxy-body-size": "8m",
+	}, []extensionsv1beta1.HTTPIngressPath{{
+		Path: "/",
+		Backend: extensionsv1beta1.IngressBackend{
+			ServiceName: "app-name", ServicePort: intstr.FromInt(80)},
+	}})
+	ingress.Spec.TLS = []extensionsv1beta1.IngressTLS{{
+		Hosts:      []string{"example.com"},
+		SecretName: "example-cert",
+	}}
+	s.assertExposeService(c, exposedService(nil), application.ConfigAttributes{
+		"juju-external-hostname":         "example.com",
+		"kubernetes-ingress-tls-secret":  "example-cert",
+		"kubernetes-ingress-annotations": map[string]interface{}{"nginx.ingress.kubernetes.io/proxy-body-size": "8m"},
+	}, ingress)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceWithPodSpecIngress(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	svc := exposedService(map[string]string{
+		"juju.io/ingress-spec": `{"class":"traefik","annotations":{"a":"b"},"tls":{"clusterIssuer":"letsencrypt"},"paths":[{"path":"/"},{"path":"/api","port":8080}]}`,
+	})
+	ingress := ingressArg(map[string]string{
+		"kubernetes.io/ingress.class":       "traefik",
+		"a":                                 "b",
+		"certmanager.k8s.io/cluster-issuer": "letsencrypt",
+	}, []extensionsv1beta1.HTTPIngressPath{{
+		Path: "/",
+		Backend: extensionsv1beta1.IngressBackend{
+			ServiceName: "app-name", ServicePort: intstr.FromInt(80)},
+	}, {
+		Path: "/api",
+		Backend: extensionsv1beta1.IngressBackend{
+			ServiceName: "app-name", ServicePort: intstr.FromInt(8080)},
+	}})
+	ingress.Spec.TLS = []extensionsv1beta1.IngressTLS{{
+		Hosts:      []string{"example.com"},
+		SecretName: "app-name-tls",
+	}}
+	s.assertExposeService(c, svc, application.ConfigAttributes{
+		"juju-external-hostname":        "example.com",
+		"kubernetes-ingress-class":      "nginx",
+		"kubernetes-ingress-tls-secret": "example-cert",
+	}, ingress)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceUnknownPathPort(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	svc := exposedService(map[string]string{
+		"juju.io/ingress-spec": `{"paths":[{"path":"/api","port":9090}]}`,
+	})
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(svc, nil),
+	)
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname": "example.com",
+	})
+	c.Assert(err, gc.ErrorMatches, `port 9090 for ingress path "/api" not found`)
+}
+
+func (s *K8sBrokerSuite) TestServiceIngressHostnames(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	svc := core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "app-name",
+			UID:  "uid-xxxxx",
+		},
+		Spec: core.ServiceSpec{
+			ClusterIP: "10.0.0.1",
+		},
+	}
+	ingress := &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Status: extensionsv1beta1.IngressStatus{
+			LoadBalancer: core.LoadBalancerStatus{
+				Ingress: []core.LoadBalancerIngress{
+					{Hostname: "lb.example.com"},
+					{IP: "10.0.0.2"},
+				},
+			},
+		},
+	}
+	gomock.InOrder(
+		s.mockServices.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+			Return(&core.ServiceList{Items: []core.Service{svc}}, nil),
+		s.mockIngressInterface.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(ingress, nil),
+	)
+
+	result, err := s.broker.Service("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, &caas.Service{
+		Id: "uid-xxxxx",
+		Addresses: []network.Address{{
+			Value: "10.0.0.1",
+			Type:  network.IPv4Address,
+			Scope: network.ScopeCloudLocal,
+		}},
+		Hostnames: []string{"lb.example.com", "10.0.0.2"},
+	})
+}
+
+func (s *K8sBrokerSuite) TestUnitsReadiness(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := func(name string, ready core.ConditionStatus, message string) core.Pod {
+		return core.Pod{
+			ObjectMeta: v1.ObjectMeta{Name: name, UID: "uid-" + types.UID(name)},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{Name: "test"}},
+			},
+			Status: core.PodStatus{
+				Phase: core.PodRunning,
+				Conditions: []core.PodCondition{{
+					Type:    core.PodReady,
+					Status:  ready,
+					Message: message,
+				}},
+			},
+		}
+	}
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{
+			pod("ready", core.ConditionTrue, "all good"),
+			pod("not-ready", core.ConditionFalse, "containers with unready status: [test]"),
+		}}, nil)
+
+	units, err := s.broker.Units("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	c.Check(units[0].Id, gc.Equals, "uid-ready")
+	c.Check(units[0].Status.Status, gc.Equals, status.Running)
+	c.Check(units[1].Id, gc.Equals, "uid-not-ready")
+	c.Check(units[1].Status.Status, gc.Equals, status.Waiting)
+	c.Check(units[1].Status.Message, gc.Equals, "containers with unready status: [test]")
+}
+
+func (s *K8sBrokerSuite) TestUnitsFinishedPods(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	pod := func(name string, phase core.PodPhase) core.Pod {
+		return core.Pod{
+			ObjectMeta: v1.ObjectMeta{Name: name, UID: "uid-" + types.UID(name)},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{Name: "test"}},
+			},
+			Status: core.PodStatus{
+				Phase:   phase,
+				Message: "finished",
+			},
+		}
+	}
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==app-name"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{
+			pod("succeeded", core.PodSucceeded),
+			pod("failed", core.PodFailed),
+		}}, nil)
+
+	units, err := s.broker.Units("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	c.Check(units[0].Status.Status, gc.Equals, status.Terminated)
+	c.Check(units[1].Status.Status, gc.Equals, status.Error)
+}
+
 func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/yaml.v2"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	"github.com/juju/juju/caas"
@@ -30,8 +31,6 @@ type k8sContainers struct {
 // K8sContainerSpec is a subset of v1.Container which defines
 // attributes we expose for charms to set.
 type K8sContainerSpec struct {
-	LivenessProbe   *core.Probe     `json:"livenessProbe,omitempty"`
-	ReadinessProbe  *core.Probe     `json:"readinessProbe,omitempty"`
 	ImagePullPolicy core.PullPolicy `json:"imagePullPolicy,omitempty"`
 }
 
@@ -70,6 +69,21 @@ func (*K8sPodSpec) Validate() error {
 	return nil
 }
 
+// ValidateTrust is defined on ProviderPod. Running the pod as the root
+// user, or as an existing service account which may have been granted
+// roles the application hasn't, requires trust. Pod specs can't share
+// the node's network, process or IPC namespaces, so those need no
+// check.
+func (p *K8sPodSpec) ValidateTrust() error {
+	if sc := p.SecurityContext; sc != nil && sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		return errors.NotValidf("running pod as root user for application without trust")
+	}
+	if p.ServiceAccountName != "" {
+		return errors.NotValidf("service account name %q for application without trust", p.ServiceAccountName)
+	}
+	return nil
+}
+
 var boolValues = set.NewStrings(
 	strings.Split("y|Y|yes|Yes|YES|n|N|no|No|NO|true|True|TRUE|false|False|FALSE|on|On|ON|off|Off|OFF", "|")...)
 
@@ -90,6 +104,9 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 		return nil, errors.Trace(err)
 	}
 	if pod.K8sPodSpec != nil {
+		if pod.ServiceAccountName != "" && spec.ServiceAccount != nil {
+			return nil, errors.NotValidf("specifying both serviceAccountName and serviceAccount")
+		}
 		spec.ProviderPod = pod.K8sPodSpec
 	}
 
@@ -133,6 +150,17 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 			WorkingDir:   c.WorkingDir,
 			Config:       c.Config,
 			Files:        c.Files,
+
+			EnvFromSecrets: c.EnvFromSecrets,
+			SecretEnv:      c.SecretEnv,
+
+			LivenessProbe:   c.LivenessProbe,
+			ReadinessProbe:  c.ReadinessProbe,
+			Resources:       c.Resources,
+			SecurityContext: c.SecurityContext,
+		}
+		if _, err := k8sResourceRequirements(c.Resources); err != nil {
+			return nil, errors.Annotatef(err, "resources for %q", c.Name)
 		}
 		if c.K8sContainerSpec != nil {
 			spec.Containers[i].ProviderContainer = c.K8sContainerSpec
@@ -140,3 +168,92 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 	}
 	return &spec, nil
 }
+
+// k8sProbe returns the kubernetes probe for the specified container probe.
+func k8sProbe(probe *caas.Probe) *core.Probe {
+	result := &core.Probe{
+		InitialDelaySeconds: probe.InitialDelaySeconds,
+		TimeoutSeconds:      probe.TimeoutSeconds,
+		PeriodSeconds:       probe.PeriodSeconds,
+		SuccessThreshold:    probe.SuccessThreshold,
+		FailureThreshold:    probe.FailureThreshold,
+	}
+	if h := probe.HTTPGet; h != nil {
+		result.HTTPGet = &core.HTTPGetAction{
+			Path:   h.Path,
+			Port:   h.Port,
+			Host:   h.Host,
+			Scheme: core.URIScheme(h.Scheme),
+		}
+	}
+	if t := probe.TCPSocket; t != nil {
+		result.TCPSocket = &core.TCPSocketAction{
+			Port: t.Port,
+			Host: t.Host,
+		}
+	}
+	if e := probe.Exec; e != nil {
+		result.Exec = &core.ExecAction{
+			Command: e.Command,
+		}
+	}
+	return result
+}
+
+// k8sResourceRequirements returns the kubernetes resource requirements
+// for the specified container resources, checking that each request
+// does not exceed any corresponding limit.
+func k8sResourceRequirements(in caas.ResourceRequirements) (core.ResourceRequirements, error) {
+	var result core.ResourceRequirements
+	parse := func(resources map[string]string) (core.ResourceList, error) {
+		if len(resources) == 0 {
+			return nil, nil
+		}
+		list := make(core.ResourceList, len(resources))
+		for name, value := range resources {
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return nil, errors.NotValidf("quantity %q for resource %q", value, name)
+			}
+			list[core.ResourceName(name)] = quantity
+		}
+		return list, nil
+	}
+	var err error
+	if result.Requests, err = parse(in.Requests); err != nil {
+		return result, errors.Trace(err)
+	}
+	if result.Limits, err = parse(in.Limits); err != nil {
+		return result, errors.Trace(err)
+	}
+	for name, request := range result.Requests {
+		limit, ok := result.Limits[name]
+		if ok && request.Cmp(limit) > 0 {
+			return result, errors.NotValidf("request %v for resource %q exceeding limit %v", request.String(), name, limit.String())
+		}
+	}
+	return result, nil
+}
+
+// k8sSecurityContext returns the kubernetes security context
+// for the specified container security context.
+func k8sSecurityContext(sc *caas.SecurityContext) *core.SecurityContext {
+	result := &core.SecurityContext{
+		RunAsUser:                sc.RunAsUser,
+		RunAsGroup:               sc.RunAsGroup,
+		RunAsNonRoot:             sc.RunAsNonRoot,
+		Privileged:               sc.Privileged,
+		AllowPrivilegeEscalation: sc.AllowPrivilegeEscalation,
+		ReadOnlyRootFilesystem:   sc.ReadOnlyRootFilesystem,
+	}
+	if c := sc.Capabilities; c != nil {
+		result.Capabilities = &core.Capabilities{}
+		for _, add := range c.Add {
+			result.Capabilities.Add = append(result.Capabilities.Add, core.Capability(add))
+		}
+		for _, drop := range c.Drop {
+			result.Capabilities.Drop = append(result.Capabilities.Drop, core.Capability(drop))
+		}
+	}
+	return result
+}
@@ -169,27 +169,23 @@ foo: bar
 					},
 				},
 			},
-			ProviderContainer: &provider.K8sContainerSpec{
-				ImagePullPolicy: "Always",
-				LivenessProbe: &core.Probe{
-					InitialDelaySeconds: 10,
-					Handler: core.Handler{
-						HTTPGet: &core.HTTPGetAction{
-							Path: "/ping",
-							Port: intstr.IntOrString{IntVal: 8080},
-						},
-					},
+			LivenessProbe: &caas.Probe{
+				InitialDelaySeconds: 10,
+				HTTPGet: &caas.HTTPGetAction{
+					Path: "/ping",
+					Port: intstr.IntOrString{IntVal: 8080},
 				},
-				ReadinessProbe: &core.Probe{
-					InitialDelaySeconds: 10,
-					Handler: core.Handler{
-						HTTPGet: &core.HTTPGetAction{
-							Path: "/pingReady",
-							Port: intstr.IntOrString{StrVal: "www", Type: 1},
-						},
-					},
+			},
+			ReadinessProbe: &caas.Probe{
+				InitialDelaySeconds: 10,
+				HTTPGet: &caas.HTTPGetAction{
+					Path: "/pingReady",
+					Port: intstr.IntOrString{StrVal: "www", Type: 1},
 				},
 			},
+			ProviderContainer: &provider.K8sContainerSpec{
+				ImagePullPolicy: "Always",
+			},
 		}, {
 			Name:  "gitlab-helper",
 			Image: "gitlab-helper/latest",
@@ -342,3 +338,542 @@ containers:
 	err = spec.Validate()
 	c.Assert(err, gc.ErrorMatches, `mount path is missing for file set "configuration"`)
 }
+
+func (s *ContainersSuite) TestParseSecretsAndServiceAccount(c *gc.C) {
+
+	specStr := `
+secrets:
+  - name: config
+    data:
+      password: hunter2
+  - name: tls
+    type: kubernetes.io/tls
+    data:
+      tls.crt: cert
+      tls.key: key
+serviceAccount:
+  automountServiceAccountToken: true
+  global: true
+  rules:
+    - apiGroups: [""]
+      resources: ["pods"]
+      verbs: ["get", "list"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFromSecrets: [config]
+    secretEnv:
+      - name: TLS_KEY
+        secretName: tls
+        key: tls.key
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, &caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:           "gitlab",
+			Image:          "gitlab/latest",
+			EnvFromSecrets: []string{"config"},
+			SecretEnv: []caas.SecretEnvVar{
+				{Name: "TLS_KEY", SecretName: "tls", Key: "tls.key"},
+			},
+		}},
+		Secrets: []caas.Secret{{
+			Name: "config",
+			Data: map[string]string{"password": "hunter2"},
+		}, {
+			Name: "tls",
+			Type: "kubernetes.io/tls",
+			Data: map[string]string{"tls.crt": "cert", "tls.key": "key"},
+		}},
+		ServiceAccount: &caas.ServiceAccountSpec{
+			AutomountServiceAccountToken: boolPtr(true),
+			Global:                       true,
+			Rules: []caas.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "list"},
+			}},
+		},
+	})
+}
+
+func (s *ContainersSuite) TestParseServiceAccountConflict(c *gc.C) {
+
+	specStr := `
+serviceAccountName: existing
+serviceAccount:
+  rules:
+    - verbs: ["get"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	_, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, "specifying both serviceAccountName and serviceAccount not valid")
+}
+
+func (s *ContainersSuite) TestValidateSecrets(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: `
+secrets:
+  - data:
+      foo: bar
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: "secret name is missing",
+	}, {
+		spec: `
+secrets:
+  - name: config
+  - name: config
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: `duplicate secret "config" not valid`,
+	}, {
+		spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFromSecrets: [config]
+`,
+		err: `secret "config" for container "gitlab" not found`,
+	}, {
+		spec: `
+secrets:
+  - name: config
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    secretEnv:
+      - name: PASSWORD
+        secretName: config
+`,
+		err: `secret name and key are required for environment variable "PASSWORD"`,
+	}, {
+		spec: `
+serviceAccount:
+  rules:
+    - resources: ["pods"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: "service account rule with no verbs not valid",
+	}, {
+		spec: `
+serviceAccount:
+  rules:
+    - resources: ["pods", "nodes"]
+      verbs: ["get"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: `rule for cluster-scoped resource "nodes" in service account that is not global not valid`,
+	}} {
+		c.Logf("test %d", i)
+		spec, err := provider.ParseK8sPodSpec(test.spec[1:])
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(spec.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ContainersSuite) TestParseResourcesAndSecurityContext(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    livenessProbe:
+      exec:
+        command: ["check"]
+      failureThreshold: 3
+    resources:
+      requests:
+        cpu: 250m
+      limits:
+        cpu: "1"
+        memory: 1Gi
+    securityContext:
+      runAsUser: 1000
+      readOnlyRootFilesystem: true
+      capabilities:
+        add: [NET_ADMIN]
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	c.Assert(spec.Containers, jc.DeepEquals, []caas.ContainerSpec{{
+		Name:  "gitlab",
+		Image: "gitlab/latest",
+		LivenessProbe: &caas.Probe{
+			Exec:             &caas.ExecAction{Command: []string{"check"}},
+			FailureThreshold: 3,
+		},
+		Resources: caas.ResourceRequirements{
+			Requests: map[string]string{"cpu": "250m"},
+			Limits:   map[string]string{"cpu": "1", "memory": "1Gi"},
+		},
+		SecurityContext: &caas.SecurityContext{
+			RunAsUser:              int64Ptr(1000),
+			ReadOnlyRootFilesystem: boolPtr(true),
+			Capabilities:           &caas.Capabilities{Add: []string{"NET_ADMIN"}},
+		},
+	}})
+}
+
+func (s *ContainersSuite) TestParseInvalidResources(c *gc.C) {
+	for i, test := range []struct {
+		resources string
+		err       string
+	}{{
+		resources: `
+      requests:
+        memory: lots
+`,
+		err: `resources for "gitlab": quantity "lots" for resource "memory" not valid`,
+	}, {
+		resources: `
+      requests:
+        cpu: "2"
+      limits:
+        cpu: 500m
+`,
+		err: `resources for "gitlab": request 2 for resource "cpu" exceeding limit 500m not valid`,
+	}} {
+		c.Logf("test %d", i)
+		specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    resources:`[1:] + test.resources
+		_, err := provider.ParseK8sPodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ContainersSuite) TestValidateProbesAndSecurityContext(c *gc.C) {
+	for i, test := range []struct {
+		container string
+		err       string
+	}{{
+		container: `
+    readinessProbe:
+      initialDelaySeconds: 10
+`,
+		err: `readiness probe for "gitlab": probe without exactly one of httpGet, tcpSocket or exec not valid`,
+	}, {
+		container: `
+    livenessProbe:
+      httpGet:
+        path: /ping
+        port: 8080
+      tcpSocket:
+        port: 8080
+`,
+		err: `liveness probe for "gitlab": probe without exactly one of httpGet, tcpSocket or exec not valid`,
+	}, {
+		container: `
+    livenessProbe:
+      httpGet:
+        path: /ping
+`,
+		err: `liveness probe for "gitlab": http probe with no port not valid`,
+	}, {
+		container: `
+    livenessProbe:
+      httpGet:
+        port: 8080
+        scheme: FTP
+`,
+		err: `liveness probe for "gitlab": http probe scheme "FTP" not valid`,
+	}, {
+		container: `
+    readinessProbe:
+      tcpSocket:
+        port: www
+      periodSeconds: -1
+`,
+		err: `readiness probe for "gitlab": negative probe timing not valid`,
+	}, {
+		container: `
+    securityContext:
+      runAsNonRoot: true
+      runAsUser: 0
+`,
+		err: `security context for "gitlab": non root container running as root user not valid`,
+	}, {
+		container: `
+    securityContext:
+      privileged: true
+      allowPrivilegeEscalation: false
+`,
+		err: `security context for "gitlab": privileged container without privilege escalation not valid`,
+	}} {
+		c.Logf("test %d", i)
+		specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest`[1:] + test.container
+		spec, err := provider.ParseK8sPodSpec(specStr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(spec.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ContainersSuite) TestValidateTrust(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: `
+serviceAccount:
+  global: true
+  rules:
+    - resources: ["pods"]
+      verbs: ["get"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: "global service account for application without trust not valid",
+	}, {
+		spec: `
+serviceAccount:
+  rules:
+    - resources: ["pods", "secrets"]
+      verbs: ["get"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: `service account rule for "secrets" resources for application without trust not valid`,
+	}, {
+		spec: `
+serviceAccount:
+  rules:
+    - resources: ["*"]
+      verbs: ["get"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: `service account rule for "\*" resources for application without trust not valid`,
+	}, {
+		spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    securityContext:
+      privileged: true
+`,
+		err: `security context for "gitlab": privileged container for application without trust not valid`,
+	}, {
+		spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    securityContext:
+      allowPrivilegeEscalation: true
+`,
+		err: `security context for "gitlab": allowing privilege escalation for application without trust not valid`,
+	}, {
+		spec: `
+securityContext:
+  runAsUser: 0
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: "running pod as root user for application without trust not valid",
+	}, {
+		spec: `
+serviceAccountName: other
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: `service account name "other" for application without trust not valid`,
+	}, {
+		spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    securityContext:
+      capabilities:
+        add: [NET_ADMIN]
+`,
+		err: `security context for "gitlab": adding capabilities for application without trust not valid`,
+	}, {
+		spec: `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    securityContext:
+      runAsUser: 0
+`,
+		err: `security context for "gitlab": running as root user for application without trust not valid`,
+	}} {
+		c.Logf("test %d", i)
+		spec, err := provider.ParseK8sPodSpec(test.spec[1:])
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(spec.ValidateTrust(false), gc.ErrorMatches, test.err)
+		c.Check(spec.ValidateTrust(true), jc.ErrorIsNil)
+	}
+
+	spec, err := provider.ParseK8sPodSpec(`
+serviceAccount:
+  rules:
+    - resources: ["pods"]
+      verbs: ["get"]
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    securityContext:
+      runAsUser: 1000
+      capabilities:
+        drop: [ALL]
+`[1:])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.ValidateTrust(false), jc.ErrorIsNil)
+}
+
+func (s *ContainersSuite) TestParseDeploymentType(c *gc.C) {
+
+	specStr := `
+deploymentType: job
+job:
+  schedule: "*/5 * * * *"
+  backoffLimit: 3
+  activeDeadlineSeconds: 60
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	c.Assert(spec.DeploymentType, gc.Equals, caas.DeploymentJob)
+	c.Assert(spec.Job, jc.DeepEquals, &caas.JobSpec{
+		Schedule:              "*/5 * * * *",
+		BackoffLimit:          int32Ptr(3),
+		ActiveDeadlineSeconds: int64Ptr(60),
+	})
+}
+
+func (s *ContainersSuite) TestValidateDeploymentType(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: `
+deploymentType: replicated
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: `deployment type "replicated" not valid`,
+	}, {
+		spec: `
+deploymentType: daemon
+job:
+  backoffLimit: 3
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`,
+		err: `job settings for "daemon" deployment not valid`,
+	}} {
+		c.Logf("test %d", i)
+		spec, err := provider.ParseK8sPodSpec(test.spec[1:])
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(spec.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ContainersSuite) TestParseIngress(c *gc.C) {
+
+	specStr := `
+ingress:
+  class: traefik
+  annotations:
+    foo: bar
+  tls:
+    issuer: letsencrypt
+  paths:
+    - path: /
+    - path: /api
+      port: 8080
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	c.Assert(spec.Ingress, jc.DeepEquals, &caas.IngressSpec{
+		Class:       "traefik",
+		Annotations: map[string]string{"foo": "bar"},
+		TLS:         &caas.IngressTLS{Issuer: "letsencrypt"},
+		Paths: []caas.IngressPath{
+			{Path: "/"},
+			{Path: "/api", Port: 8080},
+		},
+	})
+}
+
+func (s *ContainersSuite) TestValidateIngress(c *gc.C) {
+	for i, test := range []struct {
+		ingress string
+		err     string
+	}{{
+		ingress: `
+  tls:
+    issuer: letsencrypt
+    clusterIssuer: letsencrypt
+`,
+		err: "specifying both issuer and clusterIssuer not valid",
+	}, {
+		ingress: `
+  tls: {}
+`,
+		err: "ingress TLS requires a secret name or certificate issuer",
+	}, {
+		ingress: `
+  paths:
+    - path: api
+`,
+		err: `ingress path "api" not valid`,
+	}, {
+		ingress: `
+  paths:
+    - path: /api
+    - path: /api
+`,
+		err: `duplicate ingress path "/api" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+ingress:`[1:] + test.ingress
+		spec, err := provider.ParseK8sPodSpec(specStr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(spec.Validate(), gc.ErrorMatches, test.err)
+	}
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: k8s.io/client-go/kubernetes/typed/apps/v1 (interfaces: AppsV1Interface,DeploymentInterface,StatefulSetInterface)
+// Source: k8s.io/client-go/kubernetes/typed/apps/v1 (interfaces: AppsV1Interface,DaemonSetInterface,DeploymentInterface,StatefulSetInterface)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -111,6 +111,149 @@ func (mr *MockAppsV1InterfaceMockRecorder) StatefulSets(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatefulSets", reflect.TypeOf((*MockAppsV1Interface)(nil).StatefulSets), arg0)
 }
 
+// MockDaemonSetInterface is a mock of DaemonSetInterface interface
+type MockDaemonSetInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDaemonSetInterfaceMockRecorder
+}
+
+// MockDaemonSetInterfaceMockRecorder is the mock recorder for MockDaemonSetInterface
+type MockDaemonSetInterfaceMockRecorder struct {
+	mock *MockDaemonSetInterface
+}
+
+// NewMockDaemonSetInterface creates a new mock instance
+func NewMockDaemonSetInterface(ctrl *gomock.Controller) *MockDaemonSetInterface {
+	mock := &MockDaemonSetInterface{ctrl: ctrl}
+	mock.recorder = &MockDaemonSetInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDaemonSetInterface) EXPECT() *MockDaemonSetInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockDaemonSetInterface) Create(arg0 *v1.DaemonSet) (*v1.DaemonSet, error) {
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*v1.DaemonSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockDaemonSetInterfaceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDaemonSetInterface)(nil).Create), arg0)
+}
+
+// Delete mocks base method
+func (m *MockDaemonSetInterface) Delete(arg0 string, arg1 *v10.DeleteOptions) error {
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockDaemonSetInterfaceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDaemonSetInterface)(nil).Delete), arg0, arg1)
+}
+
+// DeleteCollection mocks base method
+func (m *MockDaemonSetInterface) DeleteCollection(arg0 *v10.DeleteOptions, arg1 v10.ListOptions) error {
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection
+func (mr *MockDaemonSetInterfaceMockRecorder) DeleteCollection(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockDaemonSetInterface)(nil).DeleteCollection), arg0, arg1)
+}
+
+// Get mocks base method
+func (m *MockDaemonSetInterface) Get(arg0 string, arg1 v10.GetOptions) (*v1.DaemonSet, error) {
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*v1.DaemonSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockDaemonSetInterfaceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDaemonSetInterface)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method
+func (m *MockDaemonSetInterface) List(arg0 v10.ListOptions) (*v1.DaemonSetList, error) {
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].(*v1.DaemonSetList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockDaemonSetInterfaceMockRecorder) List(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDaemonSetInterface)(nil).List), arg0)
+}
+
+// Patch mocks base method
+func (m *MockDaemonSetInterface) Patch(arg0 string, arg1 types.PatchType, arg2 []byte, arg3 ...string) (*v1.DaemonSet, error) {
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1.DaemonSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch
+func (mr *MockDaemonSetInterfaceMockRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockDaemonSetInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method
+func (m *MockDaemonSetInterface) Update(arg0 *v1.DaemonSet) (*v1.DaemonSet, error) {
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(*v1.DaemonSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockDaemonSetInterfaceMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDaemonSetInterface)(nil).Update), arg0)
+}
+
+// UpdateStatus mocks base method
+func (m *MockDaemonSetInterface) UpdateStatus(arg0 *v1.DaemonSet) (*v1.DaemonSet, error) {
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0)
+	ret0, _ := ret[0].(*v1.DaemonSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus
+func (mr *MockDaemonSetInterfaceMockRecorder) UpdateStatus(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockDaemonSetInterface)(nil).UpdateStatus), arg0)
+}
+
+// Watch mocks base method
+func (m *MockDaemonSetInterface) Watch(arg0 v10.ListOptions) (watch.Interface, error) {
+	ret := m.ctrl.Call(m, "Watch", arg0)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockDaemonSetInterfaceMockRecorder) Watch(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockDaemonSetInterface)(nil).Watch), arg0)
+}
+
 // MockDeploymentInterface is a mock of DeploymentInterface interface
 type MockDeploymentInterface struct {
 	ctrl     *gomock.Controller
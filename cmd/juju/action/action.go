@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/watcher"
 )
 
 // type APIClient represents the action API functionality.
@@ -60,6 +61,33 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// AddActionSchedules adds schedules on which the controller queues
+	// actions, returning the added schedule for each.
+	AddActionSchedules(params.AddActionSchedules) (params.ActionScheduleResults, error)
+
+	// ListActionSchedules returns all the model's action schedules.
+	ListActionSchedules() (params.ActionSchedules, error)
+
+	// PauseActionSchedules stops the given schedules queueing actions.
+	PauseActionSchedules(params.ActionScheduleIds) (params.ErrorResults, error)
+
+	// ResumeActionSchedules restarts the given paused schedules.
+	ResumeActionSchedules(params.ActionScheduleIds) (params.ErrorResults, error)
+
+	// RemoveActionSchedules removes the given schedules.
+	RemoveActionSchedules(params.ActionScheduleIds) (params.ErrorResults, error)
+
+	// AddActionRollouts starts running actions across the units of
+	// applications, returning each rollout.
+	AddActionRollouts(params.AddActionRollouts) (params.ActionRolloutResults, error)
+
+	// ActionRollouts returns the progress of the given rollouts.
+	ActionRollouts(params.ActionRolloutIds) (params.ActionRolloutResults, error)
+
+	// WatchActionProgress returns a watcher for the json encoded
+	// progress messages logged by the action with the given id.
+	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
@@ -53,6 +53,10 @@ func (c *RunCommand) Args() [][]string {
 	return c.args
 }
 
+func (c *RunCommand) ApplicationName() string {
+	return c.applicationReceiver
+}
+
 type ListCommand struct {
 	*listCommand
 }
@@ -95,6 +99,36 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunCommand{c}
 }
 
+func NewSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &schedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewPauseScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := newPauseScheduleCommand()
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewResumeScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := newResumeScheduleCommand()
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := newRemoveScheduleCommand()
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewShowRolloutCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &showRolloutCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
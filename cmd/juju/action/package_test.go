@@ -17,6 +17,8 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -132,6 +134,19 @@ type fakeAPIClient struct {
 	charmActions       map[string]params.ActionSpec
 	apiVersion         int
 	apiErr             error
+
+	addedSchedules   params.AddActionSchedules
+	scheduleResults  []params.ActionScheduleResult
+	schedules        []params.ActionSchedule
+	updatedSchedules params.ActionScheduleIds
+	errorResults     []params.ErrorResult
+
+	addedRollouts  params.AddActionRollouts
+	rolloutResults []params.ActionRolloutResult
+	rolloutIds     params.ActionRolloutIds
+
+	progress        chan []string
+	watchedProgress string
 }
 
 var _ action.APIClient = (*fakeAPIClient)(nil)
@@ -217,3 +232,47 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) AddActionSchedules(args params.AddActionSchedules) (params.ActionScheduleResults, error) {
+	c.addedSchedules = args
+	return params.ActionScheduleResults{Results: c.scheduleResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ListActionSchedules() (params.ActionSchedules, error) {
+	return params.ActionSchedules{Schedules: c.schedules}, c.apiErr
+}
+
+func (c *fakeAPIClient) PauseActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	return c.updateActionSchedules(args)
+}
+
+func (c *fakeAPIClient) ResumeActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	return c.updateActionSchedules(args)
+}
+
+func (c *fakeAPIClient) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	return c.updateActionSchedules(args)
+}
+
+func (c *fakeAPIClient) updateActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	c.updatedSchedules = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) AddActionRollouts(args params.AddActionRollouts) (params.ActionRolloutResults, error) {
+	c.addedRollouts = args
+	return params.ActionRolloutResults{Results: c.rolloutResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionRollouts(args params.ActionRolloutIds) (params.ActionRolloutResults, error) {
+	c.rolloutIds = args
+	return params.ActionRolloutResults{Results: c.rolloutResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	c.watchedProgress = actionId
+	if c.apiErr != nil {
+		return nil, c.apiErr
+	}
+	return watchertest.NewMockStringsWatcher(c.progress), nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// rolloutFlags holds the run-action options for running an action
+// across the units of an application.
+type rolloutFlags struct {
+	all           bool
+	maxParallel   int
+	batchSize     int
+	stopOnFailure bool
+	leaderOnly    bool
+	nonLeaderOnly bool
+}
+
+func (f *rolloutFlags) setFlags(fs *gnuflag.FlagSet) {
+	fs.BoolVar(&f.all, "all", false, "Run the action across the units of an application")
+	fs.IntVar(&f.maxParallel, "max-parallel", 0, "With --all, the most actions to have unfinished at once")
+	fs.IntVar(&f.batchSize, "batch-size", 0, "With --all, run the action on batches of this many units")
+	fs.BoolVar(&f.stopOnFailure, "stop-on-failure", false, "With --all, stop queueing actions when one fails")
+	fs.BoolVar(&f.leaderOnly, "leader-only", false, "With --all, run the action on the leader only")
+	fs.BoolVar(&f.nonLeaderOnly, "non-leader-only", false, "With --all, run the action on all but the leader")
+}
+
+func (f *rolloutFlags) validate() error {
+	if !f.all {
+		if f.maxParallel != 0 || f.batchSize != 0 || f.stopOnFailure || f.leaderOnly || f.nonLeaderOnly {
+			return errors.New("--max-parallel, --batch-size, --stop-on-failure, --leader-only and --non-leader-only require --all")
+		}
+		return nil
+	}
+	if f.maxParallel < 0 {
+		return errors.New("--max-parallel must not be negative")
+	}
+	if f.batchSize < 0 {
+		return errors.New("--batch-size must not be negative")
+	}
+	if f.leaderOnly && f.nonLeaderOnly {
+		return errors.New("cannot specify both --leader-only and --non-leader-only")
+	}
+	return nil
+}
+
+func (f *rolloutFlags) targets() string {
+	switch {
+	case f.leaderOnly:
+		return "leader"
+	case f.nonLeaderOnly:
+		return "non-leaders"
+	}
+	return "all"
+}
+
+// addRollout asks the controller to run the action across the units
+// of the application, and optionally waits for it to finish.
+func (c *runCommand) addRollout(ctx *cmd.Context, actionParams map[string]interface{}) error {
+	results, err := c.api.AddActionRollouts(params.AddActionRollouts{
+		Rollouts: []params.AddActionRollout{{
+			Application:   names.NewApplicationTag(c.applicationReceiver).String(),
+			Name:          c.actionName,
+			Parameters:    actionParams,
+			Targets:       c.rollout.targets(),
+			MaxParallel:   c.rollout.maxParallel,
+			BatchSize:     c.rollout.batchSize,
+			StopOnFailure: c.rollout.stopOnFailure,
+		}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	if results.Results[0].Error != nil {
+		return results.Results[0].Error
+	}
+	rollout := *results.Results[0].Rollout
+
+	if c.wait.forever || c.wait.d > 0 {
+		var wait *time.Timer
+		if c.wait.forever {
+			// Indefinite wait. Discard the tick.
+			wait = time.NewTimer(0 * time.Second)
+			<-wait.C
+		} else {
+			wait = time.NewTimer(c.wait.d)
+		}
+		rollout, err = waitForRollout(c.api, rollout.Id, wait)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return c.out.Write(ctx, formatRollout(rollout))
+}
+
+// waitForRollout repeatedly fetches the rollout until it has finished,
+// or until wait fires, returning its latest progress.
+func waitForRollout(api APIClient, id string, wait *time.Timer) (params.ActionRollout, error) {
+	tick := time.NewTimer(0)
+	for {
+		select {
+		case <-wait.C:
+			return fetchRollout(api, id)
+		case <-tick.C:
+		}
+		rollout, err := fetchRollout(api, id)
+		if err != nil || rollout.Status != "running" {
+			return rollout, err
+		}
+		tick.Reset(2 * time.Second)
+	}
+}
+
+func fetchRollout(api APIClient, id string) (params.ActionRollout, error) {
+	results, err := api.ActionRollouts(params.ActionRolloutIds{Ids: []string{id}})
+	if err != nil {
+		return params.ActionRollout{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ActionRollout{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if results.Results[0].Error != nil {
+		return params.ActionRollout{}, results.Results[0].Error
+	}
+	return *results.Results[0].Rollout, nil
+}
+
+// formatRollout returns the rollout's progress as a map for cmd.Output,
+// with a count of the units' action statuses.
+func formatRollout(rollout params.ActionRollout) map[string]interface{} {
+	summary := make(map[string]int)
+	units := make(map[string]interface{}, len(rollout.Units))
+	for _, unit := range rollout.Units {
+		summary[unit.Status]++
+		unitName := unit.Unit
+		if tag, err := names.ParseUnitTag(unit.Unit); err == nil {
+			unitName = tag.Id()
+		}
+		out := map[string]string{"status": unit.Status}
+		if tag, err := names.ParseActionTag(unit.Action); err == nil {
+			out["id"] = tag.Id()
+		}
+		if unit.Message != "" {
+			out["message"] = unit.Message
+		}
+		units[unitName] = out
+	}
+	application := rollout.Application
+	if tag, err := names.ParseApplicationTag(rollout.Application); err == nil {
+		application = tag.Id()
+	}
+	return map[string]interface{}{
+		"rollout":     rollout.Id,
+		"application": application,
+		"action":      rollout.Name,
+		"status":      rollout.Status,
+		"summary":     summary,
+		"units":       units,
+	}
+}
+
+func NewShowRolloutCommand() cmd.Command {
+	return modelcmd.Wrap(&showRolloutCommand{})
+}
+
+// showRolloutCommand shows the progress of an action rollout.
+type showRolloutCommand struct {
+	ActionCommandBase
+	out  cmd.Output
+	id   string
+	wait waitFlag
+}
+
+const showRolloutDoc = `
+Show the progress of an action being run across the units of an
+application with "juju run-action <application> --all", including the
+status of the action on each unit and a count of the statuses.
+
+Units the action has yet to be queued on are "waiting"; units it was
+not queued on because the rollout stopped after a failure, or because
+the unit was removed, are "skipped".
+
+Examples:
+
+    juju show-action-rollout 0
+    juju show-action-rollout 0 --wait 10m
+
+See also:
+    run-action
+    show-action-output
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *showRolloutCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.Var(&c.wait, "wait", "Wait for the rollout to finish, with optional timeout")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *showRolloutCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-action-rollout",
+		Args:    "<rollout id>",
+		Purpose: "Show the progress of an action run across an application.",
+		Doc:     showRolloutDoc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *showRolloutCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no rollout id specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *showRolloutCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	var rollout params.ActionRollout
+	switch {
+	case c.wait.forever:
+		wait := time.NewTimer(0 * time.Second)
+		<-wait.C
+		rollout, err = waitForRollout(api, c.id, wait)
+	case c.wait.d > 0:
+		rollout, err = waitForRollout(api, c.id, time.NewTimer(c.wait.d))
+	default:
+		rollout, err = fetchRollout(api, c.id)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatRollout(rollout))
+}
@@ -0,0 +1,198 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type RolloutSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&RolloutSuite{})
+
+func (s *RolloutSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeAPIClient{apiVersion: 4}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *RolloutSuite) TestRunInitRollout(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectError string
+	}{{
+		args: []string{"mysql", "--all", "backup", "--max-parallel", "2", "--batch-size", "4", "--stop-on-failure"},
+	}, {
+		args: []string{"mysql", "--all", "backup", "--non-leader-only"},
+	}, {
+		args:        []string{"mysql/0", "backup", "--batch-size", "2"},
+		expectError: "--max-parallel, --batch-size, --stop-on-failure, --leader-only and --non-leader-only require --all",
+	}, {
+		args:        []string{"mysql/0", "--all", "backup"},
+		expectError: "--all requires an application name",
+	}, {
+		args:        []string{"mysql", "mysql/0", "--all", "backup"},
+		expectError: "cannot specify units with --all",
+	}, {
+		args:        []string{"mysql", "--all", "backup", "--schedule", "@daily"},
+		expectError: "cannot schedule an action with --all",
+	}, {
+		args:        []string{"mysql", "--all", "backup", "--leader-only", "--non-leader-only"},
+		expectError: "cannot specify both --leader-only and --non-leader-only",
+	}, {
+		args:        []string{"mysql", "--all", "backup", "--max-parallel", "-1"},
+		expectError: "--max-parallel must not be negative",
+	}, {
+		args:        []string{"mysql", "--all"},
+		expectError: "no action specified",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		wrapped, command := action.NewRunCommandForTest(s.store)
+		err := cmdtesting.InitCommand(wrapped, append([]string{"-m", "admin"}, test.args...))
+		if test.expectError != "" {
+			c.Check(err, gc.ErrorMatches, test.expectError)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.ApplicationName(), gc.Equals, "mysql")
+		c.Check(command.UnitNames(), gc.HasLen, 0)
+		c.Check(command.ActionName(), gc.Equals, "backup")
+	}
+}
+
+func (s *RolloutSuite) TestRunRollout(c *gc.C) {
+	s.client.rolloutResults = []params.ActionRolloutResult{{
+		Rollout: &params.ActionRollout{
+			Id:          "0",
+			Application: "application-mysql",
+			Name:        "backup",
+			Status:      "running",
+			Units: []params.ActionRolloutUnit{
+				{Unit: "unit-mysql-0", Status: "waiting"},
+				{Unit: "unit-mysql-2", Status: "waiting"},
+			},
+		},
+	}}
+	wrapped, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrapped, "-m", "admin",
+		"mysql", "--all", "backup", "dest=s3", "--batch-size", "1", "--non-leader-only")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.addedRollouts.Rollouts, jc.DeepEquals, []params.AddActionRollout{{
+		Application: "application-mysql",
+		Name:        "backup",
+		Parameters:  map[string]interface{}{"dest": "s3"},
+		Targets:     "non-leaders",
+		BatchSize:   1,
+	}})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+action: backup
+application: mysql
+rollout: "0"
+status: running
+summary:
+  waiting: 2
+units:
+  mysql/0:
+    status: waiting
+  mysql/2:
+    status: waiting
+`[1:])
+}
+
+func (s *RolloutSuite) TestRunRolloutWait(c *gc.C) {
+	// The fake returns the same results when adding and fetching the
+	// rollout, so the wait finishes at once.
+	s.client.rolloutResults = []params.ActionRolloutResult{{
+		Rollout: &params.ActionRollout{
+			Id:          "0",
+			Application: "application-mysql",
+			Name:        "backup",
+			Status:      "stopped",
+			Units: []params.ActionRolloutUnit{{
+				Unit:    "unit-mysql-0",
+				Action:  "action-f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				Status:  "failed",
+				Message: "disk full",
+			}, {
+				Unit:   "unit-mysql-1",
+				Status: "skipped",
+			}},
+		},
+	}}
+	wrapped, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrapped, "-m", "admin",
+		"mysql", "--all", "backup", "--stop-on-failure", "--wait", "1m")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.rolloutIds.Ids, jc.DeepEquals, []string{"0"})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+action: backup
+application: mysql
+rollout: "0"
+status: stopped
+summary:
+  failed: 1
+  skipped: 1
+units:
+  mysql/0:
+    id: f47ac10b-58cc-4372-a567-0e02b2c3d479
+    message: disk full
+    status: failed
+  mysql/1:
+    status: skipped
+`[1:])
+}
+
+func (s *RolloutSuite) TestRunRolloutError(c *gc.C) {
+	s.client.rolloutResults = []params.ActionRolloutResult{{
+		Error: &params.Error{Message: `cannot add action rollout: no units of "mysql" to run "backup" on`},
+	}}
+	wrapped, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrapped, "-m", "admin", "mysql", "--all", "backup")
+	c.Assert(err, gc.ErrorMatches, `cannot add action rollout: no units of "mysql" to run "backup" on`)
+}
+
+func (s *RolloutSuite) TestShowRollout(c *gc.C) {
+	s.client.rolloutResults = []params.ActionRolloutResult{{
+		Rollout: &params.ActionRollout{
+			Id:          "3",
+			Application: "application-mysql",
+			Name:        "backup",
+			Status:      "completed",
+			Units: []params.ActionRolloutUnit{{
+				Unit:   "unit-mysql-0",
+				Action: "action-f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				Status: "completed",
+			}},
+		},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, action.NewShowRolloutCommandForTest(s.store), "-m", "admin", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.rolloutIds.Ids, jc.DeepEquals, []string{"3"})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+action: backup
+application: mysql
+rollout: "3"
+status: completed
+summary:
+  completed: 1
+units:
+  mysql/0:
+    id: f47ac10b-58cc-4372-a567-0e02b2c3d479
+    status: completed
+`[1:])
+}
+
+func (s *RolloutSuite) TestShowRolloutNoId(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewShowRolloutCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, gc.ErrorMatches, "no rollout id specified")
+}
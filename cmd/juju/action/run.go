@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/schedule"
 )
 
 // leaderSnippet is a regular expression for unit ID-like syntax that is used
@@ -39,15 +40,20 @@ func NewRunCommand() cmd.Command {
 // params
 type runCommand struct {
 	ActionCommandBase
-	api           APIClient
-	unitReceivers []string
-	leaders       map[string]string
-	actionName    string
-	paramsYAML    cmd.FileVar
-	parseStrings  bool
-	wait          waitFlag
-	out           cmd.Output
-	args          [][]string
+	api                 APIClient
+	unitReceivers       []string
+	applicationReceiver string
+	leaders             map[string]string
+	actionName          string
+	paramsYAML          cmd.FileVar
+	parseStrings        bool
+	wait                waitFlag
+	at                  string
+	atTime              time.Time
+	schedule            string
+	rollout             rolloutFlags
+	out                 cmd.Output
+	args                [][]string
 }
 
 const runDoc = `
@@ -64,6 +70,8 @@ resolved before the action is enqueued.
 
 Params are validated according to the charm for the unit's application.  The
 valid params can be seen using "juju actions <application> --schema".
+Params the schema doesn't define are rejected, every problem with the params
+is reported at once, and any defaults in the schema are filled in.
 Params may be in a yaml file which is passed with the --params option, or they
 may be specified by a key.key.key...=value format (see examples below.)
 
@@ -74,6 +82,27 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+Instead of being queued immediately, the action can be scheduled to be
+queued by the controller at a later time with --at, or repeatedly with
+--schedule. A schedule is either a five field crontab spec ("minute hour
+day-of-month month day-of-week", in UTC), or one of @hourly, @daily,
+@weekly, @monthly or "@every <duration>". A scheduled action may also
+be given an application in place of units, in which case it is queued
+on all the application's units each time it runs. Scheduled actions are
+managed with the action-schedules, pause-action-schedule,
+resume-action-schedule and remove-action-schedule commands.
+
+With --all, the action is run across the units of a single application,
+given in place of units. The controller queues the action on the units
+in order, with at most --max-parallel actions unfinished at once. With
+--batch-size, the units are split into batches, and no action in a batch
+is queued until every action in the previous batch has finished. With
+--stop-on-failure, no more actions are queued once one has failed or
+been cancelled. --leader-only and --non-leader-only restrict the units
+to the application leader or the other units. Use --wait to wait for
+the rollout to finish and show a summary of the results, or see the
+rollout's progress later with show-action-rollout.
+
 Examples:
 
 $ juju run-action mysql/3 backup --wait
@@ -138,6 +167,34 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/leader backup --schedule "0 3 * * *"
+mysql/leader:
+  id: "0"
+  next-run: 2018-10-18T03:00:00Z
+
+$ juju run-action mysql backup --at 2018-10-18T22:00:00Z
+mysql:
+  id: "1"
+  next-run: 2018-10-18T22:00:00Z
+
+$ juju run-action mysql --all backup --batch-size 2 --stop-on-failure --wait
+rollout: "0"
+application: mysql
+action: backup
+status: completed
+summary:
+  completed: 3
+units:
+  mysql/0:
+    id: <ID>
+    status: completed
+  mysql/1:
+    id: <ID>
+    status: completed
+  mysql/2:
+    id: <ID>
+    status: completed
 `
 
 // SetFlags offers an option for YAML output.
@@ -147,6 +204,37 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.StringVar(&c.at, "at", "", "Queue the action once at the given RFC3339 time, e.g. 2018-10-18T22:00:00Z")
+	f.StringVar(&c.schedule, "schedule", "", "Queue the action repeatedly on the given crontab-style schedule")
+	c.rollout.setFlags(f)
+}
+
+// scheduled reports whether the action is to be scheduled by the
+// controller rather than queued immediately.
+func (c *runCommand) scheduled() bool {
+	return c.at != "" || c.schedule != ""
+}
+
+func (c *runCommand) initSchedule() error {
+	if c.at != "" && c.schedule != "" {
+		return errors.New("cannot specify both --at and --schedule")
+	}
+	if c.wait.forever || c.wait.d > 0 {
+		return errors.New("cannot wait for the results of a scheduled action")
+	}
+	if c.at != "" {
+		at, err := time.Parse(time.RFC3339, c.at)
+		if err != nil {
+			return errors.Errorf("invalid --at time %q, expected a time such as 2018-10-18T22:00:00Z", c.at)
+		}
+		c.atTime = at
+	}
+	if c.schedule != "" {
+		if _, err := schedule.Parse(c.schedule); err != nil {
+			return errors.Annotate(err, "invalid --schedule")
+		}
+	}
+	return nil
 }
 
 func (c *runCommand) Info() *cmd.Info {
@@ -160,7 +248,34 @@ func (c *runCommand) Info() *cmd.Info {
 
 // Init gets the unit tag(s), action name and action arguments.
 func (c *runCommand) Init(args []string) (err error) {
-	for _, arg := range args {
+	if err := c.rollout.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	var receiverArgs int
+	if c.rollout.all {
+		if c.scheduled() {
+			return errors.New("cannot schedule an action with --all")
+		}
+		if len(args) == 0 || !names.IsValidApplication(args[0]) {
+			return errors.New("--all requires an application name")
+		}
+		c.applicationReceiver = args[0]
+		receiverArgs = 1
+		if len(args) > 1 && (names.IsValidUnit(args[1]) || validLeader.MatchString(args[1])) {
+			return errors.New("cannot specify units with --all")
+		}
+	} else if c.scheduled() {
+		if err := c.initSchedule(); err != nil {
+			return errors.Trace(err)
+		}
+		// A scheduled action may be queued on all of an
+		// application's units each time it runs.
+		if len(args) > 0 && names.IsValidApplication(args[0]) {
+			c.applicationReceiver = args[0]
+			receiverArgs = 1
+		}
+	}
+	for _, arg := range args[receiverArgs:] {
 		if names.IsValidUnit(arg) || validLeader.MatchString(arg) {
 			c.unitReceivers = append(c.unitReceivers, arg)
 		} else if nameRule.MatchString(arg) {
@@ -170,7 +285,7 @@ func (c *runCommand) Init(args []string) (err error) {
 			return errors.Errorf("invalid unit or action name %q", arg)
 		}
 	}
-	if len(c.unitReceivers) == 0 {
+	if len(c.unitReceivers) == 0 && c.applicationReceiver == "" {
 		return errors.New("no unit specified")
 	}
 	if c.actionName == "" {
@@ -179,7 +294,8 @@ func (c *runCommand) Init(args []string) (err error) {
 
 	// Parse CLI key-value args if they exist.
 	c.args = make([][]string, 0)
-	for _, arg := range args[len(c.unitReceivers)+1:] {
+	receiverArgs += len(c.unitReceivers)
+	for _, arg := range args[receiverArgs+1:] {
 		thisArg := strings.SplitN(arg, "=", 2)
 		if len(thisArg) != 2 {
 			return errors.Errorf("argument %q must be of the form key...=value", arg)
@@ -256,6 +372,13 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.scheduled() {
+		return c.addSchedules(ctx, actionParams)
+	}
+	if c.rollout.all {
+		return c.addRollout(ctx, actionParams)
+	}
+
 	actions := make([]params.Action, len(c.unitReceivers))
 	for i, unitReceiver := range c.unitReceivers {
 		if strings.HasSuffix(unitReceiver, "leader") {
@@ -354,6 +477,56 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, out)
 }
 
+// addSchedules asks the controller to queue the action on each of the
+// receivers on the requested schedule.
+func (c *runCommand) addSchedules(ctx *cmd.Context, actionParams map[string]interface{}) error {
+	receivers := c.unitReceivers
+	if c.applicationReceiver != "" {
+		receivers = append([]string{c.applicationReceiver}, receivers...)
+	}
+	args := params.AddActionSchedules{
+		Schedules: make([]params.AddActionSchedule, len(receivers)),
+	}
+	for i, receiver := range receivers {
+		arg := &args.Schedules[i]
+		switch {
+		case receiver == c.applicationReceiver:
+			arg.Receiver = names.NewApplicationTag(receiver).String()
+		case validLeader.MatchString(receiver):
+			arg.Receiver = receiver
+		default:
+			arg.Receiver = names.NewUnitTag(receiver).String()
+		}
+		arg.Name = c.actionName
+		arg.Parameters = actionParams
+		arg.Schedule = c.schedule
+		if c.at != "" {
+			at := c.atTime
+			arg.At = &at
+		}
+	}
+	results, err := c.api.AddActionSchedules(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(receivers) {
+		return errors.New("illegal number of results returned")
+	}
+
+	out := make(map[string]interface{}, len(receivers))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return result.Error
+		}
+		sched := map[string]interface{}{"id": result.Schedule.Id}
+		if result.Schedule.NextRun != nil {
+			sched["next-run"] = result.Schedule.NextRun.UTC()
+		}
+		out[receivers[i]] = sched
+	}
+	return c.out.Write(ctx, out)
+}
+
 func (c *runCommand) ensureAPI() (err error) {
 	if c.api != nil {
 		return nil
@@ -0,0 +1,326 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&schedulesCommand{})
+}
+
+// schedulesCommand lists the action schedules of a model.
+type schedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const schedulesDoc = `
+List the schedules on which the controller queues actions in the model.
+Schedules are added with the --at and --schedule options of run-action.
+
+A schedule's status is "active" while it will queue actions, "paused"
+after pause-action-schedule, and "done" once a schedule given with --at
+has queued its action.
+
+Schedules aren't migrated with their model, and a model with schedules
+can't be migrated; remove them before migrating and add them again on
+the target controller.
+
+Examples:
+
+    juju action-schedules
+    juju action-schedules --format yaml
+
+See also:
+    run-action
+    pause-action-schedule
+    resume-action-schedule
+    remove-action-schedule
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *schedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printSchedulesTabular,
+	})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *schedulesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "action-schedules",
+		Purpose: "List the schedules on which actions are queued.",
+		Doc:     schedulesDoc,
+		Aliases: []string{"list-action-schedules"},
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *schedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *schedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	result, err := api.ListActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules in this model.")
+		return nil
+	}
+	schedules := make([]scheduleOutput, len(result.Schedules))
+	for i, sched := range result.Schedules {
+		schedules[i] = makeScheduleOutput(sched)
+	}
+	return c.out.Write(ctx, schedules)
+}
+
+type scheduleOutput struct {
+	Id         string                 `yaml:"id" json:"id"`
+	Receiver   string                 `yaml:"receiver" json:"receiver"`
+	Action     string                 `yaml:"action" json:"action"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Schedule   string                 `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	Status     string                 `yaml:"status" json:"status"`
+	NextRun    *time.Time             `yaml:"next-run,omitempty" json:"next-run,omitempty"`
+	LastRun    *time.Time             `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+	LastError  string                 `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+	Created    time.Time              `yaml:"created" json:"created"`
+	CreatedBy  string                 `yaml:"created-by" json:"created-by"`
+}
+
+func makeScheduleOutput(sched params.ActionSchedule) scheduleOutput {
+	utc := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		u := t.UTC()
+		return &u
+	}
+	out := scheduleOutput{
+		Id:         sched.Id,
+		Receiver:   displayReceiver(sched.Receiver),
+		Action:     sched.Name,
+		Parameters: sched.Parameters,
+		Schedule:   sched.Schedule,
+		NextRun:    utc(sched.NextRun),
+		LastRun:    utc(sched.LastRun),
+		LastError:  sched.LastError,
+		Created:    sched.Created.UTC(),
+		CreatedBy:  sched.CreatedBy,
+	}
+	switch {
+	case sched.Paused:
+		out.Status = "paused"
+	case sched.NextRun == nil:
+		out.Status = "done"
+	default:
+		out.Status = "active"
+	}
+	return out
+}
+
+// displayReceiver returns the name of an action schedule's receiver
+// as given to run-action.
+func displayReceiver(receiver string) string {
+	tag, err := names.ParseTag(receiver)
+	if err != nil {
+		return receiver
+	}
+	return tag.Id()
+}
+
+func printSchedulesTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.([]scheduleOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Id\tReceiver\tAction\tSchedule\tStatus\tNext run\tLast run\tLast error")
+	for _, sched := range schedules {
+		schedule := sched.Schedule
+		if schedule == "" {
+			schedule = "once"
+		}
+		cells := []string{
+			sched.Id, sched.Receiver, sched.Action, schedule, sched.Status,
+			formatTime(sched.NextRun), formatTime(sched.LastRun), sched.LastError,
+		}
+		// Drop empty trailing cells so rows aren't padded with spaces.
+		for cells[len(cells)-1] == "" {
+			cells = cells[:len(cells)-1]
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+func NewPauseScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(newPauseScheduleCommand())
+}
+
+func NewResumeScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(newResumeScheduleCommand())
+}
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(newRemoveScheduleCommand())
+}
+
+func newPauseScheduleCommand() *updateScheduleCommand {
+	return &updateScheduleCommand{
+		info: cmd.Info{
+			Name:    "pause-action-schedule",
+			Args:    "<schedule id> [<schedule id> ...]",
+			Purpose: "Stop action schedules queueing actions.",
+			Doc: `
+Pause the given action schedules. A paused schedule queues no actions
+until it is resumed with resume-action-schedule.
+
+Examples:
+
+    juju pause-action-schedule 3
+
+See also:
+    action-schedules
+    resume-action-schedule
+`,
+		},
+		verb:   "pause",
+		update: APIClient.PauseActionSchedules,
+	}
+}
+
+func newResumeScheduleCommand() *updateScheduleCommand {
+	return &updateScheduleCommand{
+		info: cmd.Info{
+			Name:    "resume-action-schedule",
+			Args:    "<schedule id> [<schedule id> ...]",
+			Purpose: "Restart paused action schedules.",
+			Doc: `
+Resume the given paused action schedules. Runs of a recurring schedule
+missed while it was paused are skipped; it next runs at the first time
+its schedule gives after it is resumed.
+
+Examples:
+
+    juju resume-action-schedule 3
+
+See also:
+    action-schedules
+    pause-action-schedule
+`,
+		},
+		verb:   "resume",
+		update: APIClient.ResumeActionSchedules,
+	}
+}
+
+func newRemoveScheduleCommand() *updateScheduleCommand {
+	return &updateScheduleCommand{
+		info: cmd.Info{
+			Name:    "remove-action-schedule",
+			Args:    "<schedule id> [<schedule id> ...]",
+			Purpose: "Remove action schedules.",
+			Doc: `
+Remove the given action schedules. Actions the schedules have already
+queued are not cancelled; use cancel-action for those.
+
+Examples:
+
+    juju remove-action-schedule 3 4
+
+See also:
+    action-schedules
+    cancel-action
+`,
+		},
+		verb:   "remove",
+		update: APIClient.RemoveActionSchedules,
+	}
+}
+
+// updateScheduleCommand applies an update to each of the given action
+// schedules.
+type updateScheduleCommand struct {
+	ActionCommandBase
+	info   cmd.Info
+	verb   string
+	update func(APIClient, params.ActionScheduleIds) (params.ErrorResults, error)
+	ids    []string
+}
+
+// Info is part of the cmd.Command interface.
+func (c *updateScheduleCommand) Info() *cmd.Info {
+	info := c.info
+	return jujucmd.Info(&info)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *updateScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action schedules specified")
+	}
+	c.ids = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *updateScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := c.update(api, params.ActionScheduleIds{Ids: c.ids})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(c.ids) {
+		return errors.Errorf("expected %d results, got %d", len(c.ids), len(results.Results))
+	}
+	var failed []string
+	for i, result := range results.Results {
+		if result.Error != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", c.ids[i], result.Error))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("cannot %s action schedules:\n%s", c.verb, strings.Join(failed, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/jujuclient"
+)
+
+type SchedulesSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&SchedulesSuite{})
+
+func (s *SchedulesSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeAPIClient{apiVersion: 4}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *SchedulesSuite) runUpdate(c *gc.C, newCommand func(jujuclient.ClientStore) cmd.Command, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, newCommand(s.store), append([]string{"-m", "admin"}, args...)...)
+	return err
+}
+
+func (s *SchedulesSuite) TestRunInitSchedule(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectError string
+		application string
+		units       []string
+	}{{
+		args:        []string{"mysql", "backup", "--schedule", "0 3 * * *"},
+		application: "mysql",
+	}, {
+		args:        []string{"mysql", "mysql/1", "backup", "--at", "2018-10-18T22:00:00Z"},
+		application: "mysql",
+		units:       []string{"mysql/1"},
+	}, {
+		args:  []string{"mysql/leader", "backup", "--schedule", "@daily"},
+		units: []string{"mysql/leader"},
+	}, {
+		args:        []string{"mysql", "backup"},
+		expectError: "no unit specified",
+	}, {
+		args:        []string{"mysql/0", "backup", "--at", "tomorrow"},
+		expectError: `invalid --at time "tomorrow", expected a time such as 2018-10-18T22:00:00Z`,
+	}, {
+		args:        []string{"mysql/0", "backup", "--schedule", "every day"},
+		expectError: `invalid --schedule: .*`,
+	}, {
+		args:        []string{"mysql/0", "backup", "--schedule", "@daily", "--at", "2018-10-18T22:00:00Z"},
+		expectError: "cannot specify both --at and --schedule",
+	}, {
+		args:        []string{"mysql/0", "backup", "--schedule", "@daily", "--wait"},
+		expectError: "cannot wait for the results of a scheduled action",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		wrapped, command := action.NewRunCommandForTest(s.store)
+		err := cmdtesting.InitCommand(wrapped, append([]string{"-m", "admin"}, test.args...))
+		if test.expectError != "" {
+			c.Check(err, gc.ErrorMatches, test.expectError)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.ApplicationName(), gc.Equals, test.application)
+		c.Check(command.UnitNames(), jc.DeepEquals, test.units)
+		c.Check(command.ActionName(), gc.Equals, "backup")
+	}
+}
+
+func (s *SchedulesSuite) TestRunSchedule(c *gc.C) {
+	next := time.Date(2018, 10, 18, 3, 0, 0, 0, time.UTC)
+	s.client.scheduleResults = []params.ActionScheduleResult{
+		{Schedule: &params.ActionSchedule{Id: "0", NextRun: &next}},
+		{Schedule: &params.ActionSchedule{Id: "1", NextRun: &next}},
+	}
+	wrapped, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrapped, "-m", "admin",
+		"mysql", "wordpress/leader", "backup", "dest=s3", "--schedule", "0 3 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	actionParams := map[string]interface{}{"dest": "s3"}
+	c.Check(s.client.addedSchedules.Schedules, jc.DeepEquals, []params.AddActionSchedule{{
+		Receiver:   "application-mysql",
+		Name:       "backup",
+		Parameters: actionParams,
+		Schedule:   "0 3 * * *",
+	}, {
+		Receiver:   "wordpress/leader",
+		Name:       "backup",
+		Parameters: actionParams,
+		Schedule:   "0 3 * * *",
+	}})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql:
+  id: "0"
+  next-run: 2018-10-18T03:00:00Z
+wordpress/leader:
+  id: "1"
+  next-run: 2018-10-18T03:00:00Z
+`[1:])
+}
+
+func (s *SchedulesSuite) TestRunAt(c *gc.C) {
+	at := time.Date(2018, 10, 18, 22, 0, 0, 0, time.UTC)
+	s.client.scheduleResults = []params.ActionScheduleResult{
+		{Schedule: &params.ActionSchedule{Id: "2", NextRun: &at}},
+	}
+	wrapped, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrapped, "-m", "admin",
+		"mysql/0", "backup", "--at", "2018-10-18T22:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.addedSchedules.Schedules, gc.HasLen, 1)
+	added := s.client.addedSchedules.Schedules[0]
+	c.Check(added.Receiver, gc.Equals, "unit-mysql-0")
+	c.Check(added.Schedule, gc.Equals, "")
+	c.Assert(added.At, gc.NotNil)
+	c.Check(added.At.Equal(at), jc.IsTrue)
+}
+
+func (s *SchedulesSuite) TestRunScheduleError(c *gc.C) {
+	s.client.scheduleResults = []params.ActionScheduleResult{
+		{Error: &params.Error{Message: `action "backup" not defined for application "mysql"`}},
+	}
+	wrapped, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrapped, "-m", "admin", "mysql", "backup", "--schedule", "@daily")
+	c.Assert(err, gc.ErrorMatches, `action "backup" not defined for application "mysql"`)
+}
+
+func (s *SchedulesSuite) TestListTabular(c *gc.C) {
+	next := time.Date(2018, 10, 18, 3, 0, 0, 0, time.UTC)
+	last := time.Date(2018, 10, 17, 3, 0, 0, 0, time.UTC)
+	s.client.schedules = []params.ActionSchedule{{
+		Id:        "0",
+		Receiver:  "application-mysql",
+		Name:      "backup",
+		Schedule:  "0 3 * * *",
+		NextRun:   &next,
+		LastRun:   &last,
+		LastError: "unit mysql/1 not found",
+		Created:   last.Add(-time.Hour),
+		CreatedBy: "admin",
+	}, {
+		Id:        "1",
+		Receiver:  "unit-mysql-0",
+		Name:      "backup",
+		LastRun:   &last,
+		Created:   last.Add(-time.Hour),
+		CreatedBy: "admin",
+	}, {
+		Id:        "2",
+		Receiver:  "mysql/leader",
+		Name:      "restore",
+		Schedule:  "@weekly",
+		Paused:    true,
+		Created:   last.Add(-time.Hour),
+		CreatedBy: "admin",
+	}}
+	ctx, err := cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Id  Receiver      Action   Schedule   Status  Next run              Last run              Last error
+0   mysql         backup   0 3 * * *  active  2018-10-18T03:00:00Z  2018-10-17T03:00:00Z  unit mysql/1 not found
+1   mysql/0       backup   once       done                          2018-10-17T03:00:00Z
+2   mysql/leader  restore  @weekly    paused
+`[1:])
+}
+
+func (s *SchedulesSuite) TestListYAML(c *gc.C) {
+	created := time.Date(2018, 10, 17, 2, 0, 0, 0, time.UTC)
+	s.client.schedules = []params.ActionSchedule{{
+		Id:         "2",
+		Receiver:   "mysql/leader",
+		Name:       "restore",
+		Parameters: map[string]interface{}{"from": "s3"},
+		Schedule:   "@weekly",
+		Paused:     true,
+		Created:    created,
+		CreatedBy:  "admin",
+	}}
+	ctx, err := cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: "2"
+  receiver: mysql/leader
+  action: restore
+  parameters:
+    from: s3
+  schedule: '@weekly'
+  status: paused
+  created: 2018-10-17T02:00:00Z
+  created-by: admin
+`[1:])
+}
+
+func (s *SchedulesSuite) TestListEmpty(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules in this model.\n")
+}
+
+func (s *SchedulesSuite) TestUpdate(c *gc.C) {
+	for _, newCommand := range []func(jujuclient.ClientStore) cmd.Command{
+		action.NewPauseScheduleCommandForTest,
+		action.NewResumeScheduleCommandForTest,
+		action.NewRemoveScheduleCommandForTest,
+	} {
+		s.client.errorResults = []params.ErrorResult{{}, {}}
+		err := s.runUpdate(c, newCommand, "1", "2")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.client.updatedSchedules.Ids, jc.DeepEquals, []string{"1", "2"})
+	}
+}
+
+func (s *SchedulesSuite) TestUpdateErrors(c *gc.C) {
+	s.client.errorResults = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `action schedule "2" not found`}},
+	}
+	err := s.runUpdate(c, action.NewPauseScheduleCommandForTest, "1", "2")
+	c.Assert(err, gc.ErrorMatches, `cannot pause action schedules:
+2: action schedule "2" not found`)
+}
+
+func (s *SchedulesSuite) TestUpdateNoIds(c *gc.C) {
+	err := s.runUpdate(c, action.NewRemoveScheduleCommandForTest)
+	c.Assert(err, gc.ErrorMatches, "no action schedules specified")
+}
@@ -4,12 +4,15 @@
 package action
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
@@ -28,6 +31,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	follow      bool
 }
 
 const showOutputDoc = `
@@ -39,6 +43,16 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To watch a running action, use --follow.  Progress messages logged by the
+action with the action-log hook tool are written to stderr as they arrive,
+and the results are shown once the action finishes.
+
+Examples:
+
+    juju show-action-output 1234
+    juju show-action-output 1234 --wait 10m
+    juju show-action-output 1234 --follow
 `
 
 // Set up the output.
@@ -46,6 +60,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.follow, "follow", false, "Show progress messages as they are logged, until the action finishes")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -64,6 +79,9 @@ func (c *showOutputCommand) Init(args []string) error {
 		return errors.New("no action ID specified")
 	case 1:
 		c.requestedId = args[0]
+		if c.follow && c.wait != "-1s" {
+			return errors.New("cannot specify both --follow and --wait")
+		}
 		return nil
 	default:
 		return cmd.CheckEmpty(args[1:])
@@ -91,6 +109,14 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
+	if c.follow {
+		result, err := followActionProgress(ctx, api, c.requestedId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, FormatActionResult(result))
+	}
+
 	wait := time.NewTimer(0 * time.Second)
 
 	switch {
@@ -161,6 +187,74 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 	}
 }
 
+// followActionProgress writes the progress messages logged by an action
+// to stderr as they arrive, until the action finishes, and then returns
+// its result.
+func followActionProgress(ctx *cmd.Context, api APIClient, requestedId string) (params.ActionResult, error) {
+	none := params.ActionResult{}
+
+	actionTag, err := getActionTagByPrefix(api, requestedId)
+	if err != nil {
+		return none, err
+	}
+	w, err := api.WatchActionProgress(actionTag.Id())
+	if err != nil {
+		return none, errors.Trace(err)
+	}
+	defer worker.Stop(w)
+
+	// The watcher only reports new messages, so check for the action
+	// finishing every two seconds.
+	var logged int
+	tick := time.NewTimer(0)
+	for {
+		select {
+		case changes, ok := <-w.Changes():
+			if !ok {
+				err := w.Wait()
+				if err == nil {
+					err = errors.New("watcher stopped")
+				}
+				return none, errors.Annotate(err, "watching action progress")
+			}
+			for _, change := range changes {
+				var msg params.ActionMessage
+				if err := json.Unmarshal([]byte(change), &msg); err != nil {
+					return none, errors.Annotate(err, "cannot decode action progress")
+				}
+				writeActionMessage(ctx, msg)
+				logged++
+			}
+		case <-tick.C:
+			result, err := fetchResult(api, requestedId)
+			if err != nil {
+				return none, err
+			}
+			switch result.Status {
+			case params.ActionRunning, params.ActionPending:
+				tick.Reset(2 * time.Second)
+				continue
+			}
+			// Messages logged just before the action finished may
+			// not have reached the watcher yet.
+			if logged < len(result.Log) {
+				for _, msg := range result.Log[logged:] {
+					writeActionMessage(ctx, msg)
+				}
+			}
+			return result, nil
+		}
+	}
+}
+
+func writeActionMessage(ctx *cmd.Context, msg params.ActionMessage) {
+	fmt.Fprintf(ctx.Stderr, "%s %s\n", formatActionMessageTime(msg.Timestamp), msg.Message)
+}
+
+func formatActionMessageTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
 // fetchResult queries the given API for the given Action ID prefix, and
 // makes sure the results are acceptable, returning an error if they are not.
 func fetchResult(api APIClient, requestedId string) (params.ActionResult, error) {
@@ -205,6 +299,13 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		log := make([]string, len(result.Log))
+		for i, msg := range result.Log {
+			log[i] = formatActionMessageTime(msg.Timestamp) + " " + msg.Message
+		}
+		response["log"] = log
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
@@ -40,6 +40,10 @@ func (s *ShowOutputSuite) TestInit(c *gc.C) {
 		should:      "fail with multiple args",
 		args:        []string{"12345", "54321"},
 		expectError: `unrecognized args: \["54321"\]`,
+	}, {
+		should:      "fail with both --follow and --wait",
+		args:        []string{"12345", "--follow", "--wait", "1m"},
+		expectError: "cannot specify both --follow and --wait",
 	}}
 
 	for i, t := range tests {
@@ -290,6 +294,71 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestFollow(c *gc.C) {
+	logged := func(minute int, message string) params.ActionMessage {
+		return params.ActionMessage{
+			Timestamp: time.Date(2015, time.February, 14, 8, minute, 0, 0, time.UTC),
+			Message:   message,
+		}
+	}
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{"outfile": "dump.sql"},
+			Log: []params.ActionMessage{
+				logged(16, "dumping table users"),
+				logged(17, "dumping table posts"),
+				logged(18, "compressing"),
+			},
+		}},
+		params.ActionsByNames{}, "",
+	)
+	// The last message is only in the action's result, as if it was
+	// logged just before the action finished.
+	client.progress = make(chan []string, 1)
+	client.progress <- []string{
+		`{"timestamp":"2015-02-14T08:16:00Z","message":"dumping table users"}`,
+		`{"timestamp":"2015-02-14T08:17:00Z","message":"dumping table posts"}`,
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--follow")
+	c.Assert(err, gc.IsNil)
+	c.Check(client.watchedProgress, gc.Equals, validActionId)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+2015-02-14T08:16:00Z dumping table users
+2015-02-14T08:17:00Z dumping table posts
+2015-02-14T08:18:00Z compressing
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+log:
+- 2015-02-14T08:16:00Z dumping table users
+- 2015-02-14T08:17:00Z dumping table posts
+- 2015-02-14T08:18:00Z compressing
+results:
+  outfile: dump.sql
+status: completed
+`[1:])
+}
+
+func (s *ShowOutputSuite) TestFollowAPIError(c *gc.C) {
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		nil, params.ActionsByNames{}, "api call error",
+	)
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--follow")
+	c.Assert(err, gc.ErrorMatches, "api call error")
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
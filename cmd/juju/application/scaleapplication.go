@@ -44,6 +44,9 @@ Scale a Kubernetes application by specifying how many units there should be.
 The new number of units can be greater or less than the current number, thus
 allowing both scale up and scale down.
 
+Applications deployed as a daemon run a unit on every node in the
+cluster, so cannot be scaled.
+
 Examples:
 
     juju scale-application mariadb 2
@@ -19,6 +19,15 @@ const (
 	trustSummary = `Sets the trust status of a deployed application to true.`
 	trustDetails = `Sets the trust configuration value to true.
 
+On Kubernetes models, only trusted applications may run privileged
+containers, allow privilege escalation, add Linux capabilities, run as
+the root user, use an existing service account, have a global service
+account or have service account rules for secrets or all ("*")
+resources. If trust is removed from an application, the roles granted
+to its service account are deleted, any of its pods with privileges
+over their node are stopped, and its workload is not updated until it
+is trusted again or its pod spec no longer asks for those privileges.
+
 Examples:
     juju trust media-wiki
 
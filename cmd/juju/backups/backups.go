@@ -6,7 +6,9 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -28,7 +30,7 @@ import (
 type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
-	Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
+	Create(notes string, keepCopy, noDownload bool, encryption *params.BackupsEncryption) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -99,10 +101,17 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "checksum format: %q\n", result.ChecksumFormat)
 	fmt.Fprintf(ctx.Stdout, "size (B):        %d\n", result.Size)
 	fmt.Fprintf(ctx.Stdout, "stored:          %v\n", result.Stored)
+	if result.StorageTarget != "" {
+		fmt.Fprintf(ctx.Stdout, "storage target:  %s\n", result.StorageTarget)
+	}
 
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	fmt.Fprintf(ctx.Stdout, "encrypted:       %t\n", result.Encrypted)
+	if result.Failure != "" {
+		fmt.Fprintf(ctx.Stdout, "failure:         %q\n", result.Failure)
+	}
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
@@ -183,3 +192,74 @@ var getArchive = func(filename string) (rc ArchiveReader, metaResult *params.Bac
 
 	return archive, metaResult, nil
 }
+
+// readKeyFile returns the contents of the named key file, or an empty
+// string if no file is named.
+func readKeyFile(filename string) (string, error) {
+	if filename == "" {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// readPassphraseFile returns the passphrase in the named file, without
+// any trailing newline, or an empty string if no file is named.
+func readPassphraseFile(filename string) (string, error) {
+	passphrase, err := readKeyFile(filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	passphrase = strings.TrimRight(passphrase, "\r\n")
+	if filename != "" && passphrase == "" {
+		return "", errors.Errorf("passphrase file %q is empty", filename)
+	}
+	return passphrase, nil
+}
+
+// decryptionHint tells the user how to restore an encrypted backup.
+const decryptionHint = "use --decryption-key-file or --passphrase-file"
+
+// decryptArchive decrypts the named backup archive, if it is encrypted,
+// into a temporary file. It returns the name of the file to restore
+// from and a function to remove any temporary file.
+var decryptArchive = func(filename string, dec *statebackups.Decryption) (string, func(), error) {
+	noCleanup := func() {}
+	archive, err := os.Open(filename)
+	if err != nil {
+		return "", noCleanup, errors.Trace(err)
+	}
+	defer archive.Close()
+
+	encrypted, err := statebackups.IsEncryptedArchive(archive)
+	if err != nil {
+		return "", noCleanup, errors.Trace(err)
+	}
+	if !encrypted {
+		return filename, noCleanup, nil
+	}
+	if dec == nil {
+		return "", noCleanup, errors.Errorf(
+			"backup archive %q is encrypted: %s", filename, decryptionHint)
+	}
+
+	decrypted, err := ioutil.TempFile("", "juju-backup-")
+	if err != nil {
+		return "", noCleanup, errors.Trace(err)
+	}
+	cleanup := func() { os.Remove(decrypted.Name()) }
+	// A tampered archive is only detected once it has been read to the
+	// end, so the decrypted file is only used if decryption succeeds.
+	err = statebackups.DecryptArchive(decrypted, archive, *dec)
+	if cerr := decrypted.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		cleanup()
+		return "", noCleanup, errors.Annotatef(err, "cannot decrypt backup archive %q", filename)
+	}
+	return decrypted.Name(), cleanup, nil
+}
@@ -35,9 +35,18 @@ Use --no-download to avoid getting a local copy of the backup downloaded
 at the end of the backup process.
 
 Use --keep-copy option to store a copy of backup remotely on the controller.
+Where remote copies are kept is set by the "backup-storage" controller
+config: in the controller database (the default), in a directory on the
+controller machine, or in an S3-compatible object store.
 
 Use --verbose to see extra information about backup.
 
+Use --encryption-key-file to encrypt the backup archive with an
+ASCII-armored OpenPGP public key, or --passphrase-file to encrypt it with
+the passphrase in the given file.  Encrypted archives are downloaded with
+a .gpg suffix and must be decrypted with the matching private key or
+passphrase when restored.
+
 To access remote backups stored on the controller, see 'juju download-backup'.
 
 Examples:
@@ -46,6 +55,7 @@ Examples:
     juju create-backup --no-download --keep-copy=false // ignores --keep-copy
     juju create-backup --keep-copy
     juju create-backup --verbose
+    juju create-backup --encryption-key-file backup-key.asc
 
 See also:
     backups
@@ -68,7 +78,13 @@ type createCommand struct {
 	Notes string
 	// KeepCopy means the backup archive should be stored in the controller db.
 	KeepCopy bool
-	fs       *gnuflag.FlagSet
+	// EncryptionKeyFile holds the public key to encrypt the archive with.
+	EncryptionKeyFile string
+	// PassphraseFile holds the passphrase to encrypt the archive with.
+	PassphraseFile string
+	fs             *gnuflag.FlagSet
+
+	encryption *params.BackupsEncryption
 }
 
 // Info implements Command.Info.
@@ -87,6 +103,8 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive, implies keep-copy")
 	f.BoolVar(&c.KeepCopy, "keep-copy", false, "Keep a copy of the archive on the controller")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.StringVar(&c.EncryptionKeyFile, "encryption-key-file", "", "Encrypt the archive with the OpenPGP public key in this file")
+	f.StringVar(&c.PassphraseFile, "passphrase-file", "", "Encrypt the archive with the passphrase in this file")
 	c.fs = f
 }
 
@@ -120,6 +138,28 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+
+	if c.EncryptionKeyFile != "" && c.PassphraseFile != "" {
+		return errors.Errorf("cannot mix --encryption-key-file and --passphrase-file")
+	}
+	if c.EncryptionKeyFile != "" || c.PassphraseFile != "" {
+		publicKey, err := readKeyFile(c.EncryptionKeyFile)
+		if err != nil {
+			return errors.Annotate(err, "reading encryption key")
+		}
+		passphrase, err := readPassphraseFile(c.PassphraseFile)
+		if err != nil {
+			return errors.Annotate(err, "reading passphrase")
+		}
+		encryption := backups.Encryption{PublicKey: publicKey, Passphrase: passphrase}
+		if err := encryption.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		c.encryption = &params.BackupsEncryption{
+			PublicKey:  publicKey,
+			Passphrase: passphrase,
+		}
+	}
 	return nil
 }
 
@@ -137,6 +177,10 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.encryption != nil && apiVersion < 3 {
+		return errors.New("encrypted backups are not supported by this controller")
+	}
+
 	if apiVersion < 2 {
 		if c.KeepCopy {
 			return errors.New("--keep-copy is not supported by this controller")
@@ -183,6 +227,9 @@ func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timest
 		return filename
 	}
 	// Downloading but no filename given, so generate one.
+	if c.encryption != nil {
+		return timestamp.Format(backups.FilenameTemplate) + backups.EncryptedFilenameSuffix
+	}
 	return timestamp.Format(backups.FilenameTemplate)
 }
 
@@ -208,7 +255,7 @@ func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom st
 }
 
 func (c *createCommand) create(client APIClient, apiVersion int) (*params.BackupsMetadataResult, string, error) {
-	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload, c.encryption)
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
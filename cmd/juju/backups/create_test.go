@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -16,8 +17,11 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	statebackups "github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 type createSuite struct {
@@ -282,3 +286,56 @@ func (s *createSuite) TestError(c *gc.C) {
 
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *createSuite) writeFile(c *gc.C, name, content string) string {
+	filename := filepath.Join(c.MkDir(), name)
+	err := ioutil.WriteFile(filename, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *createSuite) TestPassphraseFile(c *gc.C) {
+	s.apiVersion = 3
+	client := s.setDownload()
+	passphraseFile := s.writeFile(c, "passphrase", "secret\n")
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Create", "Download")
+	c.Check(client.encryption, jc.DeepEquals, &params.BackupsEncryption{Passphrase: "secret"})
+	s.checkDownload(c, ctx)
+	c.Check(s.filename, jc.HasSuffix, ".tar.gz"+statebackups.EncryptedFilenameSuffix)
+}
+
+func (s *createSuite) TestEncryptionKeyFile(c *gc.C) {
+	s.apiVersion = 3
+	client := s.setDownload()
+	publicKey, _ := backupstesting.NewEncryptionKeys(c)
+	keyFile := s.writeFile(c, "key.asc", publicKey)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--encryption-key-file", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Create", "Download")
+	c.Check(client.encryption, jc.DeepEquals, &params.BackupsEncryption{PublicKey: publicKey})
+	s.checkDownload(c, ctx)
+}
+
+func (s *createSuite) TestInvalidEncryptionKeyFile(c *gc.C) {
+	keyFile := s.writeFile(c, "key.asc", "not a key")
+	err := cmdtesting.InitCommand(s.wrappedCommand, []string{"--encryption-key-file", keyFile})
+	c.Check(err, gc.ErrorMatches, "cannot read public key: .*")
+}
+
+func (s *createSuite) TestEncryptionKeyAndPassphraseFile(c *gc.C) {
+	err := cmdtesting.InitCommand(s.wrappedCommand, []string{
+		"--encryption-key-file", "key.asc", "--passphrase-file", "passphrase",
+	})
+	c.Check(err, gc.ErrorMatches, "cannot mix --encryption-key-file and --passphrase-file")
+}
+
+func (s *createSuite) TestEncryptionV2Fail(c *gc.C) {
+	s.setDownload()
+	passphraseFile := s.writeFile(c, "passphrase", "secret")
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--passphrase-file", passphraseFile)
+	c.Check(err, gc.ErrorMatches, "encrypted backups are not supported by this controller")
+}
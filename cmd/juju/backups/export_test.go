@@ -16,9 +16,10 @@ const (
 )
 
 var (
-	NewAPIClient = &newAPIClient
-	NewGetAPI    = &getAPI
-	GetArchive   = &getArchive
+	NewAPIClient   = &newAPIClient
+	NewGetAPI      = &getAPI
+	GetArchive     = &getArchive
+	DecryptArchive = &decryptArchive
 )
 
 type CreateCommand struct {
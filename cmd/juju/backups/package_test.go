@@ -33,6 +33,7 @@ stored:          0001-01-01 00:00:00 +0000 UTC
 started:         0001-01-01 00:00:00 +0000 UTC
 finished:        0001-01-01 00:00:00 +0000 UTC
 notes:           ""
+encrypted:       false
 model ID:        ""
 machine ID:      ""
 created on host: ""
@@ -142,10 +143,11 @@ type fakeAPIClient struct {
 	archive    io.ReadCloser
 	err        error
 
-	calls []string
-	args  []string
-	idArg string
-	notes string
+	calls      []string
+	args       []string
+	idArg      string
+	notes      string
+	encryption *params.BackupsEncryption
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -162,10 +164,11 @@ func (f *fakeAPIClient) CheckArgs(c *gc.C, args ...string) {
 	c.Check(f.args, jc.DeepEquals, args)
 }
 
-func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool, encryption *params.BackupsEncryption) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
 	c.args = append(c.args, notes, fmt.Sprintf("%t", keepCopy), fmt.Sprintf("%t", noDownload))
 	c.notes = notes
+	c.encryption = encryption
 	if c.err != nil {
 		return nil, c.err
 	}
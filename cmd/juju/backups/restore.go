@@ -6,6 +6,8 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -21,6 +23,7 @@ import (
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/bootstrap"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 // NewRestoreCommand returns a command used to restore a backup.
@@ -38,6 +41,14 @@ type restoreCommand struct {
 
 	Filename string
 	BackupId string
+
+	// DecryptionKeyFile holds the private key to decrypt the archive with.
+	DecryptionKeyFile string
+	// PassphraseFile holds the passphrase the archive, or the private
+	// key, is encrypted with.
+	PassphraseFile string
+
+	decryption *statebackups.Decryption
 }
 
 // RestoreAPI is used to invoke various API calls.
@@ -72,6 +83,12 @@ machines will be added or removed during the restore process.
 Note: Extra care is needed to restore in an HA environment, please see
 https://docs.jujucharms.com/stable/controllers-backup for more information.
 
+If the backup archive is encrypted, use --decryption-key-file to give the
+ASCII-armored OpenPGP private key it was encrypted for, or --passphrase-file
+to give the passphrase it was encrypted with.  If the private key is itself
+protected by a passphrase, use both.  An archive that fails its integrity
+check is not restored.
+
 If the provided state cannot be restored, this command will fail with
 an explanation.
 `
@@ -91,6 +108,8 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "file", "", "Provide a file to be used as the backup")
 	f.StringVar(&c.BackupId, "id", "", "Provide the name of the backup to be restored")
+	f.StringVar(&c.DecryptionKeyFile, "decryption-key-file", "", "Decrypt the backup with the OpenPGP private key in this file")
+	f.StringVar(&c.PassphraseFile, "passphrase-file", "", "Decrypt the backup with the passphrase in this file")
 }
 
 // Init is where the preconditions for this command can be checked.
@@ -110,6 +129,21 @@ func (c *restoreCommand) Init(args []string) error {
 		}
 	}
 
+	if c.DecryptionKeyFile != "" || c.PassphraseFile != "" {
+		privateKey, err := readKeyFile(c.DecryptionKeyFile)
+		if err != nil {
+			return errors.Annotate(err, "reading decryption key")
+		}
+		passphrase, err := readPassphraseFile(c.PassphraseFile)
+		if err != nil {
+			return errors.Annotate(err, "reading passphrase")
+		}
+		c.decryption = &statebackups.Decryption{
+			PrivateKey: privateKey,
+			Passphrase: passphrase,
+		}
+	}
+
 	return nil
 }
 
@@ -176,12 +210,12 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	if c.Filename != "" {
 		// Read archive specified by the Filename
 		target = c.Filename
-		var err error
-		archive, meta, err = getArchive(c.Filename)
+		var closeArchive func()
+		archive, meta, closeArchive, err = c.openArchive(c.Filename)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		defer archive.Close()
+		defer closeArchive()
 	}
 
 	client, err := c.NewAPIClient()
@@ -190,9 +224,36 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.Filename == "" && c.decryption == nil {
+		// Fail early rather than have the controller try to
+		// restore an archive it can't read.
+		info, err := client.Info(c.BackupId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if info.Encrypted {
+			return errors.Errorf("backup %q is encrypted: %s", c.BackupId, decryptionHint)
+		}
+	}
+	if c.Filename == "" && c.decryption != nil {
+		// The controller can't decrypt a stored backup, so fetch it
+		// and restore from the decrypted archive instead.
+		filename, removeDownload, err := downloadArchive(client, c.BackupId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer removeDownload()
+		var closeArchive func()
+		archive, meta, closeArchive, err = c.openArchive(filename)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer closeArchive()
+	}
+
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
-	if c.Filename != "" {
+	if archive != nil {
 		err = client.RestoreReader(archive, meta, c.newClient)
 	} else {
 		err = client.Restore(c.BackupId, c.newClient)
@@ -203,3 +264,49 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	fmt.Fprintf(ctx.Stdout, "restore from %q completed\n", target)
 	return nil
 }
+
+// openArchive opens the named backup archive for restoring, decrypting
+// it first if necessary. The returned function closes the archive and
+// removes any decrypted copy.
+func (c *restoreCommand) openArchive(filename string) (ArchiveReader, *params.BackupsMetadataResult, func(), error) {
+	plainFilename, removeDecrypted, err := decryptArchive(filename, c.decryption)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	archive, meta, err := getArchive(plainFilename)
+	if err != nil {
+		removeDecrypted()
+		return nil, nil, nil, errors.Trace(err)
+	}
+	return archive, meta, func() {
+		archive.Close()
+		removeDecrypted()
+	}, nil
+}
+
+// downloadArchive downloads the identified backup archive into a
+// temporary file. It returns the name of the file and a function to
+// remove it.
+func downloadArchive(client APIClient, id string) (string, func(), error) {
+	noCleanup := func() {}
+	resultArchive, err := client.Download(id)
+	if err != nil {
+		return "", noCleanup, errors.Trace(err)
+	}
+	defer resultArchive.Close()
+
+	archive, err := ioutil.TempFile("", "juju-backup-")
+	if err != nil {
+		return "", noCleanup, errors.Trace(err)
+	}
+	cleanup := func() { os.Remove(archive.Name()) }
+	_, err = io.Copy(archive, resultArchive)
+	if cerr := archive.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		cleanup()
+		return "", noCleanup, errors.Annotatef(err, "while downloading backup %q", id)
+	}
+	return archive.Name(), cleanup, nil
+}
@@ -4,8 +4,12 @@
 package backups_test
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/cmd"
@@ -23,6 +27,7 @@ import (
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/lxd"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/testing"
 )
 
@@ -77,6 +82,11 @@ func (s *restoreSuite) patch(c *gc.C, archiveErr error) (*gomock.Controller, *Mo
 		},
 	)
 	archiveClient := NewMockArchiveReader(ctrl)
+	s.PatchValue(backups.DecryptArchive,
+		func(filename string, _ *statebackups.Decryption) (string, func(), error) {
+			return filename, func() {}, nil
+		},
+	)
 	s.PatchValue(backups.GetArchive,
 		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
 			return archiveClient, &params.BackupsMetadataResult{}, archiveErr
@@ -193,6 +203,7 @@ func (s *restoreSuite) TestRestoreFromBackupId(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
+		apiClient.EXPECT().Info("an_id").Return(&params.BackupsMetadataResult{ID: "an_id"}, nil),
 		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
 			nil,
 		),
@@ -209,6 +220,7 @@ func (s *restoreSuite) TestRestoreFromBackupIdFail(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
+		apiClient.EXPECT().Info("an_id").Return(&params.BackupsMetadataResult{ID: "an_id"}, nil),
 		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
 			errors.New("restore failed"),
 		),
@@ -218,6 +230,63 @@ func (s *restoreSuite) TestRestoreFromBackupIdFail(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "restore failed")
 }
 
+func (s *restoreSuite) TestRestoreFromEncryptedBackupId(c *gc.C) {
+	ctlr, apiClient, archiveReader, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	gomock.InOrder(
+		apiClient.EXPECT().Download("an_id").Return(
+			ioutil.NopCloser(strings.NewReader("<encrypted archive>")), nil,
+		),
+		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, gomock.Any()).Return(
+			nil,
+		),
+		archiveReader.EXPECT().Close(),
+		apiClient.EXPECT().Close(),
+	)
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("secret"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+	out := fmt.Sprintf("restore from %q completed\n", s.command.BackupId)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, out)
+}
+
+func (s *restoreSuite) TestRestoreFromEncryptedBackupIdWithoutKey(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	gomock.InOrder(
+		apiClient.EXPECT().Info("an_id").Return(&params.BackupsMetadataResult{
+			ID:        "an_id",
+			Encrypted: true,
+		}, nil),
+		apiClient.EXPECT().Close(),
+	)
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id")
+	c.Assert(err, gc.ErrorMatches, `backup "an_id" is encrypted: use --decryption-key-file or --passphrase-file`)
+}
+
+func (s *restoreSuite) TestRestoreDecryptFail(c *gc.C) {
+	ctlr, _, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	s.PatchValue(backups.DecryptArchive,
+		func(string, *statebackups.Decryption) (string, func(), error) {
+			return "", func() {}, errors.New("archive failed integrity check")
+		},
+	)
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--file", "afile")
+	c.Assert(err, gc.ErrorMatches, "archive failed integrity check")
+}
+
+func (s *restoreSuite) TestRestoreMissingPassphraseFile(c *gc.C) {
+	err := cmdtesting.InitCommand(s.wrappedCommand, []string{"--id", "an_id", "--passphrase-file", "missing"})
+	c.Assert(err, gc.ErrorMatches, "reading passphrase: open missing: .*")
+}
+
 func (s *restoreSuite) TestRestoreFromBackupGetArchiveFail(c *gc.C) {
 	ctlr, _, _, modelStatusClient := s.patch(c, errors.New("get archive fail"))
 	defer ctlr.Finish()
@@ -265,3 +334,73 @@ func (s *restoreSuite) TestRestoreFromBackupHAFail(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id")
 	c.Assert(err, gc.ErrorMatches, "unable to restore backup in HA configuration.  For help see https://docs.jujucharms.com/stable/controllers-backup")
 }
+
+type decryptArchiveSuite struct {
+	testing.BaseSuite
+	dir string
+}
+
+var _ = gc.Suite(&decryptArchiveSuite{})
+
+func (s *decryptArchiveSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+// archive is a stand-in for a backup archive, starting with the gzip
+// magic bytes.
+const archive = "\x1f\x8b<compressed tarball>"
+
+func (s *decryptArchiveSuite) writeEncrypted(c *gc.C, passphrase string) string {
+	filename := filepath.Join(s.dir, "backup.tar.gz.gpg")
+	var encrypted bytes.Buffer
+	err := statebackups.EncryptArchive(&encrypted, strings.NewReader(archive), statebackups.Encryption{Passphrase: passphrase})
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filename, encrypted.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *decryptArchiveSuite) TestPlainArchive(c *gc.C) {
+	filename := filepath.Join(s.dir, "backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte(archive), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	plainFilename, cleanup, err := (*backups.DecryptArchive)(filename, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer cleanup()
+	c.Check(plainFilename, gc.Equals, filename)
+}
+
+func (s *decryptArchiveSuite) TestDecrypt(c *gc.C) {
+	filename := s.writeEncrypted(c, "secret")
+
+	plainFilename, cleanup, err := (*backups.DecryptArchive)(filename, &statebackups.Decryption{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(plainFilename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, archive)
+
+	cleanup()
+	_, err = os.Stat(plainFilename)
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *decryptArchiveSuite) TestEncryptedWithoutKey(c *gc.C) {
+	filename := s.writeEncrypted(c, "secret")
+
+	_, _, err := (*backups.DecryptArchive)(filename, nil)
+	c.Check(err, gc.ErrorMatches, `backup archive ".*" is encrypted: use --decryption-key-file or --passphrase-file`)
+}
+
+func (s *decryptArchiveSuite) TestTampered(c *gc.C) {
+	filename := s.writeEncrypted(c, "secret")
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	data[len(data)-5] ^= 1
+	err = ioutil.WriteFile(filename, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = (*backups.DecryptArchive)(filename, &statebackups.Decryption{Passphrase: "secret"})
+	c.Check(err, gc.ErrorMatches, `cannot decrypt backup archive ".*": archive failed integrity check: .*`)
+}
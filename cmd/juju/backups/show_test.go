@@ -4,6 +4,8 @@
 package backups_test
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
@@ -35,6 +37,16 @@ func (s *showSuite) TestOkay(c *gc.C) {
 	s.checkStd(c, ctx, out, "")
 }
 
+func (s *showSuite) TestStorageTarget(c *gc.C) {
+	s.metaresult.StorageTarget = "s3"
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, s.metaresult.ID)
+	c.Check(err, jc.ErrorIsNil)
+
+	out := strings.Replace(MetaResultString, "started:", "storage target:  s3\nstarted:", 1)
+	s.checkStd(c, ctx, out, "")
+}
+
 func (s *showSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := cmdtesting.RunCommand(c, s.subcommand, s.metaresult.ID)
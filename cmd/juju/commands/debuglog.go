@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -40,6 +41,12 @@ Each log line is emitted in this format:
 
   <entity> <timestamp> <log-level> <module>:<line-no> <message>
 
+With '--format json', each log record is instead emitted as a single line
+JSON object with the keys "model", "model-uuid", "entity", "timestamp",
+"level", "module", "location" and "message". Messages spanning multiple
+lines are kept within the one object, making the output suitable for
+processing with tools such as jq.
+
 The "entity" is the source of the message: a machine or unit. The names for
 machines and units can be seen in the output of `[1:] + "`juju status`" + `.
 
@@ -95,6 +102,10 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show the messages of unit mysql/0 as JSON, extracting just the message text:
+
+    juju debug-log --include mysql/0 --format json | jq -r .message
+
 See also: 
     status
     ssh`
@@ -134,8 +145,17 @@ type debugLogCommand struct {
 
 	format string
 	tz     *time.Location
+
+	// outputFormat is either "text" or "json".
+	outputFormat string
+	modelName    string
 }
 
+const (
+	debugLogFormatText = "text"
+	debugLogFormatJSON = "json"
+)
+
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeEntity), "i", "Only show log messages for these entities")
@@ -161,6 +181,7 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.location, "location", false, "Show filename and line numbers")
 	f.BoolVar(&c.date, "date", false, "Show dates as well as times")
 	f.BoolVar(&c.ms, "ms", false, "Show times to millisecond precision")
+	f.StringVar(&c.outputFormat, "format", debugLogFormatText, `Specify output format ("text"|"json")`)
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -172,6 +193,12 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	switch c.outputFormat {
+	case debugLogFormatText, debugLogFormatJSON:
+	default:
+		return errors.Errorf("format value %q is not one of %q, %q",
+			c.outputFormat, debugLogFormatText, debugLogFormatJSON)
+	}
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
@@ -261,6 +288,19 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if c.outputFormat == debugLogFormatJSON {
+		if c.modelName, err = c.ModelName(); err != nil {
+			return errors.Trace(err)
+		}
+		encoder := json.NewEncoder(ctx.Stdout)
+		encoder.SetEscapeHTML(false)
+		for msg := range messages {
+			if err := c.writeJSONRecord(encoder, msg); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
 	writer := ansiterm.NewWriter(ctx.Stdout)
 	if c.color {
 		writer.SetColorCapable(true)
@@ -298,3 +338,29 @@ func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage
 	}
 	fmt.Fprintln(w, r.Message)
 }
+
+// jsonLogRecord is the form of each log record written by
+// debug-log --format json.
+type jsonLogRecord struct {
+	Model     string    `json:"model"`
+	ModelUUID string    `json:"model-uuid,omitempty"`
+	Entity    string    `json:"entity"`
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Module    string    `json:"module"`
+	Location  string    `json:"location,omitempty"`
+	Message   string    `json:"message"`
+}
+
+func (c *debugLogCommand) writeJSONRecord(encoder *json.Encoder, r common.LogMessage) error {
+	return encoder.Encode(jsonLogRecord{
+		Model:     c.modelName,
+		ModelUUID: r.ModelUUID,
+		Entity:    r.Entity,
+		Timestamp: r.Timestamp.In(c.tz),
+		Level:     r.Severity,
+		Module:    r.Module,
+		Location:  r.Location,
+		Message:   r.Message,
+	})
+}
@@ -98,6 +98,9 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 		}, {
 			args:     []string{"--no-tail", "--tail"},
 			errMatch: `setting --tail and --no-tail not valid`,
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
 		}, {
 			args: []string{"--limit", "100"},
 			expected: common.DebugLogParams{
@@ -184,6 +187,39 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
 }
 
+func (s *DebugLogSuite) TestJSONOutput(c *gc.C) {
+	tz := time.FixedZone("test", 6*60*60)
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Entity:    "machine-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 345000000, time.UTC),
+				Severity:  "INFO",
+				Module:    "test.module",
+				Location:  "somefile.go:123",
+				Message:   "this is the log output",
+			}, {
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Entity:    "unit-mysql-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 24, 0, time.UTC),
+				Severity:  "ERROR",
+				Module:    "unit.mysql/0.juju-log",
+				Message:   "it failed:\n<oops>",
+			},
+		}}, nil
+	})
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommandTZ(jujuclienttesting.MinimalStore(), tz), "--format", "json", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		`{"model":"king/sword","model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","entity":"machine-0",`+
+		`"timestamp":"2016-10-09T08:15:23.345Z","level":"INFO","module":"test.module",`+
+		`"location":"somefile.go:123","message":"this is the log output"}`+"\n"+
+		`{"model":"king/sword","model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","entity":"unit-mysql-0",`+
+		`"timestamp":"2016-10-09T08:15:24Z","level":"ERROR","module":"unit.mysql/0.juju-log",`+
+		`"message":"it failed:\n<oops>"}`+"\n")
+}
+
 type fakeDebugLogAPI struct {
 	log    []common.LogMessage
 	params common.DebugLogParams
@@ -379,11 +379,15 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 		r.Register(model.NewAddGenerationCommand())
 		r.Register(model.NewCancelGenerationCommand())
 		r.Register(model.NewAdvanceGenerationCommand())
+		r.Register(model.NewDiffGenerationCommand())
+		r.Register(model.NewPromoteGenerationCommand())
+		r.Register(model.NewShowGenerationPromotionCommand())
 		r.Register(model.NewSwitchGenerationCommand())
 	}
 
 	r.Register(newMigrateCommand())
 	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewExportModelCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
@@ -396,6 +400,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewSchedulesCommand())
+	r.Register(action.NewPauseScheduleCommand())
+	r.Register(action.NewResumeScheduleCommand())
+	r.Register(action.NewRemoveScheduleCommand())
+	r.Register(action.NewShowRolloutCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -458,6 +467,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
+	r.Register(controller.NewImportModelCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
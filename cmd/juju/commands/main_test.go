@@ -411,6 +411,7 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"action-schedules",
 	"actions",
 	"add-cloud",
 	"add-credential",
@@ -429,6 +430,7 @@ var commandNames = []string{
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -464,6 +466,7 @@ var commandNames = []string{
 	"enable-ha",
 	"enable-user",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -477,8 +480,10 @@ var commandNames = []string{
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"kill-controller",
+	"list-action-schedules",
 	"list-actions",
 	"list-agreements",
 	"list-backups",
@@ -514,12 +519,14 @@ var commandNames = []string{
 	"models",
 	"offer",
 	"offers",
+	"pause-action-schedule",
 	"payloads",
 	"plans",
 	"regions",
 	"register",
 	"relate", //alias for add-relation
 	"reload-spaces",
+	"remove-action-schedule",
 	"remove-application",
 	"remove-backup",
 	"remove-cached-images",
@@ -540,6 +547,7 @@ var commandNames = []string{
 	"resolve",
 	"resources",
 	"restore-backup",
+	"resume-action-schedule",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
@@ -559,6 +567,7 @@ var commandNames = []string{
 	"set-series",
 	"set-wallet",
 	"show-action-output",
+	"show-action-rollout",
 	"show-action-status",
 	"show-application",
 	"show-backup",
@@ -4,13 +4,25 @@
 package commands
 
 import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
@@ -27,13 +39,31 @@ type migrateCommand struct {
 	modelcmd.ModelCommandBase
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
+	statusAPI        migrationStatusAPI
 	targetController string
+	modelArgs        []string
+	dryRun           bool
+	maxConcurrent    int
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	InitiateMigrations(specs []controller.MigrationSpec, maxConcurrent int) (string, []controller.MigrationBatchResult, error)
+	MigrationBatchStatus(batchID string) (controller.MigrationBatchStatus, error)
+	DryRunMigration(spec controller.MigrationSpec) (controller.MigrationDryRunResult, error)
+	DryRunMigrations(specs []controller.MigrationSpec) ([]controller.MigrationDryRunResult, error)
+}
+
+// migrationStatusAPI is used to follow the progress of migrations
+// started in batch mode.
+type migrationStatusAPI interface {
+	ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error)
 }
 
+// migrationPollInterval is how often the progress of each migration is
+// checked in batch mode.
+var migrationPollInterval = 5 * time.Second
+
 const migrateDoc = `
 migrate begins the migration of a model from its current controller to
 a new controller. This is useful for load balancing when a controller
@@ -57,6 +87,44 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the migration isn't started. Instead every check that
+would be made before and during the migration is run against the model
+and both controllers, including a trial import of the model into the
+target controller, and every problem found is reported along with the
+charms, agent binaries and resources the migration would upload. The
+command fails if any problems are found.
+
+Several models can be migrated in one go by naming each of them, or
+by giving a pattern such as "prod-*" that is matched against model
+names (patterns without an owner match the current user's models). In
+this batch mode the models must all be migrated to the same target
+controller. The prechecks are run for every model before any migration
+is started, and nothing is migrated if any of them fail. The controller
+then runs the migrations, with at most --max-concurrent in progress at
+a time, starting the queued ones as earlier migrations finish. The
+command follows the migrations until they have all finished, reporting
+each model's progress as it goes. Interrupting the command doesn't
+stop the batch: the controller carries on with the remaining
+migrations, whose progress can be tracked with the "models" and
+"status" commands.
+
+Models with cross-model relations (consuming or offering applications
+with active connections) can't be migrated, even in the same batch as
+the models at the other end of their relations: offers and remote
+applications aren't moved to the target controller.
+
+Models with action schedules, or with actions still being rolled out
+across an application's units, can't be migrated either: schedules and
+rollouts aren't moved to the target controller. Remove the schedules
+with "remove-action-schedule" before migrating and add them again
+afterwards.
+
+Examples:
+    juju migrate mymodel other-controller
+    juju migrate --dry-run mymodel other-controller
+    juju migrate web db cache other-controller
+    juju migrate --max-concurrent 10 'prod-*' other-controller
+
 See also:
     login
     controllers
@@ -67,12 +135,19 @@ See also:
 func (c *migrateCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
+		Args:    "<model-name>... <target-controller-name>",
 		Purpose: "Migrate a hosted model to another controller.",
 		Doc:     migrateDoc,
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check the migration and report all problems without starting it")
+	f.IntVar(&c.maxConcurrent, "max-concurrent", 5, "Maximum number of migrations in progress at once in batch mode")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -81,15 +156,25 @@ func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 2 {
 		return errors.New("target controller not specified")
 	}
-	if len(args) > 2 {
-		return errors.New("too many arguments specified")
+	if c.maxConcurrent < 1 {
+		return errors.Errorf("--max-concurrent must be at least 1, got %d", c.maxConcurrent)
 	}
 
-	c.SetModelName(args[0], false)
-	c.targetController = args[1]
+	c.modelArgs = args[:len(args)-1]
+	c.SetModelName(c.modelArgs[0], false)
+	c.targetController = args[len(args)-1]
 	return nil
 }
 
+// isBatch returns whether more than one model may be migrated.
+func (c *migrateCommand) isBatch() bool {
+	return len(c.modelArgs) > 1 || isModelPattern(c.modelArgs[0])
+}
+
+func isModelPattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	store := c.ClientStore()
 
@@ -128,6 +213,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.isBatch() {
+		return c.runBatch(ctx, *spec)
+	}
 	modelName, err := c.ModelName()
 	if err != nil {
 		return errors.Trace(err)
@@ -141,6 +229,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.runDryRun(ctx, api, *spec)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -149,6 +240,365 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, api migrateAPI, spec controller.MigrationSpec) error {
+	result, err := api.DryRunMigration(spec)
+	if err != nil {
+		return err
+	}
+	writeDryRunReport(ctx.Stdout, result)
+	if problems := len(result.SourceProblems) + len(result.TargetProblems); problems > 0 {
+		return errors.Errorf("migration dry run found %d problem(s)", problems)
+	}
+	ctx.Infof("Migration dry run passed, no migration started")
+	return nil
+}
+
+func writeDryRunReport(w io.Writer, result controller.MigrationDryRunResult) {
+	writeDryRunSection(w, "Source controller checks", result.SourceProblems, "passed")
+	writeDryRunSection(w, "Target controller checks", result.TargetProblems, "passed")
+	writeDryRunSection(w, "Charms to upload", result.Charms, "none")
+	writeDryRunSection(w, "Agent binaries to upload", result.Tools, "none")
+	writeDryRunSection(w, "Resources to upload", result.Resources, "none")
+}
+
+func writeDryRunSection(w io.Writer, heading string, items []string, empty string) {
+	fmt.Fprintf(w, "%s:\n", heading)
+	if len(items) == 0 {
+		fmt.Fprintf(w, "  %s\n", empty)
+	}
+	for _, item := range items {
+		fmt.Fprintf(w, "  %s\n", item)
+	}
+}
+
+// batchModel identifies a model migrated in batch mode.
+type batchModel struct {
+	name string
+	uuid string
+}
+
+// resolveModels returns the models named by the command's arguments,
+// expanding any patterns, in the order given.
+func (c *migrateCommand) resolveModels() ([]batchModel, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	store := c.ClientStore()
+	var (
+		models []batchModel
+		seen   = set.NewStrings()
+		all    map[string]jujuclient.ModelDetails
+	)
+	add := func(name, uuid string) {
+		if !seen.Contains(uuid) {
+			seen.Add(uuid)
+			models = append(models, batchModel{name: name, uuid: uuid})
+		}
+	}
+	for _, arg := range c.modelArgs {
+		argController, name := modelcmd.SplitModelName(arg)
+		if argController != "" && argController != controllerName {
+			return nil, errors.Errorf("model %q isn't on controller %q, models must be migrated from a single controller", arg, controllerName)
+		}
+		if !isModelPattern(name) {
+			uuids, err := c.ModelUUIDs([]string{name})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			add(name, uuids[0])
+			continue
+		}
+
+		if all == nil {
+			if err := c.RefreshModels(store, controllerName); err != nil {
+				return nil, errors.Annotate(err, "refreshing models")
+			}
+			if all, err = store.AllModels(controllerName); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if !jujuclient.IsQualifiedModelName(name) {
+			account, err := store.AccountDetails(controllerName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			name = jujuclient.JoinOwnerModelName(names.NewUserTag(account.User), name)
+		}
+		var matched []string
+		for modelName := range all {
+			if ok, err := path.Match(name, modelName); err != nil {
+				return nil, errors.Annotatef(err, "invalid model pattern %q", arg)
+			} else if ok {
+				matched = append(matched, modelName)
+			}
+		}
+		if len(matched) == 0 {
+			return nil, errors.Errorf("no models match %q", arg)
+		}
+		sort.Strings(matched)
+		for _, modelName := range matched {
+			add(modelName, all[modelName].ModelUUID)
+		}
+	}
+	return models, nil
+}
+
+// runBatch migrates several models. All the prechecks are run first,
+// and no migrations are started unless every model passes them. The
+// controller then runs the migrations, and the command follows their
+// progress until they have all finished.
+func (c *migrateCommand) runBatch(ctx *cmd.Context, spec controller.MigrationSpec) error {
+	models, err := c.resolveModels()
+	if err != nil {
+		return err
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	specs := make([]controller.MigrationSpec, len(models))
+	for i, model := range models {
+		specs[i] = spec
+		specs[i].ModelUUID = model.uuid
+	}
+
+	results, err := api.DryRunMigrations(specs)
+	if err != nil {
+		return errors.Annotate(err, "running prechecks")
+	}
+	failed := 0
+	for i, result := range results {
+		if c.dryRun {
+			fmt.Fprintf(ctx.Stdout, "Model %s:\n", models[i].name)
+			if result.Error == nil {
+				writeDryRunReport(ctx.Stdout, result)
+			}
+		}
+		var problems []string
+		problems = append(problems, result.SourceProblems...)
+		problems = append(problems, result.TargetProblems...)
+		if result.Error != nil {
+			problems = append(problems, result.Error.Error())
+			if c.dryRun {
+				fmt.Fprintf(ctx.Stdout, "  %v\n", result.Error)
+			}
+		}
+		if len(problems) == 0 {
+			continue
+		}
+		failed++
+		if !c.dryRun {
+			for _, problem := range problems {
+				fmt.Fprintf(ctx.Stdout, "%s: %s\n", models[i].name, problem)
+			}
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("prechecks failed for %d of %d models, no migrations started", failed, len(models))
+	}
+	ctx.Infof("Prechecks passed for %d models", len(models))
+	if c.dryRun {
+		return nil
+	}
+
+	statusAPI, err := c.getStatusAPI()
+	if err != nil {
+		return err
+	}
+	// Earlier migrations of the models are ignored when following
+	// the progress of the batch.
+	previous, err := latestMigrationStarts(statusAPI, models)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	batchID, started, err := api.InitiateMigrations(specs, c.maxConcurrent)
+	if err != nil {
+		return errors.Annotate(err, "starting migrations")
+	}
+	if batchID == "" {
+		// The controller's prechecks failed, so nothing was
+		// started.
+		failed = 0
+		for i, result := range started {
+			if result.Error != nil {
+				failed++
+				fmt.Fprintf(ctx.Stdout, "%s: %v\n", models[i].name, result.Error)
+			}
+		}
+		return errors.Errorf("prechecks failed for %d of %d models, no migrations started", failed, len(models))
+	}
+
+	batch := newBatchProgress(ctx.Stdout, models, previous)
+	for i, result := range started {
+		switch {
+		case result.Error != nil:
+			batch.fail(models[i], result.Error)
+		case result.MigrationId != "":
+			batch.report(models[i], "migration started with ID %q", result.MigrationId)
+		default:
+			batch.report(models[i], "queued")
+		}
+	}
+	for batch.remaining() > 0 {
+		<-time.After(migrationPollInterval)
+		if err := batch.update(api, statusAPI, batchID); err != nil {
+			return errors.Annotate(err, "checking migration progress")
+		}
+	}
+	if batch.failed > 0 {
+		return errors.Errorf("%d of %d migrations failed", batch.failed, len(models))
+	}
+	ctx.Infof("Migrated %d models", len(models))
+	return nil
+}
+
+// latestMigrationStarts returns the start times of the models' latest
+// migrations, keyed by model UUID. Models which have never been
+// migrated have nil start times.
+func latestMigrationStarts(statusAPI migrationStatusAPI, models []batchModel) (map[string]*time.Time, error) {
+	tags := make([]names.ModelTag, len(models))
+	for i, model := range models {
+		tags[i] = names.NewModelTag(model.uuid)
+	}
+	results, err := statusAPI.ModelInfo(tags)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting model details")
+	}
+	starts := make(map[string]*time.Time)
+	for i, result := range results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "getting details of model %s", models[i].name)
+		}
+		if result.Result.Migration != nil {
+			starts[models[i].uuid] = result.Result.Migration.Start
+		}
+	}
+	return starts, nil
+}
+
+// batchProgress follows the progress of the migrations in a batch,
+// reporting each model's progress as it goes.
+type batchProgress struct {
+	out      io.Writer
+	models   []batchModel
+	previous map[string]*time.Time
+	status   map[string]string
+	done     set.Strings
+	failed   int
+}
+
+func newBatchProgress(out io.Writer, models []batchModel, previous map[string]*time.Time) *batchProgress {
+	return &batchProgress{
+		out:      out,
+		models:   models,
+		previous: previous,
+		status:   make(map[string]string),
+		done:     set.NewStrings(),
+	}
+}
+
+func (b *batchProgress) report(model batchModel, format string, args ...interface{}) {
+	fmt.Fprintf(b.out, "%s: %s\n", model.name, fmt.Sprintf(format, args...))
+}
+
+func (b *batchProgress) fail(model batchModel, err error) {
+	b.report(model, "failed: %v", err)
+	b.done.Add(model.uuid)
+	b.failed++
+}
+
+func (b *batchProgress) succeed(model batchModel) {
+	b.report(model, "migrated")
+	b.done.Add(model.uuid)
+}
+
+// remaining returns the number of models whose migrations haven't
+// finished yet.
+func (b *batchProgress) remaining() int {
+	return len(b.models) - len(b.done)
+}
+
+// update checks on the batch's queued migrations, and on the progress
+// of the migrations which have been started.
+func (b *batchProgress) update(api migrateAPI, statusAPI migrationStatusAPI, batchID string) error {
+	batchStatus, err := api.MigrationBatchStatus(batchID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	queued := set.NewStrings(batchStatus.Queued...)
+	var (
+		models []batchModel
+		tags   []names.ModelTag
+	)
+	for _, model := range b.models {
+		switch {
+		case b.done.Contains(model.uuid), queued.Contains(model.uuid):
+		case batchStatus.Failed[model.uuid] != nil:
+			b.fail(model, batchStatus.Failed[model.uuid])
+		default:
+			models = append(models, model)
+			tags = append(tags, names.NewModelTag(model.uuid))
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	results, err := statusAPI.ModelInfo(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i, result := range results {
+		model := models[i]
+		if err := result.Error; err != nil {
+			// Once the migration is complete, the model is
+			// removed from the source controller.
+			if params.IsCodeNotFound(err) {
+				b.succeed(model)
+				continue
+			}
+			return errors.Trace(err)
+		}
+		migration := result.Result.Migration
+		if migration == nil || sameTime(migration.Start, b.previous[model.uuid]) {
+			// The migration hasn't been started yet.
+			continue
+		}
+		if migration.Status != b.status[model.uuid] {
+			b.status[model.uuid] = migration.Status
+			b.report(model, "%s", migration.Status)
+		}
+		// The model has been activated on the target controller
+		// once the migration is successful, even if removing it
+		// from the source controller fails.
+		if strings.HasPrefix(migration.Status, "successful") {
+			b.succeed(model)
+		} else if migration.End != nil {
+			b.fail(model, errors.New("migration did not succeed"))
+		}
+	}
+	return nil
+}
+
+func sameTime(t1, t2 *time.Time) bool {
+	if t1 == nil || t2 == nil {
+		return t1 == t2
+	}
+	return t1.Equal(*t2)
+}
+
+func (c *migrateCommand) getStatusAPI() (migrationStatusAPI, error) {
+	if c.statusAPI != nil {
+		return c.statusAPI, nil
+	}
+	apiRoot, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(apiRoot), nil
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -6,12 +6,16 @@ package commands
 import (
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
@@ -19,6 +23,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
@@ -75,6 +80,7 @@ func (s *MigrateSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.api = &fakeMigrateAPI{}
+	s.PatchValue(&migrationPollInterval, time.Millisecond)
 	s.modelAPI = &fakeModelAPI{
 		models: []base.UserModel{{
 			Name:  "model",
@@ -136,9 +142,9 @@ func (s *MigrateSuite) TestMissingTargetController(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "target controller not specified")
 }
 
-func (s *MigrateSuite) TestTooManyArgs(c *gc.C) {
-	_, err := s.makeAndRun(c, "one", "too", "many")
-	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+func (s *MigrateSuite) TestBadMaxConcurrent(c *gc.C) {
+	_, err := s.makeAndRun(c, "--max-concurrent", "0", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--max-concurrent must be at least 1, got 0")
 }
 
 func (s *MigrateSuite) TestSuccess(c *gc.C) {
@@ -219,6 +225,223 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	s.api.dryRunResult = controller.MigrationDryRunResult{
+		Charms: []string{"cs:xenial/mysql-1"},
+		Tools:  []string{"2.5.0-xenial-amd64"},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.dryRunSeen, jc.IsTrue)
+	c.Check(s.api.specSeen.ModelUUID, gc.Equals, modelUUID)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Source controller checks:
+  passed
+Target controller checks:
+  passed
+Charms to upload:
+  cs:xenial/mysql-1
+Agent binaries to upload:
+  2.5.0-xenial-amd64
+Resources to upload:
+  none
+`[1:])
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration dry run passed, no migration started\n")
+}
+
+func (s *MigrateSuite) TestDryRunProblems(c *gc.C) {
+	s.api.dryRunResult = controller.MigrationDryRunResult{
+		SourceProblems: []string{"machine 0 is dying", "cleanup needed"},
+		TargetProblems: []string{"model named \"model\" already exists"},
+		Resources:      []string{"mysql/data"},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `migration dry run found 3 problem\(s\)`)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Source controller checks:
+  machine 0 is dying
+  cleanup needed
+Target controller checks:
+  model named "model" already exists
+Charms to upload:
+  none
+Agent binaries to upload:
+  none
+Resources to upload:
+  mysql/data
+`[1:])
+}
+
+func (s *MigrateSuite) TestBatch(c *gc.C) {
+	s.api.statuses = map[string][]params.ModelInfoResult{
+		modelUUID:     {migrationStatus("importing model into target controller", false)},
+		"prod-2-uuid": {migrationStatus("successful, removing model from source controller", false)},
+	}
+	ctx, err := s.makeAndRun(c, "model", "production", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.api.dryRunSpecs, gc.HasLen, 2)
+	c.Check(s.api.dryRunSpecs[0].ModelUUID, gc.Equals, modelUUID)
+	c.Check(s.api.dryRunSpecs[1].ModelUUID, gc.Equals, "prod-2-uuid")
+	c.Check(s.api.dryRunSpecs[1].TargetControllerUUID, gc.Equals, targetControllerUUID)
+	c.Check(s.api.started, jc.SameContents, []string{modelUUID, "prod-2-uuid"})
+
+	output := strings.Split(cmdtesting.Stdout(ctx), "\n")
+	c.Check(output, jc.SameContents, []string{
+		`model: migration started with ID "uuid:0"`,
+		"model: importing model into target controller",
+		"model: migrated",
+		`production: migration started with ID "uuid:0"`,
+		"production: successful, removing model from source controller",
+		"production: migrated",
+		"",
+	})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Prechecks passed for 2 models\nMigrated 2 models\n")
+}
+
+func (s *MigrateSuite) TestBatchPattern(c *gc.C) {
+	_, err := s.makeAndRun(c, "prod*", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	// Unqualified patterns only match the current user's models.
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-2-uuid"})
+}
+
+func (s *MigrateSuite) TestBatchQualifiedPattern(c *gc.C) {
+	_, err := s.makeAndRun(c, "*/production", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.dryRunSpecs, gc.HasLen, 3)
+	var uuids []string
+	for _, spec := range s.api.dryRunSpecs {
+		uuids = append(uuids, spec.ModelUUID)
+	}
+	c.Check(uuids, jc.DeepEquals, []string{"prod-1-uuid", "prod-2-uuid", modelUUID})
+}
+
+func (s *MigrateSuite) TestBatchPatternNoMatch(c *gc.C) {
+	_, err := s.makeAndRun(c, "staging-*", "target")
+	c.Assert(err, gc.ErrorMatches, `no models match "staging-\*"`)
+	c.Check(s.api.dryRunSpecs, gc.HasLen, 0)
+}
+
+func (s *MigrateSuite) TestBatchPrechecksFail(c *gc.C) {
+	s.api.dryRunResults = map[string]controller.MigrationDryRunResult{
+		"prod-2-uuid": {
+			SourceProblems: []string{"machine 0 is dying"},
+			TargetProblems: []string{"model named \"production\" already exists"},
+		},
+	}
+	ctx, err := s.makeAndRun(c, "model", "production", "target")
+	c.Assert(err, gc.ErrorMatches, "prechecks failed for 1 of 2 models, no migrations started")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+production: machine 0 is dying
+production: model named "production" already exists
+`[1:])
+	c.Check(s.api.started, gc.HasLen, 0)
+}
+
+func (s *MigrateSuite) TestBatchDryRun(c *gc.C) {
+	s.api.dryRunResults = map[string]controller.MigrationDryRunResult{
+		modelUUID: {Charms: []string{"cs:xenial/mysql-1"}},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "production", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model model:
+Source controller checks:
+  passed
+Target controller checks:
+  passed
+Charms to upload:
+  cs:xenial/mysql-1
+Agent binaries to upload:
+  none
+Resources to upload:
+  none
+Model production:
+Source controller checks:
+  passed
+Target controller checks:
+  passed
+Charms to upload:
+  none
+Agent binaries to upload:
+  none
+Resources to upload:
+  none
+`[1:])
+	c.Check(s.api.started, gc.HasLen, 0)
+}
+
+func (s *MigrateSuite) TestBatchMigrationFails(c *gc.C) {
+	s.api.statuses = map[string][]params.ModelInfoResult{
+		modelUUID: {
+			migrationStatus("importing model into target controller", false),
+			migrationStatus("aborted, removing model from target controller: boom", true),
+		},
+	}
+	ctx, err := s.makeAndRun(c, "model", "production", "target")
+	c.Assert(err, gc.ErrorMatches, "1 of 2 migrations failed")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains,
+		"model: aborted, removing model from target controller: boom\n")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains,
+		"model: failed: migration did not succeed\n")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "production: migrated\n")
+}
+
+func (s *MigrateSuite) TestBatchMaxConcurrent(c *gc.C) {
+	s.api.statuses = map[string][]params.ModelInfoResult{
+		modelUUID:     {migrationStatus("exporting model", false)},
+		"prod-1-uuid": {migrationStatus("exporting model", false)},
+		"prod-2-uuid": {migrationStatus("exporting model", false)},
+	}
+	ctx, err := s.makeAndRun(c, "--max-concurrent", "1", "model", "*/production", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.maxConcurrent, gc.Equals, 1)
+	c.Check(s.api.started, jc.DeepEquals, []string{modelUUID, "prod-1-uuid", "prod-2-uuid"})
+	c.Check(s.api.maxRunning, gc.Equals, 1)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "alpha/production: queued\n")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "alpha/production: exporting model\n")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "alpha/production: migrated\n")
+}
+
+func (s *MigrateSuite) TestBatchControllerPrechecksFail(c *gc.C) {
+	s.api.precheckErrs = map[string]error{
+		"prod-2-uuid": errors.New("application mysql is related to model alpha/production, which isn't being migrated with it"),
+	}
+	ctx, err := s.makeAndRun(c, "model", "production", "target")
+	c.Assert(err, gc.ErrorMatches, "prechecks failed for 1 of 2 models, no migrations started")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+production: application mysql is related to model alpha/production, which isn't being migrated with it
+`[1:])
+	c.Check(s.api.started, gc.HasLen, 0)
+}
+
+func (s *MigrateSuite) TestBatchQueuedMigrationFailsToStart(c *gc.C) {
+	s.api.startErrs = map[string]error{
+		"prod-2-uuid": errors.New("failed to create migration: model is not alive"),
+	}
+	ctx, err := s.makeAndRun(c, "--max-concurrent", "1", "model", "production", "target")
+	c.Assert(err, gc.ErrorMatches, "1 of 2 migrations failed")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "model: migrated\n")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains,
+		"production: failed: failed to create migration: model is not alive\n")
+}
+
+func (s *MigrateSuite) TestBatchIgnoresEarlierMigrations(c *gc.C) {
+	// The model was migrated before, and the migration was aborted.
+	earlier := migrationStatus("aborted", true)
+	s.api.started = []string{modelUUID}
+	s.api.statuses = map[string][]params.ModelInfoResult{
+		modelUUID: {earlier, earlier, migrationStatus("exporting model", false)},
+	}
+	ctx, err := s.makeAndRun(c, "mod*", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "aborted")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "model: migrated\n")
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -229,6 +452,7 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 	cmd.SetModelAPI(s.modelAPI)
 	inner := modelcmd.InnerCommand(cmd).(*migrateCommand)
 	inner.api = s.api
+	inner.statusAPI = s.api
 	inner.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
 		return s.targetControllerAPI, nil
 	}
@@ -236,7 +460,22 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen     *controller.MigrationSpec
+	dryRunSeen   bool
+	dryRunResult controller.MigrationDryRunResult
+
+	// Used in batch mode.
+	dryRunSpecs   []controller.MigrationSpec
+	dryRunResults map[string]controller.MigrationDryRunResult
+	precheckErrs  map[string]error
+	startErrs     map[string]error
+	maxConcurrent int
+	queued        []string
+	started       []string
+	failed        map[string]error
+	statuses      map[string][]params.ModelInfoResult
+	running       int
+	maxRunning    int
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -244,6 +483,117 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+// InitiateMigrations queues the models' migrations, and starts as many
+// as it can, as the controller does.
+func (a *fakeMigrateAPI) InitiateMigrations(specs []controller.MigrationSpec, maxConcurrent int) (string, []controller.MigrationBatchResult, error) {
+	results := make([]controller.MigrationBatchResult, len(specs))
+	failed := false
+	for i, spec := range specs {
+		results[i].ModelUUID = spec.ModelUUID
+		results[i].Error = a.precheckErrs[spec.ModelUUID]
+		failed = failed || results[i].Error != nil
+	}
+	if failed {
+		return "", results, nil
+	}
+	a.maxConcurrent = maxConcurrent
+	a.failed = make(map[string]error)
+	for _, spec := range specs {
+		a.queued = append(a.queued, spec.ModelUUID)
+	}
+	a.startQueued()
+	for i, spec := range specs {
+		if err := a.failed[spec.ModelUUID]; err != nil {
+			results[i].Error = err
+		} else if set.NewStrings(a.started...).Contains(spec.ModelUUID) {
+			results[i].MigrationId = "uuid:0"
+		}
+	}
+	return "1", results, nil
+}
+
+func (a *fakeMigrateAPI) startQueued() {
+	for len(a.queued) > 0 && a.running < a.maxConcurrent {
+		uuid := a.queued[0]
+		a.queued = a.queued[1:]
+		if err := a.startErrs[uuid]; err != nil {
+			a.failed[uuid] = err
+			continue
+		}
+		a.started = append(a.started, uuid)
+		a.running++
+		if a.running > a.maxRunning {
+			a.maxRunning = a.running
+		}
+	}
+}
+
+func (a *fakeMigrateAPI) MigrationBatchStatus(batchID string) (controller.MigrationBatchStatus, error) {
+	a.startQueued()
+	return controller.MigrationBatchStatus{
+		Queued: append([]string(nil), a.queued...),
+		Failed: a.failed,
+	}, nil
+}
+
+func (a *fakeMigrateAPI) DryRunMigrations(specs []controller.MigrationSpec) ([]controller.MigrationDryRunResult, error) {
+	a.dryRunSpecs = specs
+	results := make([]controller.MigrationDryRunResult, len(specs))
+	for i, spec := range specs {
+		results[i] = a.dryRunResults[spec.ModelUUID]
+		results[i].ModelUUID = spec.ModelUUID
+	}
+	return results, nil
+}
+
+// ModelInfo returns the statuses set up for each started model in
+// turn, finishing with the model not being found once it's been
+// migrated. Models whose migrations haven't been started have never
+// been migrated.
+func (a *fakeMigrateAPI) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
+	started := set.NewStrings(a.started...)
+	results := make([]params.ModelInfoResult, len(tags))
+	for i, tag := range tags {
+		uuid := tag.Id()
+		if !started.Contains(uuid) {
+			results[i] = params.ModelInfoResult{Result: &params.ModelInfo{}}
+			continue
+		}
+		statuses := a.statuses[uuid]
+		if len(statuses) == 0 {
+			a.running--
+			results[i] = params.ModelInfoResult{
+				Error: &params.Error{Code: params.CodeNotFound, Message: "model not found"},
+			}
+			continue
+		}
+		a.statuses[uuid] = statuses[1:]
+		results[i] = statuses[0]
+		if results[i].Result.Migration.End != nil {
+			a.running--
+		}
+	}
+	return results, nil
+}
+
+func migrationStatus(status string, done bool) params.ModelInfoResult {
+	start := time.Now()
+	migration := &params.ModelMigrationStatus{Status: status, Start: &start}
+	if done {
+		end := time.Now()
+		migration.End = &end
+	}
+	return params.ModelInfoResult{
+		Result: &params.ModelInfo{Migration: migration},
+	}
+}
+
+func (a *fakeMigrateAPI) DryRunMigration(spec controller.MigrationSpec) (controller.MigrationDryRunResult, error) {
+	a.specSeen = &spec
+	a.dryRunSeen = true
+	return a.dryRunResult, nil
+}
+
 type fakeModelAPI struct {
 	models []base.UserModel
 }
@@ -0,0 +1,300 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const auditLogHelpDoc = `
+Shows the audited conversations recorded by the controller, across all
+of its machines. Each conversation is a command run by a client, along
+with the API calls it made. Querying the audit log requires superuser
+access to the controller.
+
+Conversations can be filtered by the user who ran them, the model they
+targeted, the API methods they called and the time they started. Methods
+are given as Facade.Method, or just Facade to match any method on that
+facade. Times are given in RFC3339 format, or as a date (YYYY-MM-DD), or
+as a duration relative to now (e.g. 2h).
+
+With --follow, new conversations are shown as they are recorded until
+the command is interrupted.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --errors-only
+    juju audit-log --method Application.Deploy --from 2018-10-01
+    juju audit-log --model-uuid deadbeef-0bad-400d-8000-4b1d0d06f00d --from 2h
+    juju audit-log --follow --format json
+
+See also:
+    controller-config
+`
+
+// defaultAuditLogPollInterval is how often the audit log is queried
+// for new conversations when following.
+const defaultAuditLogPollInterval = 5 * time.Second
+
+// NewAuditLogCommand returns a command that queries the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{
+		clock:        clock.WallClock,
+		pollInterval: defaultAuditLogPollInterval,
+	})
+}
+
+// auditLogCommand shows audited conversations from the controller.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	api auditLogAPI
+	out cmd.Output
+
+	clock        clock.Clock
+	pollInterval time.Duration
+
+	user       string
+	modelUUID  string
+	methods    []string
+	from       string
+	to         string
+	errorsOnly bool
+	limit      int
+	follow     bool
+	isoTime    bool
+
+	query params.AuditLogQuery
+}
+
+// auditLogAPI defines the API methods used by the audit-log command.
+type auditLogAPI interface {
+	Close() error
+	Conversations(params.AuditLogQuery) ([]params.AuditConversation, error)
+}
+
+// Info implements cmd.Command.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Shows the audit log of a controller.",
+		Doc:     strings.TrimSpace(auditLogHelpDoc),
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+	f.StringVar(&c.user, "user", "", "Only show conversations by this user")
+	f.StringVar(&c.modelUUID, "model-uuid", "", "Only show conversations with the model with this UUID")
+	f.Var(cmd.NewAppendStringsValue(&c.methods), "method", "Only show conversations calling this method (may be repeated)")
+	f.StringVar(&c.from, "from", "", "Only show conversations started at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show conversations started at or before this time")
+	f.BoolVar(&c.errorsOnly, "errors-only", false, "Only show conversations where a call returned an error")
+	f.IntVar(&c.limit, "limit", 0, "Show at most this many of the most recent conversations (0 for all)")
+	f.BoolVar(&c.follow, "follow", false, "Keep showing new conversations as they are recorded")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+// Init implements cmd.Command.
+func (c *auditLogCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return err
+	}
+	if c.limit < 0 {
+		return errors.NotValidf("negative --limit")
+	}
+	if c.modelUUID != "" && !utils.IsValidUUIDString(c.modelUUID) {
+		return errors.NotValidf("model UUID %q", c.modelUUID)
+	}
+	var methods []string
+	for _, method := range c.methods {
+		for _, m := range strings.Split(method, ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				methods = append(methods, m)
+			}
+		}
+	}
+	now := c.clock.Now()
+	from, err := parseAuditLogTime(c.from, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --from")
+	}
+	to, err := parseAuditLogTime(c.to, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --to")
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return errors.New("--to must not be before --from")
+	}
+	if c.follow && to != nil {
+		return errors.New("--to cannot be used with --follow")
+	}
+	c.query = params.AuditLogQuery{
+		Who:        c.user,
+		ModelUUID:  c.modelUUID,
+		Methods:    methods,
+		From:       from,
+		To:         to,
+		ErrorsOnly: c.errorsOnly,
+		Limit:      c.limit,
+	}
+	return nil
+}
+
+// parseAuditLogTime parses a time given as RFC3339, a date, or a
+// duration before now. An empty value results in nil.
+func parseAuditLogTime(value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return &t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		t := now.Add(-d)
+		return &t, nil
+	}
+	return nil, errors.Errorf("%q is not a time, date or duration", value)
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	conversations, err := client.Conversations(c.query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !c.follow {
+		return c.out.Write(ctx, conversations)
+	}
+	return c.followConversations(ctx, client, conversations)
+}
+
+// followConversations writes each batch of new conversations until the
+// command is interrupted.
+func (c *auditLogCommand) followConversations(
+	ctx *cmd.Context, client auditLogAPI, conversations []params.AuditConversation,
+) error {
+	interrupted := make(chan os.Signal, 1)
+	defer close(interrupted)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	// Each query only asks for conversations started at or after the
+	// latest one seen. Those started at the same time as the latest
+	// are requested again, so remember which have been written; the
+	// older ones can't be returned again, so are forgotten.
+	query := c.query
+	query.Limit = 0
+	seen := make(map[string]time.Time)
+	for {
+		var unseen []params.AuditConversation
+		for _, conversation := range conversations {
+			if _, ok := seen[conversation.ConversationID]; ok {
+				continue
+			}
+			seen[conversation.ConversationID] = conversation.When
+			unseen = append(unseen, conversation)
+			if query.From == nil || conversation.When.After(*query.From) {
+				when := conversation.When
+				query.From = &when
+			}
+		}
+		for id, when := range seen {
+			if query.From != nil && when.Before(*query.From) {
+				delete(seen, id)
+			}
+		}
+		if len(unseen) > 0 {
+			if err := c.out.Write(ctx, unseen); err != nil {
+				return errors.Trace(err)
+			}
+		}
+
+		select {
+		case <-interrupted:
+			return nil
+		case <-c.clock.After(c.pollInterval):
+		}
+		var err error
+		conversations, err = client.Conversations(query)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func (c *auditLogCommand) formatTabular(writer io.Writer, value interface{}) error {
+	conversations, ok := value.([]params.AuditConversation)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", conversations, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "User", "Model", "Command", "Calls", "Errors")
+	for _, conversation := range conversations {
+		var errorCount int
+		for _, call := range conversation.Calls {
+			errorCount += len(call.Errors)
+		}
+		w.Print(
+			common.FormatTime(&conversation.When, c.isoTime),
+			conversation.Who,
+			conversation.ModelName,
+			conversation.What,
+			len(conversation.Calls),
+		)
+		if errorCount > 0 {
+			w.PrintColor(output.ErrorHighlight, errorCount)
+		} else {
+			w.Print(errorCount)
+		}
+		w.Println()
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.clock = testclock.NewClock(time.Date(2018, 10, 17, 12, 0, 0, 0, time.UTC))
+	s.api = &fakeAuditLogAPI{
+		results: [][]params.AuditConversation{{
+			auditConversation("abc", "bob", time.Date(2018, 10, 17, 10, 0, 0, 0, time.UTC), ""),
+			auditConversation("def", "mary", time.Date(2018, 10, 17, 11, 0, 0, 0, time.UTC), "boom"),
+		}},
+	}
+}
+
+func auditConversation(id, who string, when time.Time, errMessage string) params.AuditConversation {
+	call := params.AuditCall{
+		RequestID: 1,
+		When:      when,
+		Facade:    "Application",
+		Method:    "Deploy",
+		Version:   6,
+	}
+	if errMessage != "" {
+		call.Errors = []params.AuditError{{Message: errMessage}}
+	}
+	return params.AuditConversation{
+		ConversationID: id,
+		ConnectionID:   "1",
+		Who:            who,
+		What:           "juju deploy mysql",
+		When:           when,
+		ModelName:      "admin/default",
+		ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Calls:          []params.AuditCall{call},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store, s.clock)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInit(c *gc.C) {
+	tests := []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  "negative --limit not valid",
+	}, {
+		args: []string{"--model-uuid", "foo"},
+		err:  `model UUID "foo" not valid`,
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from: "yesterday" is not a time, date or duration`,
+	}, {
+		args: []string{"--from", "1h", "--to", "2h"},
+		err:  "--to must not be before --from",
+	}, {
+		args: []string{"--follow", "--to", "1h"},
+		err:  "--to cannot be used with --follow",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.args)
+		command := controller.NewAuditLogCommandForTest(s.api, s.store, s.clock)
+		err := cmdtesting.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestQuery(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"--method", "Application.Deploy,Client",
+		"--method", "Action",
+		"--from", "2h",
+		"--to", "2018-10-17T11:30:00Z",
+		"--errors-only",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2018, 10, 17, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 10, 17, 11, 30, 0, 0, time.UTC)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Conversations", []interface{}{params.AuditLogQuery{
+			Who:        "bob",
+			ModelUUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Methods:    []string{"Application.Deploy", "Client", "Action"},
+			From:       &from,
+			To:         &to,
+			ErrorsOnly: true,
+			Limit:      5,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  User  Model          Command            Calls  Errors
+2018-10-17 10:00:00Z  bob   admin/default  juju deploy mysql  1      0
+2018-10-17 11:00:00Z  mary  admin/default  juju deploy mysql  1      1
+`[1:])
+}
+
+func (s *AuditLogSuite) TestJSON(c *gc.C) {
+	s.api.results[0] = s.api.results[0][1:]
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `[{"conversation-id":"def","connection-id":"1","who":"mary",`+
+		`"what":"juju deploy mysql","when":"2018-10-17T11:00:00Z","model-name":"admin/default",`+
+		`"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","calls":[{"request-id":1,`+
+		`"when":"2018-10-17T11:00:00Z","facade":"Application","method":"Deploy","version":6,`+
+		`"errors":[{"message":"boom"}]}]}]`+"\n")
+}
+
+func (s *AuditLogSuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *AuditLogSuite) TestFollow(c *gc.C) {
+	s.api.results = append(s.api.results, []params.AuditConversation{
+		// Already written, so it's skipped.
+		auditConversation("def", "mary", time.Date(2018, 10, 17, 11, 0, 0, 0, time.UTC), "boom"),
+		auditConversation("ghi", "bob", time.Date(2018, 10, 17, 12, 0, 0, 0, time.UTC), ""),
+	})
+	s.api.SetErrors(nil, nil, errors.New("connection closed"))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 2; i++ {
+			err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+			c.Check(err, jc.ErrorIsNil)
+		}
+	}()
+	ctx, err := s.run(c, "--follow", "--utc", "--limit", "10")
+	c.Assert(err, gc.ErrorMatches, "connection closed")
+	<-done
+
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  User  Model          Command            Calls  Errors
+2018-10-17 10:00:00Z  bob   admin/default  juju deploy mysql  1      0
+2018-10-17 11:00:00Z  mary  admin/default  juju deploy mysql  1      1
+Time                  User  Model          Command            Calls  Errors
+2018-10-17 12:00:00Z  bob   admin/default  juju deploy mysql  1      0
+`[1:])
+
+	firstSeen := time.Date(2018, 10, 17, 11, 0, 0, 0, time.UTC)
+	lastSeen := time.Date(2018, 10, 17, 12, 0, 0, 0, time.UTC)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Conversations", []interface{}{params.AuditLogQuery{Limit: 10}}},
+		{"Conversations", []interface{}{params.AuditLogQuery{From: &firstSeen}}},
+		{"Conversations", []interface{}{params.AuditLogQuery{From: &lastSeen}}},
+		{"Close", nil},
+	})
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	results [][]params.AuditConversation
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeAuditLogAPI) Conversations(query params.AuditLogQuery) ([]params.AuditConversation, error) {
+	f.MethodCall(f, "Conversations", query)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	var result []params.AuditConversation
+	if len(f.results) > 0 {
+		result = f.results[0]
+		f.results = f.results[1:]
+	}
+	return result, nil
+}
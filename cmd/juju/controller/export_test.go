@@ -93,6 +93,16 @@ func NewEnableDestroyControllerCommandForTest(api removeBlocksAPI, store jujucli
 	return modelcmd.WrapController(c)
 }
 
+// NewImportModelCommandForTest returns an importModelCommand with the
+// API mocked out.
+func NewImportModelCommandForTest(api importModelAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &importModelCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -189,3 +199,15 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewAuditLogCommandForTest returns an AuditLogCommand with
+// the api and clock provided as specified.
+func NewAuditLogCommandForTest(api auditLogAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &auditLogCommand{
+		api:          api,
+		clock:        clock,
+		pollInterval: time.Second,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
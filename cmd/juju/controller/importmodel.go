@@ -0,0 +1,231 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/api/migrationtarget"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// NewImportModelCommand returns a command to import a model archive
+// written by export-model into a controller.
+func NewImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api      importModelAPI
+	filename string
+}
+
+// importModelAPI holds the MigrationTarget calls used to import a
+// model.
+type importModelAPI interface {
+	Close() error
+	Import([]byte) error
+	Abort(modelUUID string) error
+	Activate(modelUUID string) error
+	CheckMachines(modelUUID string) ([]error, error)
+	AdoptResources(modelUUID string) error
+	UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error)
+	UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error)
+	UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error
+	SetPlaceholderResource(modelUUID string, res resource.Resource) error
+	SetUnitResource(modelUUID, unit string, res resource.Resource) error
+}
+
+const importModelDoc = `
+Loads a model archive written by export-model into the controller,
+along with the charms, agent binaries and resources it holds. This
+moves a model between controllers that have no network path between
+them, where migrate can't be used.
+
+Only controller administrators can import models, and the model's
+owner must already exist on the controller.
+
+As in a migration, the model's machines are checked against the cloud
+before the model is activated, and its cloud resources are then
+adopted by this controller. Unlike a migration, an import can't update
+the model's agents, which stay connected to the controller the model
+was exported from. Until they're reconfigured to use this controller,
+its machines and units won't report here.
+
+The model must not be destroyed on the original controller: that
+would destroy the machines and storage now managed by this one. Once
+the imported model is confirmed to be working and no longer needed on
+the original controller, retire that controller with kill-controller
+while its API server is unreachable, which destroys only its own
+machines and leaves those of its hosted models running.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model -c othercontroller mymodel.tar.gz
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.Info.
+func (c *importModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-model",
+		Args:    "<file>",
+		Purpose: "Imports a model from an archive file into a controller.",
+		Doc:     importModelDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+type importModelClient struct {
+	*migrationtarget.Client
+	io.Closer
+}
+
+func (c *importModelCommand) getAPI() (importModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return importModelClient{migrationtarget.NewClient(root), root}, nil
+}
+
+// Run implements Command.Run.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	file, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer file.Close()
+	archive, err := coremigration.ReadArchive(file)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+	model, err := description.Deserialize(archive.Model.Bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelUUID := model.Tag().Id()
+	modelName, _ := model.Config()["name"].(string)
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.Import(archive.Model.Bytes); err != nil {
+		return errors.Annotate(err, "importing model")
+	}
+	uploader := &importUploader{client: client, modelUUID: modelUUID}
+	err = coremigration.UploadBinaries(coremigration.UploadBinariesConfig{
+		Charms:             archive.Model.Charms,
+		CharmDownloader:    archive,
+		CharmUploader:      uploader,
+		Tools:              archive.Model.Tools,
+		ToolsDownloader:    archive,
+		ToolsUploader:      uploader,
+		Resources:          archive.Model.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+	if err == nil {
+		err = checkMachines(client, modelUUID)
+	}
+	if err == nil {
+		err = client.Activate(modelUUID)
+	}
+	if err != nil {
+		// Don't leave a half imported model behind.
+		if abortErr := client.Abort(modelUUID); abortErr != nil {
+			logger.Errorf("removing partially imported model: %v", abortErr)
+		}
+		return errors.Annotate(err, "importing model")
+	}
+	// The model is active now, so it's kept even if its cloud
+	// resources can't be adopted.
+	if err := client.AdoptResources(modelUUID); err != nil {
+		return errors.Annotatef(err, "model %q imported, but adopting its cloud resources failed", modelName)
+	}
+	ctx.Infof("Imported model %q owned by %s", modelName, model.Owner().Id())
+	return nil
+}
+
+// checkMachines checks that the imported model's machines match the
+// instances in the cloud, logging every problem found.
+func checkMachines(client importModelAPI, modelUUID string) error {
+	results, err := client.CheckMachines(modelUUID)
+	if err != nil {
+		return errors.Annotate(err, "checking machines")
+	}
+	for _, resultErr := range results {
+		logger.Errorf("%v", resultErr)
+	}
+	switch len(results) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New("machine check failed, 1 error found")
+	}
+	return errors.Errorf("machine check failed, %d errors found", len(results))
+}
+
+// importUploader sends binaries from a model archive to the
+// controller, for the model being imported.
+type importUploader struct {
+	client    importModelAPI
+	modelUUID string
+}
+
+// UploadCharm implements coremigration.CharmUploader.
+func (u *importUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadTools implements coremigration.ToolsUploader.
+func (u *importUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers, additionalSeries...)
+}
+
+// UploadResource implements coremigration.ResourceUploader.
+func (u *importUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource implements coremigration.ResourceUploader.
+func (u *importUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource implements coremigration.ResourceUploader.
+func (u *importUploader) SetUnitResource(unit string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unit, res)
+}
@@ -0,0 +1,228 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/resource"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type importModelSuite struct {
+	baseControllerSuite
+	api   *fakeImportModelAPI
+	store *jujuclient.MemStore
+	dir   string
+	bytes []byte
+}
+
+var _ = gc.Suite(&importModelSuite{})
+
+func (s *importModelSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeImportModelAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.dir = c.MkDir()
+
+	model := description.NewModel(description.ModelArgs{
+		Type:   "iaas",
+		Owner:  names.NewUserTag("bob"),
+		Config: map[string]interface{}{"name": "mymodel", "uuid": coretesting.ModelTag.Id()},
+	})
+	app := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("foo"),
+		CharmURL: "cs:foo-1",
+	})
+	app.SetStatus(description.StatusArgs{Value: "active", Updated: time.Now()})
+	var err error
+	s.bytes, err = description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	s.writeArchive(c)
+}
+
+// writeArchive writes a model archive holding the model and its
+// charm to model.tar.gz.
+func (s *importModelSuite) writeArchive(c *gc.C) {
+	serialized, err := coremigration.NewSerializedModel(s.bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	file, err := os.Create(filepath.Join(s.dir, "model.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	err = coremigration.WriteArchive(file, coremigration.WriteArchiveConfig{
+		Model:              serialized,
+		CharmDownloader:    fakeSource{},
+		ToolsDownloader:    fakeSource{},
+		ResourceDownloader: fakeSource{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *importModelSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommandInDir(c, controller.NewImportModelCommandForTest(s.api, s.store), args, s.dir)
+	return cmdtesting.Stderr(ctx), err
+}
+
+func (s *importModelSuite) TestInitNoFile(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+}
+
+func (s *importModelSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := s.run(c, "model.tar.gz", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *importModelSuite) TestImport(c *gc.C) {
+	stderr, err := s.run(c, "model.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stderr, gc.Equals, "Imported model \"mymodel\" owned by bob\n")
+	uuid := coretesting.ModelTag.Id()
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Import", []interface{}{string(s.bytes)}},
+		{"UploadCharm", []interface{}{uuid, "cs:foo-1", "cs:foo-1"}},
+		{"CheckMachines", []interface{}{uuid}},
+		{"Activate", []interface{}{uuid}},
+		{"AdoptResources", []interface{}{uuid}},
+		{"Close", nil},
+	})
+}
+
+func (s *importModelSuite) TestImportMissingFile(c *gc.C) {
+	_, err := s.run(c, "missing.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "open .*missing.tar.gz: no such file or directory")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *importModelSuite) TestImportFails(c *gc.C) {
+	s.api.SetErrors(errors.New("model already exists"))
+	_, err := s.run(c, "model.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "importing model: model already exists")
+	s.api.CheckCallNames(c, "Import", "Close")
+}
+
+func (s *importModelSuite) TestUploadFailsAborts(c *gc.C) {
+	s.api.SetErrors(nil, errors.New("boom"))
+	_, err := s.run(c, "model.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "importing model: cannot upload charm: boom")
+	s.api.CheckCallNames(c, "Import", "UploadCharm", "Abort", "Close")
+}
+
+func (s *importModelSuite) TestCheckMachinesFailsAborts(c *gc.C) {
+	s.api.machineErrors = []error{
+		errors.New("machine 0 not running"),
+		errors.New("machine 1 not running"),
+	}
+	_, err := s.run(c, "model.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "importing model: machine check failed, 2 errors found")
+	s.api.CheckCallNames(c, "Import", "UploadCharm", "CheckMachines", "Abort", "Close")
+}
+
+func (s *importModelSuite) TestAdoptResourcesFailsKeepsModel(c *gc.C) {
+	s.api.SetErrors(nil, nil, nil, nil, errors.New("boom"))
+	_, err := s.run(c, "model.tar.gz")
+	c.Assert(err, gc.ErrorMatches, `model "mymodel" imported, but adopting its cloud resources failed: boom`)
+	s.api.CheckCallNames(c, "Import", "UploadCharm", "CheckMachines", "Activate", "AdoptResources", "Close")
+}
+
+// fakeSource serves each binary's name as its content.
+type fakeSource struct{}
+
+func (fakeSource) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(curl.String())), nil
+}
+
+func (fakeSource) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(uri)), nil
+}
+
+func (fakeSource) OpenResource(app, name string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(app + "/" + name)), nil
+}
+
+type fakeImportModelAPI struct {
+	jujutesting.Stub
+	machineErrors []error
+}
+
+func (f *fakeImportModelAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeImportModelAPI) Import(bytes []byte) error {
+	f.MethodCall(f, "Import", string(bytes))
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) CheckMachines(modelUUID string) ([]error, error) {
+	f.MethodCall(f, "CheckMachines", modelUUID)
+	return f.machineErrors, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) AdoptResources(modelUUID string) error {
+	f.MethodCall(f, "AdoptResources", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	f.MethodCall(f, "UploadCharm", modelUUID, curl.String(), string(data))
+	return curl, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, _ ...string) (tools.List, error) {
+	f.MethodCall(f, "UploadTools", modelUUID, vers)
+	return tools.List{&tools.Tools{Version: vers}}, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	f.MethodCall(f, "SetPlaceholderResource", modelUUID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) SetUnitResource(modelUUID, unit string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unit, res.Name)
+	return f.NextErr()
+}
@@ -28,6 +28,7 @@ Examples:
 See also:
     advance-generation
     cancel-generation
+    diff-generation
     switch-generation
 `
 )
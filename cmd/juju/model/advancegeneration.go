@@ -31,6 +31,8 @@ Examples:
 See also:
     add-generation
     cancel-generation
+    diff-generation
+    promote-generation
     switch-generation
 
 Aliases:
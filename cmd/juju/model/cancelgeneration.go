@@ -26,6 +26,7 @@ Examples:
 See also:
     add-generation
     advance-generation
+    diff-generation
     switch-generation
 `
 )
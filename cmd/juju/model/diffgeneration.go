@@ -0,0 +1,165 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const (
+	diffGenerationSummary = "Shows the changes in the next generation."
+	diffGenerationDoc     = `
+Show, for each application with changes in the next generation, the
+charm config values and charm that differ between the current and next
+generations, and which generation each of its units is on.
+
+Changes are made in the next generation by running config or
+upgrade-charm while it is selected. They are applied to the application
+when the generation completes; applications with no units on the next
+generation are left unchanged.
+
+Examples:
+    juju diff-generation
+    juju diff-generation --format json
+
+See also:
+    add-generation
+    advance-generation
+    cancel-generation
+    promote-generation
+    switch-generation
+`
+)
+
+// NewDiffGenerationCommand wraps diffGenerationCommand with sane model settings.
+func NewDiffGenerationCommand() cmd.Command {
+	return modelcmd.Wrap(&diffGenerationCommand{})
+}
+
+// diffGenerationCommand shows the differences between the current and
+// next model generations.
+type diffGenerationCommand struct {
+	modelcmd.ModelCommandBase
+
+	api DiffGenerationCommandAPI
+	out cmd.Output
+}
+
+// DiffGenerationCommandAPI defines an API interface to be used during testing.
+//go:generate mockgen -package mocks -destination ./mocks/diffgeneration_mock.go github.com/juju/juju/cmd/juju/model DiffGenerationCommandAPI
+type DiffGenerationCommandAPI interface {
+	Close() error
+	DiffGeneration(string) ([]params.GenerationApplicationDiff, error)
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *diffGenerationCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "diff-generation",
+		Purpose: diffGenerationSummary,
+		Doc:     diffGenerationDoc,
+	}
+	return jujucmd.Info(info)
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *diffGenerationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *diffGenerationCommand) Init(args []string) error {
+	if len(args) != 0 {
+		return errors.Errorf("No arguments allowed")
+	}
+	return nil
+}
+
+// getAPI returns the API. This allows passing in a test DiffGenerationCommandAPI
+// implementation.
+func (c *diffGenerationCommand) getAPI() (DiffGenerationCommandAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	client := modelgeneration.NewClient(api)
+	return client, nil
+}
+
+// Run implements the meaty part of the cmd.Command interface.
+func (c *diffGenerationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	_, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	diffs, err := client.DiffGeneration(modelDetails.ModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(diffs) == 0 {
+		ctx.Infof("no applications have changes in the next generation")
+		return nil
+	}
+	return errors.Trace(c.out.Write(ctx, formatGenerationDiff(diffs)))
+}
+
+type generationValueDiff struct {
+	Current interface{} `yaml:"current" json:"current"`
+	Next    interface{} `yaml:"next" json:"next"`
+}
+
+type generationApplicationDiff struct {
+	Charm  *generationValueDiff           `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Config map[string]generationValueDiff `yaml:"config,omitempty" json:"config,omitempty"`
+	Units  map[string]string              `yaml:"units" json:"units"`
+}
+
+// formatGenerationDiff returns the differences keyed by application
+// name, for output.
+func formatGenerationDiff(diffs []params.GenerationApplicationDiff) map[string]generationApplicationDiff {
+	result := make(map[string]generationApplicationDiff, len(diffs))
+	for _, diff := range diffs {
+		out := generationApplicationDiff{
+			Units: make(map[string]string, len(diff.Units)),
+		}
+		if diff.NextCharmURL != "" {
+			out.Charm = &generationValueDiff{
+				Current: diff.CurrentCharmURL,
+				Next:    diff.NextCharmURL,
+			}
+		}
+		if len(diff.Config) > 0 {
+			out.Config = make(map[string]generationValueDiff, len(diff.Config))
+			for _, change := range diff.Config {
+				out.Config[change.Key] = generationValueDiff{
+					Current: change.Current,
+					Next:    change.Next,
+				}
+			}
+		}
+		for unit, generation := range diff.Units {
+			out.Units[unit] = generation.String()
+		}
+		result[diff.Application] = out
+	}
+	return result
+}
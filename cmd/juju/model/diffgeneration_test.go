@@ -0,0 +1,132 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/model/mocks"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type diffGenerationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&diffGenerationSuite{})
+
+func (s *diffGenerationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.SetFeatureFlags(feature.Generations)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID:       testing.ModelTag.Id(),
+		ModelType:       coremodel.IAAS,
+		ModelGeneration: coremodel.GenerationNext,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *diffGenerationSuite) TestInitFail(c *gc.C) {
+	err := cmdtesting.InitCommand(model.NewDiffGenerationCommandForTest(nil, s.store), []string{"test"})
+	c.Assert(err, gc.ErrorMatches, "No arguments allowed")
+}
+
+func (s *diffGenerationSuite) runCommand(c *gc.C, api model.DiffGenerationCommandAPI, args ...string) (*cmd.Context, error) {
+	cmd := model.NewDiffGenerationCommandForTest(api, s.store)
+	return cmdtesting.RunCommand(c, cmd, args...)
+}
+
+func setUpDiffMocks(c *gc.C) (*gomock.Controller, *mocks.MockDiffGenerationCommandAPI) {
+	mockController := gomock.NewController(c)
+	mockDiffGenerationCommandAPI := mocks.NewMockDiffGenerationCommandAPI(mockController)
+	mockDiffGenerationCommandAPI.EXPECT().Close()
+	return mockController, mockDiffGenerationCommandAPI
+}
+
+func (s *diffGenerationSuite) TestRunCommand(c *gc.C) {
+	mockController, mockDiffGenerationCommandAPI := setUpDiffMocks(c)
+	defer mockController.Finish()
+
+	mockDiffGenerationCommandAPI.EXPECT().DiffGeneration(testing.ModelTag.Id()).Return([]params.GenerationApplicationDiff{{
+		Application:     "mysql",
+		CurrentCharmURL: "cs:mysql-1",
+		NextCharmURL:    "cs:mysql-2",
+		Config: []params.GenerationConfigChange{
+			{Key: "tuning", Current: "safest", Next: "fast"},
+			{Key: "dataset-size", Next: "80%"},
+		},
+		Units: map[string]coremodel.GenerationVersion{
+			"mysql/0": coremodel.GenerationNext,
+			"mysql/1": coremodel.GenerationCurrent,
+		},
+	}, {
+		Application:     "redis",
+		CurrentCharmURL: "cs:redis-3",
+		Units: map[string]coremodel.GenerationVersion{
+			"redis/0": coremodel.GenerationCurrent,
+		},
+	}}, nil)
+
+	ctx, err := s.runCommand(c, mockDiffGenerationCommandAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql:
+  charm:
+    current: cs:mysql-1
+    next: cs:mysql-2
+  config:
+    dataset-size:
+      current: null
+      next: 80%
+    tuning:
+      current: safest
+      next: fast
+  units:
+    mysql/0: next
+    mysql/1: current
+redis:
+  units:
+    redis/0: current
+`[1:])
+}
+
+func (s *diffGenerationSuite) TestRunCommandNoChanges(c *gc.C) {
+	mockController, mockDiffGenerationCommandAPI := setUpDiffMocks(c)
+	defer mockController.Finish()
+
+	mockDiffGenerationCommandAPI.EXPECT().DiffGeneration(gomock.Any()).Return(nil, nil)
+
+	ctx, err := s.runCommand(c, mockDiffGenerationCommandAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no applications have changes in the next generation\n")
+}
+
+func (s *diffGenerationSuite) TestRunCommandFail(c *gc.C) {
+	mockController, mockDiffGenerationCommandAPI := setUpDiffMocks(c)
+	defer mockController.Finish()
+
+	mockDiffGenerationCommandAPI.EXPECT().DiffGeneration(gomock.Any()).Return(nil, errors.Errorf("failme"))
+
+	_, err := s.runCommand(c, mockDiffGenerationCommandAPI)
+	c.Assert(err, gc.ErrorMatches, "failme")
+}
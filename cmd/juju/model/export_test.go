@@ -75,6 +75,16 @@ func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientS
 	return modelcmd.Wrap(cmd)
 }
 
+// NewExportModelCommandForTest returns an export-model command with the api
+// provided as specified.
+func NewExportModelCommandForTest(api ExportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportModelCommand{newAPIFunc: func() (ExportModelAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the api provided as specified.
 func NewDestroyCommandForTest(
 	api DestroyModelAPI,
@@ -205,6 +215,14 @@ func NewAdvanceGenerationCommandForTest(api AdvanceGenerationCommandAPI, store j
 	return modelcmd.Wrap(cmd)
 }
 
+func NewDiffGenerationCommandForTest(api DiffGenerationCommandAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &diffGenerationCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewSwitchGenerationCommandForTest(api SwitchGenerationCommandAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &switchGenerationCommand{
 		api: api,
@@ -212,3 +230,19 @@ func NewSwitchGenerationCommandForTest(api SwitchGenerationCommandAPI, store juj
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewPromoteGenerationCommandForTest(api PromoteGenerationCommandAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &promoteGenerationCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewShowGenerationPromotionCommandForTest(api ShowGenerationPromotionCommandAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showGenerationPromotionCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
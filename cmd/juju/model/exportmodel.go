@@ -0,0 +1,179 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/modelmanager"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+)
+
+// NewExportModelCommand returns a fully constructed export-model
+// command.
+func NewExportModelCommand() cmd.Command {
+	cmd := &exportModelCommand{}
+	cmd.newAPIFunc = cmd.getAPI
+	return modelcmd.Wrap(cmd)
+}
+
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (ExportModelAPI, error)
+	filename   string
+}
+
+const exportModelHelpDoc = `
+Writes the model, along with the charms, agent binaries and resources
+it uses, to an archive file. The archive can be carried to a controller
+that has no network path to this one and loaded there with
+import-model, in place of migrating the model.
+
+The model keeps running on this controller. Export it while nothing is
+being deployed or changed, since changes made during the export may
+not be captured consistently.
+
+The archive holds the model's configuration and cloud credential, so
+keep it safe.
+
+Examples:
+
+    juju export-model mymodel.tar.gz
+    juju export-model -m othermodel othermodel.tar.gz
+
+See also:
+    import-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-model",
+		Args:    "<file>",
+		Purpose: "Exports a model and its binaries to an archive file.",
+		Doc:     exportModelHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ExportModelAPI specifies the calls used to export a model and
+// download its binaries.
+type ExportModelAPI interface {
+	Close() error
+	ExportModel(names.ModelTag) ([]byte, error)
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+	OpenURI(string, url.Values) (io.ReadCloser, error)
+	OpenResource(application, name string) (io.ReadCloser, error)
+}
+
+func (c *exportModelCommand) getAPI() (ExportModelAPI, error) {
+	modelManager, err := c.NewModelManagerAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		modelManager.Close()
+		return nil, errors.Trace(err)
+	}
+	return &exportModelAPI{modelManager: modelManager, client: client}, nil
+}
+
+// exportModelAPI combines the controller and model API clients
+// needed to export a model.
+type exportModelAPI struct {
+	modelManager *modelmanager.Client
+	client       *api.Client
+}
+
+func (a *exportModelAPI) Close() error {
+	a.client.Close()
+	return a.modelManager.Close()
+}
+
+func (a *exportModelAPI) ExportModel(model names.ModelTag) ([]byte, error) {
+	return a.modelManager.ExportModel(model)
+}
+
+func (a *exportModelAPI) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.client.OpenCharm(curl)
+}
+
+func (a *exportModelAPI) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	return a.client.OpenURI(uri, query)
+}
+
+func (a *exportModelAPI) OpenResource(application, name string) (io.ReadCloser, error) {
+	return a.client.OpenURI(fmt.Sprintf("/applications/%s/resources/%s", application, name), nil)
+}
+
+// Run implements Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) error {
+	modelName, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	bytes, err := client.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	serialized, err := coremigration.NewSerializedModel(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Write to a temporary file alongside the archive, so a failed
+	// export doesn't leave a partial archive behind.
+	filename := ctx.AbsPath(c.filename)
+	file, err := ioutil.TempFile(filepath.Dir(filename), ".export-model")
+	if err != nil {
+		return errors.Annotate(err, "creating archive file")
+	}
+	defer os.Remove(file.Name())
+	err = coremigration.WriteArchive(file, coremigration.WriteArchiveConfig{
+		Model:              serialized,
+		CharmDownloader:    client,
+		ToolsDownloader:    client,
+		ResourceDownloader: client,
+	})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Annotate(err, "writing archive")
+	}
+	if err := os.Rename(file.Name(), filename); err != nil {
+		return errors.Annotate(err, "writing archive")
+	}
+	ctx.Infof("Model %q exported to %s", modelName, c.filename)
+	return nil
+}
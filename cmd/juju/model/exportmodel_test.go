@@ -0,0 +1,137 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeExportModelClient
+	store *jujuclient.MemStore
+	dir   string
+}
+
+var _ = gc.Suite(&ExportModelCommandSuite{})
+
+func (s *ExportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	modelDesc := description.NewModel(description.ModelArgs{
+		Type:   "iaas",
+		Owner:  names.NewUserTag("admin"),
+		Config: map[string]interface{}{"name": "mymodel", "uuid": testing.ModelTag.Id()},
+	})
+	bytes, err := description.Serialize(modelDesc)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake = &fakeExportModelClient{bytes: bytes}
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err = s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+	s.dir = c.MkDir()
+}
+
+func (s *ExportModelCommandSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommandInDir(c, model.NewExportModelCommandForTest(s.fake, s.store), args, s.dir)
+	return cmdtesting.Stderr(ctx), err
+}
+
+func (s *ExportModelCommandSuite) TestInitNoFile(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+}
+
+func (s *ExportModelCommandSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := s.run(c, "model.tar.gz", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ExportModelCommandSuite) TestExport(c *gc.C) {
+	stderr, err := s.run(c, "model.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stderr, gc.Equals, "Model \"admin/mymodel\" exported to model.tar.gz\n")
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"Close", nil},
+	})
+
+	file, err := os.Open(filepath.Join(s.dir, "model.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	archive, err := coremigration.ReadArchive(file)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Check(archive.Model.Bytes, jc.DeepEquals, s.fake.bytes)
+}
+
+func (s *ExportModelCommandSuite) TestExportFails(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "model.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	// Nothing is left behind.
+	entries, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+type fakeExportModelClient struct {
+	jujutesting.Stub
+	bytes []byte
+}
+
+func (f *fakeExportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeExportModelClient) ExportModel(model names.ModelTag) ([]byte, error) {
+	f.MethodCall(f, "ExportModel", model)
+	return f.bytes, f.NextErr()
+}
+
+func (f *fakeExportModelClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl)
+	return ioutil.NopCloser(strings.NewReader(curl.String())), f.NextErr()
+}
+
+func (f *fakeExportModelClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri, query)
+	return ioutil.NopCloser(strings.NewReader(uri)), f.NextErr()
+}
+
+func (f *fakeExportModelClient) OpenResource(application, name string) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenResource", application, name)
+	return ioutil.NopCloser(strings.NewReader(application + "/" + name)), f.NextErr()
+}
@@ -0,0 +1,140 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelgeneration"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	promoteGenerationSummary = "Promotes the next generation in batches, gated on unit health."
+	promoteGenerationDoc     = `
+Ask the controller to advance the units of the applications with changes
+in the next generation in batches, and to complete the generation once
+every unit has been advanced.
+
+Each batch advances the given percentage of each application's units,
+rounded up. The next batch is advanced once the units in the generation
+have stayed healthy for the soak time. Units are unhealthy if they are
+in error or, with --require-active, if their workload is not active by
+the end of the soak time.
+
+Unit health is checked every 30 seconds. If any unit in the generation
+becomes unhealthy, every unit is moved back to the current generation
+and the generation is cancelled. Units that are removed during the
+promotion are ignored.
+
+Use show-generation-promotion to follow the promotion.
+
+Examples:
+    juju promote-generation
+    juju promote-generation --percent 25 --soak-time 30m --require-active
+
+See also:
+    add-generation
+    advance-generation
+    cancel-generation
+    diff-generation
+    show-generation-promotion
+`
+)
+
+// NewPromoteGenerationCommand wraps promoteGenerationCommand with sane model settings.
+func NewPromoteGenerationCommand() cmd.Command {
+	return modelcmd.Wrap(&promoteGenerationCommand{})
+}
+
+// promoteGenerationCommand asks the controller to promote the next
+// model generation automatically.
+type promoteGenerationCommand struct {
+	modelcmd.ModelCommandBase
+
+	api PromoteGenerationCommandAPI
+
+	percent       int
+	soakTime      time.Duration
+	requireActive bool
+}
+
+// PromoteGenerationCommandAPI defines an API interface to be used during testing.
+//go:generate mockgen -package mocks -destination ./mocks/promotegeneration_mock.go github.com/juju/juju/cmd/juju/model PromoteGenerationCommandAPI
+type PromoteGenerationCommandAPI interface {
+	Close() error
+	PromoteGeneration(string, int, time.Duration, bool) error
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *promoteGenerationCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "promote-generation",
+		Purpose: promoteGenerationSummary,
+		Doc:     promoteGenerationDoc,
+	}
+	return jujucmd.Info(info)
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *promoteGenerationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.percent, "percent", 10, "Percentage of each application's units to advance in each batch")
+	f.DurationVar(&c.soakTime, "soak-time", 10*time.Minute, "How long each batch must stay healthy before the next is advanced")
+	f.BoolVar(&c.requireActive, "require-active", false, "Require workloads in the generation to be active by the end of each soak time")
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *promoteGenerationCommand) Init(args []string) error {
+	if len(args) != 0 {
+		return errors.Errorf("No arguments allowed")
+	}
+	if c.percent < 1 || c.percent > 100 {
+		return errors.Errorf("--percent must be between 1 and 100")
+	}
+	if c.soakTime < 0 {
+		return errors.Errorf("--soak-time must not be negative")
+	}
+	return nil
+}
+
+// getAPI returns the API. This allows passing in a test PromoteGenerationCommandAPI
+// implementation.
+func (c *promoteGenerationCommand) getAPI() (PromoteGenerationCommandAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	client := modelgeneration.NewClient(api)
+	return client, nil
+}
+
+// Run implements the meaty part of the cmd.Command interface.
+func (c *promoteGenerationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	_, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	err = client.PromoteGeneration(modelDetails.ModelUUID, c.percent, c.soakTime, c.requireActive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintf(ctx.Stdout, "promoting next generation in batches of %d%%, soaking each for %v\n", c.percent, c.soakTime)
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/model/mocks"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type promoteGenerationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&promoteGenerationSuite{})
+
+func (s *promoteGenerationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.SetFeatureFlags(feature.Generations)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID:       testing.ModelTag.Id(),
+		ModelType:       coremodel.IAAS,
+		ModelGeneration: coremodel.GenerationNext,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *promoteGenerationSuite) TestInitFail(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"test"},
+		err:  "No arguments allowed",
+	}, {
+		args: []string{"--percent", "0"},
+		err:  "--percent must be between 1 and 100",
+	}, {
+		args: []string{"--percent", "101"},
+		err:  "--percent must be between 1 and 100",
+	}, {
+		args: []string{"--soak-time", "-1m"},
+		err:  "--soak-time must not be negative",
+	}} {
+		err := cmdtesting.InitCommand(model.NewPromoteGenerationCommandForTest(nil, s.store), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *promoteGenerationSuite) runCommand(c *gc.C, api model.PromoteGenerationCommandAPI, args ...string) (*cmd.Context, error) {
+	cmd := model.NewPromoteGenerationCommandForTest(api, s.store)
+	return cmdtesting.RunCommand(c, cmd, args...)
+}
+
+func setUpPromoteMocks(c *gc.C) (*gomock.Controller, *mocks.MockPromoteGenerationCommandAPI) {
+	mockController := gomock.NewController(c)
+	mockPromoteGenerationCommandAPI := mocks.NewMockPromoteGenerationCommandAPI(mockController)
+	mockPromoteGenerationCommandAPI.EXPECT().Close()
+	return mockController, mockPromoteGenerationCommandAPI
+}
+
+func (s *promoteGenerationSuite) TestRunCommandDefaults(c *gc.C) {
+	mockController, mockPromoteGenerationCommandAPI := setUpPromoteMocks(c)
+	defer mockController.Finish()
+
+	mockPromoteGenerationCommandAPI.EXPECT().PromoteGeneration(testing.ModelTag.Id(), 10, 10*time.Minute, false).Return(nil)
+
+	ctx, err := s.runCommand(c, mockPromoteGenerationCommandAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "promoting next generation in batches of 10%, soaking each for 10m0s\n")
+}
+
+func (s *promoteGenerationSuite) TestRunCommand(c *gc.C) {
+	mockController, mockPromoteGenerationCommandAPI := setUpPromoteMocks(c)
+	defer mockController.Finish()
+
+	mockPromoteGenerationCommandAPI.EXPECT().PromoteGeneration(testing.ModelTag.Id(), 25, 30*time.Minute, true).Return(nil)
+
+	_, err := s.runCommand(c, mockPromoteGenerationCommandAPI, "--percent", "25", "--soak-time", "30m", "--require-active")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *promoteGenerationSuite) TestRunCommandFail(c *gc.C) {
+	mockController, mockPromoteGenerationCommandAPI := setUpPromoteMocks(c)
+	defer mockController.Finish()
+
+	mockPromoteGenerationCommandAPI.EXPECT().PromoteGeneration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.Errorf("failme"))
+
+	_, err := s.runCommand(c, mockPromoteGenerationCommandAPI)
+	c.Assert(err, gc.ErrorMatches, "failme")
+}
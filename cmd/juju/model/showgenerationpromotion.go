@@ -0,0 +1,141 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const (
+	showGenerationPromotionSummary = "Shows the progress of a generation promotion."
+	showGenerationPromotionDoc     = `
+Show the automatic promotion of the next generation started with
+promote-generation, or of the most recently completed generation if
+there is no next generation.
+
+The status is "running" while units are being advanced, "completed"
+once the generation has been completed, or "rolled-back" if a unit
+became unhealthy and the generation was cancelled. The message then
+names the unhealthy units.
+
+Examples:
+    juju show-generation-promotion
+    juju show-generation-promotion --format json
+
+See also:
+    diff-generation
+    promote-generation
+`
+)
+
+// NewShowGenerationPromotionCommand wraps showGenerationPromotionCommand with sane model settings.
+func NewShowGenerationPromotionCommand() cmd.Command {
+	return modelcmd.Wrap(&showGenerationPromotionCommand{})
+}
+
+// showGenerationPromotionCommand shows the automatic promotion of a
+// model generation.
+type showGenerationPromotionCommand struct {
+	modelcmd.ModelCommandBase
+
+	api ShowGenerationPromotionCommandAPI
+	out cmd.Output
+}
+
+// ShowGenerationPromotionCommandAPI defines an API interface to be used during testing.
+//go:generate mockgen -package mocks -destination ./mocks/showgenerationpromotion_mock.go github.com/juju/juju/cmd/juju/model ShowGenerationPromotionCommandAPI
+type ShowGenerationPromotionCommandAPI interface {
+	Close() error
+	GenerationPromotion(string) (params.GenerationPromotion, error)
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *showGenerationPromotionCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "show-generation-promotion",
+		Purpose: showGenerationPromotionSummary,
+		Doc:     showGenerationPromotionDoc,
+	}
+	return jujucmd.Info(info)
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *showGenerationPromotionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *showGenerationPromotionCommand) Init(args []string) error {
+	if len(args) != 0 {
+		return errors.Errorf("No arguments allowed")
+	}
+	return nil
+}
+
+// getAPI returns the API. This allows passing in a test ShowGenerationPromotionCommandAPI
+// implementation.
+func (c *showGenerationPromotionCommand) getAPI() (ShowGenerationPromotionCommandAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	client := modelgeneration.NewClient(api)
+	return client, nil
+}
+
+// Run implements the meaty part of the cmd.Command interface.
+func (c *showGenerationPromotionCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	_, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	promotion, err := client.GenerationPromotion(modelDetails.ModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.out.Write(ctx, formatGenerationPromotion(promotion)))
+}
+
+type generationPromotion struct {
+	Status        string `yaml:"status" json:"status"`
+	Percent       int    `yaml:"percent" json:"percent"`
+	SoakTime      string `yaml:"soak-time" json:"soak-time"`
+	RequireActive bool   `yaml:"require-active" json:"require-active"`
+	BatchStarted  string `yaml:"batch-started,omitempty" json:"batch-started,omitempty"`
+	Message       string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func formatGenerationPromotion(promotion params.GenerationPromotion) generationPromotion {
+	out := generationPromotion{
+		Status:        promotion.Status,
+		Percent:       promotion.Percent,
+		SoakTime:      promotion.SoakTime.String(),
+		RequireActive: promotion.RequireActive,
+		Message:       promotion.Message,
+	}
+	if promotion.BatchStarted != nil {
+		out.BatchStarted = promotion.BatchStarted.UTC().Format(time.RFC3339)
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/model/mocks"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type showGenerationPromotionSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&showGenerationPromotionSuite{})
+
+func (s *showGenerationPromotionSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.SetFeatureFlags(feature.Generations)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID:       testing.ModelTag.Id(),
+		ModelType:       coremodel.IAAS,
+		ModelGeneration: coremodel.GenerationNext,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *showGenerationPromotionSuite) TestInitFail(c *gc.C) {
+	err := cmdtesting.InitCommand(model.NewShowGenerationPromotionCommandForTest(nil, s.store), []string{"test"})
+	c.Assert(err, gc.ErrorMatches, "No arguments allowed")
+}
+
+func (s *showGenerationPromotionSuite) runCommand(c *gc.C, api model.ShowGenerationPromotionCommandAPI, args ...string) (*cmd.Context, error) {
+	cmd := model.NewShowGenerationPromotionCommandForTest(api, s.store)
+	return cmdtesting.RunCommand(c, cmd, args...)
+}
+
+func setUpShowPromotionMocks(c *gc.C) (*gomock.Controller, *mocks.MockShowGenerationPromotionCommandAPI) {
+	mockController := gomock.NewController(c)
+	mockShowGenerationPromotionCommandAPI := mocks.NewMockShowGenerationPromotionCommandAPI(mockController)
+	mockShowGenerationPromotionCommandAPI.EXPECT().Close()
+	return mockController, mockShowGenerationPromotionCommandAPI
+}
+
+func (s *showGenerationPromotionSuite) TestRunCommand(c *gc.C) {
+	mockController, mockShowGenerationPromotionCommandAPI := setUpShowPromotionMocks(c)
+	defer mockController.Finish()
+
+	batchStarted := time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)
+	mockShowGenerationPromotionCommandAPI.EXPECT().GenerationPromotion(testing.ModelTag.Id()).Return(params.GenerationPromotion{
+		Percent:      25,
+		SoakTime:     30 * time.Minute,
+		Status:       "rolled-back",
+		BatchStarted: &batchStarted,
+		Message:      "units unhealthy: mysql/0",
+	}, nil)
+
+	ctx, err := s.runCommand(c, mockShowGenerationPromotionCommandAPI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+status: rolled-back
+percent: 25
+soak-time: 30m0s
+require-active: false
+batch-started: 2019-02-01T10:00:00Z
+message: 'units unhealthy: mysql/0'
+`[1:])
+}
+
+func (s *showGenerationPromotionSuite) TestRunCommandFail(c *gc.C) {
+	mockController, mockShowGenerationPromotionCommandAPI := setUpShowPromotionMocks(c)
+	defer mockController.Finish()
+
+	mockShowGenerationPromotionCommandAPI.EXPECT().GenerationPromotion(gomock.Any()).Return(params.GenerationPromotion{}, errors.Errorf("failme"))
+
+	_, err := s.runCommand(c, mockShowGenerationPromotionCommandAPI)
+	c.Assert(err, gc.ErrorMatches, "failme")
+}
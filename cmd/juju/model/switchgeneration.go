@@ -28,6 +28,7 @@ See also:
     add-generation
     advance-generation
     cancel-generation
+    diff-generation
 `
 )
 
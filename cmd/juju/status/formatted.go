@@ -125,6 +125,7 @@ type applicationStatus struct {
 	Placement        string                `json:"placement,omitempty" yaml:"placement,omitempty"`
 	ProviderId       string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Address          string                `json:"address,omitempty" yaml:"address,omitempty"`
+	ExternalHostname string                `json:"external-hostname,omitempty" yaml:"external-hostname,omitempty"`
 	Exposed          bool                  `json:"exposed" yaml:"exposed"`
 	Life             string                `json:"life,omitempty" yaml:"life,omitempty"`
 	StatusInfo       statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
@@ -243,6 +243,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		Placement:        application.Placement,
 		ProviderId:       application.ProviderId,
 		Address:          application.PublicAddress,
+		ExternalHostname: application.ExternalHostname,
 		Relations:        application.Relations,
 		CanUpgradeTo:     application.CanUpgradeTo,
 		SubordinateTo:    application.SubordinateTo,
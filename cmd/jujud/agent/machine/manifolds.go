@@ -34,6 +34,8 @@ import (
 	"github.com/juju/juju/state"
 	proxyconfig "github.com/juju/juju/utils/proxy"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionrollout"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
@@ -42,6 +44,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/common"
@@ -55,6 +58,7 @@ import (
 	"github.com/juju/juju/worker/featureflag"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
+	"github.com/juju/juju/worker/generationpromoter"
 	"github.com/juju/juju/worker/globalclockupdater"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/httpserver"
@@ -731,6 +735,38 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName: agentName,
+				ClockName: clockName,
+				StateName: stateName,
+				NewWorker: backupscheduler.NewWorker,
+			},
+		))),
+
+		actionSchedulerName: ifNotMigrating(ifPrimaryController(actionscheduler.Manifold(
+			actionscheduler.ManifoldConfig{
+				ClockName: clockName,
+				StateName: stateName,
+				NewWorker: actionscheduler.NewWorker,
+			},
+		))),
+
+		actionRolloutName: ifNotMigrating(ifPrimaryController(actionrollout.Manifold(
+			actionrollout.ManifoldConfig{
+				StateName: stateName,
+				NewWorker: actionrollout.NewWorker,
+			},
+		))),
+
+		generationPromoterName: ifNotMigrating(ifPrimaryController(generationpromoter.Manifold(
+			generationpromoter.ManifoldConfig{
+				ClockName: clockName,
+				StateName: stateName,
+				NewWorker: generationpromoter.NewWorker,
+			},
+		))),
+
 		httpServerArgsName: httpserverargs.Manifold(httpserverargs.ManifoldConfig{
 			ClockName:             clockName,
 			ControllerPortName:    controllerPortName,
@@ -1026,6 +1062,10 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	logPrunerName                 = "log-pruner"
 	txnPrunerName                 = "transaction-pruner"
+	backupSchedulerName           = "backup-scheduler"
+	actionSchedulerName           = "action-scheduler"
+	actionRolloutName             = "action-rollout"
+	generationPromoterName        = "generation-promoter"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
 	modelWorkerManagerName        = "model-worker-manager"
@@ -48,12 +48,15 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 	}
 	sort.Strings(keys)
 	expectedKeys := []string{
+		"action-rollout",
+		"action-scheduler",
 		"agent",
 		"api-address-updater",
 		"api-caller",
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"backup-scheduler",
 		"central-hub",
 		"certificate-updater",
 		"certificate-watcher",
@@ -62,6 +65,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"disk-manager",
 		"external-controller-updater",
 		"fan-configurer",
+		"generation-promoter",
 		"global-clock-updater",
 		"host-key-reporter",
 		"http-server",
@@ -202,7 +206,11 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		"raft-transport",
 	)
 	primaryControllerWorkers := set.NewStrings(
+		"action-rollout",
+		"action-scheduler",
+		"backup-scheduler",
 		"external-controller-updater",
+		"generation-promoter",
 		"log-pruner",
 		"transaction-pruner",
 	)
@@ -287,6 +295,38 @@ func (s *ManifoldsSuite) TestManifoldsDependencies(c *gc.C) {
 
 var expectedMachineManifoldsWithDependencies = map[string][]string{
 
+	"action-rollout": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
+	"action-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"agent": {},
 
 	"api-address-updater": {
@@ -326,6 +366,22 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state",
 		"state-config-watcher"},
 
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-updater": {
@@ -389,6 +445,22 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-flag",
 		"upgrade-steps-gate"},
 
+	"generation-promoter": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"global-clock-updater": {
 		"agent",
 		"clock",
@@ -270,6 +270,9 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:       "juju-log-forward-http",
+				OpenHTTPFn: sinks.OpenHTTP,
 			}},
 		})),
 		// The model upgrader runs on all controller agents, and
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path"
 	"regexp"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/core/schedule"
 )
 
 const (
@@ -71,6 +73,24 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogSyslogForward determines whether audit records are also
+	// forwarded to the syslog server configured for the controller
+	// model with the syslog-host, syslog-ca-cert, syslog-client-cert
+	// and syslog-client-key model config attributes. Audit records are
+	// also forwarded, through the model's log forwarding filter, when
+	// logforward-audit is set in the controller model.
+	AuditLogSyslogForward = "audit-log-syslog-forward"
+
+	// AuditLogWebhookURL is an HTTP(S) URL that audit records are
+	// also POSTed to, as JSON.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
+	// AuditLogBufferSize is the number of audit records each remote
+	// audit log sink will hold while waiting to be sent. Records are
+	// dropped when the buffer is full, so a slow sink never blocks
+	// API calls.
+	AuditLogBufferSize = "audit-log-buffer-size"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -156,6 +176,54 @@ const (
 	// to not sleep at all.
 	PruneTxnSleepTime = "prune-txn-sleep-time"
 
+	// BackupSchedule is a cron-like schedule on which the controller
+	// creates backups of itself. Scheduled backups are disabled when
+	// no schedule is set.
+	BackupSchedule = "backup-schedule"
+
+	// BackupKeepDaily is the number of days for which the most recent
+	// scheduled backup of each day is kept.
+	BackupKeepDaily = "backup-keep-daily"
+
+	// BackupKeepWeekly is the number of weeks for which the most
+	// recent scheduled backup of each week is kept. If both this and
+	// BackupKeepDaily are 0, scheduled backups are never removed.
+	BackupKeepWeekly = "backup-keep-weekly"
+
+	// BackupStorage is the target in which new backup archives are
+	// stored: "controller" keeps them in the controller database,
+	// "local" in a directory on the controller machine (only for
+	// controllers with a single machine) and "s3" in an S3-compatible
+	// object store. Archives already stored elsewhere stay where they
+	// are.
+	BackupStorage = "backup-storage"
+
+	// BackupStorageDirectory is the absolute path of the directory
+	// used by the "local" backup storage target.
+	BackupStorageDirectory = "backup-storage-directory"
+
+	// BackupS3Endpoint is the URL of the object store used by the
+	// "s3" backup storage target, e.g. https://s3.amazonaws.com.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region used to sign requests to the
+	// object store.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3Bucket is the bucket backup archives are stored in. It
+	// must already exist.
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3AccessKey is the access key used to authenticate with
+	// the object store.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to authenticate with
+	// the object store. It can be set like any other controller
+	// config attribute, but is stored as a secret and is never
+	// returned with the controller config.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -174,6 +242,25 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultAuditLogBufferSize is the default number of audit
+	// records buffered for each remote audit log sink.
+	DefaultAuditLogBufferSize = 1000
+
+	// DefaultBackupKeepDaily is the default number of daily scheduled
+	// backups to keep.
+	DefaultBackupKeepDaily = 7
+
+	// DefaultBackupKeepWeekly is the default number of weekly scheduled
+	// backups to keep.
+	DefaultBackupKeepWeekly = 4
+
+	// DefaultBackupStorage is the default target for backup archives.
+	DefaultBackupStorage = "controller"
+
+	// DefaultBackupS3Region is the default region for the "s3"
+	// backup storage target.
+	DefaultBackupS3Region = "us-east-1"
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -268,6 +355,19 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogSyslogForward,
+		AuditLogWebhookURL,
+		AuditLogBufferSize,
+		BackupSchedule,
+		BackupKeepDaily,
+		BackupKeepWeekly,
+		BackupStorage,
+		BackupStorageDirectory,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 		CAASOperatorImagePath,
 		Features,
 		MeteringURL,
@@ -281,6 +381,19 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogSyslogForward,
+		AuditLogWebhookURL,
+		AuditLogBufferSize,
+		BackupSchedule,
+		BackupKeepDaily,
+		BackupKeepWeekly,
+		BackupStorage,
+		BackupStorageDirectory,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -465,6 +578,85 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogSyslogForward returns whether audit records should be
+// forwarded to the controller model's syslog server.
+func (c Config) AuditLogSyslogForward() bool {
+	if v, ok := c[AuditLogSyslogForward]; ok {
+		return v.(bool)
+	}
+	return false
+}
+
+// AuditLogWebhookURL returns the URL audit records should be POSTed
+// to, or "" if there is no webhook.
+func (c Config) AuditLogWebhookURL() string {
+	return c.asString(AuditLogWebhookURL)
+}
+
+// AuditLogBufferSize returns the number of audit records buffered for
+// each remote audit log sink.
+func (c Config) AuditLogBufferSize() int {
+	return c.intOrDefault(AuditLogBufferSize, DefaultAuditLogBufferSize)
+}
+
+// BackupSchedule returns the cron-like schedule on which the controller
+// creates backups, or "" if scheduled backups are disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupKeepDaily returns the number of days for which a scheduled
+// backup is kept.
+func (c Config) BackupKeepDaily() int {
+	return c.intOrDefault(BackupKeepDaily, DefaultBackupKeepDaily)
+}
+
+// BackupKeepWeekly returns the number of weeks for which a scheduled
+// backup is kept.
+func (c Config) BackupKeepWeekly() int {
+	return c.intOrDefault(BackupKeepWeekly, DefaultBackupKeepWeekly)
+}
+
+// BackupStorage returns the target in which new backup archives are
+// stored.
+func (c Config) BackupStorage() string {
+	if v := c.asString(BackupStorage); v != "" {
+		return v
+	}
+	return DefaultBackupStorage
+}
+
+// BackupStorageDirectory returns the directory used by the "local"
+// backup storage target.
+func (c Config) BackupStorageDirectory() string {
+	return c.asString(BackupStorageDirectory)
+}
+
+// BackupS3Endpoint returns the URL of the object store used by the
+// "s3" backup storage target.
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Region returns the region used to sign requests to the
+// object store.
+func (c Config) BackupS3Region() string {
+	if v := c.asString(BackupS3Region); v != "" {
+		return v
+	}
+	return DefaultBackupS3Region
+}
+
+// BackupS3Bucket returns the bucket backup archives are stored in.
+func (c Config) BackupS3Bucket() string {
+	return c.asString(BackupS3Bucket)
+}
+
+// BackupS3AccessKey returns the access key for the object store.
+func (c Config) BackupS3AccessKey() string {
+	return c.asString(BackupS3AccessKey)
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -752,6 +944,38 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AuditLogWebhookURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid audit log webhook URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid audit log webhook URL: expected http or https scheme, got %q", v)
+		}
+	}
+
+	if v, ok := c[AuditLogBufferSize].(int); ok {
+		if v <= 0 {
+			return errors.Errorf("invalid audit log buffer size: should be a positive number of records, got %d", v)
+		}
+	}
+
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := schedule.Parse(v); err != nil {
+			return errors.Annotate(err, "invalid backup schedule")
+		}
+	}
+
+	for _, key := range []string{BackupKeepDaily, BackupKeepWeekly} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.Errorf("invalid %s: should be a non-negative number of backups, got %d", key, v)
+		}
+	}
+
+	if err := validateBackupStorage(c); err != nil {
+		return errors.Trace(err)
+	}
+
 	if v, ok := c[ControllerAPIPort].(int); ok {
 		// TODO: change the validation so 0 is invalide and --reset is used.
 		// However that doesn't exist yet.
@@ -775,6 +999,42 @@ func Validate(c Config) error {
 	return nil
 }
 
+// validateBackupStorage checks that the configured backup storage
+// target is known and has the settings it needs.
+func validateBackupStorage(c Config) error {
+	switch storage := c.BackupStorage(); storage {
+	case "controller":
+	case "local":
+		dir := c.BackupStorageDirectory()
+		if dir == "" {
+			return errors.Errorf("%s must be set for local backup storage", BackupStorageDirectory)
+		}
+		// Controllers always run on Linux, wherever the config is
+		// validated.
+		if !path.IsAbs(dir) {
+			return errors.Errorf("%s must be an absolute path, got %q", BackupStorageDirectory, dir)
+		}
+	case "s3":
+		// The secret key is stored apart from the rest of the
+		// config, and is checked when the config is updated.
+		for _, key := range []string{BackupS3Endpoint, BackupS3Bucket, BackupS3AccessKey} {
+			if c.asString(key) == "" {
+				return errors.Errorf("%s must be set for s3 backup storage", key)
+			}
+		}
+		endpoint, err := url.Parse(c.BackupS3Endpoint())
+		if err != nil {
+			return errors.Annotate(err, "invalid backup s3 endpoint")
+		}
+		if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+			return errors.Errorf("invalid backup s3 endpoint: expected http or https scheme, got %q", c.BackupS3Endpoint())
+		}
+	default:
+		return errors.Errorf("invalid %s: expected controller, local or s3, got %q", BackupStorage, storage)
+	}
+	return nil
+}
+
 func (c Config) validateSpaceConfig(key, topic string) error {
 	val := c[key]
 	if val == nil {
@@ -832,6 +1092,19 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:         schema.String(),
 	AuditLogMaxBackups:      schema.ForceInt(),
 	AuditLogExcludeMethods:  schema.List(schema.String()),
+	AuditLogSyslogForward:   schema.Bool(),
+	AuditLogWebhookURL:      schema.String(),
+	AuditLogBufferSize:      schema.ForceInt(),
+	BackupSchedule:          schema.String(),
+	BackupKeepDaily:         schema.ForceInt(),
+	BackupKeepWeekly:        schema.ForceInt(),
+	BackupStorage:           schema.String(),
+	BackupStorageDirectory:  schema.String(),
+	BackupS3Endpoint:        schema.String(),
+	BackupS3Region:          schema.String(),
+	BackupS3Bucket:          schema.String(),
+	BackupS3AccessKey:       schema.String(),
+	BackupS3SecretKey:       schema.String(),
 	APIPort:                 schema.ForceInt(),
 	APIPortOpenDelay:        schema.String(),
 	ControllerAPIPort:       schema.ForceInt(),
@@ -865,6 +1138,19 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
 	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
 	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
+	AuditLogSyslogForward:   schema.Omit,
+	AuditLogWebhookURL:      schema.Omit,
+	AuditLogBufferSize:      schema.Omit,
+	BackupSchedule:          schema.Omit,
+	BackupKeepDaily:         schema.Omit,
+	BackupKeepWeekly:        schema.Omit,
+	BackupStorage:           schema.Omit,
+	BackupStorageDirectory:  schema.Omit,
+	BackupS3Endpoint:        schema.Omit,
+	BackupS3Region:          schema.Omit,
+	BackupS3Bucket:          schema.Omit,
+	BackupS3AccessKey:       schema.Omit,
+	BackupS3SecretKey:       schema.Omit,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
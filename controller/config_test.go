@@ -188,6 +188,75 @@ var validateTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "invalid audit log webhook URL scheme",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.AuditLogWebhookURL: "ftp://siem.example.com",
+	},
+	expectError: `invalid audit log webhook URL: expected http or https scheme, got "ftp://siem.example.com"`,
+}, {
+	about: "invalid audit log buffer size",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.AuditLogBufferSize: 0,
+	},
+	expectError: `invalid audit log buffer size: should be a positive number of records, got 0`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupSchedule: "0 3 * *",
+	},
+	expectError: `invalid backup schedule: schedule "0 3 \* \*": expected 5 fields, got 4`,
+}, {
+	about: "negative backup retention",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.BackupKeepWeekly: -1,
+	},
+	expectError: `invalid backup-keep-weekly: should be a non-negative number of backups, got -1`,
+}, {
+	about: "unknown backup storage",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "tape",
+	},
+	expectError: `invalid backup-storage: expected controller, local or s3, got "tape"`,
+}, {
+	about: "local backup storage without directory",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "local",
+	},
+	expectError: `backup-storage-directory must be set for local backup storage`,
+}, {
+	about: "local backup storage with relative directory",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.BackupStorage:          "local",
+		controller.BackupStorageDirectory: "backups",
+	},
+	expectError: `backup-storage-directory must be an absolute path, got "backups"`,
+}, {
+	about: "s3 backup storage without bucket",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3AccessKey: "access",
+	},
+	expectError: `backup-s3-bucket must be set for s3 backup storage`,
+}, {
+	about: "s3 backup storage with invalid endpoint",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "s3.example.com",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+	},
+	expectError: `invalid backup s3 endpoint: expected http or https scheme, got "s3.example.com"`,
 }, {
 	about: "invalid CAAS operator docker image path",
 	config: controller.Config{
@@ -402,6 +471,57 @@ func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals,
 		set.NewStrings(controller.DefaultAuditLogExcludeMethods...))
+	c.Assert(cfg.AuditLogSyslogForward(), gc.Equals, false)
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "")
+	c.Assert(cfg.AuditLogBufferSize(), gc.Equals, 1000)
+}
+
+func (s *ConfigSuite) TestBackupDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "")
+	c.Assert(cfg.BackupKeepDaily(), gc.Equals, 7)
+	c.Assert(cfg.BackupKeepWeekly(), gc.Equals, 4)
+	c.Assert(cfg.BackupStorage(), gc.Equals, "controller")
+	c.Assert(cfg.BackupS3Region(), gc.Equals, "us-east-1")
+}
+
+func (s *ConfigSuite) TestBackupValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":    "30 2 * * *",
+			"backup-keep-daily":  3,
+			"backup-keep-weekly": 0,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "30 2 * * *")
+	c.Assert(cfg.BackupKeepDaily(), gc.Equals, 3)
+	c.Assert(cfg.BackupKeepWeekly(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestBackupStorageValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-storage":           "s3",
+			"backup-storage-directory": "/var/backups/juju",
+			"backup-s3-endpoint":       "https://s3.example.com",
+			"backup-s3-region":         "eu-west-2",
+			"backup-s3-bucket":         "juju-backups",
+			"backup-s3-access-key":     "access",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorage(), gc.Equals, "s3")
+	c.Assert(cfg.BackupStorageDirectory(), gc.Equals, "/var/backups/juju")
+	c.Assert(cfg.BackupS3Endpoint(), gc.Equals, "https://s3.example.com")
+	c.Assert(cfg.BackupS3Region(), gc.Equals, "eu-west-2")
+	c.Assert(cfg.BackupS3Bucket(), gc.Equals, "juju-backups")
+	c.Assert(cfg.BackupS3AccessKey(), gc.Equals, "access")
 }
 
 func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
@@ -414,9 +534,15 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 			"audit-log-max-size":        "100M",
 			"audit-log-max-backups":     10.0,
 			"audit-log-exclude-methods": []string{"Fleet.Foxes", "King.Gizzard", "ReadOnlyMethods"},
+			"audit-log-syslog-forward":  true,
+			"audit-log-webhook-url":     "https://siem.example.com/audit",
+			"audit-log-buffer-size":     50,
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSyslogForward(), gc.Equals, true)
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "https://siem.example.com/audit")
+	c.Assert(cfg.AuditLogBufferSize(), gc.Equals, 50)
 	c.Assert(cfg.AuditingEnabled(), gc.Equals, false)
 	c.Assert(cfg.AuditLogCaptureArgs(), gc.Equals, true)
 	c.Assert(cfg.AuditLogMaxSizeMB(), gc.Equals, 100)
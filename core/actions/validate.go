@@ -0,0 +1,121 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/gojsonschema"
+	"gopkg.in/juju/charm.v6"
+)
+
+// ValidationError holds every problem found with the parameters given
+// to an action.
+type ValidationError struct {
+	Action   string
+	Problems []string
+}
+
+// Error is part of the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid parameters for action %q: %s", e.Action, strings.Join(e.Problems, "; "))
+}
+
+// ValidateParams checks the parameters for the named action against
+// the action's schema, returning them with the schema's defaults
+// inserted. Parameters the schema doesn't define are rejected, at the
+// top level and within nested objects, unless the schema explicitly
+// allows additional properties. If the parameters aren't valid, the
+// error is a *ValidationError listing every problem found.
+func ValidateParams(name string, spec charm.ActionSpec, params map[string]interface{}) (map[string]interface{}, error) {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	schema, problems := checkUnknownParams("", spec.Params, params)
+
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewGoLoader(schema),
+		gojsonschema.NewGoLoader(params),
+	)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot validate parameters for action %q", name)
+	}
+	for _, resultErr := range result.Errors() {
+		problems = append(problems, resultErr.String())
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, &ValidationError{Action: name, Problems: problems}
+	}
+
+	withDefaults, err := spec.InsertDefaults(params)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot insert defaults for action %q", name)
+	}
+	return withDefaults, nil
+}
+
+// checkUnknownParams reports the parameters at path that the object
+// schema doesn't define, recursing into nested object schemas.
+// JSON-Schema allows any additional property unless told otherwise;
+// we reject unknown keys ourselves, so the problem is reported the
+// same way for every charm, unless the schema explicitly allows them.
+// It returns a copy of the schema without the "additionalProperties"
+// constraints it checked, so they aren't reported twice.
+func checkUnknownParams(path string, schema, params map[string]interface{}) (map[string]interface{}, []string) {
+	result := make(map[string]interface{}, len(schema))
+	for k, v := range schema {
+		result[k] = v
+	}
+
+	var problems []string
+	properties, _ := schema["properties"].(map[string]interface{})
+	checkUnknown := true
+	switch additional := schema["additionalProperties"].(type) {
+	case bool:
+		checkUnknown = !additional
+	case map[string]interface{}:
+		checkUnknown = false
+	}
+	if checkUnknown {
+		for key := range params {
+			if _, ok := properties[key]; !ok {
+				problems = append(problems, fmt.Sprintf("unknown parameter %q", path+key))
+			}
+		}
+		delete(result, "additionalProperties")
+	}
+
+	if properties == nil {
+		return result, problems
+	}
+	resultProperties := make(map[string]interface{}, len(properties))
+	for key, property := range properties {
+		resultProperties[key] = property
+		propertySchema, ok := property.(map[string]interface{})
+		if !ok || !isObjectSchema(propertySchema) {
+			continue
+		}
+		value, ok := params[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		checked, propertyProblems := checkUnknownParams(path+key+".", propertySchema, value)
+		resultProperties[key] = checked
+		problems = append(problems, propertyProblems...)
+	}
+	result["properties"] = resultProperties
+	return result, problems
+}
+
+// isObjectSchema reports whether the schema describes an object.
+func isObjectSchema(schema map[string]interface{}) bool {
+	if _, ok := schema["properties"]; ok {
+		return true
+	}
+	return schema["type"] == "object"
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type validateSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&validateSuite{})
+
+var backupSpec = charm.ActionSpec{
+	Description: "Take a backup.",
+	Params: map[string]interface{}{
+		"type":     "object",
+		"title":    "backup",
+		"required": []interface{}{"dest"},
+		"properties": map[string]interface{}{
+			"dest": map[string]interface{}{
+				"type": "string",
+			},
+			"compress": map[string]interface{}{
+				"type":    "boolean",
+				"default": true,
+			},
+		},
+	},
+}
+
+func (s *validateSuite) TestValidateParamsInsertsDefaults(c *gc.C) {
+	params, err := actions.ValidateParams("backup", backupSpec, map[string]interface{}{
+		"dest": "s3",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(params, jc.DeepEquals, map[string]interface{}{
+		"dest":     "s3",
+		"compress": true,
+	})
+}
+
+func (s *validateSuite) TestValidateParamsKeepsGivenValues(c *gc.C) {
+	params, err := actions.ValidateParams("backup", backupSpec, map[string]interface{}{
+		"dest":     "s3",
+		"compress": false,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(params, jc.DeepEquals, map[string]interface{}{
+		"dest":     "s3",
+		"compress": false,
+	})
+}
+
+func (s *validateSuite) TestValidateParamsReportsEveryProblem(c *gc.C) {
+	_, err := actions.ValidateParams("backup", backupSpec, map[string]interface{}{
+		"compress": "yes",
+		"zzz":      1,
+		"aaa":      2,
+	})
+	c.Assert(err, gc.FitsTypeOf, &actions.ValidationError{})
+	verr := err.(*actions.ValidationError)
+	c.Check(verr.Action, gc.Equals, "backup")
+	c.Assert(verr.Problems, gc.HasLen, 4)
+	c.Check(verr.Problems[:2], jc.DeepEquals, []string{
+		`unknown parameter "aaa"`,
+		`unknown parameter "zzz"`,
+	})
+	c.Check(err, gc.ErrorMatches, `invalid parameters for action "backup": .*dest.*`)
+	c.Check(err, gc.ErrorMatches, `invalid parameters for action "backup": .*compress.*`)
+}
+
+func (s *validateSuite) TestValidateParamsAdditionalPropertiesAllowed(c *gc.C) {
+	spec := charm.ActionSpec{
+		Params: map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": true,
+			"properties": map[string]interface{}{
+				"dest": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+	params, err := actions.ValidateParams("backup", spec, map[string]interface{}{
+		"dest":  "s3",
+		"extra": 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(params, jc.DeepEquals, map[string]interface{}{
+		"dest":  "s3",
+		"extra": 1,
+	})
+}
+
+func (s *validateSuite) TestValidateParamsNestedUnknown(c *gc.C) {
+	spec := charm.ActionSpec{
+		Params: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"target": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"bucket": map[string]interface{}{"type": "string"},
+					},
+				},
+				"labels": map[string]interface{}{
+					"type":                 "object",
+					"additionalProperties": true,
+				},
+			},
+		},
+	}
+	_, err := actions.ValidateParams("backup", spec, map[string]interface{}{
+		"target": map[string]interface{}{"bucket": "b", "region": "r"},
+		"labels": map[string]interface{}{"any": "thing"},
+	})
+	c.Assert(err, gc.FitsTypeOf, &actions.ValidationError{})
+	c.Check(err.(*actions.ValidationError).Problems, jc.DeepEquals, []string{
+		`unknown parameter "target.region"`,
+	})
+}
+
+func (s *validateSuite) TestValidateParamsNil(c *gc.C) {
+	params, err := actions.ValidateParams(actions.JujuRunActionName, actions.PredefinedActionsSpec[actions.JujuRunActionName], nil)
+	c.Assert(err, gc.ErrorMatches, `invalid parameters for action "juju-run": .*command.*`)
+	c.Check(params, gc.IsNil)
+}
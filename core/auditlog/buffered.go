@@ -0,0 +1,200 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// NewBufferedLog returns an AuditLog that queues records in a buffer
+// holding up to size records, and writes them to the target from a
+// separate goroutine. If the target can't keep up and the buffer
+// fills, further records are dropped (and the number dropped is
+// logged) rather than blocking the API call being audited.
+//
+// Closing the log waits up to flushTimeout for the buffered records
+// to be written; any still buffered after that are dropped.
+func NewBufferedLog(target AuditLog, size int, flushTimeout time.Duration) AuditLog {
+	b := &bufferedLog{
+		target:       target,
+		flushTimeout: flushTimeout,
+		records:      make(chan Record, size),
+		done:         make(chan struct{}),
+	}
+	go b.loop()
+	return b
+}
+
+type bufferedLog struct {
+	target       AuditLog
+	flushTimeout time.Duration
+	records      chan Record
+	done         chan struct{}
+
+	// closeErr is the result of closing the target, set by the
+	// loop before done is closed.
+	closeErr error
+
+	mu        sync.Mutex
+	closed    bool
+	abandoned bool
+	dropped   int
+}
+
+// AddConversation implements AuditLog.
+func (b *bufferedLog) AddConversation(c Conversation) error {
+	b.addRecord(Record{Conversation: &c})
+	return nil
+}
+
+// AddRequest implements AuditLog.
+func (b *bufferedLog) AddRequest(r Request) error {
+	b.addRecord(Record{Request: &r})
+	return nil
+}
+
+// AddResponse implements AuditLog.
+func (b *bufferedLog) AddResponse(r ResponseErrors) error {
+	b.addRecord(Record{Errors: &r})
+	return nil
+}
+
+// Close implements AuditLog. Any records still in the buffer are
+// written to the target before it is closed. If they can't all be
+// written within the flush timeout, the rest are dropped and an error
+// reporting how many is returned; the target is then closed once the
+// write in progress completes.
+func (b *bufferedLog) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.records)
+	b.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return errors.Trace(b.closeErr)
+	case <-time.After(b.flushTimeout):
+	}
+	b.mu.Lock()
+	b.abandoned = true
+	b.mu.Unlock()
+	return errors.Errorf("audit records not written within %v, dropped %d", b.flushTimeout, len(b.records))
+}
+
+func (b *bufferedLog) addRecord(r Record) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		logger.Debugf("audit log closed, dropping record")
+		return
+	}
+	select {
+	case b.records <- r:
+	default:
+		b.dropped++
+	}
+}
+
+func (b *bufferedLog) loop() {
+	defer close(b.done)
+	for r := range b.records {
+		if b.isAbandoned() {
+			// Close has given up waiting, so the remaining
+			// records are drained without being written.
+			continue
+		}
+		if err := writeRecord(b.target, r); err != nil {
+			logger.Errorf("writing audit record: %v", err)
+		}
+		// Drops are reported whether or not the write succeeded,
+		// as a failing target is the likeliest cause of them.
+		b.reportDropped()
+	}
+	b.closeErr = b.target.Close()
+}
+
+func (b *bufferedLog) isAbandoned() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.abandoned
+}
+
+// reportDropped logs the number of records dropped since it was
+// last called, if any.
+func (b *bufferedLog) reportDropped() {
+	b.mu.Lock()
+	dropped := b.dropped
+	b.dropped = 0
+	b.mu.Unlock()
+	if dropped > 0 {
+		logger.Warningf("audit log buffer full, dropped %d records", dropped)
+	}
+}
+
+// writeRecord adds the record to the log according to its type.
+func writeRecord(log AuditLog, r Record) error {
+	switch {
+	case r.Conversation != nil:
+		return errors.Trace(log.AddConversation(*r.Conversation))
+	case r.Request != nil:
+		return errors.Trace(log.AddRequest(*r.Request))
+	case r.Errors != nil:
+		return errors.Trace(log.AddResponse(*r.Errors))
+	}
+	return errors.NotValidf("empty audit record")
+}
+
+// NewMultiLog returns an AuditLog that writes each record to all of
+// the logs passed in. Every log is written to, even if writing to an
+// earlier one fails; the first error is returned.
+func NewMultiLog(logs ...AuditLog) AuditLog {
+	return multiLog(logs)
+}
+
+type multiLog []AuditLog
+
+// AddConversation implements AuditLog.
+func (m multiLog) AddConversation(c Conversation) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddConversation(c)
+	})
+}
+
+// AddRequest implements AuditLog.
+func (m multiLog) AddRequest(r Request) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddRequest(r)
+	})
+}
+
+// AddResponse implements AuditLog.
+func (m multiLog) AddResponse(r ResponseErrors) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddResponse(r)
+	})
+}
+
+// Close implements AuditLog.
+func (m multiLog) Close() error {
+	return m.each(func(log AuditLog) error {
+		return log.Close()
+	})
+}
+
+func (m multiLog) each(f func(AuditLog) error) error {
+	var firstErr error
+	for _, log := range m {
+		if err := f(log); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return errors.Trace(firstErr)
+}
@@ -0,0 +1,160 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type BufferedLogSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&BufferedLogSuite{})
+
+func (s *BufferedLogSuite) TestWritesRecordsInOrder(c *gc.C) {
+	var target fakeLog
+	log := auditlog.NewBufferedLog(&target, 10, coretesting.LongWait)
+
+	c.Assert(log.AddConversation(auditlog.Conversation{ConversationID: "abc"}), jc.ErrorIsNil)
+	c.Assert(log.AddRequest(auditlog.Request{ConversationID: "abc", RequestID: 1}), jc.ErrorIsNil)
+	c.Assert(log.AddResponse(auditlog.ResponseErrors{ConversationID: "abc", RequestID: 1}), jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	target.stub.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse", "Close")
+}
+
+func (s *BufferedLogSuite) TestDropsRecordsWhenFull(c *gc.C) {
+	target := &blockingLog{
+		started: make(chan struct{}, 3),
+		unblock: make(chan struct{}),
+	}
+	log := auditlog.NewBufferedLog(target, 1, coretesting.LongWait)
+
+	// The first record is taken by the writer, which then blocks.
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	select {
+	case <-target.started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for first record")
+	}
+	// The second fills the buffer, and the third is dropped
+	// without blocking the caller.
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 2}), jc.ErrorIsNil)
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 3}), jc.ErrorIsNil)
+
+	close(target.unblock)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	c.Assert(target.ids, jc.DeepEquals, []uint64{1, 2})
+}
+
+func (s *BufferedLogSuite) TestReportsDropsWhenWriteFails(c *gc.C) {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("buffered-log-test", &tw), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("buffered-log-test")
+
+	target := &blockingLog{
+		started: make(chan struct{}, 3),
+		unblock: make(chan struct{}),
+		err:     errors.New("sink unavailable"),
+	}
+	log := auditlog.NewBufferedLog(target, 1, coretesting.LongWait)
+
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	select {
+	case <-target.started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for first record")
+	}
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 2}), jc.ErrorIsNil)
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 3}), jc.ErrorIsNil)
+
+	close(target.unblock)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	c.Check(tw.Log(), jc.LogMatches, jc.SimpleMessages{
+		{loggo.ERROR, "writing audit record: sink unavailable"},
+		{loggo.WARNING, "audit log buffer full, dropped 1 records"},
+	})
+}
+
+func (s *BufferedLogSuite) TestCloseDropsRecordsAfterTimeout(c *gc.C) {
+	target := &blockingLog{
+		started: make(chan struct{}, 3),
+		unblock: make(chan struct{}),
+	}
+	log := auditlog.NewBufferedLog(target, 2, coretesting.ShortWait)
+
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	select {
+	case <-target.started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for first record")
+	}
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 2}), jc.ErrorIsNil)
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 3}), jc.ErrorIsNil)
+
+	// The writer is stuck on the first record, so Close gives up
+	// on the buffered ones rather than blocking.
+	err := log.Close()
+	c.Assert(err, gc.ErrorMatches, "audit records not written within .*, dropped 2")
+
+	// The target is closed once the stuck write completes.
+	close(target.unblock)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(target.stub.Calls()) > 0 {
+			break
+		}
+	}
+	target.stub.CheckCallNames(c, "Close")
+	c.Assert(target.ids, jc.DeepEquals, []uint64{1})
+}
+
+func (s *BufferedLogSuite) TestAddAfterCloseIgnored(c *gc.C) {
+	var target fakeLog
+	log := auditlog.NewBufferedLog(&target, 10, coretesting.LongWait)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	target.stub.CheckCallNames(c, "Close")
+}
+
+func (s *BufferedLogSuite) TestMultiLogWritesToAll(c *gc.C) {
+	var first, second fakeLog
+	first.stub.SetErrors(errors.New("boom"))
+	log := auditlog.NewMultiLog(&first, &second)
+
+	err := log.AddConversation(auditlog.Conversation{ConversationID: "abc"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	first.stub.CheckCallNames(c, "AddConversation", "Close")
+	second.stub.CheckCallNames(c, "AddConversation", "Close")
+}
+
+// blockingLog records request ids, blocking on each until unblocked.
+// Each write returns err, if set.
+type blockingLog struct {
+	fakeLog
+	started chan struct{}
+	unblock chan struct{}
+	ids     []uint64
+	err     error
+}
+
+func (l *blockingLog) AddRequest(m auditlog.Request) error {
+	l.started <- struct{}{}
+	<-l.unblock
+	l.ids = append(l.ids, m.RequestID)
+	return l.err
+}
@@ -6,6 +6,9 @@ package auditlog
 import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 // Config holds parameters to control audit logging.
@@ -29,6 +32,27 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// SyslogForward says whether records should also be forwarded to
+	// the controller model's syslog server.
+	SyslogForward bool
+
+	// SyslogFilter selects the records forwarded to syslog. It is
+	// the controller model's log forwarding filter when forwarding
+	// is enabled there with logforward-audit.
+	SyslogFilter logfwd.Filter
+
+	// SyslogConfig is the syslog forwarding config of the controller
+	// model that records are sent with when SyslogForward is set. It
+	// is nil if the controller model has no syslog config.
+	SyslogConfig *syslog.RawConfig
+
+	// WebhookURL, if set, is a URL that records are also POSTed to.
+	WebhookURL string
+
+	// BufferSize is the number of records each remote sink holds
+	// while waiting to send them.
+	BufferSize int
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
@@ -0,0 +1,226 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Filter selects conversations read from the audit log. Zero-valued
+// fields match every conversation.
+type Filter struct {
+	// Who matches the user that started the conversation.
+	Who string
+
+	// ModelUUID matches the model the conversation was with.
+	ModelUUID string
+
+	// Methods matches conversations containing a call to any of the
+	// methods, each given as "Facade.Method" or just "Facade".
+	Methods []string
+
+	// From and To bound the time the conversation started.
+	From time.Time
+	To   time.Time
+
+	// ErrorsOnly matches conversations where a call returned errors.
+	ErrorsOnly bool
+
+	// Limit is the maximum number of conversations to return; the
+	// most recent are kept.
+	Limit int
+}
+
+// ConversationLog holds a conversation read from the audit log,
+// along with the calls made in it.
+type ConversationLog struct {
+	Conversation
+	Calls []Call
+}
+
+// Call holds a request read from the audit log, along with any
+// errors returned in response.
+type Call struct {
+	Request
+	Errors []*Error
+}
+
+// HasErrors returns whether any call in the conversation returned
+// errors.
+func (c ConversationLog) HasErrors() bool {
+	for _, call := range c.Calls {
+		if len(call.Errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns whether the conversation is selected by the filter.
+func (f Filter) Match(c ConversationLog) bool {
+	if f.Who != "" && f.Who != c.Who {
+		return false
+	}
+	if f.ModelUUID != "" && f.ModelUUID != c.ModelUUID {
+		return false
+	}
+	if !f.From.IsZero() || !f.To.IsZero() {
+		when, err := time.Parse(time.RFC3339, c.When)
+		if err != nil {
+			return false
+		}
+		if !f.From.IsZero() && when.Before(f.From) {
+			return false
+		}
+		if !f.To.IsZero() && when.After(f.To) {
+			return false
+		}
+	}
+	if f.ErrorsOnly && !c.HasErrors() {
+		return false
+	}
+	if len(f.Methods) > 0 && !f.matchMethods(c.Calls) {
+		return false
+	}
+	return true
+}
+
+func (f Filter) matchMethods(calls []Call) bool {
+	for _, call := range calls {
+		for _, method := range f.Methods {
+			if method == call.Facade || method == call.Facade+"."+call.Method {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReadConversations reads the audit log files written by NewLogFile
+// in logDir, including any rotated backups, and returns the
+// conversations selected by the filter, oldest first.
+func ReadConversations(logDir string, filter Filter) ([]ConversationLog, error) {
+	backups, err := filepath.Glob(filepath.Join(logDir, "audit-*.log*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The backup names include the time they were rotated, so
+	// sorting them puts them in the order they were written.
+	sort.Strings(backups)
+	paths := append(backups, filepath.Join(logDir, "audit.log"))
+
+	var reader conversationReader
+	for _, path := range paths {
+		if err := reader.readFile(path); err != nil {
+			return nil, errors.Annotatef(err, "reading %q", path)
+		}
+	}
+	return reader.selected(filter), nil
+}
+
+// SelectConversations assembles conversations from the audit records,
+// given in the order they were written, and returns the conversations
+// selected by the filter, oldest first.
+func SelectConversations(records []Record, filter Filter) []ConversationLog {
+	var reader conversationReader
+	for _, record := range records {
+		reader.add(record)
+	}
+	return reader.selected(filter)
+}
+
+// conversationReader assembles conversations from audit records.
+type conversationReader struct {
+	conversations []*ConversationLog
+	byID          map[string]*ConversationLog
+}
+
+// selected returns the conversations read that match the filter.
+func (r *conversationReader) selected(filter Filter) []ConversationLog {
+	var result []ConversationLog
+	for _, c := range r.conversations {
+		if filter.Match(*c) {
+			result = append(result, *c)
+		}
+	}
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[len(result)-filter.Limit:]
+	}
+	return result
+}
+
+func (r *conversationReader) readFile(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+
+	var source io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer gz.Close()
+		source = gz
+	}
+	return errors.Trace(r.read(source))
+}
+
+func (r *conversationReader) read(source io.Reader) error {
+	scanner := bufio.NewScanner(source)
+	// Requests can include large arguments.
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			logger.Debugf("skipping unreadable audit record: %v", err)
+			continue
+		}
+		r.add(record)
+	}
+	return errors.Trace(scanner.Err())
+}
+
+func (r *conversationReader) add(record Record) {
+	if r.byID == nil {
+		r.byID = make(map[string]*ConversationLog)
+	}
+	switch {
+	case record.Conversation != nil:
+		c := &ConversationLog{Conversation: *record.Conversation}
+		r.conversations = append(r.conversations, c)
+		r.byID[c.ConversationID] = c
+	case record.Request != nil:
+		// Requests for conversations started in a log file that
+		// has since been removed are ignored.
+		if c, ok := r.byID[record.Request.ConversationID]; ok {
+			c.Calls = append(c.Calls, Call{Request: *record.Request})
+		}
+	case record.Errors != nil:
+		c, ok := r.byID[record.Errors.ConversationID]
+		if !ok {
+			return
+		}
+		for i := range c.Calls {
+			if c.Calls[i].RequestID == record.Errors.RequestID {
+				c.Calls[i].Errors = append(c.Calls[i].Errors, record.Errors.Errors...)
+				return
+			}
+		}
+	}
+}
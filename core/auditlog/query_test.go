@@ -0,0 +1,149 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type QuerySuite struct {
+	testing.IsolationSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+func (s *QuerySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	logFile := auditlog.NewLogFile(s.dir, 300, 10)
+	defer logFile.Close()
+
+	add := func(err error) {
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	add(logFile.AddConversation(auditlog.Conversation{
+		Who:            "user-bob",
+		What:           "juju deploy mysql",
+		When:           "2018-11-01T10:00:00Z",
+		ModelUUID:      "model-1",
+		ConversationID: "aaa",
+		ConnectionID:   "1",
+	}))
+	add(logFile.AddConversation(auditlog.Conversation{
+		Who:            "user-mary",
+		What:           "juju remove-unit mysql/0",
+		When:           "2018-11-01T11:00:00Z",
+		ModelUUID:      "model-2",
+		ConversationID: "bbb",
+		ConnectionID:   "2",
+	}))
+	add(logFile.AddRequest(auditlog.Request{
+		ConversationID: "aaa",
+		ConnectionID:   "1",
+		RequestID:      1,
+		When:           "2018-11-01T10:00:01Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        8,
+	}))
+	add(logFile.AddRequest(auditlog.Request{
+		ConversationID: "bbb",
+		ConnectionID:   "2",
+		RequestID:      1,
+		When:           "2018-11-01T11:00:01Z",
+		Facade:         "Application",
+		Method:         "DestroyUnit",
+		Version:        8,
+	}))
+	add(logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "aaa",
+		ConnectionID:   "1",
+		RequestID:      1,
+		When:           "2018-11-01T10:00:02Z",
+	}))
+	add(logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "bbb",
+		ConnectionID:   "2",
+		RequestID:      1,
+		When:           "2018-11-01T11:00:02Z",
+		Errors:         []*auditlog.Error{{Message: "unit not found", Code: "not found"}},
+	}))
+}
+
+func (s *QuerySuite) conversationIDs(c *gc.C, filter auditlog.Filter) []string {
+	conversations, err := auditlog.ReadConversations(s.dir, filter)
+	c.Assert(err, jc.ErrorIsNil)
+	ids := []string{}
+	for _, conversation := range conversations {
+		ids = append(ids, conversation.ConversationID)
+	}
+	return ids
+}
+
+func (s *QuerySuite) TestReadAll(c *gc.C) {
+	conversations, err := auditlog.ReadConversations(s.dir, auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 2)
+	c.Assert(conversations[1], jc.DeepEquals, auditlog.ConversationLog{
+		Conversation: auditlog.Conversation{
+			Who:            "user-mary",
+			What:           "juju remove-unit mysql/0",
+			When:           "2018-11-01T11:00:00Z",
+			ModelUUID:      "model-2",
+			ConversationID: "bbb",
+			ConnectionID:   "2",
+		},
+		Calls: []auditlog.Call{{
+			Request: auditlog.Request{
+				ConversationID: "bbb",
+				ConnectionID:   "2",
+				RequestID:      1,
+				When:           "2018-11-01T11:00:01Z",
+				Facade:         "Application",
+				Method:         "DestroyUnit",
+				Version:        8,
+			},
+			Errors: []*auditlog.Error{{Message: "unit not found", Code: "not found"}},
+		}},
+	})
+}
+
+func (s *QuerySuite) TestFilters(c *gc.C) {
+	at := func(value string) time.Time {
+		t, err := time.Parse(time.RFC3339, value)
+		c.Assert(err, jc.ErrorIsNil)
+		return t
+	}
+	for i, test := range []struct {
+		filter auditlog.Filter
+		expect []string
+	}{
+		{auditlog.Filter{Who: "user-bob"}, []string{"aaa"}},
+		{auditlog.Filter{ModelUUID: "model-2"}, []string{"bbb"}},
+		{auditlog.Filter{Methods: []string{"Application"}}, []string{"aaa", "bbb"}},
+		{auditlog.Filter{Methods: []string{"Application.Deploy"}}, []string{"aaa"}},
+		{auditlog.Filter{Methods: []string{"Client.FullStatus"}}, []string{}},
+		{auditlog.Filter{From: at("2018-11-01T10:30:00Z")}, []string{"bbb"}},
+		{auditlog.Filter{To: at("2018-11-01T10:30:00Z")}, []string{"aaa"}},
+		{auditlog.Filter{ErrorsOnly: true}, []string{"bbb"}},
+		{auditlog.Filter{Limit: 1}, []string{"bbb"}},
+	} {
+		c.Logf("test %d: %#v", i, test.filter)
+		c.Check(s.conversationIDs(c, test.filter), jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *QuerySuite) TestNoLogFile(c *gc.C) {
+	conversations, err := auditlog.ReadConversations(c.MkDir(), auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 0)
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// SyslogSender sends records to a syslog server. It is implemented
+// by the logfwd/syslog Client.
+type SyslogSender interface {
+	Send([]logfwd.Record) error
+	Close() error
+}
+
+// NewSyslogLog returns an AuditLog that forwards each audit record,
+// in the same JSON format as the audit log file, to syslog using the
+// sender. The origin identifies the controller agent sending them.
+func NewSyslogLog(sender SyslogSender, origin logfwd.Origin) AuditLog {
+	return &syslogLog{
+		sender: sender,
+		origin: origin,
+	}
+}
+
+type syslogLog struct {
+	sender SyslogSender
+	origin logfwd.Origin
+}
+
+// AddConversation implements AuditLog.
+func (s *syslogLog) AddConversation(c Conversation) error {
+	return errors.Trace(s.send(c.When, Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (s *syslogLog) AddRequest(r Request) error {
+	return errors.Trace(s.send(r.When, Record{Request: &r}))
+}
+
+// AddResponse implements AuditLog.
+func (s *syslogLog) AddResponse(r ResponseErrors) error {
+	return errors.Trace(s.send(r.When, Record{Errors: &r}))
+}
+
+// Close implements AuditLog.
+func (s *syslogLog) Close() error {
+	return errors.Trace(s.sender.Close())
+}
+
+func (s *syslogLog) send(when string, r Record) error {
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	timestamp, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		return errors.Annotatef(err, "parsing record time %q", when)
+	}
+	return errors.Trace(s.sender.Send([]logfwd.Record{{
+		Origin:    s.origin,
+		Timestamp: timestamp,
+		Level:     loggo.INFO,
+		Location:  logfwd.SourceLocation{Module: logfwd.AuditModule},
+		Message:   string(bytes),
+	}}))
+}
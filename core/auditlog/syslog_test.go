@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SyslogSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SyslogSuite{})
+
+func (s *SyslogSuite) TestSendsRecords(c *gc.C) {
+	var sender fakeSender
+	origin := logfwd.OriginForMachineAgent(
+		names.NewMachineTag("0"),
+		coretesting.ControllerTag.Id(),
+		coretesting.ModelTag.Id(),
+		version.MustParse("2.5.0"),
+	)
+	log := auditlog.NewSyslogLog(&sender, origin)
+
+	err := log.AddConversation(auditlog.Conversation{
+		Who:            "deerhoof",
+		What:           "gojira",
+		When:           "2017-11-27T13:21:24Z",
+		ModelName:      "admin/default",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	sender.stub.CheckCallNames(c, "Send", "Close")
+	records := sender.stub.Calls()[0].Args[0].([]logfwd.Record)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Origin, jc.DeepEquals, origin)
+	c.Check(records[0].Timestamp, gc.Equals, time.Date(2017, 11, 27, 13, 21, 24, 0, time.UTC))
+	c.Check(records[0].Level, gc.Equals, loggo.INFO)
+	c.Check(records[0].Location.Module, gc.Equals, "juju.auditlog")
+	c.Check(records[0].Message, gc.Equals, `{"conversation":{"who":"deerhoof","what":"gojira","when":"2017-11-27T13:21:24Z","model-name":"admin/default","model-uuid":"","conversation-id":"0123456789abcdef","connection-id":"AC1"}}`)
+}
+
+func (s *SyslogSuite) TestBadTimestamp(c *gc.C) {
+	var sender fakeSender
+	log := auditlog.NewSyslogLog(&sender, logfwd.Origin{})
+
+	err := log.AddRequest(auditlog.Request{When: "yesterday"})
+	c.Assert(err, gc.ErrorMatches, `parsing record time "yesterday": .*`)
+	sender.stub.CheckNoCalls(c)
+}
+
+type fakeSender struct {
+	stub testing.Stub
+}
+
+func (s *fakeSender) Send(records []logfwd.Record) error {
+	s.stub.AddCall("Send", records)
+	return s.stub.NextErr()
+}
+
+func (s *fakeSender) Close() error {
+	s.stub.AddCall("Close")
+	return s.stub.NextErr()
+}
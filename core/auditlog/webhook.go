@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// defaultWebhookTimeout is how long a webhook POST may take when no
+// HTTP client is specified.
+const defaultWebhookTimeout = 30 * time.Second
+
+// NewWebhookLog returns an AuditLog that POSTs each audit record, in
+// the same JSON format as the audit log file, to the URL. If client
+// is nil an HTTP client with a default timeout is used.
+func NewWebhookLog(url string, client *http.Client) AuditLog {
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	return &webhookLog{
+		url:    url,
+		client: client,
+	}
+}
+
+type webhookLog struct {
+	url    string
+	client *http.Client
+}
+
+// AddConversation implements AuditLog.
+func (w *webhookLog) AddConversation(c Conversation) error {
+	return errors.Trace(w.post(Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (w *webhookLog) AddRequest(r Request) error {
+	return errors.Trace(w.post(Record{Request: &r}))
+}
+
+// AddResponse implements AuditLog.
+func (w *webhookLog) AddResponse(r ResponseErrors) error {
+	return errors.Trace(w.post(Record{Errors: &r}))
+}
+
+// Close implements AuditLog.
+func (w *webhookLog) Close() error {
+	return nil
+}
+
+func (w *webhookLog) post(r Record) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Annotate(err, "posting audit record")
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("posting audit record: unexpected response %q", resp.Status)
+	}
+	return nil
+}
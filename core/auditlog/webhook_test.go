@@ -0,0 +1,62 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type WebhookSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WebhookSuite{})
+
+func (s *WebhookSuite) TestPostsRecords(c *gc.C) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+
+	log := auditlog.NewWebhookLog(server.URL, nil)
+	err := log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		When:           "2017-12-12T11:35:11Z",
+		Errors: []*auditlog.Error{
+			{Message: "oops", Code: "unauthorized access"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	c.Assert(bodies, jc.DeepEquals, []string{
+		`{"errors":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":25,"when":"2017-12-12T11:35:11Z","errors":[{"message":"oops","code":"unauthorized access"}]}}`,
+	})
+}
+
+func (s *WebhookSuite) TestErrorResponse(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	log := auditlog.NewWebhookLog(server.URL, nil)
+	err := log.AddRequest(auditlog.Request{RequestID: 1})
+	c.Assert(err, gc.ErrorMatches, `posting audit record: unexpected response "503 Service Unavailable"`)
+}
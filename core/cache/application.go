@@ -43,3 +43,24 @@ func (m *Application) setDetails(details ApplicationChange) {
 		// TODO: publish config change...
 	}
 }
+
+// Name returns the name of the application.
+func (m *Application) Name() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.details.Name
+}
+
+// CharmURL returns the charm URL that the application is using.
+func (m *Application) CharmURL() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.details.CharmURL
+}
+
+// Details returns a copy of the last change applied to the application.
+func (m *Application) Details() ApplicationChange {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.details
+}
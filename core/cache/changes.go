@@ -5,6 +5,7 @@ package cache
 
 import (
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/status"
 )
@@ -29,22 +30,155 @@ type RemoveModel struct {
 // ApplicationChange represents either a new application, or a change
 // to an existing application in a model.
 type ApplicationChange struct {
+	ModelUUID            string
+	Name                 string
+	Exposed              bool
+	CharmURL             string
+	Life                 life.Value
+	MinUnits             int
+	Constraints          constraints.Value
+	Config               map[string]interface{}
+	Subordinate          bool
+	Status               status.StatusInfo
+	WorkloadVersion      string
+	Series               string
+	EndpointBindings     map[string]string
+	CharmVersion         string
+	CharmLXDProfile      *LXDProfile
+	MetricsPlanRequired  bool
+	HasMetricCredentials bool
+	LatestCharmURL       string
+	CharmModifiedVersion int
+	ForceCharm           bool
+}
+
+// LXDProfile represents the LXD profile defined by the charm
+// of a cached application.
+type LXDProfile struct {
+	Config      map[string]string
+	Description string
+	Devices     map[string]map[string]string
+}
+
+// RemoveApplication represents the situation when an application
+// is removed from a model in the database.
+type RemoveApplication struct {
+	ModelUUID string
+	Name      string
+}
+
+// Address represents a network address of a cached machine.
+type Address struct {
+	Value string
+	Type  string
+	Scope string
+}
+
+// NetworkInterface represents a network interface of a cached machine
+// that has addresses assigned to it.
+type NetworkInterface struct {
+	DeviceName     string
+	MACAddress     string
+	IsUp           bool
+	IPAddresses    []string
+	Gateways       []string
+	DNSNameservers []string
+	Spaces         []string
+}
+
+// MachineChange represents either a new machine, or a change
+// to an existing machine in a model.
+type MachineChange struct {
+	ModelUUID                string
+	Id                       string
+	InstanceId               string
+	DisplayName              string
+	AgentStatus              status.StatusInfo
+	InstanceStatus           status.StatusInfo
+	AgentVersion             string
+	Life                     life.Value
+	Series                   string
+	SupportedContainers      []instance.ContainerType
+	SupportedContainersKnown bool
+	HardwareCharacteristics  *instance.HardwareCharacteristics
+	Jobs                     []string
+	Addresses                []Address
+	HasVote                  bool
+	WantsVote                bool
+	Constraints              constraints.Value
+	CharmProfiles            []string
+	NetworkInterfaces        []NetworkInterface
+}
+
+// RemoveMachine represents the situation when a machine
+// is removed from a model in the database.
+type RemoveMachine struct {
+	ModelUUID string
+	Id        string
+}
+
+// PortRange represents a range of ports opened by a unit.
+type PortRange struct {
+	FromPort int
+	ToPort   int
+	Protocol string
+}
+
+// UnitChange represents either a new unit, or a change
+// to an existing unit in a model.
+type UnitChange struct {
 	ModelUUID       string
 	Name            string
-	Exposed         bool
+	Application     string
+	Series          string
 	CharmURL        string
 	Life            life.Value
-	MinUnits        int
-	Constraints     constraints.Value
-	Config          map[string]interface{}
+	PublicAddress   string
+	PrivateAddress  string
+	MachineId       string
+	PortRanges      []PortRange
+	Principal       string
+	Subordinates    []string
 	Subordinate     bool
-	Status          status.StatusInfo
+	WorkloadStatus  status.StatusInfo
+	AgentStatus     status.StatusInfo
+	AgentVersion    string
 	WorkloadVersion string
+	Resolved        string
 }
 
-// RemoveApplication represents the situation when an application
+// RemoveUnit represents the situation when a unit
 // is removed from a model in the database.
-type RemoveApplication struct {
+type RemoveUnit struct {
 	ModelUUID string
 	Name      string
 }
+
+// RelationEndpoint represents one side of a cached relation.
+type RelationEndpoint struct {
+	Application string
+	Name        string
+	Role        string
+	Interface   string
+	Optional    bool
+	Limit       int
+	Scope       string
+}
+
+// RelationChange represents either a new relation, or a change
+// to an existing relation in a model.
+type RelationChange struct {
+	ModelUUID string
+	Key       string
+	Id        int
+	Life      life.Value
+	Endpoints []RelationEndpoint
+	Status    status.StatusInfo
+}
+
+// RemoveRelation represents the situation when a relation
+// is removed from a model in the database.
+type RemoveRelation struct {
+	ModelUUID string
+	Key       string
+}
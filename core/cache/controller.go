@@ -5,6 +5,7 @@ package cache
 
 import (
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -72,15 +73,42 @@ func (c *Controller) loop() error {
 		case <-c.tomb.Dying():
 			return nil
 		case change := <-c.config.Changes:
+			start := time.Now()
+			var kind string
 			switch ch := change.(type) {
 			case ModelChange:
+				kind = "model"
 				c.updateModel(ch)
 			case RemoveModel:
+				kind = "remove-model"
 				c.removeModel(ch)
 			case ApplicationChange:
+				kind = "application"
 				c.updateApplication(ch)
 			case RemoveApplication:
+				kind = "remove-application"
 				c.removeApplication(ch)
+			case MachineChange:
+				kind = "machine"
+				c.updateMachine(ch)
+			case RemoveMachine:
+				kind = "remove-machine"
+				c.removeMachine(ch)
+			case UnitChange:
+				kind = "unit"
+				c.updateUnit(ch)
+			case RemoveUnit:
+				kind = "remove-unit"
+				c.removeUnit(ch)
+			case RelationChange:
+				kind = "relation"
+				c.updateRelation(ch)
+			case RemoveRelation:
+				kind = "remove-relation"
+				c.removeRelation(ch)
+			}
+			if kind != "" {
+				c.metrics.ChangeProcessing.WithLabelValues(kind).Observe(time.Since(start).Seconds())
 			}
 			if c.config.Notify != nil {
 				c.config.Notify(change)
@@ -132,6 +160,7 @@ func (c *Controller) Model(uuid string) (*Model, error) {
 	defer c.mu.Unlock()
 
 	model, found := c.models[uuid]
+	c.metrics.lookup("model", found)
 	if !found {
 		return nil, errors.NotFoundf("model %q", uuid)
 	}
@@ -145,7 +174,7 @@ func (c *Controller) updateModel(ch ModelChange) {
 
 	model, found := c.models[ch.ModelUUID]
 	if !found {
-		model = newModel(c.metrics, c.hub)
+		model = newModel(ch.ModelUUID, c.metrics, c.hub)
 		c.models[ch.ModelUUID] = model
 	}
 	model.setDetails(ch)
@@ -163,18 +192,7 @@ func (c *Controller) removeModel(ch RemoveModel) {
 // updateApplication adds or updates the application in the specified model.
 func (c *Controller) updateApplication(ch ApplicationChange) {
 	c.mu.Lock()
-
-	// While it is likely that we will receive a change update for the model
-	// before we get an update for the application for that model, but the
-	// cache needs to be resilient enough to make sure that we can handle
-	// the situation where this is not the case.
-	model, found := c.models[ch.ModelUUID]
-	if !found {
-		model = newModel(c.metrics, c.hub)
-		c.models[ch.ModelUUID] = model
-	}
-	model.updateApplication(ch)
-
+	c.ensureModel(ch.ModelUUID).updateApplication(ch)
 	c.mu.Unlock()
 }
 
@@ -191,3 +209,66 @@ func (c *Controller) removeApplication(ch RemoveApplication) {
 
 	c.mu.Unlock()
 }
+
+// updateMachine adds or updates the machine in the specified model.
+func (c *Controller) updateMachine(ch MachineChange) {
+	c.mu.Lock()
+	c.ensureModel(ch.ModelUUID).updateMachine(ch)
+	c.mu.Unlock()
+}
+
+// removeMachine removes the machine for the cached model.
+func (c *Controller) removeMachine(ch RemoveMachine) {
+	c.mu.Lock()
+	if model, found := c.models[ch.ModelUUID]; found {
+		model.removeMachine(ch)
+	}
+	c.mu.Unlock()
+}
+
+// updateUnit adds or updates the unit in the specified model.
+func (c *Controller) updateUnit(ch UnitChange) {
+	c.mu.Lock()
+	c.ensureModel(ch.ModelUUID).updateUnit(ch)
+	c.mu.Unlock()
+}
+
+// removeUnit removes the unit for the cached model.
+func (c *Controller) removeUnit(ch RemoveUnit) {
+	c.mu.Lock()
+	if model, found := c.models[ch.ModelUUID]; found {
+		model.removeUnit(ch)
+	}
+	c.mu.Unlock()
+}
+
+// updateRelation adds or updates the relation in the specified model.
+func (c *Controller) updateRelation(ch RelationChange) {
+	c.mu.Lock()
+	c.ensureModel(ch.ModelUUID).updateRelation(ch)
+	c.mu.Unlock()
+}
+
+// removeRelation removes the relation for the cached model.
+func (c *Controller) removeRelation(ch RemoveRelation) {
+	c.mu.Lock()
+	if model, found := c.models[ch.ModelUUID]; found {
+		model.removeRelation(ch)
+	}
+	c.mu.Unlock()
+}
+
+// ensureModel returns the cached model for the specified UUID, creating
+// it if it doesn't exist yet. While it is likely that we will receive a
+// change update for the model before we get an update for the entities
+// in that model, the cache needs to be resilient enough to make sure that
+// we can handle the situation where this is not the case.
+// The caller must hold the controller mutex.
+func (c *Controller) ensureModel(modelUUID string) *Model {
+	model, found := c.models[modelUUID]
+	if !found {
+		model = newModel(modelUUID, c.metrics, c.hub)
+		c.models[modelUUID] = model
+	}
+	return model
+}
@@ -8,11 +8,13 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
 )
 
 type ControllerSuite struct {
@@ -64,9 +66,36 @@ func (s *ControllerSuite) TestAddModel(c *gc.C) {
 			"name":              "model-owner/test-model",
 			"life":              life.Value("alive"),
 			"application-count": 0,
+			"machine-count":     0,
+			"unit-count":        0,
 		}})
 }
 
+func (s *ControllerSuite) TestChangeProcessingMetrics(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, cache.RemoveModel{ModelUUID: "model-uuid"}, events)
+
+	metrics := controller.Metrics()
+	c.Check(sampleCount(c, metrics.ChangeProcessing.WithLabelValues("model")), gc.Equals, uint64(2))
+	c.Check(sampleCount(c, metrics.ChangeProcessing.WithLabelValues("remove-model")), gc.Equals, uint64(1))
+}
+
+func (s *ControllerSuite) TestModelLookupMetrics(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+
+	_, err := controller.Model("model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = controller.Model("nope")
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+
+	metrics := controller.Metrics()
+	c.Check(testutil.ToFloat64(metrics.Lookups.WithLabelValues("model", "hit")), gc.Equals, float64(1))
+	c.Check(testutil.ToFloat64(metrics.Lookups.WithLabelValues("model", "miss")), gc.Equals, float64(1))
+}
+
 func (s *ControllerSuite) TestRemoveModel(c *gc.C) {
 	controller, events := s.new(c)
 	s.processChange(c, modelChange, events)
@@ -108,6 +137,118 @@ func (s *ControllerSuite) TestRemoveApplication(c *gc.C) {
 	c.Check(mod.Report()["application-count"], gc.Equals, 0)
 }
 
+func (s *ControllerSuite) TestAddMachine(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, machineChange, events)
+
+	mod, err := controller.Model("model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Report()["machine-count"], gc.Equals, 1)
+
+	machine, err := mod.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machine.Details(), jc.DeepEquals, machineChange)
+}
+
+func (s *ControllerSuite) TestRemoveMachine(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, machineChange, events)
+
+	remove := cache.RemoveMachine{
+		ModelUUID: "model-uuid",
+		Id:        "0",
+	}
+	s.processChange(c, remove, events)
+
+	mod, err := controller.Model("model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Report()["machine-count"], gc.Equals, 0)
+	_, err = mod.Machine("0")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ControllerSuite) TestAddUnit(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, unitChange, events)
+
+	mod, err := controller.Model("model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Report()["unit-count"], gc.Equals, 1)
+
+	unit, err := mod.Unit("application-name/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unit.Application(), gc.Equals, "application-name")
+}
+
+func (s *ControllerSuite) TestRemoveUnit(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, unitChange, events)
+
+	remove := cache.RemoveUnit{
+		ModelUUID: "model-uuid",
+		Name:      "application-name/0",
+	}
+	s.processChange(c, remove, events)
+
+	mod, err := controller.Model("model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Report()["unit-count"], gc.Equals, 0)
+}
+
+func (s *ControllerSuite) TestWatchUnitBeforeModelDetails(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, unitChange, events)
+
+	mod, err := controller.Model("model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := mod.WatchUnit("application-name/0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc := NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	s.processChange(c, modelChange, events)
+	change := unitChange
+	change.WorkloadStatus = status.StatusInfo{Status: status.Blocked}
+	s.processChange(c, change, events)
+	wc.AssertOneChange()
+	wc.AssertStops()
+}
+
+func (s *ControllerSuite) TestAddRelation(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, relationChange, events)
+
+	mod, err := controller.Model("model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Relations(), gc.HasLen, 1)
+
+	relation, err := mod.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(relation.Id(), gc.Equals, 1)
+}
+
+func (s *ControllerSuite) TestRemoveRelation(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, relationChange, events)
+
+	remove := cache.RemoveRelation{
+		ModelUUID: "model-uuid",
+		Key:       relationChange.Key,
+	}
+	s.processChange(c, remove, events)
+
+	mod, err := controller.Model("model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Relations(), gc.HasLen, 0)
+}
+
 func (s *ControllerSuite) new(c *gc.C) (*cache.Controller, <-chan interface{}) {
 	events := s.captureEvents(c)
 	controller, err := cache.NewController(s.config)
@@ -129,6 +270,18 @@ func (s *ControllerSuite) captureEvents(c *gc.C) <-chan interface{} {
 			send = true
 		case cache.RemoveApplication:
 			send = true
+		case cache.MachineChange:
+			send = true
+		case cache.RemoveMachine:
+			send = true
+		case cache.UnitChange:
+			send = true
+		case cache.RemoveUnit:
+			send = true
+		case cache.RelationChange:
+			send = true
+		case cache.RemoveRelation:
+			send = true
 		default:
 			// no-op
 		}
@@ -6,9 +6,53 @@ package cache
 var (
 	CreateControllerGauges = createControllerGauges
 	NewModel               = newModel
+	NewRelation            = newRelation
 )
 
 // Expose SetDetails for testing.
 func (m *Model) SetDetails(details ModelChange) {
 	m.setDetails(details)
 }
+
+// Expose SetDetails for testing.
+func (r *Relation) SetDetails(details RelationChange) {
+	r.setDetails(details)
+}
+
+// Expose the entity updates on the model for testing.
+func (m *Model) UpdateApplication(ch ApplicationChange) {
+	m.updateApplication(ch)
+}
+
+func (m *Model) RemoveApplication(ch RemoveApplication) {
+	m.removeApplication(ch)
+}
+
+func (m *Model) UpdateMachine(ch MachineChange) {
+	m.updateMachine(ch)
+}
+
+func (m *Model) RemoveMachine(ch RemoveMachine) {
+	m.removeMachine(ch)
+}
+
+func (m *Model) UpdateUnit(ch UnitChange) {
+	m.updateUnit(ch)
+}
+
+func (m *Model) RemoveUnit(ch RemoveUnit) {
+	m.removeUnit(ch)
+}
+
+func (m *Model) UpdateRelation(ch RelationChange) {
+	m.updateRelation(ch)
+}
+
+func (m *Model) RemoveRelation(ch RemoveRelation) {
+	m.removeRelation(ch)
+}
+
+// Expose the metrics for testing.
+func (c *Controller) Metrics() *ControllerGauges {
+	return c.metrics
+}
@@ -0,0 +1,47 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/juju/pubsub"
+)
+
+func newMachine(metrics *ControllerGauges, hub *pubsub.SimpleHub) *Machine {
+	m := &Machine{
+		metrics: metrics,
+		hub:     hub,
+	}
+	return m
+}
+
+// Machine represents a machine in a model.
+type Machine struct {
+	metrics *ControllerGauges
+	hub     *pubsub.SimpleHub
+	mu      sync.Mutex
+
+	details MachineChange
+}
+
+// Id returns the id of the machine.
+func (m *Machine) Id() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.details.Id
+}
+
+// Details returns a copy of the last change applied to the machine.
+func (m *Machine) Details() MachineChange {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.details
+}
+
+func (m *Machine) setDetails(details MachineChange) {
+	m.mu.Lock()
+	m.details = details
+	m.mu.Unlock()
+}
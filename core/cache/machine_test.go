@@ -0,0 +1,31 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+package cache_test
+
+import (
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+)
+
+var machineChange = cache.MachineChange{
+	ModelUUID:      "model-uuid",
+	Id:             "0",
+	InstanceId:     "juju-gd4c23-0",
+	AgentStatus:    status.StatusInfo{Status: status.Active},
+	InstanceStatus: status.StatusInfo{Status: status.Running},
+	AgentVersion:   "2.5.0",
+	Life:           life.Alive,
+	Series:         "bionic",
+	SupportedContainers: []instance.ContainerType{
+		instance.LXD,
+	},
+	SupportedContainersKnown: true,
+	Jobs:                     []string{"JobHostUnits"},
+	Addresses: []cache.Address{{
+		Value: "10.0.0.1",
+		Type:  "ipv4",
+		Scope: "local-cloud",
+	}},
+}
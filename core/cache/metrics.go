@@ -19,6 +19,13 @@ const (
 	domainLabel           = "domain"
 	agentStatusLabel      = "agent_status"
 	machineStatusLabel    = "machine_status"
+	entityLabel           = "entity"
+	resultLabel           = "result"
+	changeLabel           = "change"
+	topicLabel            = "topic"
+
+	lookupHit  = "hit"
+	lookupMiss = "miss"
 )
 
 var (
@@ -43,12 +50,30 @@ var (
 	logger = loggo.GetLogger("juju.core.cache")
 )
 
-// ControllerGauges holds the prometheus gauges for ever increasing
-// values used by the controller.
+// latencyBuckets are the histogram buckets, in seconds, used for
+// change processing and delivery latencies. Cache operations are
+// expected to take well under a millisecond.
+var latencyBuckets = prometheus.ExponentialBuckets(0.0001, 4, 8)
+
+// ControllerGauges holds the prometheus metrics updated by the
+// controller and its cached entities.
 type ControllerGauges struct {
 	ModelConfigReads   prometheus.Gauge
 	ModelHashCacheHit  prometheus.Gauge
 	ModelHashCacheMiss prometheus.Gauge
+
+	// Lookups counts requests for cached entities, labelled by
+	// entity kind and whether the entity was found.
+	Lookups *prometheus.CounterVec
+	// ChangeProcessing observes how long the controller took to
+	// apply each change, labelled by change kind.
+	ChangeProcessing *prometheus.HistogramVec
+	// Watchers tracks the number of live hub subscriptions,
+	// labelled by topic.
+	Watchers *prometheus.GaugeVec
+	// DeliveryLag observes the time between a change being published
+	// on the hub and a subscriber receiving it, labelled by topic.
+	DeliveryLag *prometheus.HistogramVec
 }
 
 func createControllerGauges() *ControllerGauges {
@@ -74,7 +99,61 @@ func createControllerGauges() *ControllerGauges {
 				Help:      "The number of times the model config change hash was generated.",
 			},
 		),
+		Lookups: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "lookups_total",
+				Help:      "The number of cached entity lookups, by entity and result.",
+			},
+			[]string{entityLabel, resultLabel},
+		),
+		ChangeProcessing: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "change_processing_seconds",
+				Help:      "The time taken to apply a change to the cache.",
+				Buckets:   latencyBuckets,
+			},
+			[]string{changeLabel},
+		),
+		Watchers: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "watchers",
+				Help:      "The number of live watcher subscriptions, by topic.",
+			},
+			[]string{topicLabel},
+		),
+		DeliveryLag: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "delivery_lag_seconds",
+				Help:      "The time between a change being published and a watcher receiving it.",
+				Buckets:   latencyBuckets,
+			},
+			[]string{topicLabel},
+		),
+	}
+}
+
+// lookup records the result of looking up a cached entity.
+func (c *ControllerGauges) lookup(entity string, found bool) {
+	result := lookupMiss
+	if found {
+		result = lookupHit
 	}
+	c.Lookups.WithLabelValues(entity, result).Inc()
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *ControllerGauges) Describe(ch chan<- *prometheus.Desc) {
+	c.ModelConfigReads.Describe(ch)
+	c.ModelHashCacheHit.Describe(ch)
+	c.ModelHashCacheMiss.Describe(ch)
+	c.Lookups.Describe(ch)
+	c.ChangeProcessing.Describe(ch)
+	c.Watchers.Describe(ch)
+	c.DeliveryLag.Describe(ch)
 }
 
 // Collect is part of the prometheus.Collector interface.
@@ -82,6 +161,10 @@ func (c *ControllerGauges) Collect(ch chan<- prometheus.Metric) {
 	c.ModelConfigReads.Collect(ch)
 	c.ModelHashCacheHit.Collect(ch)
 	c.ModelHashCacheMiss.Collect(ch)
+	c.Lookups.Collect(ch)
+	c.ChangeProcessing.Collect(ch)
+	c.Watchers.Collect(ch)
+	c.DeliveryLag.Collect(ch)
 }
 
 // Collector is a prometheus.Collector that collects metrics about
@@ -145,6 +228,7 @@ func NewMetricsCollector(controller *Controller) *Collector {
 
 // Describe is part of the prometheus.Collector interface.
 func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.controller.metrics.Describe(ch)
 	c.machines.Describe(ch)
 	c.models.Describe(ch)
 	c.users.Describe(ch)
@@ -192,7 +276,16 @@ func (c *Collector) updateModelMetrics(modelUUID string) {
 		return
 	}
 
-	// TODO: add machines, applications and units.
+	for _, machine := range model.Machines() {
+		details := machine.Details()
+		c.machines.With(prometheus.Labels{
+			agentStatusLabel:   string(details.AgentStatus.Status),
+			lifeLabel:          string(details.Life),
+			machineStatusLabel: string(details.InstanceStatus.Status),
+		}).Inc()
+	}
+
+	// TODO: add applications and units.
 
 	c.models.With(prometheus.Labels{
 		lifeLabel:   string(model.details.Life),
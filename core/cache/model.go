@@ -4,25 +4,44 @@
 package cache
 
 import (
+	"reflect"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
-	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/core/life"
 )
 
-const modelConfigChange = "model-config-change"
+const (
+	modelConfigChange = "model-config-change"
+	applicationChange = "application-change"
+	applicationRemove = "application-remove"
+	machineChange     = "machine-change"
+	machineRemove     = "machine-remove"
+	unitChange        = "unit-change"
+	unitRemove        = "unit-remove"
+	relationChange    = "relation-change"
+	relationRemove    = "relation-remove"
+)
 
-func newModel(metrics *ControllerGauges, hub *pubsub.SimpleHub) *Model {
+func newModel(uuid string, metrics *ControllerGauges, hub *pubsub.SimpleHub) *Model {
 	m := &Model{
 		metrics: metrics,
 		// TODO: consider a separate hub per model for better scalability
 		// when many models.
 		hub:          hub,
 		applications: make(map[string]*Application),
+		machines:     make(map[string]*Machine),
+		units:        make(map[string]*Unit),
+		relations:    make(map[string]*Relation),
 	}
+	// The UUID is known before the model details arrive, and is
+	// needed for the topics used by the model's watchers.
+	m.details.ModelUUID = uuid
 	return m
 }
 
@@ -37,6 +56,9 @@ type Model struct {
 	configHash   string
 	hashCache    *modelConfigHashCache
 	applications map[string]*Application
+	machines     map[string]*Machine
+	units        map[string]*Unit
+	relations    map[string]*Relation
 }
 
 // Report returns information that is used in the dependency engine report.
@@ -48,6 +70,8 @@ func (m *Model) Report() map[string]interface{} {
 		"name":              m.details.Owner + "/" + m.details.Name,
 		"life":              m.details.Life,
 		"application-count": len(m.applications),
+		"machine-count":     len(m.machines),
+		"unit-count":        len(m.units),
 	}
 }
 
@@ -58,11 +82,104 @@ func (m *Model) Application(appName string) (*Application, error) {
 	defer m.mu.Unlock()
 
 	app, found := m.applications[appName]
+	m.metrics.lookup("application", found)
 	if !found {
 		return nil, errors.NotFoundf("application %q", appName)
 	}
 	return app, nil
+}
+
+// Applications returns all the applications in the model,
+// keyed on the application name.
+func (m *Model) Applications() map[string]*Application {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[string]*Application, len(m.applications))
+	for name, app := range m.applications {
+		result[name] = app
+	}
+	return result
+}
+
+// Machine returns the machine with the input id.
+// If the machine is not found, a NotFoundError is returned.
+func (m *Model) Machine(machineId string) (*Machine, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	machine, found := m.machines[machineId]
+	m.metrics.lookup("machine", found)
+	if !found {
+		return nil, errors.NotFoundf("machine %q", machineId)
+	}
+	return machine, nil
+}
+
+// Machines returns all the machines in the model,
+// keyed on the machine id.
+func (m *Model) Machines() map[string]*Machine {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[string]*Machine, len(m.machines))
+	for id, machine := range m.machines {
+		result[id] = machine
+	}
+	return result
+}
+
+// Unit returns the unit with the input name.
+// If the unit is not found, a NotFoundError is returned.
+func (m *Model) Unit(unitName string) (*Unit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unit, found := m.units[unitName]
+	m.metrics.lookup("unit", found)
+	if !found {
+		return nil, errors.NotFoundf("unit %q", unitName)
+	}
+	return unit, nil
+}
+
+// Units returns all the units in the model, keyed on the unit name.
+func (m *Model) Units() map[string]*Unit {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[string]*Unit, len(m.units))
+	for name, unit := range m.units {
+		result[name] = unit
+	}
+	return result
+}
 
+// Relation returns the relation with the input key.
+// If the relation is not found, a NotFoundError is returned.
+func (m *Model) Relation(key string) (*Relation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	relation, found := m.relations[key]
+	m.metrics.lookup("relation", found)
+	if !found {
+		return nil, errors.NotFoundf("relation %q", key)
+	}
+	return relation, nil
+}
+
+// Relations returns all the relations in the model,
+// keyed on the relation key.
+func (m *Model) Relations() map[string]*Relation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[string]*Relation, len(m.relations))
+	for key, relation := range m.relations {
+		result[key] = relation
+	}
+	return result
 }
 
 // updateApplication adds or updates the application in the model.
@@ -75,6 +192,7 @@ func (m *Model) updateApplication(ch ApplicationChange) {
 		m.applications[ch.Name] = app
 	}
 	app.setDetails(ch)
+	m.publish(applicationChange, ch)
 
 	m.mu.Unlock()
 }
@@ -82,10 +200,127 @@ func (m *Model) updateApplication(ch ApplicationChange) {
 // removeApplication removes the application from the model.
 func (m *Model) removeApplication(ch RemoveApplication) {
 	m.mu.Lock()
-	delete(m.applications, ch.Name)
+	if _, found := m.applications[ch.Name]; found {
+		delete(m.applications, ch.Name)
+		m.publish(applicationRemove, ch)
+	}
+	m.mu.Unlock()
+}
+
+// updateMachine adds or updates the machine in the model.
+func (m *Model) updateMachine(ch MachineChange) {
+	m.mu.Lock()
+
+	machine, found := m.machines[ch.Id]
+	if !found {
+		machine = newMachine(m.metrics, m.hub)
+		m.machines[ch.Id] = machine
+	}
+	machine.setDetails(ch)
+	m.publish(machineChange, ch)
+
+	m.mu.Unlock()
+}
+
+// removeMachine removes the machine from the model.
+func (m *Model) removeMachine(ch RemoveMachine) {
+	m.mu.Lock()
+	if _, found := m.machines[ch.Id]; found {
+		delete(m.machines, ch.Id)
+		m.publish(machineRemove, ch)
+	}
 	m.mu.Unlock()
 }
 
+// updateUnit adds or updates the unit in the model.
+func (m *Model) updateUnit(ch UnitChange) {
+	m.mu.Lock()
+
+	unit, found := m.units[ch.Name]
+	if !found {
+		unit = newUnit(m.metrics, m.hub)
+		m.units[ch.Name] = unit
+	}
+	unit.setDetails(ch)
+	m.publish(unitChange, ch)
+
+	m.mu.Unlock()
+}
+
+// removeUnit removes the unit from the model.
+func (m *Model) removeUnit(ch RemoveUnit) {
+	m.mu.Lock()
+	if _, found := m.units[ch.Name]; found {
+		delete(m.units, ch.Name)
+		m.publish(unitRemove, ch)
+	}
+	m.mu.Unlock()
+}
+
+// updateRelation adds or updates the relation in the model.
+func (m *Model) updateRelation(ch RelationChange) {
+	m.mu.Lock()
+
+	relation, found := m.relations[ch.Key]
+	if !found {
+		relation = newRelation(m.metrics, m.hub)
+		m.relations[ch.Key] = relation
+	}
+	relation.setDetails(ch)
+	m.publish(relationChange, ch)
+
+	m.mu.Unlock()
+}
+
+// removeRelation removes the relation from the model.
+func (m *Model) removeRelation(ch RemoveRelation) {
+	m.mu.Lock()
+	if _, found := m.relations[ch.Key]; found {
+		delete(m.relations, ch.Key)
+		m.publish(relationRemove, ch)
+	}
+	m.mu.Unlock()
+}
+
+// published wraps a value sent over the hub with the time it was
+// published, so the delivery lag can be measured by subscribers.
+type published struct {
+	at    time.Time
+	value interface{}
+}
+
+// publish sends the value to subscribers of the model topic.
+func (m *Model) publish(topic string, value interface{}) {
+	m.hub.Publish(m.modelTopic(topic), published{at: time.Now(), value: value})
+}
+
+// subscribe calls the handler with each value published on the model
+// topic, returning a function that unsubscribes the handler.
+// Live subscriptions and their delivery lag are recorded in the
+// controller metrics.
+func (m *Model) subscribe(topic string, handler func(string, interface{})) func() {
+	watchers := m.metrics.Watchers.WithLabelValues(topic)
+	lag := m.metrics.DeliveryLag.WithLabelValues(topic)
+	unsub := m.hub.Subscribe(m.modelTopic(topic), func(topic string, data interface{}) {
+		p, ok := data.(published)
+		if !ok {
+			logger.Errorf("programming error, value published on %q not wrapped", topic)
+			return
+		}
+		lag.Observe(time.Since(p.at).Seconds())
+		handler(topic, p.value)
+	})
+	watchers.Inc()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			unsub()
+			watchers.Dec()
+		})
+	}
+}
+
 // modelTopic prefixes the topic with the model UUID.
 func (m *Model) modelTopic(topic string) string {
 	return m.details.ModelUUID + ":" + topic
@@ -99,7 +334,7 @@ func (m *Model) setDetails(details ModelChange) {
 	if configHash != m.configHash {
 		m.configHash = configHash
 		m.hashCache = hashCache
-		m.hub.Publish(m.modelTopic(modelConfigChange), hashCache)
+		m.publish(modelConfigChange, hashCache)
 	}
 
 	m.mu.Unlock()
@@ -118,31 +353,159 @@ func (m *Model) Config() map[string]interface{} {
 // those keys change values. If no keys are specified, any change in the
 // config will trigger the watcher.
 func (m *Model) WatchConfig(keys ...string) *modelConfigWatcher {
-	// We use a single entry buffered channel for the changes.
-	// This allows the config changed handler to send a value when there
-	// is a change, but if that value hasn't been consumed before the
-	// next change, the second change is discarded.
 	sort.Strings(keys)
 	watcher := &modelConfigWatcher{
-		keys:    keys,
-		changes: make(chan struct{}, 1),
+		notifyWatcherBase: newNotifyWatcherBase(),
+		keys:              keys,
 	}
 	watcher.hash = m.hashCache.getHash(keys)
-	// Send initial event down the channel. We know that this will
-	// execute immediately because it is a buffered channel.
-	watcher.changes <- struct{}{}
-
-	unsub := m.hub.Subscribe(m.modelTopic(modelConfigChange), watcher.configChanged)
 
-	watcher.tomb.Go(func() error {
-		<-watcher.tomb.Dying()
-		unsub()
-		return nil
-	})
+	unsub := m.subscribe(modelConfigChange, watcher.configChanged)
+	watcher.onDying(unsub)
 
 	return watcher
 }
 
+// WatchApplicationRelations returns a watcher that notifies of the keys
+// of relations the application joins, leaves, or that change life.
+// The initial event contains the keys of all the relations the
+// application is part of.
+func (m *Model) WatchApplicationRelations(appName string) StringsWatcher {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	involves := func(ch RelationChange) bool {
+		for _, ep := range ch.Endpoints {
+			if ep.Application == appName {
+				return true
+			}
+		}
+		return false
+	}
+
+	known := make(map[string]life.Value)
+	for key, relation := range m.relations {
+		details := relation.Details()
+		if involves(details) {
+			known[key] = details.Life
+		}
+	}
+	w := newLifecycleWatcher(known)
+
+	w.onDying(
+		m.subscribe(relationChange, func(_ string, value interface{}) {
+			if ch, ok := value.(RelationChange); ok && involves(ch) {
+				w.changed(ch.Key, ch.Life)
+			}
+		}),
+		m.subscribe(relationRemove, func(_ string, value interface{}) {
+			if ch, ok := value.(RemoveRelation); ok {
+				w.removed(ch.Key)
+			}
+		}),
+	)
+	return w
+}
+
+// WatchMachine returns a watcher that notifies when the machine with
+// the input id changes or is removed from the model.
+// If the machine is not found, a NotFoundError is returned.
+func (m *Model) WatchMachine(machineId string) (NotifyWatcher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, found := m.machines[machineId]; !found {
+		return nil, errors.NotFoundf("machine %q", machineId)
+	}
+	return m.watchEntity(func(value interface{}) bool {
+		switch ch := value.(type) {
+		case MachineChange:
+			return ch.Id == machineId
+		case RemoveMachine:
+			return ch.Id == machineId
+		}
+		return false
+	}, machineChange, machineRemove), nil
+}
+
+// WatchUnit returns a watcher that notifies when the details of the
+// unit with the input name change or it is removed from the model.
+// The details include the resolved mode and subordinates of the unit,
+// which the unit agent relies on.
+// If the unit is not found, a NotFoundError is returned.
+func (m *Model) WatchUnit(unitName string) (NotifyWatcher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unit, found := m.units[unitName]
+	if !found {
+		return nil, errors.NotFoundf("unit %q", unitName)
+	}
+	last := unit.Details()
+	return m.watchEntity(func(value interface{}) bool {
+		switch ch := value.(type) {
+		case UnitChange:
+			if ch.Name != unitName {
+				return false
+			}
+			changed := !reflect.DeepEqual(ch, last)
+			last = ch
+			return changed
+		case RemoveUnit:
+			return ch.Name == unitName
+		}
+		return false
+	}, unitChange, unitRemove), nil
+}
+
+// WatchApplication returns a watcher that notifies when the details of
+// the application with the input name change or it is removed from the
+// model. The details include the charm URL, the force flag and the
+// charm modified version, which the unit agents rely on.
+// If the application is not found, a NotFoundError is returned.
+func (m *Model) WatchApplication(appName string) (NotifyWatcher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	app, found := m.applications[appName]
+	if !found {
+		return nil, errors.NotFoundf("application %q", appName)
+	}
+	last := app.Details()
+	return m.watchEntity(func(value interface{}) bool {
+		switch ch := value.(type) {
+		case ApplicationChange:
+			if ch.Name != appName {
+				return false
+			}
+			changed := !reflect.DeepEqual(ch, last)
+			last = ch
+			return changed
+		case RemoveApplication:
+			return ch.Name == appName
+		}
+		return false
+	}, applicationChange, applicationRemove), nil
+}
+
+// watchEntity returns a notify watcher subscribed to the model topics,
+// that notifies whenever match returns true for a published value.
+// The caller is expected to hold the model lock.
+func (m *Model) watchEntity(match func(interface{}) bool, topics ...string) NotifyWatcher {
+	w := newNotifyWatcherBase()
+	handler := func(_ string, value interface{}) {
+		if match(value) {
+			w.notify()
+		}
+	}
+	unsubs := make([]func(), len(topics))
+	for i, topic := range topics {
+		unsubs[i] = m.subscribe(topic, handler)
+	}
+	w.onDying(unsubs...)
+	return w
+}
+
 type modelConfigHashCache struct {
 	metrics *ControllerGauges
 	config  map[string]interface{}
@@ -201,15 +564,10 @@ func (c *modelConfigHashCache) generateHash(keys []string) string {
 }
 
 type modelConfigWatcher struct {
-	keys    []string
-	hash    string
-	tomb    tomb.Tomb
-	changes chan struct{}
-	// We can't send down a closed channel, so protect the sending
-	// with a mutex and bool. Since you can't really even ask a channel
-	// if it is closed.
-	closed bool
-	mu     sync.Mutex
+	*notifyWatcherBase
+
+	keys []string
+	hash string
 }
 
 func (w *modelConfigWatcher) configChanged(topic string, value interface{}) {
@@ -223,41 +581,5 @@ func (w *modelConfigWatcher) configChanged(topic string, value interface{}) {
 		return
 	}
 	// Let the listener know.
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	if w.closed {
-		return
-	}
-
-	select {
-	case w.changes <- struct{}{}:
-	default:
-		// Already a pending change, so do nothing.
-	}
-}
-
-// Changes is part of the core watcher definition.
-// The changes channel is never closed.
-func (w *modelConfigWatcher) Changes() <-chan struct{} {
-	return w.changes
-}
-
-// Kill is part of the worker.Worker interface.
-func (w *modelConfigWatcher) Kill() {
-	w.mu.Lock()
-	w.closed = true
-	close(w.changes)
-	w.mu.Unlock()
-	w.tomb.Kill(nil)
-}
-
-// Wait is part of the worker.Worker interface.
-func (w *modelConfigWatcher) Wait() error {
-	return w.tomb.Wait()
-}
-
-// Stop is currently required by the Resources wrapper in the apiserver.
-func (w *modelConfigWatcher) Stop() error {
-	w.Kill()
-	return w.Wait()
+	w.notify()
 }
@@ -25,7 +25,7 @@ func (s *ModelSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *ModelSuite) newModel(details cache.ModelChange) *cache.Model {
-	m := cache.NewModel(s.gauges, s.hub)
+	m := cache.NewModel(details.ModelUUID, s.gauges, s.hub)
 	m.SetDetails(details)
 	return m
 }
@@ -36,6 +36,8 @@ func (s *ModelSuite) TestReport(c *gc.C) {
 		"name":              "model-owner/test-model",
 		"life":              life.Value("alive"),
 		"application-count": 0,
+		"machine-count":     0,
+		"unit-count":        0,
 	})
 }
 
@@ -134,6 +136,161 @@ func (s *ModelSuite) TestApplicationNotFoundError(c *gc.C) {
 	c.Assert(errors.IsNotFound(err), jc.IsTrue)
 }
 
+func (s *ModelSuite) TestLookupMetrics(c *gc.C) {
+	m := s.newModel(modelChange)
+	m.UpdateMachine(machineChange)
+
+	_, err := m.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = m.Application("nope")
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+
+	c.Check(testutil.ToFloat64(s.gauges.Lookups.WithLabelValues("machine", "hit")), gc.Equals, float64(1))
+	c.Check(testutil.ToFloat64(s.gauges.Lookups.WithLabelValues("application", "miss")), gc.Equals, float64(1))
+}
+
+func (s *ModelSuite) TestWatcherMetrics(c *gc.C) {
+	m := s.newModel(modelChange)
+	w := m.WatchApplicationRelations("mysql")
+	wc := NewStringsWatcherC(c, w)
+	wc.AssertOneChange()
+	c.Check(testutil.ToFloat64(s.gauges.Watchers.WithLabelValues("relation-change")), gc.Equals, float64(1))
+	c.Check(testutil.ToFloat64(s.gauges.Watchers.WithLabelValues("relation-remove")), gc.Equals, float64(1))
+
+	m.UpdateRelation(relationChange)
+	wc.AssertOneChange("application-name:db mysql:server")
+	c.Check(sampleCount(c, s.gauges.DeliveryLag.WithLabelValues("relation-change")), gc.Equals, uint64(1))
+
+	wc.AssertStops()
+	c.Check(testutil.ToFloat64(s.gauges.Watchers.WithLabelValues("relation-change")), gc.Equals, float64(0))
+	c.Check(testutil.ToFloat64(s.gauges.Watchers.WithLabelValues("relation-remove")), gc.Equals, float64(0))
+}
+
+func (s *ModelSuite) TestApplicationRelationsWatcher(c *gc.C) {
+	m := s.newModel(modelChange)
+
+	w := m.WatchApplicationRelations("mysql")
+	defer workertest.CleanKill(c, w)
+	wc := NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	m.UpdateRelation(relationChange)
+	wc.AssertOneChange("application-name:db mysql:server")
+
+	// Changes that don't alter the life are not reported.
+	m.UpdateRelation(relationChange)
+	wc.AssertNoChange()
+
+	change := relationChange
+	change.Life = life.Dying
+	m.UpdateRelation(change)
+	wc.AssertOneChange("application-name:db mysql:server")
+
+	m.RemoveRelation(cache.RemoveRelation{ModelUUID: "model-uuid", Key: relationChange.Key})
+	wc.AssertOneChange("application-name:db mysql:server")
+}
+
+func (s *ModelSuite) TestMachineWatcher(c *gc.C) {
+	m := s.newModel(modelChange)
+	m.UpdateMachine(machineChange)
+
+	w, err := m.WatchMachine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	wc := NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	other := machineChange
+	other.Id = "1"
+	m.UpdateMachine(other)
+	wc.AssertNoChange()
+
+	change := machineChange
+	change.AgentVersion = "2.5.1"
+	m.UpdateMachine(change)
+	wc.AssertOneChange()
+
+	m.RemoveMachine(cache.RemoveMachine{ModelUUID: "model-uuid", Id: "0"})
+	wc.AssertOneChange()
+}
+
+func (s *ModelSuite) TestMachineWatcherNotFound(c *gc.C) {
+	m := s.newModel(modelChange)
+	_, err := m.WatchMachine("0")
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *ModelSuite) TestUnitWatcher(c *gc.C) {
+	m := s.newModel(modelChange)
+	m.UpdateUnit(unitChange)
+
+	w, err := m.WatchUnit("application-name/0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc := NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	change := unitChange
+	change.WorkloadStatus = status.StatusInfo{Status: status.Blocked}
+	m.UpdateUnit(change)
+	wc.AssertOneChange()
+	wc.AssertStops()
+}
+
+func (s *ModelSuite) TestUnitWatcherResolved(c *gc.C) {
+	m := s.newModel(modelChange)
+	m.UpdateUnit(unitChange)
+
+	w, err := m.WatchUnit("application-name/0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	wc := NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	// Identical details are not reported.
+	m.UpdateUnit(unitChange)
+	wc.AssertNoChange()
+
+	change := unitChange
+	change.Resolved = "retry-hooks"
+	m.UpdateUnit(change)
+	wc.AssertOneChange()
+
+	change.Subordinates = []string{"logging/0"}
+	m.UpdateUnit(change)
+	wc.AssertOneChange()
+}
+
+func (s *ModelSuite) TestApplicationWatcher(c *gc.C) {
+	m := s.newModel(modelChange)
+	m.UpdateApplication(appChange)
+
+	w, err := m.WatchApplication("application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	wc := NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	m.UpdateApplication(appChange)
+	wc.AssertNoChange()
+
+	change := appChange
+	change.CharmModifiedVersion = 1
+	m.UpdateApplication(change)
+	wc.AssertOneChange()
+
+	change.ForceCharm = true
+	m.UpdateApplication(change)
+	wc.AssertOneChange()
+
+	m.RemoveApplication(cache.RemoveApplication{ModelUUID: "model-uuid", Name: "application-name"})
+	wc.AssertOneChange()
+}
+
 var modelChange = cache.ModelChange{
 	ModelUUID: "model-uuid",
 	Name:      "test-model",
@@ -10,6 +10,8 @@ import (
 	"github.com/juju/pubsub"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/cache"
@@ -50,6 +52,16 @@ func (s *entitySuite) SetUpTest(c *gc.C) {
 	})
 }
 
+// sampleCount returns the number of observations made by a histogram.
+func sampleCount(c *gc.C, observer prometheus.Observer) uint64 {
+	histogram, ok := observer.(prometheus.Histogram)
+	c.Assert(ok, jc.IsTrue)
+	var metric dto.Metric
+	err := histogram.Write(&metric)
+	c.Assert(err, jc.ErrorIsNil)
+	return metric.GetHistogram().GetSampleCount()
+}
+
 type ImportSuite struct{}
 
 var _ = gc.Suite(&ImportSuite{})
@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+)
+
+func newRelation(metrics *ControllerGauges, hub *pubsub.SimpleHub) *Relation {
+	r := &Relation{
+		metrics: metrics,
+		hub:     hub,
+	}
+	return r
+}
+
+// Relation represents a relation between applications in a model.
+type Relation struct {
+	metrics *ControllerGauges
+	hub     *pubsub.SimpleHub
+	mu      sync.Mutex
+
+	details RelationChange
+}
+
+// Key returns the key of the relation.
+func (r *Relation) Key() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.details.Key
+}
+
+// Id returns the integer id of the relation.
+func (r *Relation) Id() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.details.Id
+}
+
+// Details returns a copy of the last change applied to the relation.
+func (r *Relation) Details() RelationChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.details
+}
+
+// Endpoint returns the endpoint of the relation for the specified
+// application. If the application is not part of the relation,
+// a NotFoundError is returned.
+func (r *Relation) Endpoint(appName string) (RelationEndpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ep := range r.details.Endpoints {
+		if ep.Application == appName {
+			return ep, nil
+		}
+	}
+	return RelationEndpoint{}, errors.NotFoundf("application %q endpoint in relation %q", appName, r.details.Key)
+}
+
+func (r *Relation) setDetails(details RelationChange) {
+	r.mu.Lock()
+	r.details = details
+	r.mu.Unlock()
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+package cache_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/life"
+)
+
+type RelationSuite struct {
+	entitySuite
+}
+
+var _ = gc.Suite(&RelationSuite{})
+
+func (s *RelationSuite) newRelation(details cache.RelationChange) *cache.Relation {
+	r := cache.NewRelation(s.gauges, s.hub)
+	r.SetDetails(details)
+	return r
+}
+
+func (s *RelationSuite) TestEndpoint(c *gc.C) {
+	r := s.newRelation(relationChange)
+	ep, err := r.Endpoint("application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ep, jc.DeepEquals, relationChange.Endpoints[0])
+}
+
+func (s *RelationSuite) TestEndpointNotFound(c *gc.C) {
+	r := s.newRelation(relationChange)
+	_, err := r.Endpoint("nope")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+var relationChange = cache.RelationChange{
+	ModelUUID: "model-uuid",
+	Key:       "application-name:db mysql:server",
+	Id:        1,
+	Life:      life.Alive,
+	Endpoints: []cache.RelationEndpoint{{
+		Application: "application-name",
+		Name:        "db",
+		Role:        "requirer",
+		Interface:   "mysql",
+		Scope:       "global",
+	}, {
+		Application: "mysql",
+		Name:        "server",
+		Role:        "provider",
+		Interface:   "mysql",
+		Scope:       "global",
+	}},
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/juju/pubsub"
+)
+
+func newUnit(metrics *ControllerGauges, hub *pubsub.SimpleHub) *Unit {
+	u := &Unit{
+		metrics: metrics,
+		hub:     hub,
+	}
+	return u
+}
+
+// Unit represents a unit in a model.
+type Unit struct {
+	metrics *ControllerGauges
+	hub     *pubsub.SimpleHub
+	mu      sync.Mutex
+
+	details UnitChange
+}
+
+// Name returns the name of the unit.
+func (u *Unit) Name() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.details.Name
+}
+
+// Application returns the name of the application the unit belongs to.
+func (u *Unit) Application() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.details.Application
+}
+
+// Details returns a copy of the last change applied to the unit.
+func (u *Unit) Details() UnitChange {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.details
+}
+
+func (u *Unit) setDetails(details UnitChange) {
+	u.mu.Lock()
+	u.details = details
+	u.mu.Unlock()
+}
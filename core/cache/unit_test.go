@@ -0,0 +1,29 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+package cache_test
+
+import (
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+)
+
+var unitChange = cache.UnitChange{
+	ModelUUID:      "model-uuid",
+	Name:           "application-name/0",
+	Application:    "application-name",
+	Series:         "bionic",
+	CharmURL:       "www.charm-url.com",
+	Life:           life.Alive,
+	PublicAddress:  "10.0.0.1",
+	PrivateAddress: "10.0.0.1",
+	MachineId:      "0",
+	PortRanges: []cache.PortRange{{
+		FromPort: 80,
+		ToPort:   80,
+		Protocol: "tcp",
+	}},
+	WorkloadStatus: status.StatusInfo{Status: status.Active},
+	AgentStatus:    status.StatusInfo{Status: status.Idle},
+	AgentVersion:   "2.5.0",
+}
@@ -4,7 +4,13 @@
 package cache
 
 import (
+	"sort"
+	"sync"
+
 	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/core/life"
 )
 
 // The watchers used in the cache package are closer to state watchers
@@ -25,3 +31,238 @@ type NotifyWatcher interface {
 	Watcher
 	Changes() <-chan struct{}
 }
+
+// StringsWatcher will return what has changed.
+type StringsWatcher interface {
+	Watcher
+	Changes() <-chan []string
+}
+
+type notifyWatcherBase struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+	// We can't send down a closed channel, so protect the sending
+	// with a mutex and bool. Since you can't really even ask a channel
+	// if it is closed.
+	closed bool
+	mu     sync.Mutex
+}
+
+func newNotifyWatcherBase() *notifyWatcherBase {
+	// We use a single entry buffered channel for the changes.
+	// This allows the change handler to send a value when there
+	// is a change, but if that value hasn't been consumed before the
+	// next change, the second change is discarded.
+	ch := make(chan struct{}, 1)
+
+	// Send initial event down the channel. We know that this will
+	// execute immediately because it is a buffered channel.
+	ch <- struct{}{}
+
+	return &notifyWatcherBase{changes: ch}
+}
+
+// notify lets the listener know that something has changed.
+func (w *notifyWatcherBase) notify() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+
+	select {
+	case w.changes <- struct{}{}:
+	default:
+		// Already a pending change, so do nothing.
+	}
+}
+
+// Changes is part of the core watcher definition.
+// The changes channel is closed when the watcher is killed.
+func (w *notifyWatcherBase) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *notifyWatcherBase) Kill() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.changes)
+	}
+	w.mu.Unlock()
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *notifyWatcherBase) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Stop is currently required by the Resources wrapper in the apiserver.
+func (w *notifyWatcherBase) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// onDying arranges for the unsubscribe functions to be called
+// when the watcher is killed.
+func (w *notifyWatcherBase) onDying(unsubs ...func()) {
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		for _, unsub := range unsubs {
+			unsub()
+		}
+		return nil
+	})
+}
+
+type stringsWatcherBase struct {
+	tomb    tomb.Tomb
+	changes chan []string
+	wake    chan struct{}
+	pending []string
+	mu      sync.Mutex
+}
+
+func newStringsWatcherBase(initial []string) *stringsWatcherBase {
+	w := &stringsWatcherBase{
+		changes: make(chan []string),
+		wake:    make(chan struct{}, 1),
+		pending: initial,
+	}
+	w.tomb.Go(w.loop)
+	return w
+}
+
+func (w *stringsWatcherBase) loop() error {
+	defer close(w.changes)
+
+	// The initial event is always sent, even when there are no values.
+	sentInitial := false
+	for {
+		w.mu.Lock()
+		values := make([]string, len(w.pending))
+		copy(values, w.pending)
+		w.mu.Unlock()
+
+		var out chan<- []string
+		if !sentInitial || len(values) > 0 {
+			out = w.changes
+		}
+		select {
+		case <-w.tomb.Dying():
+			return nil
+		case <-w.wake:
+		case out <- values:
+			sentInitial = true
+			w.mu.Lock()
+			w.pending = w.pending[len(values):]
+			w.mu.Unlock()
+		}
+	}
+}
+
+// notify adds the values to those pending delivery to the listener.
+func (w *stringsWatcherBase) notify(values ...string) {
+	w.mu.Lock()
+	for _, value := range values {
+		found := false
+		for _, existing := range w.pending {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			w.pending = append(w.pending, value)
+		}
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+		// The loop has already been woken.
+	}
+}
+
+// Changes is part of the core watcher definition.
+// The changes channel is closed when the watcher is killed.
+func (w *stringsWatcherBase) Changes() <-chan []string {
+	return w.changes
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *stringsWatcherBase) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *stringsWatcherBase) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Stop is currently required by the Resources wrapper in the apiserver.
+func (w *stringsWatcherBase) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// onDying arranges for the unsubscribe functions to be called
+// when the watcher is killed.
+func (w *stringsWatcherBase) onDying(unsubs ...func()) {
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		for _, unsub := range unsubs {
+			unsub()
+		}
+		return nil
+	})
+}
+
+// lifecycleWatcher reports the ids of entities that have been added,
+// have changed life, or have been removed.
+type lifecycleWatcher struct {
+	*stringsWatcherBase
+
+	known   map[string]life.Value
+	knownMu sync.Mutex
+}
+
+func newLifecycleWatcher(known map[string]life.Value) *lifecycleWatcher {
+	initial := make([]string, 0, len(known))
+	for id := range known {
+		initial = append(initial, id)
+	}
+	sort.Strings(initial)
+	return &lifecycleWatcher{
+		stringsWatcherBase: newStringsWatcherBase(initial),
+		known:              known,
+	}
+}
+
+// changed records the life of the entity, notifying the listener
+// if the entity is new or its life has changed.
+func (w *lifecycleWatcher) changed(id string, value life.Value) {
+	w.knownMu.Lock()
+	current, found := w.known[id]
+	w.known[id] = value
+	w.knownMu.Unlock()
+
+	if !found || current != value {
+		w.notify(id)
+	}
+}
+
+// removed notifies the listener if the entity was known to the watcher.
+func (w *lifecycleWatcher) removed(id string) {
+	w.knownMu.Lock()
+	_, found := w.known[id]
+	delete(w.known, id)
+	w.knownMu.Unlock()
+
+	if found {
+		w.notify(id)
+	}
+}
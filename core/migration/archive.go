@@ -0,0 +1,383 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// A model archive is a gzipped tar file holding the serialized model
+// description along with every charm, agent binary and resource that
+// a migration would upload to the target controller. It lets a model
+// be moved between controllers that can't reach each other.
+const (
+	archiveModelEntry     = "model.yaml"
+	archiveCharmsDir      = "charms"
+	archiveToolsDir       = "tools"
+	archiveResourcesDir   = "resources"
+	archiveToolsURIPrefix = "/tools/"
+)
+
+func charmEntry(curl *charm.URL) string {
+	return path.Join(archiveCharmsDir, curl.String())
+}
+
+func toolsEntry(v version.Binary) string {
+	return path.Join(archiveToolsDir, v.String()+".tar.gz")
+}
+
+func resourceEntry(application, name string) string {
+	return path.Join(archiveResourcesDir, application, name)
+}
+
+// NewSerializedModel returns the serialized model for the model
+// description in the bytes, listing the charms, agent binaries and
+// resources the model uses. Agent binaries are given their API
+// download URI, relative to the model.
+func NewSerializedModel(bytes []byte) (SerializedModel, error) {
+	var empty SerializedModel
+	desc, err := description.Deserialize(bytes)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	resources, err := modelResources(desc)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	binaries := ModelBinaries(desc)
+	serialized := SerializedModel{
+		Bytes:     bytes,
+		Charms:    binaries.Charms,
+		Tools:     make(map[version.Binary]string),
+		Resources: resources,
+	}
+	for _, v := range binaries.Tools {
+		serialized.Tools[v] = archiveToolsURIPrefix + v.String()
+	}
+	return serialized, nil
+}
+
+func modelResources(desc description.Model) ([]SerializedModelResource, error) {
+	var out []SerializedModelResource
+	for _, app := range desc.Applications() {
+		for _, res := range app.Resources() {
+			appRev, err := resourceRevision(app.Name(), res.Name(), res.ApplicationRevision())
+			if err != nil {
+				return nil, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			csRev, err := resourceRevision(app.Name(), res.Name(), res.CharmStoreRevision())
+			if err != nil {
+				return nil, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			unitRevs := make(map[string]resource.Resource)
+			for _, unit := range app.Units() {
+				for _, unitRes := range unit.Resources() {
+					if unitRes.Name() != res.Name() {
+						continue
+					}
+					unitRev, err := resourceRevision(app.Name(), res.Name(), unitRes.Revision())
+					if err != nil {
+						return nil, errors.Annotatef(err, "resource %s/%s for unit %s", app.Name(), res.Name(), unit.Name())
+					}
+					unitRevs[unit.Name()] = unitRev
+				}
+			}
+			out = append(out, SerializedModelResource{
+				ApplicationRevision: appRev,
+				CharmStoreRevision:  csRev,
+				UnitRevisions:       unitRevs,
+			})
+		}
+	}
+	return out, nil
+}
+
+func resourceRevision(app, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	if rev == nil {
+		return resource.Resource{}, nil
+	}
+	type_, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if rev.FingerprintHex() != "" {
+		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex()); err != nil {
+			return resource.Resource{}, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        type_,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Size:        rev.Size(),
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
+
+// WriteArchiveConfig holds what's needed to write a model archive.
+type WriteArchiveConfig struct {
+	Model              SerializedModel
+	CharmDownloader    CharmDownloader
+	ToolsDownloader    ToolsDownloader
+	ResourceDownloader ResourceDownloader
+}
+
+// Validate makes sure that all the config values are non-nil.
+func (c *WriteArchiveConfig) Validate() error {
+	if len(c.Model.Bytes) == 0 {
+		return errors.NotValidf("empty model description")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	return nil
+}
+
+// WriteArchive writes a model archive holding the model description
+// and all the binaries it uses, downloaded from the source controller
+// exactly as UploadBinaries would for a migration.
+func WriteArchive(w io.Writer, config WriteArchiveConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	gzw := gzip.NewWriter(w)
+	aw := &archiveWriter{tw: tar.NewWriter(gzw), now: time.Now()}
+	if err := aw.add(archiveModelEntry, bytes.NewReader(config.Model.Bytes)); err != nil {
+		return errors.Trace(err)
+	}
+	err := UploadBinaries(UploadBinariesConfig{
+		Charms:             config.Model.Charms,
+		CharmDownloader:    config.CharmDownloader,
+		CharmUploader:      aw,
+		Tools:              config.Model.Tools,
+		ToolsDownloader:    config.ToolsDownloader,
+		ToolsUploader:      aw,
+		Resources:          config.Model.Resources,
+		ResourceDownloader: config.ResourceDownloader,
+		ResourceUploader:   aw,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := aw.tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+// archiveWriter stands in for the target controller when writing a
+// model archive, adding each binary it's sent to the archive.
+type archiveWriter struct {
+	tw  *tar.Writer
+	now time.Time
+}
+
+func (w *archiveWriter) add(name string, r io.ReadSeeker) error {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	err = w.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: w.now,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "writing %s", name)
+	}
+	if _, err := io.Copy(w.tw, r); err != nil {
+		return errors.Annotatef(err, "writing %s", name)
+	}
+	return nil
+}
+
+// UploadCharm implements CharmUploader.
+func (w *archiveWriter) UploadCharm(curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	return curl, errors.Trace(w.add(charmEntry(curl), r))
+}
+
+// UploadTools implements ToolsUploader.
+func (w *archiveWriter) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
+	if err := w.add(toolsEntry(v), r); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tools.List{&tools.Tools{Version: v}}, nil
+}
+
+// UploadResource implements ResourceUploader.
+func (w *archiveWriter) UploadResource(res resource.Resource, r io.ReadSeeker) error {
+	return errors.Trace(w.add(resourceEntry(res.ApplicationID, res.Name), r))
+}
+
+// SetPlaceholderResource implements ResourceUploader. Placeholders
+// and unit resources aren't stored, since they're recreated from the
+// model description when the archive is imported.
+func (w *archiveWriter) SetPlaceholderResource(resource.Resource) error {
+	return nil
+}
+
+// SetUnitResource implements ResourceUploader.
+func (w *archiveWriter) SetUnitResource(string, resource.Resource) error {
+	return nil
+}
+
+// Archive is a model archive that has been read for import. It serves
+// as the charm, agent binary and resource downloader when passed to
+// UploadBinaries, in place of the source controller.
+type Archive struct {
+	// Model holds the serialized model from the archive.
+	Model SerializedModel
+
+	dir   string
+	files map[string]string
+}
+
+// ReadArchive reads a model archive written by WriteArchive,
+// unpacking its binaries into a temporary directory. The archive
+// must be closed once it's no longer needed, to remove them.
+func ReadArchive(r io.Reader) (_ *Archive, err error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model archive")
+	}
+	dir, err := ioutil.TempDir("", "juju-model-archive")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive := &Archive{dir: dir, files: make(map[string]string)}
+	defer func() {
+		if err != nil {
+			archive.Close()
+		}
+	}()
+
+	var modelBytes []byte
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "reading model archive")
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		if hdr.Name == archiveModelEntry {
+			if modelBytes, err = ioutil.ReadAll(tr); err != nil {
+				return nil, errors.Annotatef(err, "reading %s", hdr.Name)
+			}
+			continue
+		}
+		// Entries are never unpacked using their own names, so
+		// an archive can't write outside the directory.
+		if err := archive.unpack(hdr.Name, tr); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if modelBytes == nil {
+		return nil, errors.NotValidf("model archive without %s", archiveModelEntry)
+	}
+	if archive.Model, err = NewSerializedModel(modelBytes); err != nil {
+		return nil, errors.Annotate(err, "reading model description")
+	}
+	return archive, nil
+}
+
+func (a *Archive) unpack(name string, r io.Reader) error {
+	file, err := ioutil.TempFile(a.dir, "entry")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Annotatef(err, "unpacking %s", name)
+	}
+	a.files[name] = file.Name()
+	return nil
+}
+
+func (a *Archive) open(name string) (io.ReadCloser, error) {
+	filename, ok := a.files[name]
+	if !ok {
+		return nil, errors.NotFoundf("%s in model archive", name)
+	}
+	file, err := os.Open(filename)
+	return file, errors.Trace(err)
+}
+
+// OpenCharm implements CharmDownloader.
+func (a *Archive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.open(charmEntry(curl))
+}
+
+// OpenURI implements ToolsDownloader, for the agent binary URIs in
+// the archive's serialized model.
+func (a *Archive) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	if !strings.HasPrefix(uri, archiveToolsURIPrefix) {
+		return nil, errors.NotFoundf("%s in model archive", uri)
+	}
+	v, err := version.ParseBinary(strings.TrimPrefix(uri, archiveToolsURIPrefix))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.open(toolsEntry(v))
+}
+
+// OpenResource implements ResourceDownloader.
+func (a *Archive) OpenResource(application, name string) (io.ReadCloser, error) {
+	return a.open(resourceEntry(application, name))
+}
+
+// Close removes the unpacked binaries.
+func (a *Archive) Close() error {
+	return errors.Trace(os.RemoveAll(a.dir))
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/migration"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ArchiveSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) TestWriteArchiveConfigValidate(c *gc.C) {
+	downloader := &fakeDownloader{}
+	config := migration.WriteArchiveConfig{
+		Model:              migration.SerializedModel{Bytes: []byte("model")},
+		CharmDownloader:    downloader,
+		ToolsDownloader:    downloader,
+		ResourceDownloader: downloader,
+	}
+	c.Assert(config.Validate(), jc.ErrorIsNil)
+
+	check := func(modify func(*migration.WriteArchiveConfig), expected string) {
+		config := config
+		modify(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, expected+" not valid")
+	}
+	check(func(c *migration.WriteArchiveConfig) { c.Model.Bytes = nil }, "empty model description")
+	check(func(c *migration.WriteArchiveConfig) { c.CharmDownloader = nil }, "missing CharmDownloader")
+	check(func(c *migration.WriteArchiveConfig) { c.ToolsDownloader = nil }, "missing ToolsDownloader")
+	check(func(c *migration.WriteArchiveConfig) { c.ResourceDownloader = nil }, "missing ResourceDownloader")
+}
+
+func (s *ArchiveSuite) TestReadArchiveNotGzipped(c *gc.C) {
+	_, err := migration.ReadArchive(bytes.NewBufferString("not an archive"))
+	c.Assert(err, gc.ErrorMatches, "reading model archive: .*")
+}
+
+func (s *ArchiveSuite) TestReadArchiveMissingModel(c *gc.C) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	err := tw.WriteHeader(&tar.Header{Name: "charms/cs:foo-1", Mode: 0600, Size: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write([]byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+
+	_, err = migration.ReadArchive(&buf)
+	c.Assert(err, gc.ErrorMatches, "model archive without model.yaml not valid")
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package schedule parses the cron-like schedules used to configure
+// recurring controller tasks.
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/robfig/cron.v2"
+)
+
+// Schedule describes when a recurring task runs.
+type Schedule interface {
+	// Next returns the first time after t that the task should run.
+	Next(t time.Time) time.Time
+}
+
+// Parse parses a schedule given either as a standard five field
+// crontab spec ("minute hour day-of-month month day-of-week"), or as
+// one of the descriptors @yearly, @monthly, @weekly, @daily, @hourly or
+// "@every <duration>". Times are interpreted in the location of the
+// time passed to Next.
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, errors.NotValidf("empty schedule")
+	}
+	if !strings.HasPrefix(spec, "@") {
+		if fields := strings.Fields(spec); len(fields) != 5 {
+			return nil, errors.NewNotValid(nil, fmt.Sprintf("schedule %q: expected 5 fields, got %d", spec, len(fields)))
+		}
+		// The parser expects a leading seconds field;
+		// tasks always run on the minute.
+		spec = "0 " + spec
+	}
+	s, err := cron.Parse(spec)
+	if err != nil {
+		return nil, errors.NewNotValid(err, "schedule")
+	}
+	return s, nil
+}
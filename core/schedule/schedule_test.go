@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schedule_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/schedule"
+)
+
+type scheduleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestNext(c *gc.C) {
+	now := time.Date(2018, 10, 17, 12, 30, 15, 0, time.UTC)
+	tests := []struct {
+		spec string
+		next time.Time
+	}{{
+		spec: "0 3 * * *",
+		next: time.Date(2018, 10, 18, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec: "*/15 * * * *",
+		next: time.Date(2018, 10, 17, 12, 45, 0, 0, time.UTC),
+	}, {
+		spec: "30 1 * * 0",
+		next: time.Date(2018, 10, 21, 1, 30, 0, 0, time.UTC),
+	}, {
+		spec: "@daily",
+		next: time.Date(2018, 10, 18, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@every 1h",
+		next: time.Date(2018, 10, 17, 13, 30, 15, 0, time.UTC),
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.spec)
+		sched, err := schedule.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(sched.Next(now), gc.Equals, test.next)
+	}
+}
+
+func (s *scheduleSuite) TestParseErrors(c *gc.C) {
+	tests := []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  "empty schedule not valid",
+	}, {
+		spec: "0 3 * *",
+		err:  `schedule "0 3 \* \*": expected 5 fields, got 4`,
+	}, {
+		spec: "0 25 * * *",
+		err:  "schedule: .*",
+	}, {
+		spec: "@fortnightly",
+		err:  "schedule: .*",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := schedule.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
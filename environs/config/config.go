@@ -26,6 +26,8 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -188,6 +190,44 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdHTTPURL sets the http or https URL to which log records
+	// are posted as newline-delimited JSON.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP log forwarding endpoint's certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdHTTPBearerToken sets the bearer token sent when posting
+	// log records to the HTTP log forwarding endpoint.
+	LogFwdHTTPBearerToken = "logforward-http-bearer-token"
+
+	// LogFwdLevel sets the lowest level of log record which is
+	// forwarded.
+	LogFwdLevel = "logforward-level"
+
+	// LogFwdIncludeModules sets a comma-separated list of logging
+	// modules; if set, only records from these modules are forwarded.
+	LogFwdIncludeModules = "logforward-include-modules"
+
+	// LogFwdExcludeModules sets a comma-separated list of logging
+	// modules whose records are not forwarded.
+	LogFwdExcludeModules = "logforward-exclude-modules"
+
+	// LogFwdIncludeEntities sets a comma-separated list of entity tags,
+	// which may contain wildcards; if set, only records from these
+	// entities are forwarded.
+	LogFwdIncludeEntities = "logforward-include-entities"
+
+	// LogFwdExcludeEntities sets a comma-separated list of entity tags,
+	// which may contain wildcards, whose records are not forwarded.
+	LogFwdExcludeEntities = "logforward-exclude-entities"
+
+	// LogFwdAudit determines whether audit records are forwarded.
+	// Audit records are written by the controller agents, so it
+	// only takes effect in the controller model.
+	LogFwdAudit = "logforward-audit"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -589,9 +629,24 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	httpCfg, hasHTTP := cfg.LogFwdHTTP()
 	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
-		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+		// Forwarding may be enabled for just the HTTP endpoint,
+		// in which case no syslog host is needed.
+		if lfCfg.Host != "" || !hasHTTP {
+			if err := lfCfg.Validate(); err != nil {
+				return errors.Annotate(err, "invalid syslog forwarding config")
+			}
+		}
+	}
+	if hasHTTP {
+		if err := httpCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid HTTP log forwarding config")
+		}
+	}
+	if v := cfg.asString(LogFwdLevel); v != "" {
+		if _, ok := loggo.ParseLevel(v); !ok {
+			return errors.NotValidf("%s %q", LogFwdLevel, v)
 		}
 	}
 
@@ -1068,6 +1123,50 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdFilter returns the filter applied to forwarded log records.
+func (c *Config) LogFwdFilter() logfwd.Filter {
+	level, _ := loggo.ParseLevel(c.asString(LogFwdLevel))
+	audit, _ := c.defined[LogFwdAudit].(bool)
+	return logfwd.Filter{
+		MinLevel:      level,
+		IncludeModule: c.asCommaList(LogFwdIncludeModules),
+		ExcludeModule: c.asCommaList(LogFwdExcludeModules),
+		IncludeEntity: c.asCommaList(LogFwdIncludeEntities),
+		ExcludeEntity: c.asCommaList(LogFwdExcludeEntities),
+		IncludeAudit:  audit,
+	}
+}
+
+// asCommaList returns the named attribute split into a list of
+// non-empty, trimmed values.
+func (c *Config) asCommaList(name string) []string {
+	var values []string
+	for _, value := range strings.Split(c.asString(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// LogFwdHTTP returns the HTTP log forwarding config. It is only
+// available if an HTTP log forwarding URL has been set.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	url, _ := c.defined[LogFwdHTTPURL].(string)
+	if url == "" {
+		return nil, false
+	}
+	lfCfg := httpjson.RawConfig{
+		URL:         url,
+		CACert:      c.asString(LogFwdHTTPCACert),
+		BearerToken: c.asString(LogFwdHTTPBearerToken),
+	}
+	if enabled, ok := c.defined[LogForwardEnabled].(bool); ok {
+		lfCfg.Enabled = enabled
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1442,6 +1541,15 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdHTTPBearerToken:  schema.Omit,
+	LogFwdLevel:            schema.Omit,
+	LogFwdIncludeModules:   schema.Omit,
+	LogFwdExcludeModules:   schema.Omit,
+	LogFwdIncludeEntities:  schema.Omit,
+	LogFwdExcludeEntities:  schema.Omit,
+	LogFwdAudit:            schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1898,6 +2006,51 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdHTTPURL: {
+		Description: `The http or https URL to which log records are posted as newline-delimited JSON.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the HTTP log forwarding endpoint's certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPBearerToken: {
+		Description: `The bearer token sent when posting log records to the HTTP log forwarding endpoint.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLevel: {
+		Description: `The lowest level of log record which is forwarded, one of TRACE, DEBUG, INFO, WARNING, ERROR or CRITICAL.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdIncludeModules: {
+		Description: `A comma-separated list of logging modules; if set, only records from these modules (or modules below them) are forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdExcludeModules: {
+		Description: `A comma-separated list of logging modules whose records (and those of modules below them) are not forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdIncludeEntities: {
+		Description: `A comma-separated list of entity tags, which may contain wildcards (e.g. unit-mysql-*); if set, only records from these entities are forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdExcludeEntities: {
+		Description: `A comma-separated list of entity tags, which may contain wildcards (e.g. machine-*), whose records are not forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdAudit: {
+		Description: `Whether the controller's audit records are forwarded (controller model only).`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
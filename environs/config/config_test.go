@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/testing"
 )
 
@@ -552,6 +553,38 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid HTTP log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":           true,
+			"logforward-http-url":          "https://logs.example.com/ingest",
+			"logforward-http-ca-cert":      testing.CACert,
+			"logforward-http-bearer-token": "sekrit",
+		}),
+	}, {
+		about:       "Invalid HTTP log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":  true,
+			"logforward-http-url": "ftp://logs.example.com",
+		}),
+		err: `invalid HTTP log forwarding config: URL scheme "ftp" not valid`,
+	}, {
+		about:       "Invalid HTTP log forwarding ca cert",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-http-url":     "https://logs.example.com/ingest",
+			"logforward-http-ca-cert": "abc",
+		}),
+		err: `invalid HTTP log forwarding config: validating TLS config: parsing CA certificate: no certificates found`,
+	}, {
+		about:       "Invalid log forwarding level",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-level": "LOUD",
+		}),
+		err: `logforward-level "LOUD" not valid`,
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -673,6 +706,20 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+	if v, _ := test.attrs["logforward-http-url"].(string); v != "" {
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.URL, gc.Equals, v)
+		enabled, _ := test.attrs["logforward-enabled"].(bool)
+		c.Assert(httpCfg.Enabled, gc.Equals, enabled)
+		caCert, _ := test.attrs["logforward-http-ca-cert"].(string)
+		c.Assert(httpCfg.CACert, gc.Equals, caCert)
+		token, _ := test.attrs["logforward-http-bearer-token"].(string)
+		c.Assert(httpCfg.BearerToken, gc.Equals, token)
+	} else {
+		c.Assert(hasHTTPCfg, jc.IsFalse)
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -1344,6 +1391,30 @@ func (s *ConfigSuite) TestContainerInheritProperies(c *gc.C) {
 	c.Assert(cfg.ContainerInheritProperies(), gc.Equals, "ca-certs,apt-primary")
 }
 
+func (s *ConfigSuite) TestLogFwdFilterDefault(c *gc.C) {
+	cfg := newTestConfig(c, nil)
+	c.Assert(cfg.LogFwdFilter(), jc.DeepEquals, logfwd.Filter{})
+}
+
+func (s *ConfigSuite) TestLogFwdFilter(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-level":            "warning",
+		"logforward-include-modules":  "juju.worker, unit",
+		"logforward-exclude-modules":  "juju.worker.uniter",
+		"logforward-include-entities": "unit-mysql-*,",
+		"logforward-exclude-entities": "unit-mysql-2",
+		"logforward-audit":            true,
+	})
+	c.Assert(cfg.LogFwdFilter(), jc.DeepEquals, logfwd.Filter{
+		MinLevel:      loggo.WARNING,
+		IncludeModule: []string{"juju.worker", "unit"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+		IncludeEntity: []string{"unit-mysql-*"},
+		ExcludeEntity: []string{"unit-mysql-2"},
+		IncludeAudit:  true,
+	})
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
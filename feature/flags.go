@@ -53,3 +53,15 @@ const Generations = "generations"
 // MongoDbSnap tells Juju to install MongoDB as a snap, rather than installing
 // it from APT.
 const MongoDbSnap = "mongodb-snap"
+
+// CachedStatus tells the Client facade to answer FullStatus requests from
+// the in-memory model cache rather than reading every entity from the
+// database. This value is only checked using the controller config
+// "features" attribute.
+const CachedStatus = "cached-status"
+
+// CachedWatchers tells the agent facades to serve entity and relation
+// watchers from the in-memory model cache rather than from database
+// watchers. This value is only checked using the controller config
+// "features" attribute.
+const CachedWatchers = "cached-watchers"
@@ -171,6 +171,10 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-annotations:
+    description: a space separated set of annotations to add to the ingress resource
+    source: unset
+    type: attrs
   kubernetes-ingress-class:
     default: nginx
     description: the class of the ingress controller to be used by the ingress resource
@@ -189,6 +193,20 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-tls-cluster-issuer:
+    description: the cert-manager cluster issuer used to obtain a TLS certificate
+      for the ingress resource
+    source: unset
+    type: string
+  kubernetes-ingress-tls-issuer:
+    description: the cert-manager issuer used to obtain a TLS certificate for the
+      ingress resource
+    source: unset
+    type: string
+  kubernetes-ingress-tls-secret:
+    description: the secret holding the TLS certificate used by the ingress resource
+    source: unset
+    type: string
   kubernetes-service-annotations:
     description: a space separated set of annotations to add to the service
     source: unset
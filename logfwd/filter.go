@@ -0,0 +1,132 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+)
+
+// AuditModule is the logging module of audit records. Records from
+// this module, or any below it, are only forwarded if the filter's
+// IncludeAudit is set.
+const AuditModule = "juju.auditlog"
+
+// Filter determines which log records are forwarded. The zero value
+// matches every record other than audit records.
+type Filter struct {
+	// MinLevel is the lowest level of record to forward.
+	MinLevel loggo.Level
+
+	// IncludeModule, if not empty, holds the only modules whose
+	// records are forwarded. A module also matches the modules
+	// below it, so "juju.worker" matches "juju.worker.uniter".
+	IncludeModule []string
+
+	// ExcludeModule holds the modules whose records are not
+	// forwarded, matched as for IncludeModule.
+	ExcludeModule []string
+
+	// IncludeEntity, if not empty, holds the only entities whose
+	// records are forwarded. Entities are given as tags, and may
+	// contain wildcards (e.g. "unit-mysql-*").
+	IncludeEntity []string
+
+	// ExcludeEntity holds the entities whose records are not
+	// forwarded, matched as for IncludeEntity.
+	ExcludeEntity []string
+
+	// IncludeAudit is true if audit records are forwarded.
+	IncludeAudit bool
+}
+
+// Validate ensures that the filter is correct.
+func (f Filter) Validate() error {
+	if f.MinLevel > loggo.CRITICAL {
+		return errors.NotValidf("level %d", f.MinLevel)
+	}
+	return nil
+}
+
+// Match returns true if the record should be forwarded.
+func (f Filter) Match(rec Record) bool {
+	if rec.Level < f.MinLevel {
+		return false
+	}
+	module := rec.Location.Module
+	if !f.IncludeAudit && matchModule(AuditModule, module) {
+		return false
+	}
+	if len(f.IncludeModule) > 0 && !matchAnyModule(f.IncludeModule, module) {
+		return false
+	}
+	if matchAnyModule(f.ExcludeModule, module) {
+		return false
+	}
+	if len(f.IncludeEntity) == 0 && len(f.ExcludeEntity) == 0 {
+		return true
+	}
+	entity := originEntity(rec.Origin)
+	if len(f.IncludeEntity) > 0 && !matchAnyEntity(f.IncludeEntity, entity) {
+		return false
+	}
+	return !matchAnyEntity(f.ExcludeEntity, entity)
+}
+
+// FilterRecords returns the records matched by the filter.
+func (f Filter) FilterRecords(records []Record) []Record {
+	var matched []Record
+	for _, rec := range records {
+		if f.Match(rec) {
+			matched = append(matched, rec)
+		}
+	}
+	return matched
+}
+
+func matchModule(filter, module string) bool {
+	return module == filter || strings.HasPrefix(module, filter+".")
+}
+
+func matchAnyModule(filters []string, module string) bool {
+	for _, filter := range filters {
+		if matchModule(filter, module) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAnyEntity(patterns []string, entity string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, entity); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// originEntity returns the tag string of the entity which created
+// a record, or an empty string if it is not known.
+func originEntity(o Origin) string {
+	switch o.Type {
+	case OriginTypeMachine:
+		if names.IsValidMachine(o.Name) {
+			return names.NewMachineTag(o.Name).String()
+		}
+	case OriginTypeUnit:
+		if names.IsValidUnit(o.Name) {
+			return names.NewUnitTag(o.Name).String()
+		}
+	case OriginTypeUser:
+		if names.IsValidUser(o.Name) {
+			return names.NewUserTag(o.Name).String()
+		}
+	}
+	return ""
+}
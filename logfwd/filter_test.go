@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func filterRecord(originType logfwd.OriginType, name, module string, level loggo.Level) logfwd.Record {
+	rec := validRecord
+	rec.Origin.Type = originType
+	rec.Origin.Name = name
+	rec.Location.Module = module
+	rec.Level = level
+	return rec
+}
+
+func (s *FilterSuite) TestZeroMatchesAllButAudit(c *gc.C) {
+	var filter logfwd.Filter
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker", loggo.TRACE)), jc.IsTrue)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeUnit, "mysql/0", "unit.mysql/0.juju-log", loggo.DEBUG)), jc.IsTrue)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.auditlog", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.auditlogger", loggo.INFO)), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestIncludeAudit(c *gc.C) {
+	filter := logfwd.Filter{IncludeAudit: true}
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.auditlog", loggo.INFO)), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestMinLevel(c *gc.C) {
+	filter := logfwd.Filter{MinLevel: loggo.WARNING}
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.DEBUG)), jc.IsFalse)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.WARNING)), jc.IsTrue)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.ERROR)), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestModules(c *gc.C) {
+	filter := logfwd.Filter{
+		IncludeModule: []string{"juju.worker", "unit"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	}
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker.deployer", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.workers", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker.uniter", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker.uniter.operation", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeUnit, "mysql/0", "unit.mysql/0.juju-log", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju.apiserver", loggo.INFO)), jc.IsFalse)
+}
+
+func (s *FilterSuite) TestEntities(c *gc.C) {
+	filter := logfwd.Filter{
+		IncludeEntity: []string{"unit-mysql-*", "machine-1"},
+		ExcludeEntity: []string{"unit-mysql-2"},
+	}
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeUnit, "mysql/0", "juju", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeUnit, "mysql/2", "juju", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeUnit, "wordpress/0", "juju", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "1", "juju", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "10", "juju", loggo.INFO)), jc.IsFalse)
+}
+
+func (s *FilterSuite) TestExcludeEntityOnly(c *gc.C) {
+	filter := logfwd.Filter{
+		ExcludeEntity: []string{"machine-*"},
+	}
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.INFO)), jc.IsFalse)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeUnit, "mysql/0", "juju", loggo.INFO)), jc.IsTrue)
+	c.Check(filter.Match(filterRecord(logfwd.OriginTypeUser, "a-user", "juju", loggo.INFO)), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestFilterRecords(c *gc.C) {
+	filter := logfwd.Filter{MinLevel: loggo.ERROR}
+	keep := filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.ERROR)
+	drop := filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.DEBUG)
+	c.Check(filter.FilterRecords([]logfwd.Record{drop, keep, drop}), jc.DeepEquals, []logfwd.Record{keep})
+	c.Check(filter.FilterRecords([]logfwd.Record{drop}), gc.HasLen, 0)
+}
+
+func (s *FilterSuite) TestValidate(c *gc.C) {
+	c.Check(logfwd.Filter{MinLevel: loggo.WARNING}.Validate(), jc.ErrorIsNil)
+	c.Check(logfwd.Filter{MinLevel: loggo.Level(42)}.Validate(), gc.ErrorMatches, `level 42 not valid`)
+}
@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// ContentType is the content type of the requests posted by Client.
+const ContentType = "application/x-ndjson"
+
+// defaultTimeout is the timeout applied to each request.
+const defaultTimeout = 30 * time.Second
+
+// Doer sends an HTTP request and returns the response.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client posts batches of log records to an HTTP endpoint.
+type Client struct {
+	// Doer is the HTTP client this client wraps.
+	Doer Doer
+
+	url         string
+	bearerToken string
+}
+
+// Open returns a new client that posts to the endpoint described by
+// the config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	doer := &http.Client{
+		Timeout: defaultTimeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsCfg,
+		},
+	}
+	return OpenForDoer(cfg, doer)
+}
+
+// OpenForDoer returns a new client that posts to the endpoint
+// described by the config, using the given doer.
+func OpenForDoer(cfg RawConfig, doer Doer) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		Doer:        doer,
+		url:         cfg.URL,
+		bearerToken: cfg.BearerToken,
+	}, nil
+}
+
+// Close implements io.Closer. The client holds no connection of its
+// own, so there is nothing to do.
+func (client *Client) Close() error {
+	return nil
+}
+
+// Send posts the records to the endpoint in a single request, one
+// JSON object per line.
+func (client *Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, rec := range records {
+		if err := encoder.Encode(recordFromLogfwd(rec)); err != nil {
+			return errors.Annotate(err, "encoding log record")
+		}
+	}
+
+	req, err := http.NewRequest("POST", client.url, &body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", ContentType)
+	if client.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+client.bearerToken)
+	}
+	resp, err := client.Doer.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "posting log records")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("posting log records: %s: %s", resp.Status, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// Record is the JSON form of a forwarded log record.
+type Record struct {
+	ID              int64     `json:"id"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname,omitempty"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name"`
+	Software        string    `json:"software,omitempty"`
+	SoftwareVersion string    `json:"software-version,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+	Level           string    `json:"level"`
+	Module          string    `json:"module,omitempty"`
+	Location        string    `json:"location,omitempty"`
+	Message         string    `json:"message"`
+}
+
+func recordFromLogfwd(rec logfwd.Record) Record {
+	out := Record{
+		ID:             rec.ID,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		Software:       rec.Origin.Software.Name,
+		Timestamp:      rec.Timestamp.UTC(),
+		Level:          rec.Level.String(),
+		Module:         rec.Location.Module,
+		Location:       rec.Location.String(),
+		Message:        rec.Message,
+	}
+	if out.Software != "" {
+		out.SoftwareVersion = rec.Origin.Software.Version.String()
+	}
+	return out
+}
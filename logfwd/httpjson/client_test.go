@@ -0,0 +1,170 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	requests []*http.Request
+	bodies   []string
+	status   int
+	server   *httptest.Server
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.status = http.StatusOK
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, string(body))
+		w.WriteHeader(s.status)
+		w.Write([]byte("computer says no\n"))
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) open(c *gc.C, token string) *httpjson.Client {
+	client, err := httpjson.Open(httpjson.RawConfig{
+		Enabled:     true,
+		URL:         s.server.URL + "/ingest",
+		BearerToken: token,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) records() []logfwd.Record {
+	origin := logfwd.Origin{
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Hostname:       "machine-0.deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Type:           logfwd.OriginTypeMachine,
+		Name:           "0",
+		Software: logfwd.Software{
+			PrivateEnterpriseNumber: 28978,
+			Name:                    "jujud-machine-agent",
+			Version:                 version.MustParse("2.5.0"),
+		},
+	}
+	return []logfwd.Record{{
+		ID:        10,
+		Origin:    origin,
+		Timestamp: time.Date(2018, 10, 17, 12, 0, 0, 0, time.UTC),
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.uniter",
+			Filename: "uniter.go",
+			Line:     42,
+		},
+		Message: "started",
+	}, {
+		ID:        11,
+		Origin:    origin,
+		Timestamp: time.Date(2018, 10, 17, 12, 0, 1, 0, time.UTC),
+		Level:     loggo.ERROR,
+		Message:   "it failed:\nbadly",
+	}}
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := s.open(c, "")
+	err := client.Send(s.records())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	req := s.requests[0]
+	c.Check(req.Method, gc.Equals, "POST")
+	c.Check(req.URL.Path, gc.Equals, "/ingest")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+	c.Check(req.Header.Get("Authorization"), gc.Equals, "")
+	c.Check(s.bodies[0], gc.Equals, ""+
+		`{"id":10,"controller-uuid":"9f484882-2f18-4fd2-967d-db9663db7bea",`+
+		`"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d",`+
+		`"hostname":"machine-0.deadbeef-0bad-400d-8000-4b1d0d06f00d",`+
+		`"origin-type":"machine","origin-name":"0","software":"jujud-machine-agent",`+
+		`"software-version":"2.5.0","timestamp":"2018-10-17T12:00:00Z","level":"INFO",`+
+		`"module":"juju.worker.uniter","location":"uniter.go:42","message":"started"}`+"\n"+
+		`{"id":11,"controller-uuid":"9f484882-2f18-4fd2-967d-db9663db7bea",`+
+		`"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d",`+
+		`"hostname":"machine-0.deadbeef-0bad-400d-8000-4b1d0d06f00d",`+
+		`"origin-type":"machine","origin-name":"0","software":"jujud-machine-agent",`+
+		`"software-version":"2.5.0","timestamp":"2018-10-17T12:00:01Z","level":"ERROR",`+
+		`"message":"it failed:\nbadly"}`+"\n")
+}
+
+func (s *ClientSuite) TestSendBearerToken(c *gc.C) {
+	client := s.open(c, "sekrit")
+	err := client.Send(s.records())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].Header.Get("Authorization"), gc.Equals, "Bearer sekrit")
+}
+
+func (s *ClientSuite) TestSendNoRecords(c *gc.C) {
+	client := s.open(c, "")
+	err := client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *ClientSuite) TestSendErrorStatus(c *gc.C) {
+	s.status = http.StatusUnauthorized
+	client := s.open(c, "")
+	err := client.Send(s.records())
+	c.Assert(err, gc.ErrorMatches, "posting log records: 401 Unauthorized: computer says no")
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpjson.Open(httpjson.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, "empty URL not valid")
+}
+
+func (s *ClientSuite) TestOpenForDoer(c *gc.C) {
+	var doer stubDoer
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com/ingest",
+	}, &doer)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Send(s.records()[:1])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doer.req, gc.NotNil)
+	c.Check(doer.req.URL.String(), gc.Equals, "https://logs.example.com/ingest")
+}
+
+type stubDoer struct {
+	req *http.Request
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	d.req = req
+	return &http.Response{
+		Status:     "204 No Content",
+		StatusCode: http.StatusNoContent,
+		Body:       ioutil.NopCloser(&bytes.Buffer{}),
+	}, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestValidateValid(c *gc.C) {
+	for i, cfg := range []httpjson.RawConfig{{
+		Enabled: true,
+		URL:     "https://logs.example.com/ingest",
+	}, {
+		Enabled:     true,
+		URL:         "http://10.0.0.1:3100/loki/api/v1/push",
+		BearerToken: "sekrit",
+	}, {
+		Enabled: true,
+		URL:     "https://logs.example.com/ingest",
+		CACert:  coretesting.CACert,
+	}, {
+		Enabled: false,
+	}} {
+		c.Logf("test %d", i)
+		c.Check(cfg.Validate(), jc.ErrorIsNil)
+	}
+}
+
+func (s *ConfigSuite) TestValidateInvalid(c *gc.C) {
+	for i, test := range []struct {
+		cfg httpjson.RawConfig
+		err string
+	}{{
+		cfg: httpjson.RawConfig{Enabled: true},
+		err: "empty URL not valid",
+	}, {
+		cfg: httpjson.RawConfig{URL: "ftp://logs.example.com"},
+		err: `URL scheme "ftp" not valid`,
+	}, {
+		cfg: httpjson.RawConfig{URL: "https:///ingest"},
+		err: `URL "https:///ingest" without host not valid`,
+	}, {
+		cfg: httpjson.RawConfig{URL: "https://logs.example.com", CACert: "abc"},
+		err: "validating TLS config: parsing CA certificate: no certificates found",
+	}} {
+		c.Logf("test %d", i)
+		c.Check(test.cfg.Validate(), gc.ErrorMatches, test.err)
+	}
+}
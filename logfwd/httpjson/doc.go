@@ -0,0 +1,7 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The httpjson package holds the tools needed to perform log forwarding
+// from Juju to a remote HTTP endpoint, posting batches of records as
+// newline-delimited JSON.
+package httpjson
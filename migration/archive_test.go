@@ -0,0 +1,151 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/tools"
+)
+
+// ArchiveSuite checks that model archives round trip real model
+// exports.
+type ArchiveSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) serializedModel(c *gc.C) []byte {
+	s.Factory.MakeUnit(c, nil)
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return bytes
+}
+
+func (s *ArchiveSuite) TestNewSerializedModel(c *gc.C) {
+	modelBytes := s.serializedModel(c)
+	model, err := description.Deserialize(modelBytes)
+	c.Assert(err, jc.ErrorIsNil)
+	binaries := coremigration.ModelBinaries(model)
+
+	serialized, err := coremigration.NewSerializedModel(modelBytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.Bytes, jc.DeepEquals, modelBytes)
+	c.Check(serialized.Charms, jc.DeepEquals, binaries.Charms)
+	expectedTools := make(map[version.Binary]string)
+	for _, v := range binaries.Tools {
+		expectedTools[v] = "/tools/" + v.String()
+	}
+	c.Check(serialized.Tools, jc.DeepEquals, expectedTools)
+	c.Check(serialized.Resources, gc.HasLen, 0)
+}
+
+func (s *ArchiveSuite) TestRoundTrip(c *gc.C) {
+	serialized, err := coremigration.NewSerializedModel(s.serializedModel(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var buf bytes.Buffer
+	source := archiveSource{}
+	err = coremigration.WriteArchive(&buf, coremigration.WriteArchiveConfig{
+		Model:              serialized,
+		CharmDownloader:    source,
+		ToolsDownloader:    source,
+		ResourceDownloader: source,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	archive, err := coremigration.ReadArchive(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Check(archive.Model, jc.DeepEquals, serialized)
+
+	// The archive stands in for the source controller when the
+	// binaries are uploaded to the target.
+	target := &archiveTarget{tools: make(map[version.Binary]string)}
+	err = coremigration.UploadBinaries(coremigration.UploadBinariesConfig{
+		Charms:             archive.Model.Charms,
+		CharmDownloader:    archive,
+		CharmUploader:      target,
+		Tools:              archive.Model.Tools,
+		ToolsDownloader:    archive,
+		ToolsUploader:      target,
+		Resources:          archive.Model.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   target,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(target.charms, jc.DeepEquals, serialized.Charms)
+	c.Check(target.tools, jc.DeepEquals, serialized.Tools)
+}
+
+// archiveSource serves each binary's name as its content.
+type archiveSource struct{}
+
+func (archiveSource) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewBufferString(curl.String())), nil
+}
+
+func (archiveSource) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewBufferString(uri)), nil
+}
+
+func (archiveSource) OpenResource(app, name string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewBufferString(app + "/" + name)), nil
+}
+
+// archiveTarget records the binaries uploaded to it, checking that
+// their content is as served by archiveSource.
+type archiveTarget struct {
+	charms []string
+	tools  map[version.Binary]string
+}
+
+func (t *archiveTarget) UploadCharm(curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if string(data) != curl.String() {
+		return nil, errors.Errorf("unexpected content for charm %s: %q", curl, data)
+	}
+	t.charms = append(t.charms, curl.String())
+	return curl, nil
+}
+
+func (t *archiveTarget) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	t.tools[v] = string(data)
+	return tools.List{&tools.Tools{Version: v}}, nil
+}
+
+func (t *archiveTarget) UploadResource(resource.Resource, io.ReadSeeker) error {
+	return errors.New("unexpected resource")
+}
+
+func (t *archiveTarget) SetPlaceholderResource(resource.Resource) error {
+	return errors.New("unexpected resource")
+}
+
+func (t *archiveTarget) SetUnitResource(string, resource.Resource) error {
+	return errors.New("unexpected resource")
+}
@@ -0,0 +1,27 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/description"
+	"github.com/juju/errors"
+)
+
+// ImportChecker describes the method needed to check that a model
+// could be imported into the database.
+type ImportChecker interface {
+	CheckImport(model description.Model) error
+}
+
+// DryRunImport deserializes a model description from the bytes and
+// checks that the import step of a migration would succeed. Nothing is
+// written to the database, so a failed or abandoned dry run leaves
+// nothing behind to clean up.
+func DryRunImport(checker ImportChecker, bytes []byte) error {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(checker.CheckImport(model))
+}
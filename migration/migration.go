@@ -4,24 +4,13 @@
 package migration
 
 import (
-	"io"
-	"io/ioutil"
-	"net/url"
-	"os"
-
 	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
-	"github.com/juju/naturalsort"
-	"github.com/juju/version"
-	"gopkg.in/juju/charm.v6"
 
 	"github.com/juju/juju/core/leadership"
-	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/feature"
-	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
-	"github.com/juju/juju/tools"
 )
 
 var logger = loggo.GetLogger("juju.migration")
@@ -122,228 +111,3 @@ func ImportModel(importer StateImporter, getClaimer ClaimerFunc, bytes []byte) (
 
 	return dbModel, dbState, nil
 }
-
-// CharmDownlaoder defines a single method that is used to download a
-// charm from the source controller in a migration.
-type CharmDownloader interface {
-	OpenCharm(*charm.URL) (io.ReadCloser, error)
-}
-
-// CharmUploader defines a single method that is used to upload a
-// charm to the target controller in a migration.
-type CharmUploader interface {
-	UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error)
-}
-
-// ToolsDownloader defines a single method that is used to download
-// tools from the source controller in a migration.
-type ToolsDownloader interface {
-	OpenURI(string, url.Values) (io.ReadCloser, error)
-}
-
-// ToolsUploader defines a single method that is used to upload tools
-// to the target controller in a migration.
-type ToolsUploader interface {
-	UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error)
-}
-
-// ResourceDownloader defines the interface for downloading resources
-// from the source controller during a migration.
-type ResourceDownloader interface {
-	OpenResource(string, string) (io.ReadCloser, error)
-}
-
-// ResourceUploader defines the interface for uploading resources into
-// the target controller during a migration.
-type ResourceUploader interface {
-	UploadResource(resource.Resource, io.ReadSeeker) error
-	SetPlaceholderResource(resource.Resource) error
-	SetUnitResource(string, resource.Resource) error
-}
-
-// UploadBinariesConfig provides all the configuration that the
-// UploadBinaries function needs to operate. To construct the config
-// with the default helper functions, use `NewUploadBinariesConfig`.
-type UploadBinariesConfig struct {
-	Charms          []string
-	CharmDownloader CharmDownloader
-	CharmUploader   CharmUploader
-
-	Tools           map[version.Binary]string
-	ToolsDownloader ToolsDownloader
-	ToolsUploader   ToolsUploader
-
-	Resources          []migration.SerializedModelResource
-	ResourceDownloader ResourceDownloader
-	ResourceUploader   ResourceUploader
-}
-
-// Validate makes sure that all the config values are non-nil.
-func (c *UploadBinariesConfig) Validate() error {
-	if c.CharmDownloader == nil {
-		return errors.NotValidf("missing CharmDownloader")
-	}
-	if c.CharmUploader == nil {
-		return errors.NotValidf("missing CharmUploader")
-	}
-	if c.ToolsDownloader == nil {
-		return errors.NotValidf("missing ToolsDownloader")
-	}
-	if c.ToolsUploader == nil {
-		return errors.NotValidf("missing ToolsUploader")
-	}
-	if c.ResourceDownloader == nil {
-		return errors.NotValidf("missing ResourceDownloader")
-	}
-	if c.ResourceUploader == nil {
-		return errors.NotValidf("missing ResourceUploader")
-	}
-	return nil
-}
-
-// UploadBinaries will send binaries stored in the source blobstore to
-// the target controller.
-func UploadBinaries(config UploadBinariesConfig) error {
-	if err := config.Validate(); err != nil {
-		return errors.Trace(err)
-	}
-	if err := uploadCharms(config); err != nil {
-		return errors.Trace(err)
-	}
-	if err := uploadTools(config); err != nil {
-		return errors.Trace(err)
-	}
-	if err := uploadResources(config); err != nil {
-		return errors.Trace(err)
-	}
-	return nil
-}
-
-func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err error) {
-	tempFile, err := ioutil.TempFile("", "juju-migrate-binary")
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-	defer func() {
-		if err != nil {
-			os.Remove(tempFile.Name())
-		}
-	}()
-	_, err = io.Copy(tempFile, r)
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-	tempFile.Seek(0, 0)
-	rmTempFile := func() {
-		filename := tempFile.Name()
-		tempFile.Close()
-		os.Remove(filename)
-	}
-
-	return tempFile, rmTempFile, nil
-}
-
-func uploadCharms(config UploadBinariesConfig) error {
-	// It is critical that charms are uploaded in ascending charm URL
-	// order so that charm revisions end up the same in the target as
-	// they were in the source.
-	naturalsort.Sort(config.Charms)
-
-	for _, charmURL := range config.Charms {
-		logger.Debugf("sending charm %s to target", charmURL)
-
-		curl, err := charm.ParseURL(charmURL)
-		if err != nil {
-			return errors.Annotate(err, "bad charm URL")
-		}
-
-		reader, err := config.CharmDownloader.OpenCharm(curl)
-		if err != nil {
-			return errors.Annotate(err, "cannot open charm")
-		}
-		defer reader.Close()
-
-		content, cleanup, err := streamThroughTempFile(reader)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		defer cleanup()
-
-		if usedCurl, err := config.CharmUploader.UploadCharm(curl, content); err != nil {
-			return errors.Annotate(err, "cannot upload charm")
-		} else if usedCurl.String() != curl.String() {
-			// The target controller shouldn't assign a different charm URL.
-			return errors.Errorf("charm %s unexpectedly assigned %s", curl, usedCurl)
-		}
-	}
-	return nil
-}
-
-func uploadTools(config UploadBinariesConfig) error {
-	for v, uri := range config.Tools {
-		logger.Debugf("sending agent binaries to target: %s", v)
-
-		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
-		if err != nil {
-			return errors.Annotate(err, "cannot open charm")
-		}
-		defer reader.Close()
-
-		content, cleanup, err := streamThroughTempFile(reader)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		defer cleanup()
-
-		if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
-			return errors.Annotate(err, "cannot upload agent binaries")
-		}
-	}
-	return nil
-}
-
-func uploadResources(config UploadBinariesConfig) error {
-	for _, res := range config.Resources {
-		if res.ApplicationRevision.IsPlaceholder() {
-			// Resource placeholders created in the migration import rather
-			// than attempting to post empty resources.
-		} else {
-			err := uploadAppResource(config, res.ApplicationRevision)
-			if err != nil {
-				return errors.Trace(err)
-			}
-		}
-		for unitName, unitRev := range res.UnitRevisions {
-			if err := config.ResourceUploader.SetUnitResource(unitName, unitRev); err != nil {
-				return errors.Annotate(err, "cannot set unit resource")
-			}
-		}
-		// Each config.Resources element also contains a
-		// CharmStoreRevision field. This isn't especially important
-		// to migrate so is skipped for now.
-	}
-	return nil
-}
-
-func uploadAppResource(config UploadBinariesConfig, rev resource.Resource) error {
-	logger.Debugf("opening application resource for %s: %s", rev.ApplicationID, rev.Name)
-	reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
-	if err != nil {
-		return errors.Annotate(err, "cannot open resource")
-	}
-	defer reader.Close()
-
-	// TODO(menn0) - validate that the downloaded revision matches
-	// the expected metadata. Check revision and fingerprint.
-
-	content, cleanup, err := streamThroughTempFile(reader)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	defer cleanup()
-
-	if err := config.ResourceUploader.UploadResource(rev, content); err != nil {
-		return errors.Annotate(err, "cannot upload resource")
-	}
-	return nil
-}
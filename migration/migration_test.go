@@ -4,22 +4,18 @@
 package migration_test
 
 import (
-	"bytes"
 	"fmt"
-	"io"
 	"io/ioutil"
-	"net/url"
 	"time"
 
 	"github.com/juju/description"
-	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
-	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/component/all"
 	"github.com/juju/juju/core/leadership"
@@ -29,13 +25,10 @@ import (
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
-	"github.com/juju/juju/resource"
-	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
-	"github.com/juju/juju/tools"
 )
 
 func init() {
@@ -172,201 +165,82 @@ func (s *ImportSuite) makeUnitApplicationLeaderLegacy(c *gc.C, unitName, applica
 	c.Assert(err, jc.ErrorIsNil)
 }
 
-func (s *ImportSuite) TestUploadBinariesConfigValidate(c *gc.C) {
-	type T migration.UploadBinariesConfig // alias for brevity
-
-	check := func(modify func(*T), missing string) {
-		config := T{
-			CharmDownloader:    struct{ migration.CharmDownloader }{},
-			CharmUploader:      struct{ migration.CharmUploader }{},
-			ToolsDownloader:    struct{ migration.ToolsDownloader }{},
-			ToolsUploader:      struct{ migration.ToolsUploader }{},
-			ResourceDownloader: struct{ migration.ResourceDownloader }{},
-			ResourceUploader:   struct{ migration.ResourceUploader }{},
-		}
-		modify(&config)
-		realConfig := migration.UploadBinariesConfig(config)
-		c.Check(realConfig.Validate(), gc.ErrorMatches, fmt.Sprintf("missing %s not valid", missing))
-	}
-
-	check(func(c *T) { c.CharmDownloader = nil }, "CharmDownloader")
-	check(func(c *T) { c.CharmUploader = nil }, "CharmUploader")
-	check(func(c *T) { c.ToolsDownloader = nil }, "ToolsDownloader")
-	check(func(c *T) { c.ToolsUploader = nil }, "ToolsUploader")
-	check(func(c *T) { c.ResourceDownloader = nil }, "ResourceDownloader")
-	check(func(c *T) { c.ResourceUploader = nil }, "ResourceUploader")
-}
-
-func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
-	downloader := &fakeDownloader{}
-	uploader := &fakeUploader{
-		tools:     make(map[version.Binary]string),
-		resources: make(map[string]string),
-	}
-
-	toolsMap := map[version.Binary]string{
-		version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
-		version.MustParseBinary("2.0.0-xenial-amd64"): "/tools/1",
-	}
-
-	app0Res := resourcetesting.NewResource(c, nil, "blob0", "app0", "blob0").Resource
-	app1Res := resourcetesting.NewResource(c, nil, "blob1", "app1", "blob1").Resource
-	app1UnitRes := app1Res
-	app1UnitRes.Revision = 1
-	app2Res := resourcetesting.NewPlaceholderResource(c, "blob2", "app2")
-	resources := []coremigration.SerializedModelResource{
-		{ApplicationRevision: app0Res},
-		{
-			ApplicationRevision: app1Res,
-			UnitRevisions:       map[string]resource.Resource{"app1/99": app1UnitRes},
-		},
-		{ApplicationRevision: app2Res},
-	}
-
-	config := migration.UploadBinariesConfig{
-		Charms: []string{
-			// These 2 are out of order. Rev 2 must be uploaded first.
-			"local:trusty/magic-10",
-			"local:trusty/magic-2",
-			"cs:trusty/postgresql-42",
-		},
-		CharmDownloader:    downloader,
-		CharmUploader:      uploader,
-		Tools:              toolsMap,
-		ToolsDownloader:    downloader,
-		ToolsUploader:      uploader,
-		Resources:          resources,
-		ResourceDownloader: downloader,
-		ResourceUploader:   uploader,
-	}
-	err := migration.UploadBinaries(config)
+func (s *ImportSuite) TestDryRunImport(c *gc.C) {
+	s.makeApplicationWithUnits(c, "wordpress", 2)
+	model, err := s.State.Export()
 	c.Assert(err, jc.ErrorIsNil)
-
-	expectedCharms := []string{
-		// Note ordering.
-		"cs:trusty/postgresql-42",
-		"local:trusty/magic-2",
-		"local:trusty/magic-10",
-	}
-	c.Assert(downloader.charms, jc.DeepEquals, expectedCharms)
-	c.Assert(uploader.charms, jc.DeepEquals, expectedCharms)
-
-	c.Assert(downloader.uris, jc.SameContents, []string{
-		"/tools/0",
-		"/tools/1",
-	})
-	c.Assert(uploader.tools, jc.DeepEquals, toolsMap)
-
-	c.Assert(downloader.resources, jc.SameContents, []string{
-		"app0/blob0",
-		"app1/blob1",
-	})
-	c.Assert(uploader.resources, jc.DeepEquals, map[string]string{
-		"app0/blob0": "blob0",
-		"app1/blob1": "blob1",
+	uuid := utils.MustNewUUID().String()
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": uuid,
 	})
-	c.Assert(uploader.unitResources, jc.SameContents, []string{"app1/99-blob1"})
-}
-
-func (s *ImportSuite) TestWrongCharmURLAssigned(c *gc.C) {
-	downloader := &fakeDownloader{}
-	uploader := &fakeUploader{
-		reassignCharmURL: true,
-	}
-
-	config := migration.UploadBinariesConfig{
-		Charms:             []string{"local:foo/bar-2"},
-		CharmDownloader:    downloader,
-		CharmUploader:      uploader,
-		ToolsDownloader:    downloader,
-		ToolsUploader:      uploader,
-		ResourceDownloader: downloader,
-		ResourceUploader:   uploader,
-	}
-	err := migration.UploadBinaries(config)
-	c.Assert(err, gc.ErrorMatches,
-		"charm local:foo/bar-2 unexpectedly assigned local:foo/bar-1")
-}
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
 
-type fakeDownloader struct {
-	charms    []string
-	uris      []string
-	resources []string
-}
+	controller := state.NewController(s.StatePool)
+	err = migration.DryRunImport(controller, bytes)
+	c.Assert(err, jc.ErrorIsNil)
 
-func (d *fakeDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
-	urlStr := curl.String()
-	d.charms = append(d.charms, urlStr)
-	// Return the charm URL string as the fake charm content
-	return ioutil.NopCloser(bytes.NewReader([]byte(urlStr + " content"))), nil
+	// Nothing is imported.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
 }
 
-func (d *fakeDownloader) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
-	if query != nil {
-		panic("query should be empty")
-	}
-	d.uris = append(d.uris, uri)
-	// Return the URI string as fake content
-	return ioutil.NopCloser(bytes.NewReader([]byte(uri))), nil
-}
+func (s *ImportSuite) TestDryRunImportModelExists(c *gc.C) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
 
-func (d *fakeDownloader) OpenResource(app, name string) (io.ReadCloser, error) {
-	d.resources = append(d.resources, app+"/"+name)
-	// Use the resource name as the content.
-	return ioutil.NopCloser(bytes.NewReader([]byte(name))), nil
+	controller := state.NewController(s.StatePool)
+	err = migration.DryRunImport(controller, bytes)
+	c.Assert(err, gc.ErrorMatches, `model .* already exists`)
 }
 
-type fakeUploader struct {
-	tools            map[version.Binary]string
-	charms           []string
-	resources        map[string]string
-	unitResources    []string
-	reassignCharmURL bool
-}
+func (s *ImportSuite) TestDryRunImportModelNameExists(c *gc.C) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"uuid": utils.MustNewUUID().String(),
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
 
-func (f *fakeUploader) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
-	data, err := ioutil.ReadAll(r)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	f.tools[v] = string(data)
-	return tools.List{&tools.Tools{Version: v}}, nil
+	controller := state.NewController(s.StatePool)
+	err = migration.DryRunImport(controller, bytes)
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`model %q for %s already exists`, s.Model.Name(), s.Model.Owner().Id()))
 }
 
-func (f *fakeUploader) UploadCharm(u *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
-	data, err := ioutil.ReadAll(r)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	if string(data) != u.String()+" content" {
-		panic(fmt.Sprintf("unexpected charm body for %s: %s", u.String(), data))
-	}
-	f.charms = append(f.charms, u.String())
-
-	outU := *u
-	if f.reassignCharmURL {
-		outU.Revision--
-	}
-	return &outU, nil
-}
+func (s *ImportSuite) TestDryRunImportChecksMachines(c *gc.C) {
+	s.makeApplicationWithUnits(c, "wordpress", 1)
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": utils.MustNewUUID().String(),
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
 
-func (f *fakeUploader) UploadResource(res resource.Resource, r io.ReadSeeker) error {
-	body, err := ioutil.ReadAll(r)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	f.resources[res.ApplicationID+"/"+res.Name] = string(body)
-	return nil
-}
+	var desc map[string]interface{}
+	err = yaml.Unmarshal(bytes, &desc)
+	c.Assert(err, jc.ErrorIsNil)
+	machines := desc["machines"].(map[interface{}]interface{})
+	machine := machines["machines"].([]interface{})[0].(map[interface{}]interface{})
+	machine["jobs"] = []string{"bogus"}
+	bytes, err = yaml.Marshal(desc)
+	c.Assert(err, jc.ErrorIsNil)
 
-func (f *fakeUploader) SetPlaceholderResource(res resource.Resource) error {
-	f.resources[res.ApplicationID+"/"+res.Name] = "<placeholder>"
-	return nil
+	controller := state.NewController(s.StatePool)
+	err = migration.DryRunImport(controller, bytes)
+	c.Assert(err, gc.ErrorMatches, `machines: 0: unknown machine job: "bogus"`)
 }
 
-func (f *fakeUploader) SetUnitResource(unit string, res resource.Resource) error {
-	f.unitResources = append(f.unitResources, unit+"-"+res.Name)
-	return nil
+func (s *ImportSuite) TestDryRunImportBadBytes(c *gc.C) {
+	controller := state.NewController(s.StatePool)
+	err := migration.DryRunImport(controller, []byte("not a model"))
+	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
 }
 
 type ExportSuite struct {
@@ -384,6 +258,40 @@ func (s *ExportSuite) TestExportModel(c *gc.C) {
 	c.Assert(modelDesc.Validate(), jc.ErrorIsNil)
 }
 
+func (s *ExportSuite) TestModelBinaries(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	machineID, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineID)
+	c.Assert(err, jc.ErrorIsNil)
+	machineTools, err := machine.AgentTools()
+	c.Assert(err, jc.ErrorIsNil)
+	unitTools, err := unit.AgentTools()
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	binaries := coremigration.ModelBinaries(model)
+	c.Check(binaries.Charms, jc.DeepEquals, []string{curl.String()})
+	c.Check(binaries.Tools, jc.SameContents, uniqueVersions(machineTools.Version, unitTools.Version))
+	c.Check(binaries.Resources, gc.HasLen, 0)
+}
+
+func uniqueVersions(versions ...version.Binary) []version.Binary {
+	var unique []version.Binary
+	seen := make(map[version.Binary]bool)
+	for _, v := range versions {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
 func fakeGetClaimer(string) (leadership.Claimer, error) {
 	return &fakeClaimer{}, nil
 }
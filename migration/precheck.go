@@ -35,6 +35,8 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	ActionScheduleIds() ([]string, error)
+	RunningActionRolloutIds() ([]string, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -120,8 +122,67 @@ func SourcePrecheck(
 	modelPresence ModelPresence,
 	controllerPresence ModelPresence,
 ) error {
-	ctx := precheckContext{backend, modelPresence}
-	if err := ctx.checkModel(); err != nil {
+	ctx := precheckContext{backend: backend, presence: modelPresence}
+	return errors.Trace(ctx.sourcePrecheck(controllerPresence))
+}
+
+// SourcePrecheckAll runs the same checks as SourcePrecheck, but
+// carries on past any that fail and returns every problem found.
+func SourcePrecheckAll(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+) []error {
+	var problems []error
+	ctx := precheckContext{backend: backend, presence: modelPresence, problems: &problems}
+	ctx.sourcePrecheck(controllerPresence)
+	return problems
+}
+
+// precheckContext holds what's needed to run prechecks against a
+// single model.
+type precheckContext struct {
+	backend  PrecheckBackend
+	presence ModelPresence
+
+	// problems collects every problem found when set. Otherwise
+	// the prechecks stop at the first problem.
+	problems *[]error
+
+	// label annotates the problems found, when set.
+	label string
+}
+
+// check records the problem found by a failed check. It returns the
+// problem, stopping the prechecks, unless problems are being
+// collected. A nil error is ignored.
+func (ctx *precheckContext) check(err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctx.label != "" {
+		err = errors.Annotate(err, ctx.label)
+	}
+	if ctx.problems == nil {
+		return err
+	}
+	*ctx.problems = append(*ctx.problems, err)
+	return nil
+}
+
+// forController returns a context for checking a controller model,
+// which records problems in the same way as this one.
+func (ctx *precheckContext) forController(backend PrecheckBackend, presence ModelPresence, label string) *precheckContext {
+	return &precheckContext{
+		backend:  backend,
+		presence: presence,
+		problems: ctx.problems,
+		label:    label,
+	}
+}
+
+func (ctx *precheckContext) sourcePrecheck(controllerPresence ModelPresence) error {
+	if err := ctx.check(ctx.checkModel()); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -138,27 +199,27 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
-	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
-		return errors.Annotate(err, "checking cleanups")
+	if err := ctx.checkActions(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if cleanupNeeded, err := ctx.backend.NeedsCleanup(); err != nil {
+		if err := ctx.check(errors.Annotate(err, "checking cleanups")); err != nil {
+			return err
+		}
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if err := ctx.check(errors.New("cleanup needed")); err != nil {
+			return err
+		}
 	}
 
 	// Check the source controller.
-	controllerBackend, err := backend.ControllerBackend()
+	controllerBackend, err := ctx.backend.ControllerBackend()
 	if err != nil {
-		return errors.Trace(err)
-	}
-	controllerCtx := precheckContext{controllerBackend, controllerPresence}
-	if err := controllerCtx.checkController(); err != nil {
-		return errors.Annotate(err, "controller")
+		return ctx.check(errors.Trace(err))
 	}
-	return nil
-}
-
-type precheckContext struct {
-	backend  PrecheckBackend
-	presence ModelPresence
+	controllerCtx := ctx.forController(controllerBackend, controllerPresence, "controller")
+	return errors.Trace(controllerCtx.checkController())
 }
 
 func (ctx *precheckContext) checkModel() error {
@@ -184,12 +245,54 @@ func (ctx *precheckContext) checkModel() error {
 	return nil
 }
 
+// checkActions refuses models with action schedules or running action
+// rollouts, neither of which are migrated.
+func (ctx *precheckContext) checkActions() error {
+	scheduleIds, err := ctx.backend.ActionScheduleIds()
+	if err != nil {
+		if err := ctx.check(errors.Annotate(err, "retrieving action schedules")); err != nil {
+			return err
+		}
+	}
+	for _, id := range scheduleIds {
+		err := errors.Errorf("action schedule %s can't be migrated; remove it and add it again after migrating", id)
+		if err := ctx.check(err); err != nil {
+			return err
+		}
+	}
+
+	rolloutIds, err := ctx.backend.RunningActionRolloutIds()
+	if err != nil {
+		return ctx.check(errors.Annotate(err, "retrieving action rollouts"))
+	}
+	for _, id := range rolloutIds {
+		if err := ctx.check(errors.Errorf("action rollout %s is still running", id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // TargetPrecheck checks the state of the target controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
+	ctx := precheckContext{backend: backend, presence: presence}
+	return errors.Trace(ctx.targetPrecheck(pool, modelInfo))
+}
+
+// TargetPrecheckAll runs the same checks as TargetPrecheck, but
+// carries on past any that fail and returns every problem found.
+func TargetPrecheckAll(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) []error {
+	var problems []error
+	ctx := precheckContext{backend: backend, presence: presence, problems: &problems}
+	ctx.targetPrecheck(pool, modelInfo)
+	return problems
+}
+
+func (ctx *precheckContext) targetPrecheck(pool Pool, modelInfo coremigration.ModelInfo) error {
 	if err := modelInfo.Validate(); err != nil {
-		return errors.Trace(err)
+		return ctx.check(errors.Trace(err))
 	}
 
 	// This check is necessary because there is a window between the
@@ -199,41 +302,55 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	// window can upset the migrationmaster worker.
 	//
 	// See also https://lpad.tv/1611391
-	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
-		return errors.Annotate(err, "checking for active migration")
+	if migrating, err := ctx.backend.IsMigrationActive(modelInfo.UUID); err != nil {
+		if err := ctx.check(errors.Annotate(err, "checking for active migration")); err != nil {
+			return err
+		}
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if err := ctx.check(errors.New("model is being migrated out of target controller")); err != nil {
+			return err
+		}
 	}
 
-	controllerVersion, err := backend.AgentVersion()
+	controllerVersion, err := ctx.backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
-	}
-
-	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
-	}
+		if err := ctx.check(errors.Annotate(err, "retrieving model version")); err != nil {
+			return err
+		}
+	} else {
+		if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
+			err := errors.Errorf("model has higher version than target controller (%s > %s)",
+				modelInfo.AgentVersion, controllerVersion)
+			if err := ctx.check(err); err != nil {
+				return err
+			}
+		}
 
-	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
+			err := errors.Errorf("source controller has higher version than target controller (%s > %s)",
+				modelInfo.ControllerAgentVersion, controllerVersion)
+			if err := ctx.check(err); err != nil {
+				return err
+			}
+		}
 	}
 
-	controllerCtx := precheckContext{backend, presence}
-	if err := controllerCtx.checkController(); err != nil {
+	if err := ctx.checkController(); err != nil {
 		return errors.Trace(err)
 	}
 
 	// Check for conflicts with existing models
-	modelUUIDs, err := backend.AllModelUUIDs()
+	modelUUIDs, err := ctx.backend.AllModelUUIDs()
 	if err != nil {
-		return errors.Annotate(err, "retrieving models")
+		return ctx.check(errors.Annotate(err, "retrieving models"))
 	}
 	for _, modelUUID := range modelUUIDs {
 		model, release, err := pool.GetModel(modelUUID)
 		if err != nil {
-			return errors.Trace(err)
+			if err := ctx.check(errors.Trace(err)); err != nil {
+				return err
+			}
+			continue
 		}
 		defer release()
 
@@ -241,10 +358,15 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			err := errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			if err := ctx.check(err); err != nil {
+				return err
+			}
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if err := ctx.check(errors.Errorf("model named %q already exists", model.Name())); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -271,16 +393,22 @@ func versionToMajMin(ver version.Number) version.Number {
 func (ctx *precheckContext) checkController() error {
 	model, err := ctx.backend.Model()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model")
+		return ctx.check(errors.Annotate(err, "retrieving model"))
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.check(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
-		return errors.Annotate(err, "checking for upgrades")
+		if err := ctx.check(errors.Annotate(err, "checking for upgrades")); err != nil {
+			return err
+		}
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if err := ctx.check(errors.New("upgrade in progress")); err != nil {
+			return err
+		}
 	}
 
 	return errors.Trace(ctx.checkMachines())
@@ -289,70 +417,78 @@ func (ctx *precheckContext) checkController() error {
 func (ctx *precheckContext) checkMachines() error {
 	modelVersion, err := ctx.backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		return ctx.check(errors.Annotate(err, "retrieving model version"))
 	}
 
 	machines, err := ctx.backend.AllMachines()
 	if err != nil {
-		return errors.Annotate(err, "retrieving machines")
+		return ctx.check(errors.Annotate(err, "retrieving machines"))
 	}
-	modelPresenceContext := common.ModelPresenceContext{ctx.presence}
 	for _, machine := range machines {
-		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+		if err := ctx.check(ctx.checkMachine(machine, modelVersion)); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		if statusInfo, err := machine.InstanceStatus(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
-		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
-		}
+func (ctx *precheckContext) checkMachine(machine PrecheckMachine, modelVersion version.Number) error {
+	if machine.Life() != state.Alive {
+		return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+	}
 
-		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
-		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
-		}
+	if statusInfo, err := machine.InstanceStatus(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
+	} else if statusInfo.Status != status.Running {
+		return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+	}
 
-		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
-		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
-		}
+	modelPresenceContext := common.ModelPresenceContext{ctx.presence}
+	if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+	} else if statusInfo.Status != status.Started {
+		return newStatusError("machine %s agent not functioning at this time",
+			machine.Id(), statusInfo.Status)
+	}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
-			return errors.Trace(err)
-		}
+	if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
+	} else if rebootAction != state.ShouldDoNothing {
+		return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
 	}
-	return nil
+
+	return errors.Trace(checkAgentTools(modelVersion, machine, "machine "+machine.Id()))
 }
 
 func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, error) {
 	modelVersion, err := ctx.backend.AgentVersion()
 	if err != nil {
-		return nil, errors.Annotate(err, "retrieving model version")
+		return nil, ctx.check(errors.Annotate(err, "retrieving model version"))
 	}
 	apps, err := ctx.backend.AllApplications()
 	if err != nil {
-		return nil, errors.Annotate(err, "retrieving applications")
+		return nil, ctx.check(errors.Annotate(err, "retrieving applications"))
 	}
 
 	model, err := ctx.backend.Model()
 	if err != nil {
-		return nil, errors.Annotate(err, "retrieving model")
+		return nil, ctx.check(errors.Annotate(err, "retrieving model"))
 	}
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if err := ctx.check(errors.Errorf("application %s is %s", app.Name(), app.Life())); err != nil {
+				return nil, err
+			}
 		}
 		units, err := app.AllUnits()
 		if err != nil {
-			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
+			if err := ctx.check(errors.Annotatef(err, "retrieving units for %s", app.Name())); err != nil {
+				return nil, err
+			}
+			continue
 		}
-		err = ctx.checkUnits(app, units, modelVersion, model.Type())
-		if err != nil {
+		if err := ctx.checkUnits(app, units, modelVersion, model.Type()); err != nil {
 			return nil, errors.Trace(err)
 		}
 		appUnits[app.Name()] = units
@@ -362,31 +498,40 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType) error {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		err := errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		if err := ctx.check(err); err != nil {
+			return err
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
-
 	for _, unit := range units {
-		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+		if err := ctx.check(ctx.checkUnit(unit, appCharmURL, modelVersion, modelType)); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		if err := ctx.checkUnitAgentStatus(unit); err != nil {
-			return errors.Trace(err)
-		}
+func (ctx *precheckContext) checkUnit(unit PrecheckUnit, appCharmURL *charm.URL, modelVersion version.Number, modelType state.ModelType) error {
+	if unit.Life() != state.Alive {
+		return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+	}
 
-		if modelType == state.ModelTypeIAAS {
-			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
-				return errors.Trace(err)
-			}
-		}
+	if err := ctx.checkUnitAgentStatus(unit); err != nil {
+		return errors.Trace(err)
+	}
 
-		unitCharmURL, _ := unit.CharmURL()
-		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+	if modelType == state.ModelTypeIAAS {
+		if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+			return errors.Trace(err)
 		}
 	}
+
+	unitCharmURL, _ := unit.CharmURL()
+	if appCharmURL.String() != unitCharmURL.String() {
+		return errors.Errorf("unit %s is upgrading", unit.Name())
+	}
 	return nil
 }
 
@@ -434,7 +579,7 @@ func newStatusError(format, id string, s status.Status) error {
 func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) error {
 	relations, err := ctx.backend.AllRelations()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model relations")
+		return ctx.check(errors.Annotate(err, "retrieving model relations"))
 	}
 	for _, rel := range relations {
 		// We expect a relationScope and settings for each of the
@@ -442,33 +587,44 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 		// remote application.
 		crossModel, err := rel.IsCrossModel()
 		if err != nil {
-			return errors.Annotatef(err, "checking whether relation %s is cross-model", rel)
+			err := errors.Annotatef(err, "checking whether relation %s is cross-model", rel)
+			if err := ctx.check(err); err != nil {
+				return err
+			}
+			continue
 		}
 		if crossModel {
 			continue
 		}
 		for _, ep := range rel.Endpoints() {
 			for _, unit := range appUnits[ep.ApplicationName] {
-				ru, err := rel.Unit(unit)
-				if err != nil {
-					return errors.Trace(err)
-				}
-				valid, err := ru.Valid()
-				if err != nil {
-					return errors.Trace(err)
-				}
-				if !valid {
-					continue
-				}
-				inScope, err := ru.InScope()
-				if err != nil {
-					return errors.Trace(err)
-				}
-				if !inScope {
-					return errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)
+				if err := ctx.check(checkRelationUnit(rel, unit)); err != nil {
+					return err
 				}
 			}
 		}
 	}
 	return nil
 }
+
+func checkRelationUnit(rel PrecheckRelation, unit PrecheckUnit) error {
+	ru, err := rel.Unit(unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	valid, err := ru.Valid()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !valid {
+		return nil
+	}
+	inScope, err := ru.InScope()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !inScope {
+		return errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)
+	}
+	return nil
+}
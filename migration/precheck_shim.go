@@ -111,6 +111,40 @@ func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, er
 	return resources, nil
 }
 
+// ActionScheduleIds implements PrecheckBackend.
+func (s *precheckShim) ActionScheduleIds() ([]string, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	schedules, err := model.ActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, len(schedules))
+	for i, schedule := range schedules {
+		ids[i] = schedule.Id()
+	}
+	return ids, nil
+}
+
+// RunningActionRolloutIds implements PrecheckBackend.
+func (s *precheckShim) RunningActionRolloutIds() ([]string, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rollouts, err := model.RunningActionRollouts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, len(rollouts))
+	for i, rollout := range rollouts {
+		ids[i] = rollout.Id()
+	}
+	return ids, nil
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackend, error) {
 	return PrecheckShim(s.controllerState, s.controllerState)
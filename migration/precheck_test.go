@@ -93,6 +93,27 @@ func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "model is being imported as part of another migration")
 }
 
+func (*SourcePrecheckSuite) TestActionSchedules(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionSchedules = []string{"3"}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "action schedule 3 can't be migrated; remove it and add it again after migrating")
+}
+
+func (*SourcePrecheckSuite) TestActionSchedulesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionSchedulesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving action schedules: boom")
+}
+
+func (*SourcePrecheckSuite) TestRunningActionRollouts(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionRollouts = []string{"1"}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "action rollout 1 is still running")
+}
+
 func (*SourcePrecheckSuite) TestCleanupsError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.cleanupErr = errors.New("boom")
@@ -416,6 +437,36 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (*SourcePrecheckSuite) TestAllSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	problems := migration.SourcePrecheckAll(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(problems, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestAllCollectsProblems(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name: "foo",
+			life: state.Dying,
+		},
+	}
+	backend.cleanupNeeded = true
+	backend.controllerBackend = newBackendWithRebootingMachine()
+	problems := migration.SourcePrecheckAll(backend, allAlivePresence(), allAlivePresence())
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	c.Assert(messages, jc.DeepEquals, []string{
+		"machine 0 is dying",
+		"application foo is dying",
+		"cleanup needed",
+		"controller: machine 0 is scheduled to reboot",
+	})
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -649,6 +700,33 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestAllCollectsProblems(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{
+				uuid:      modelUUID,
+				name:      modelName,
+				modelType: state.ModelTypeIAAS,
+				owner:     modelOwner,
+			},
+		},
+	}
+	backend := newBackendWithDyingMachine()
+	backend.models = pool.uuids()
+	s.modelInfo.AgentVersion = version.MustParse("1.2.4")
+	problems := migration.TargetPrecheckAll(backend, pool, s.modelInfo, allAlivePresence())
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	c.Assert(messages, jc.DeepEquals, []string{
+		"model has higher version than target controller (1.2.4 > 1.2.3)",
+		"machine 0 is dying",
+		"model with same UUID already exists (model-uuid)",
+		"model named \"model-name\" already exists",
+	})
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -784,6 +862,12 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	actionSchedules    []string
+	actionSchedulesErr error
+
+	actionRollouts    []string
+	actionRolloutsErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -831,6 +915,14 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) ActionScheduleIds() ([]string, error) {
+	return b.actionSchedules, b.actionSchedulesErr
+}
+
+func (b *fakeBackend) RunningActionRolloutIds() ([]string, error) {
+	return b.actionRollouts, b.actionRolloutsErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
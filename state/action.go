@@ -4,7 +4,9 @@
 package state
 
 import (
+	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -17,6 +19,12 @@ import (
 
 const (
 	actionMarker = "_a_"
+
+	// maxActionMessages limits how many progress messages an action
+	// may log, and maxActionMessageLength how long each may be, which
+	// keeps an action document well within mongo's size limit.
+	maxActionMessages      = 1000
+	maxActionMessageLength = 1024
 )
 
 var (
@@ -104,6 +112,16 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Logs holds the progress messages logged by the action while
+	// it was running.
+	Logs []ActionMessage `bson:"messages"`
+}
+
+// ActionMessage is a progress message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
+	Message   string    `bson:"message" json:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +180,12 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action, oldest
+// first.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -210,6 +234,61 @@ func (a *action) Begin() (Action, error) {
 	return m.Action(a.Id())
 }
 
+// Log records a progress message against the action. It asserts that
+// the action is currently running and has logged fewer than
+// maxActionMessages messages. Messages longer than
+// maxActionMessageLength bytes are truncated.
+func (a *action) Log(message string) error {
+	msg := ActionMessage{
+		Timestamp: a.st.clock().Now().UTC(),
+		Message:   truncateMessage(message, maxActionMessageLength),
+	}
+	err := a.st.db().RunTransaction([]txn.Op{{
+		C:  actionsC,
+		Id: a.doc.DocId,
+		Assert: bson.D{
+			{"status", ActionRunning},
+			{fmt.Sprintf("messages.%d", maxActionMessages-1), bson.D{{"$exists", false}}},
+		},
+		Update: bson.D{{"$push", bson.D{{"messages", msg}}}},
+	}})
+	if err == txn.ErrAborted {
+		err = a.logAborted()
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot log message for action %q", a.Id())
+	}
+	a.doc.Logs = append(a.doc.Logs, msg)
+	return nil
+}
+
+// logAborted returns the reason a message couldn't be logged.
+func (a *action) logAborted() error {
+	actions, closer := a.st.db().GetCollection(actionsC)
+	defer closer()
+
+	var doc actionDoc
+	if err := actions.FindId(a.doc.DocId).One(&doc); err != nil {
+		return errors.Trace(err)
+	}
+	if doc.Status != ActionRunning {
+		return errors.New("action is not running")
+	}
+	return errors.Errorf("action has already logged %d messages", len(doc.Logs))
+}
+
+// truncateMessage returns the longest prefix of message no longer than
+// max bytes that doesn't split a character.
+func truncateMessage(message string, max int) string {
+	if len(message) <= max {
+		return message
+	}
+	for max > 0 && !utf8.RuneStart(message[max]) {
+		max--
+	}
+	return message[:max]
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
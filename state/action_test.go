@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -136,7 +138,7 @@ func (s *ActionSuite) TestAddAction(c *gc.C) {
 		params: map[string]interface{}{
 			"outfile": 5.0,
 		},
-		expectedErr: "validation failed: \\(root\\)\\.outfile : must be of type string, given 5",
+		expectedErr: "invalid parameters for action \"snapshot\": \\(root\\)\\.outfile : must be of type string, given 5",
 	}} {
 		c.Logf("Test %d: should %s", i, t.should)
 		before := state.NowToTheSecond(s.State)
@@ -484,6 +486,119 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.model.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := s.Clock.Now().UTC()
+	err = a.Log("dumping table users")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = a.Log("dumping table posts")
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := []state.ActionMessage{
+		{Timestamp: now, Message: "dumping table users"},
+		{Timestamp: now.Add(time.Minute), Message: "dumping table posts"},
+	}
+	c.Check(a.Messages(), jc.DeepEquals, expected)
+
+	a, err = s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Messages(), gc.HasLen, 2)
+	for i, msg := range a.Messages() {
+		c.Check(msg.Message, gc.Equals, expected[i].Message)
+		c.Check(msg.Timestamp.Equal(expected[i].Timestamp), jc.IsTrue)
+	}
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.model.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("too soon")
+	c.Assert(err, gc.ErrorMatches, `cannot log message for action ".*": action is not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message for action ".*": action is not running`)
+	c.Assert(a.Messages(), gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestLogTruncatesLongMessages(c *gc.C) {
+	a, err := s.model.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The leading "x" leaves a two byte "é" across the limit.
+	err = a.Log("x" + strings.Repeat("é", state.MaxActionMessageLength))
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Messages(), gc.HasLen, 1)
+	message := a.Messages()[0].Message
+	c.Check(len(message) <= state.MaxActionMessageLength, jc.IsTrue)
+	c.Check(len(message) >= state.MaxActionMessageLength-1, jc.IsTrue)
+	c.Check(utf8.ValidString(message), jc.IsTrue)
+}
+
+func (s *ActionSuite) TestLogTooManyMessages(c *gc.C) {
+	a, err := s.model.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	messages := make([]state.ActionMessage, state.MaxActionMessages-1)
+	for i := range messages {
+		messages[i] = state.ActionMessage{Timestamp: s.Clock.Now(), Message: fmt.Sprint(i)}
+	}
+	actions, closer := state.GetRawCollection(s.State, "actions")
+	defer closer()
+	err = actions.UpdateId(state.DocID(s.State, a.Id()), bson.D{{"$set", bson.D{{"messages", messages}}}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("last")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("one too many")
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`cannot log message for action ".*": action has already logged %d messages`, state.MaxActionMessages))
+}
+
+func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
+	a, err := s.model.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.model.WatchActionLogs(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	// Mongo stores times to the millisecond.
+	now := s.Clock.Now().UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano)
+	wc.AssertChange(fmt.Sprintf(`{"timestamp":%q,"message":"first"}`, now))
+	wc.AssertNoChange()
+
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("third")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(
+		fmt.Sprintf(`{"timestamp":%q,"message":"second"}`, now),
+		fmt.Sprintf(`{"timestamp":%q,"message":"third"}`, now),
+	)
+
+	// Finishing the action logs nothing new.
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -0,0 +1,494 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ActionRolloutTargets selects which of an application's units an
+// action rollout runs on.
+type ActionRolloutTargets string
+
+const (
+	// RolloutAllUnits runs the action on every unit.
+	RolloutAllUnits ActionRolloutTargets = "all"
+
+	// RolloutLeaderOnly runs the action on the application leader.
+	RolloutLeaderOnly ActionRolloutTargets = "leader"
+
+	// RolloutNonLeaders runs the action on every unit except the
+	// application leader.
+	RolloutNonLeaders ActionRolloutTargets = "non-leaders"
+)
+
+// ActionRolloutStatus describes the progress of an action rollout.
+type ActionRolloutStatus string
+
+const (
+	// RolloutRunning means actions are still to be enqueued or to
+	// finish.
+	RolloutRunning ActionRolloutStatus = "running"
+
+	// RolloutCompleted means the action completed on every unit.
+	RolloutCompleted ActionRolloutStatus = "completed"
+
+	// RolloutFailed means the action ran on every unit, but failed
+	// or was cancelled on some.
+	RolloutFailed ActionRolloutStatus = "failed"
+
+	// RolloutStopped means the action failed on a unit and the
+	// rollout was stopped, leaving some units without the action.
+	RolloutStopped ActionRolloutStatus = "stopped"
+)
+
+// actionRolloutDoc holds an action that the controller enqueues across
+// the units of an application, a few at a time.
+type actionRolloutDoc struct {
+	DocId     string `bson:"_id"`
+	Id        string `bson:"rollout-id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Application, Name and Parameters describe the action to enqueue.
+	Application string                 `bson:"application"`
+	Name        string                 `bson:"name"`
+	Parameters  map[string]interface{} `bson:"parameters"`
+
+	// Units holds the names of the units to enqueue the action on,
+	// in the order it is enqueued. Actions holds the ids of the
+	// actions enqueued so far, in the same order, with an empty id
+	// for each unit skipped because it had been removed.
+	Units   []string `bson:"units"`
+	Actions []string `bson:"actions"`
+
+	// MaxParallel limits how many of the actions may be unfinished at
+	// once; zero means no limit.
+	MaxParallel int `bson:"max-parallel"`
+
+	// BatchSize splits the units into batches; no action is enqueued
+	// in a batch until every action in the previous batches has
+	// finished. Zero means the units form a single batch.
+	BatchSize int `bson:"batch-size"`
+
+	// StopOnFailure stops the rollout when any action fails.
+	StopOnFailure bool `bson:"stop-on-failure"`
+
+	Status    ActionRolloutStatus `bson:"status"`
+	Created   int64               `bson:"created"`
+	CreatedBy string              `bson:"created-by"`
+	Finished  int64               `bson:"finished"`
+}
+
+// ActionRolloutArgs holds the parameters for starting an action
+// rollout.
+type ActionRolloutArgs struct {
+	// Application, Name and Parameters describe the action to run.
+	Application string
+	Name        string
+	Parameters  map[string]interface{}
+
+	// Targets selects which of the application's units to run the
+	// action on. It defaults to RolloutAllUnits.
+	Targets ActionRolloutTargets
+
+	// MaxParallel, BatchSize and StopOnFailure control the pace of
+	// the rollout, as described on ActionRollout.
+	MaxParallel   int
+	BatchSize     int
+	StopOnFailure bool
+
+	// CreatedBy is the name of the user starting the rollout.
+	CreatedBy string
+}
+
+// ActionRollout represents an action being enqueued by the controller
+// across the units of an application. No more than MaxParallel of the
+// actions are unfinished at once, and each batch of BatchSize units
+// starts only when the previous batch has finished.
+type ActionRollout struct {
+	st  *State
+	doc actionRolloutDoc
+}
+
+// Id returns the id of the rollout, which is unique within its model.
+func (r *ActionRollout) Id() string {
+	return r.doc.Id
+}
+
+// Application returns the name of the application the action runs on.
+func (r *ActionRollout) Application() string {
+	return r.doc.Application
+}
+
+// Name returns the name of the action.
+func (r *ActionRollout) Name() string {
+	return r.doc.Name
+}
+
+// Parameters returns the parameters of the action.
+func (r *ActionRollout) Parameters() map[string]interface{} {
+	return r.doc.Parameters
+}
+
+// Units returns the names of the units the action runs on, in the
+// order it is enqueued.
+func (r *ActionRollout) Units() []string {
+	return r.doc.Units
+}
+
+// ActionIds returns the ids of the actions enqueued so far, in the
+// same order as Units. The id is empty for units that were skipped
+// because they had been removed.
+func (r *ActionRollout) ActionIds() []string {
+	return r.doc.Actions
+}
+
+// MaxParallel returns the maximum number of unfinished actions, or
+// zero if there is no limit.
+func (r *ActionRollout) MaxParallel() int {
+	return r.doc.MaxParallel
+}
+
+// BatchSize returns the number of units in each batch, or zero if the
+// units form a single batch.
+func (r *ActionRollout) BatchSize() int {
+	return r.doc.BatchSize
+}
+
+// StopOnFailure returns whether the rollout stops when an action fails.
+func (r *ActionRollout) StopOnFailure() bool {
+	return r.doc.StopOnFailure
+}
+
+// Status returns the progress of the rollout.
+func (r *ActionRollout) Status() ActionRolloutStatus {
+	return r.doc.Status
+}
+
+// Created returns when the rollout was started.
+func (r *ActionRollout) Created() time.Time {
+	return unixNanoTime(r.doc.Created)
+}
+
+// CreatedBy returns the name of the user that started the rollout.
+func (r *ActionRollout) CreatedBy() string {
+	return r.doc.CreatedBy
+}
+
+// Finished returns when the rollout finished, or the zero time if it
+// is still running.
+func (r *ActionRollout) Finished() time.Time {
+	return unixNanoTime(r.doc.Finished)
+}
+
+// rolloutUnits returns the names of the application's units selected
+// by targets, sorted by unit number.
+func (m *Model) rolloutUnits(appName string, targets ActionRolloutTargets) ([]string, error) {
+	app, err := m.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var leader string
+	if targets != RolloutAllUnits {
+		leaders, err := m.st.ApplicationLeaders()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ok bool
+		if leader, ok = leaders[appName]; !ok {
+			return nil, errors.Errorf("could not determine leader for %q", appName)
+		}
+	}
+	var unitNames []string
+	for _, unit := range units {
+		isLeader := unit.Name() == leader
+		switch {
+		case targets == RolloutLeaderOnly && !isLeader:
+		case targets == RolloutNonLeaders && isLeader:
+		default:
+			unitNames = append(unitNames, unit.Name())
+		}
+	}
+	naturalsort.Sort(unitNames)
+	return unitNames, nil
+}
+
+// AddActionRollout starts enqueueing an action across the units of an
+// application. The units are chosen when the rollout starts; units
+// added later don't run the action.
+func (m *Model) AddActionRollout(args ActionRolloutArgs) (*ActionRollout, error) {
+	if args.Name == "" {
+		return nil, errors.New("cannot add action rollout: empty action name")
+	}
+	if args.MaxParallel < 0 || args.BatchSize < 0 {
+		return nil, errors.New("cannot add action rollout: max parallel and batch size must not be negative")
+	}
+	switch args.Targets {
+	case "":
+		args.Targets = RolloutAllUnits
+	case RolloutAllUnits, RolloutLeaderOnly, RolloutNonLeaders:
+	default:
+		return nil, errors.Annotate(errors.NotValidf("targets %q", args.Targets), "cannot add action rollout")
+	}
+	parameters, err := m.validateApplicationAction(args.Application, args.Name, args.Parameters)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add action rollout")
+	}
+	units, err := m.rolloutUnits(args.Application, args.Targets)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add action rollout")
+	}
+	if len(units) == 0 {
+		return nil, errors.Errorf("cannot add action rollout: no units of %q to run %q on", args.Application, args.Name)
+	}
+
+	seq, err := sequence(m.st, "actionrollout")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := actionRolloutDoc{
+		DocId:         m.st.docID(id),
+		Id:            id,
+		ModelUUID:     m.st.ModelUUID(),
+		Application:   args.Application,
+		Name:          args.Name,
+		Parameters:    parameters,
+		Units:         units,
+		Actions:       []string{},
+		MaxParallel:   args.MaxParallel,
+		BatchSize:     args.BatchSize,
+		StopOnFailure: args.StopOnFailure,
+		Status:        RolloutRunning,
+		Created:       m.st.clock().Now().UnixNano(),
+		CreatedBy:     args.CreatedBy,
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.st.ModelUUID(),
+		Assert: isAliveDoc,
+	}, {
+		C:      actionRolloutsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(onAbort(err, errors.New("model is no longer alive")), "cannot add action rollout")
+	}
+	return &ActionRollout{st: m.st, doc: doc}, nil
+}
+
+// ActionRollout returns the action rollout with the given id.
+func (m *Model) ActionRollout(id string) (*ActionRollout, error) {
+	rollouts, closer := m.st.db().GetCollection(actionRolloutsC)
+	defer closer()
+
+	var doc actionRolloutDoc
+	err := rollouts.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action rollout %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action rollout %q", id)
+	}
+	return &ActionRollout{st: m.st, doc: doc}, nil
+}
+
+// RunningActionRollouts returns the model's action rollouts that have
+// yet to finish.
+func (m *Model) RunningActionRollouts() ([]*ActionRollout, error) {
+	rollouts, closer := m.st.db().GetCollection(actionRolloutsC)
+	defer closer()
+
+	var docs []actionRolloutDoc
+	if err := rollouts.Find(bson.D{{"status", RolloutRunning}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get running action rollouts")
+	}
+	result := make([]*ActionRollout, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionRollout{st: m.st, doc: doc}
+	}
+	return result, nil
+}
+
+// actionFinished reports whether an action with the given status will
+// not change again, and whether it failed.
+func actionFinished(status ActionStatus) (finished, failed bool) {
+	switch status {
+	case ActionCompleted:
+		return true, false
+	case ActionFailed, ActionCancelled:
+		return true, true
+	}
+	return false, false
+}
+
+// Advance enqueues the action on as many further units as the
+// rollout's limits allow, and records whether the rollout has
+// finished. Only one agent may advance a given rollout.
+func (r *ActionRollout) Advance() error {
+	if r.doc.Status != RolloutRunning {
+		return nil
+	}
+	model, err := r.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Find the first unit that has yet to finish, noting any
+	// failures along the way.
+	var (
+		unfinished  int
+		firstActive = len(r.doc.Actions)
+		failed      bool
+	)
+	for i, id := range r.doc.Actions {
+		if id == "" {
+			continue
+		}
+		action, err := model.Action(id)
+		if err != nil {
+			return errors.Annotatef(err, "cannot advance action rollout %q", r.doc.Id)
+		}
+		done, actionFailed := actionFinished(action.Status())
+		failed = failed || actionFailed
+		if !done {
+			unfinished++
+			if i < firstActive {
+				firstActive = i
+			}
+		}
+	}
+
+	actionIds := r.doc.Actions
+	status := RolloutRunning
+	var enqueueErr error
+	switch {
+	case failed && r.doc.StopOnFailure:
+		status = RolloutStopped
+	case unfinished == 0 && len(actionIds) == len(r.doc.Units):
+		status = RolloutCompleted
+		if failed {
+			status = RolloutFailed
+		}
+	default:
+		// Record the actions that were enqueued even if enqueueing
+		// failed part way, so they aren't enqueued again.
+		actionIds, enqueueErr = r.enqueue(firstActive, unfinished)
+	}
+	if status == RolloutRunning && len(actionIds) == len(r.doc.Actions) {
+		return errors.Annotatef(enqueueErr, "cannot advance action rollout %q", r.doc.Id)
+	}
+
+	set := bson.D{{"actions", actionIds}, {"status", status}}
+	var finished int64
+	if status != RolloutRunning {
+		finished = r.st.clock().Now().UnixNano()
+		set = append(set, bson.DocElem{"finished", finished})
+	}
+	ops := []txn.Op{{
+		C:      actionRolloutsC,
+		Id:     r.doc.DocId,
+		Assert: bson.D{{"status", RolloutRunning}, {"actions", bson.D{{"$size", len(r.doc.Actions)}}}},
+		Update: bson.D{{"$set", set}},
+	}}
+	if err := r.st.db().RunTransaction(ops); err != nil {
+		err = onAbort(err, errors.Errorf("action rollout %q changed concurrently", r.doc.Id))
+		return errors.Annotatef(err, "cannot advance action rollout %q", r.doc.Id)
+	}
+	r.doc.Actions = actionIds
+	r.doc.Status = status
+	r.doc.Finished = finished
+	return errors.Annotatef(enqueueErr, "cannot advance action rollout %q", r.doc.Id)
+}
+
+// enqueue enqueues the action on the next units that the rollout's
+// limits allow, given the index of the first unit whose action has yet
+// to finish and the number of unfinished actions. It returns the ids
+// of all the rollout's actions, including those enqueued before any
+// error. Units that have been removed are skipped.
+func (r *ActionRollout) enqueue(firstActive, unfinished int) ([]string, error) {
+	actionIds := append([]string(nil), r.doc.Actions...)
+	batchOf := func(i int) int {
+		if r.doc.BatchSize == 0 {
+			return 0
+		}
+		return i / r.doc.BatchSize
+	}
+	for next := len(actionIds); next < len(r.doc.Units); next++ {
+		if r.doc.MaxParallel > 0 && unfinished >= r.doc.MaxParallel {
+			break
+		}
+		if firstActive < next && batchOf(firstActive) != batchOf(next) {
+			break
+		}
+		unit, err := r.st.Unit(r.doc.Units[next])
+		if errors.IsNotFound(err) {
+			actionIds = append(actionIds, "")
+			continue
+		} else if err != nil {
+			return actionIds, errors.Trace(err)
+		}
+		if unit.Life() == Dead {
+			actionIds = append(actionIds, "")
+			continue
+		}
+		action, err := unit.AddAction(r.doc.Name, r.doc.Parameters)
+		if err != nil {
+			return actionIds, errors.Annotatef(err, "cannot enqueue action on %q", unit.Name())
+		}
+		actionIds = append(actionIds, action.Id())
+		unfinished++
+	}
+	return actionIds, nil
+}
+
+// ActionRolloutKey identifies an action rollout across all models.
+type ActionRolloutKey struct {
+	ModelUUID string
+	Id        string
+}
+
+// WatchActionRollouts returns a NotifyWatcher that triggers when the
+// action rollouts of any model change. It is intended to be used by
+// the controller worker that advances them, along with WatchActions.
+func (st *State) WatchActionRollouts() NotifyWatcher {
+	return newNotifyCollWatcher(st, actionRolloutsC, nil)
+}
+
+// WatchAllActions returns a NotifyWatcher that triggers when an action
+// in any model changes.
+func (st *State) WatchAllActions() NotifyWatcher {
+	return newNotifyCollWatcher(st, actionsC, nil)
+}
+
+// RunningActionRollouts returns the action rollouts in all models that
+// have yet to finish.
+func (st *State) RunningActionRollouts() ([]ActionRolloutKey, error) {
+	rollouts, closer := st.db().GetRawCollection(actionRolloutsC)
+	defer closer()
+
+	var docs []actionRolloutDoc
+	query := bson.D{{"status", RolloutRunning}}
+	if err := rollouts.Find(query).Select(bson.D{{"model-uuid", 1}, {"rollout-id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get running action rollouts")
+	}
+	result := make([]ActionRolloutKey, len(docs))
+	for i, doc := range docs {
+		result[i] = ActionRolloutKey{ModelUUID: doc.ModelUUID, Id: doc.Id}
+	}
+	return result, nil
+}
@@ -0,0 +1,319 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ActionRolloutSuite struct {
+	ConnSuite
+	application *state.Application
+}
+
+var _ = gc.Suite(&ActionRolloutSuite{})
+
+func (s *ActionRolloutSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", ch)
+	for i := 0; i < 4; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(ch.URL())
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ActionRolloutSuite) addRollout(c *gc.C, args state.ActionRolloutArgs) *state.ActionRollout {
+	args.Application = "dummy"
+	if args.Name == "" {
+		args.Name = "snapshot"
+	}
+	rollout, err := s.Model.AddActionRollout(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return rollout
+}
+
+func (s *ActionRolloutSuite) makeLeader(c *gc.C, unitName string) {
+	target := s.State.LeaseNotifyTarget(ioutil.Discard, loggo.GetLogger("actionrollout_test"))
+	target.Claimed(lease.Key{
+		Namespace: "application-leadership",
+		ModelUUID: s.State.ModelUUID(),
+		Lease:     "dummy",
+	}, unitName)
+}
+
+// finish finishes the action the rollout enqueued on its i'th unit.
+func (s *ActionRolloutSuite) finish(c *gc.C, rollout *state.ActionRollout, i int, status state.ActionStatus) {
+	action, err := s.Model.Action(rollout.ActionIds()[i])
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Finish(state.ActionResults{Status: status})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionRolloutSuite) advance(c *gc.C, rollout *state.ActionRollout) {
+	err := rollout.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionRolloutSuite) TestAdd(c *gc.C) {
+	now := s.Clock.Now()
+	rollout := s.addRollout(c, state.ActionRolloutArgs{
+		Parameters:    map[string]interface{}{"outfile": "out.bz2"},
+		MaxParallel:   2,
+		BatchSize:     3,
+		StopOnFailure: true,
+		CreatedBy:     "bob",
+	})
+	c.Check(rollout.Id(), gc.Equals, "0")
+	c.Check(rollout.Application(), gc.Equals, "dummy")
+	c.Check(rollout.Name(), gc.Equals, "snapshot")
+	c.Check(rollout.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.bz2"})
+	c.Check(rollout.Units(), jc.DeepEquals, []string{"dummy/0", "dummy/1", "dummy/2", "dummy/3"})
+	c.Check(rollout.ActionIds(), gc.HasLen, 0)
+	c.Check(rollout.MaxParallel(), gc.Equals, 2)
+	c.Check(rollout.BatchSize(), gc.Equals, 3)
+	c.Check(rollout.StopOnFailure(), jc.IsTrue)
+	c.Check(rollout.Status(), gc.Equals, state.RolloutRunning)
+	c.Check(rollout.Created().Equal(now), jc.IsTrue)
+	c.Check(rollout.CreatedBy(), gc.Equals, "bob")
+	c.Check(rollout.Finished().IsZero(), jc.IsTrue)
+
+	fetched, err := s.Model.ActionRollout(rollout.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fetched, jc.DeepEquals, rollout)
+}
+
+func (s *ActionRolloutSuite) TestAddInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.ActionRolloutArgs
+		err  string
+	}{{
+		args: state.ActionRolloutArgs{Application: "dummy"},
+		err:  "cannot add action rollout: empty action name",
+	}, {
+		args: state.ActionRolloutArgs{Application: "dummy", Name: "snapshot", BatchSize: -1},
+		err:  "cannot add action rollout: max parallel and batch size must not be negative",
+	}, {
+		args: state.ActionRolloutArgs{Application: "dummy", Name: "snapshot", Targets: "some"},
+		err:  `cannot add action rollout: targets "some" not valid`,
+	}, {
+		args: state.ActionRolloutArgs{Application: "dummy", Name: "missing"},
+		err:  `cannot add action rollout: action "missing" not defined for application "dummy"`,
+	}, {
+		args: state.ActionRolloutArgs{Application: "nope", Name: "snapshot"},
+		err:  `cannot add action rollout: application "nope" not found`,
+	}, {
+		args: state.ActionRolloutArgs{Application: "dummy", Name: "snapshot", Targets: state.RolloutLeaderOnly},
+		err:  `cannot add action rollout: could not determine leader for "dummy"`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.Model.AddActionRollout(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionRolloutSuite) TestAddLeaderTargets(c *gc.C) {
+	s.makeLeader(c, "dummy/2")
+	leader := s.addRollout(c, state.ActionRolloutArgs{Targets: state.RolloutLeaderOnly})
+	c.Check(leader.Units(), jc.DeepEquals, []string{"dummy/2"})
+	others := s.addRollout(c, state.ActionRolloutArgs{Targets: state.RolloutNonLeaders})
+	c.Check(others.Units(), jc.DeepEquals, []string{"dummy/0", "dummy/1", "dummy/3"})
+}
+
+func (s *ActionRolloutSuite) TestActionRolloutNotFound(c *gc.C) {
+	_, err := s.Model.ActionRollout("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `action rollout "42" not found`)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceUnlimited(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{})
+	s.advance(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 4)
+	for i, id := range rollout.ActionIds() {
+		action, err := s.Model.Action(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(action.Receiver(), gc.Equals, rollout.Units()[i])
+		c.Check(action.Name(), gc.Equals, "snapshot")
+	}
+	c.Check(rollout.Status(), gc.Equals, state.RolloutRunning)
+
+	for i := range rollout.ActionIds() {
+		s.finish(c, rollout, i, state.ActionCompleted)
+	}
+	s.advance(c, rollout)
+	c.Check(rollout.Status(), gc.Equals, state.RolloutCompleted)
+	c.Check(rollout.Finished().Equal(s.Clock.Now()), jc.IsTrue)
+
+	fetched, err := s.Model.ActionRollout(rollout.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fetched, jc.DeepEquals, rollout)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceSkipsRemovedUnits(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{})
+	unit, err := s.State.Unit(rollout.Units()[1])
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.advance(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 4)
+	c.Check(rollout.ActionIds()[1], gc.Equals, "")
+
+	for _, i := range []int{0, 2, 3} {
+		s.finish(c, rollout, i, state.ActionCompleted)
+	}
+	s.advance(c, rollout)
+	c.Check(rollout.Status(), gc.Equals, state.RolloutCompleted)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceRecordsPartialProgress(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{})
+	unit, err := s.State.Unit(rollout.Units()[2])
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := unit.CharmURL()
+	actionless := s.AddTestingCharm(c, "actionless")
+	err = unit.SetCharmURL(actionless.URL())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rollout.Advance()
+	c.Assert(err, gc.ErrorMatches, `cannot advance action rollout "0": cannot enqueue action on "dummy/2": .*`)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 2)
+	enqueued := rollout.ActionIds()
+
+	fetched, err := s.Model.ActionRollout(rollout.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fetched.ActionIds(), jc.DeepEquals, enqueued)
+
+	// The actions already enqueued aren't enqueued again.
+	err = unit.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	s.advance(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 4)
+	c.Check(rollout.ActionIds()[:2], jc.DeepEquals, enqueued)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceMaxParallel(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{MaxParallel: 2})
+	s.advance(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 2)
+
+	// Nothing more is enqueued until an action finishes.
+	s.advance(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 2)
+
+	s.finish(c, rollout, 0, state.ActionCompleted)
+	s.advance(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 3)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceBatches(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{BatchSize: 2})
+	s.advance(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 2)
+
+	// The second batch waits for all of the first.
+	s.finish(c, rollout, 0, state.ActionCompleted)
+	s.advance(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 2)
+
+	s.finish(c, rollout, 1, state.ActionCompleted)
+	s.advance(c, rollout)
+	c.Assert(rollout.ActionIds(), gc.HasLen, 4)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceFailed(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{MaxParallel: 1})
+	for i := 0; i < 4; i++ {
+		s.advance(c, rollout)
+		status := state.ActionCompleted
+		if i == 1 {
+			status = state.ActionFailed
+		}
+		s.finish(c, rollout, i, status)
+	}
+	s.advance(c, rollout)
+	c.Check(rollout.Status(), gc.Equals, state.RolloutFailed)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceStopOnFailure(c *gc.C) {
+	rollout := s.addRollout(c, state.ActionRolloutArgs{MaxParallel: 2, StopOnFailure: true})
+	s.advance(c, rollout)
+	s.finish(c, rollout, 0, state.ActionFailed)
+	s.advance(c, rollout)
+	c.Check(rollout.Status(), gc.Equals, state.RolloutStopped)
+	c.Check(rollout.ActionIds(), gc.HasLen, 2)
+
+	// A stopped rollout enqueues nothing more.
+	s.finish(c, rollout, 1, state.ActionCompleted)
+	s.advance(c, rollout)
+	c.Check(rollout.ActionIds(), gc.HasLen, 2)
+}
+
+func (s *ActionRolloutSuite) TestRunningActionRollouts(c *gc.C) {
+	s.addRollout(c, state.ActionRolloutArgs{})
+	done := s.addRollout(c, state.ActionRolloutArgs{StopOnFailure: true})
+	s.advance(c, done)
+	s.finish(c, done, 0, state.ActionCancelled)
+	s.advance(c, done)
+	c.Assert(done.Status(), gc.Equals, state.RolloutStopped)
+
+	running, err := s.State.RunningActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(running, jc.DeepEquals, []state.ActionRolloutKey{
+		{ModelUUID: s.State.ModelUUID(), Id: "0"},
+	})
+}
+
+func (s *ActionRolloutSuite) TestModelRunningActionRollouts(c *gc.C) {
+	s.addRollout(c, state.ActionRolloutArgs{})
+	done := s.addRollout(c, state.ActionRolloutArgs{StopOnFailure: true})
+	s.advance(c, done)
+	s.finish(c, done, 0, state.ActionCancelled)
+	s.advance(c, done)
+
+	running, err := s.Model.RunningActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	c.Check(running[0].Id(), gc.Equals, "0")
+}
+
+func (s *ActionRolloutSuite) TestWatchActionRollouts(c *gc.C) {
+	w := s.State.WatchActionRollouts()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	rollout := s.addRollout(c, state.ActionRolloutArgs{})
+	wc.AssertOneChange()
+	s.advance(c, rollout)
+	wc.AssertOneChange()
+}
+
+func (s *ActionRolloutSuite) TestWatchAllActions(c *gc.C) {
+	w := s.State.WatchAllActions()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	rollout := s.addRollout(c, state.ActionRolloutArgs{MaxParallel: 1})
+	s.advance(c, rollout)
+	wc.AssertOneChange()
+	s.finish(c, rollout, 0, state.ActionCompleted)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,599 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/schedule"
+)
+
+// actionScheduleDoc holds an action that is enqueued at a future time,
+// once or repeatedly.
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	Id        string `bson:"schedule-id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Receiver is the name of the unit or application the action is
+	// enqueued on, or "<application>/leader" for the application's
+	// leader at the time the action is enqueued.
+	Receiver string `bson:"receiver"`
+
+	// Name and Parameters are those of the action to enqueue.
+	Name       string                 `bson:"name"`
+	Parameters map[string]interface{} `bson:"parameters"`
+
+	// Schedule holds the cron-style spec of a recurring schedule. It
+	// is empty for a schedule that runs the action only once.
+	Schedule string `bson:"schedule,omitempty"`
+
+	// Paused schedules don't enqueue actions until resumed.
+	Paused bool `bson:"paused"`
+
+	// NextRun is when the action will next be enqueued, in Unix
+	// nanoseconds. It is zero once a one-off schedule has run.
+	NextRun int64 `bson:"next-run"`
+
+	// LastRun is when the action was last enqueued, and LastError
+	// records why enqueueing it failed, if it did.
+	LastRun   int64  `bson:"last-run"`
+	LastError string `bson:"last-error"`
+
+	Created   int64  `bson:"created"`
+	CreatedBy string `bson:"created-by"`
+}
+
+// ActionScheduleArgs holds the parameters for adding an action
+// schedule to a model. Exactly one of Schedule and At must be set.
+type ActionScheduleArgs struct {
+	// Receiver is the name of a unit or application, or
+	// "<application>/leader". Actions for an application are enqueued
+	// on all of its units.
+	Receiver string
+
+	// Name and Parameters describe the action to enqueue.
+	Name       string
+	Parameters map[string]interface{}
+
+	// Schedule is a cron-style spec, as accepted by schedule.Parse,
+	// for an action that should be enqueued repeatedly.
+	Schedule string
+
+	// At is the time at which to enqueue an action once.
+	At time.Time
+
+	// CreatedBy is the name of the user adding the schedule.
+	CreatedBy string
+}
+
+// ActionSchedule represents an action that is enqueued by the
+// controller at a future time, once or repeatedly.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Id returns the id of the schedule, which is unique within its model.
+func (s *ActionSchedule) Id() string {
+	return s.doc.Id
+}
+
+// Receiver returns the unit, application or application leader that
+// actions are enqueued on.
+func (s *ActionSchedule) Receiver() string {
+	return s.doc.Receiver
+}
+
+// Name returns the name of the action that is enqueued.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters of the action that is enqueued.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Schedule returns the cron-style spec of a recurring schedule, or ""
+// if the action is enqueued only once.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// Paused reports whether the schedule is paused.
+func (s *ActionSchedule) Paused() bool {
+	return s.doc.Paused
+}
+
+// NextRun returns when the action will next be enqueued. It returns the
+// zero time once a one-off schedule has run.
+func (s *ActionSchedule) NextRun() time.Time {
+	return unixNanoTime(s.doc.NextRun)
+}
+
+// LastRun returns when the action was last enqueued, or the zero time
+// if it never has been.
+func (s *ActionSchedule) LastRun() time.Time {
+	return unixNanoTime(s.doc.LastRun)
+}
+
+// LastError returns the reason enqueueing the action failed the last
+// time the schedule ran, or "" if it succeeded.
+func (s *ActionSchedule) LastError() string {
+	return s.doc.LastError
+}
+
+// Created returns when the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return unixNanoTime(s.doc.Created)
+}
+
+// CreatedBy returns the name of the user who added the schedule.
+func (s *ActionSchedule) CreatedBy() string {
+	return s.doc.CreatedBy
+}
+
+func unixNanoTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, t).UTC()
+}
+
+// receiverApplication returns the name of the application that the
+// given schedule receiver belongs to.
+func receiverApplication(receiver string) (string, error) {
+	switch {
+	case strings.HasSuffix(receiver, "/leader"):
+		app := strings.TrimSuffix(receiver, "/leader")
+		if names.IsValidApplication(app) {
+			return app, nil
+		}
+	case names.IsValidUnit(receiver):
+		return names.UnitApplication(receiver)
+	case names.IsValidApplication(receiver):
+		return receiver, nil
+	}
+	return "", errors.NotValidf("action receiver %q", receiver)
+}
+
+// validateActionSchedule checks that the schedule's receiver exists,
+// and that its charm defines the action with the given parameters. It
+// returns the parameters with the action's defaults inserted.
+func (m *Model) validateActionSchedule(args ActionScheduleArgs) (map[string]interface{}, error) {
+	if args.Name == "" {
+		return nil, errors.NotValidf("empty action name")
+	}
+	if (args.Schedule == "") == args.At.IsZero() {
+		return nil, errors.New("exactly one of a recurring schedule and a time must be given")
+	}
+	appName, err := receiverApplication(args.Receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if names.IsValidUnit(args.Receiver) {
+		if _, err := m.st.Unit(args.Receiver); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return m.validateApplicationAction(appName, args.Name, args.Parameters)
+}
+
+// validateApplicationAction checks that the application's charm
+// defines the named action, and that the parameters are valid for it.
+// It returns the parameters with the action's defaults inserted.
+func (m *Model) validateApplicationAction(appName, name string, params map[string]interface{}) (map[string]interface{}, error) {
+	app, err := m.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec, ok := actions.PredefinedActionsSpec[name]
+	if !ok {
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var specs map[string]charm.ActionSpec
+		if chActions := ch.Actions(); chActions != nil {
+			specs = chActions.ActionSpecs
+		}
+		if spec, ok = specs[name]; !ok {
+			return nil, errors.Errorf("action %q not defined for application %q", name, appName)
+		}
+	}
+	return actions.ValidateParams(name, spec, params)
+}
+
+// AddActionSchedule adds a schedule on which the controller enqueues
+// an action.
+func (m *Model) AddActionSchedule(args ActionScheduleArgs) (*ActionSchedule, error) {
+	parameters, err := m.validateActionSchedule(args)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	now := m.st.clock().Now()
+	var next time.Time
+	if args.Schedule != "" {
+		sched, err := schedule.Parse(args.Schedule)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot add action schedule")
+		}
+		next = sched.Next(now)
+	} else {
+		if !args.At.After(now) {
+			return nil, errors.Errorf("cannot add action schedule: time %s is in the past", args.At.UTC().Format(time.RFC3339))
+		}
+		next = args.At
+	}
+
+	seq, err := sequence(m.st, "actionschedule")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := actionScheduleDoc{
+		DocId:      m.st.docID(id),
+		Id:         id,
+		ModelUUID:  m.st.ModelUUID(),
+		Receiver:   args.Receiver,
+		Name:       args.Name,
+		Parameters: parameters,
+		Schedule:   args.Schedule,
+		NextRun:    next.UnixNano(),
+		Created:    now.UnixNano(),
+		CreatedBy:  args.CreatedBy,
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.st.ModelUUID(),
+		Assert: isAliveDoc,
+	}, {
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(onAbort(err, errors.New("model is no longer alive")), "cannot add action schedule")
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (m *Model) ActionSchedule(id string) (*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedules returns all the model's action schedules, in the
+// order they were added.
+func (m *Model) ActionSchedules() ([]*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionSchedule{st: m.st, doc: doc}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, _ := strconv.Atoi(result[i].doc.Id)
+		b, _ := strconv.Atoi(result[j].doc.Id)
+		return a < b
+	})
+	return result, nil
+}
+
+func (s *ActionSchedule) update(set bson.D) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", set}},
+	}}
+	err := s.st.db().RunTransaction(ops)
+	return onAbort(err, errors.NotFoundf("action schedule %q", s.doc.Id))
+}
+
+// Pause stops the schedule enqueueing actions until it is resumed.
+func (s *ActionSchedule) Pause() error {
+	if err := s.update(bson.D{{"paused", true}}); err != nil {
+		return errors.Annotatef(err, "cannot pause action schedule %q", s.doc.Id)
+	}
+	s.doc.Paused = true
+	return nil
+}
+
+// Resume restarts a paused schedule. A recurring schedule next runs at
+// its first time after now; runs missed while paused are skipped. A
+// one-off schedule whose time passed while it was paused runs
+// immediately.
+func (s *ActionSchedule) Resume() error {
+	next := s.doc.NextRun
+	if s.doc.Schedule != "" {
+		sched, err := schedule.Parse(s.doc.Schedule)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		next = sched.Next(s.st.clock().Now()).UnixNano()
+	}
+	if err := s.update(bson.D{{"paused", false}, {"next-run", next}}); err != nil {
+		return errors.Annotatef(err, "cannot resume action schedule %q", s.doc.Id)
+	}
+	s.doc.Paused = false
+	s.doc.NextRun = next
+	return nil
+}
+
+// Remove removes the schedule. Actions it has already enqueued are not
+// affected.
+func (s *ActionSchedule) Remove() error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := s.st.db().RunTransaction(ops)
+	if err != nil {
+		err = onAbort(err, errors.NotFoundf("action schedule %q", s.doc.Id))
+		return errors.Annotatef(err, "cannot remove action schedule %q", s.doc.Id)
+	}
+	return nil
+}
+
+// receiverUnits returns the units that the schedule's action should
+// be enqueued on now.
+func (s *ActionSchedule) receiverUnits() ([]*Unit, error) {
+	receiver := s.doc.Receiver
+	switch {
+	case strings.HasSuffix(receiver, "/leader"):
+		appName := strings.TrimSuffix(receiver, "/leader")
+		leaders, err := s.st.ApplicationLeaders()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		leader, ok := leaders[appName]
+		if !ok {
+			return nil, errors.Errorf("could not determine leader for %q", appName)
+		}
+		receiver = leader
+		fallthrough
+	case names.IsValidUnit(receiver):
+		unit, err := s.st.Unit(receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []*Unit{unit}, nil
+	default:
+		app, err := s.st.Application(receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return app.AllUnits()
+	}
+}
+
+// Run enqueues the schedule's action on its receivers, and records when
+// the action should next be enqueued, in a single transaction. If the
+// action could not be enqueued on all the receivers, the failure is
+// recorded against the schedule and returned; the schedule still
+// advances to its next run. If the schedule has been paused, or has
+// already been run by someone else, nothing is enqueued.
+func (s *ActionSchedule) Run(now time.Time) ([]Action, error) {
+	var next int64
+	if s.doc.Schedule != "" {
+		sched, err := schedule.Parse(s.doc.Schedule)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		next = sched.Next(now).UnixNano()
+	}
+
+	expectedRun := s.doc.NextRun
+	var (
+		docs      []actionDoc
+		lastError string
+	)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Paused || s.doc.NextRun != expectedRun {
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		docs = nil
+		var failures []string
+		var actionOps []txn.Op
+		units, err := s.receiverUnits()
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+		for _, unit := range units {
+			ops, doc, err := s.enqueueActionOps(unit)
+			if err != nil {
+				failures = append(failures, "unit "+unit.Name()+": "+err.Error())
+				continue
+			}
+			actionOps = append(actionOps, ops...)
+			docs = append(docs, doc)
+		}
+		lastError = strings.Join(failures, "; ")
+
+		ops := []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     s.doc.DocId,
+			Assert: bson.D{{"next-run", expectedRun}, {"paused", false}},
+			Update: bson.D{{"$set", bson.D{
+				{"next-run", next},
+				{"last-run", now.UnixNano()},
+				{"last-error", lastError},
+			}}},
+		}}
+		return append(ops, actionOps...), nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot run action schedule %q", s.doc.Id)
+	}
+	if s.doc.Paused || s.doc.NextRun != expectedRun {
+		return nil, nil
+	}
+
+	s.doc.NextRun = next
+	s.doc.LastRun = now.UnixNano()
+	s.doc.LastError = lastError
+	enqueued := make([]Action, len(docs))
+	for i, doc := range docs {
+		enqueued[i] = newAction(s.st, doc)
+	}
+	if lastError != "" {
+		return enqueued, errors.Errorf("cannot enqueue action %q: %s", s.doc.Name, lastError)
+	}
+	return enqueued, nil
+}
+
+// enqueueActionOps returns the operations that enqueue the schedule's
+// action on the given unit, and the document of the enqueued action.
+func (s *ActionSchedule) enqueueActionOps(unit *Unit) ([]txn.Op, actionDoc, error) {
+	if unit.Life() == Dead {
+		return nil, actionDoc{}, ErrDead
+	}
+	spec, ok := actions.PredefinedActionsSpec[s.doc.Name]
+	if !ok {
+		specs, err := unit.ActionSpecs()
+		if err != nil {
+			return nil, actionDoc{}, errors.Trace(err)
+		}
+		if spec, ok = specs[s.doc.Name]; !ok {
+			return nil, actionDoc{}, errors.Errorf("action %q not defined on unit %q", s.doc.Name, unit.Name())
+		}
+	}
+	parameters, err := actions.ValidateParams(s.doc.Name, spec, s.doc.Parameters)
+	if err != nil {
+		return nil, actionDoc{}, errors.Trace(err)
+	}
+	doc, ndoc, err := newActionDoc(s.st, unit.Tag(), s.doc.Name, parameters)
+	if err != nil {
+		return nil, actionDoc{}, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:      unitsC,
+		Id:     unit.doc.DocID,
+		Assert: notDeadDoc,
+	}, {
+		C:      actionsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}, {
+		C:      actionNotificationsC,
+		Id:     ndoc.DocId,
+		Assert: txn.DocMissing,
+		Insert: ndoc,
+	}}, doc, nil
+}
+
+// refresh reloads the schedule's details from the database.
+func (s *ActionSchedule) refresh() error {
+	schedules, closer := s.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(s.doc.DocId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("action schedule %q", s.doc.Id)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot refresh action schedule %q", s.doc.Id)
+	}
+	s.doc = doc
+	return nil
+}
+
+// ActionScheduleKey identifies an action schedule across all models.
+type ActionScheduleKey struct {
+	ModelUUID string
+	Id        string
+}
+
+// WatchActionSchedules returns a NotifyWatcher that triggers when the
+// action schedules of any model change. It is intended to be used by
+// the controller worker that runs them.
+func (st *State) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(st, actionSchedulesC, nil)
+}
+
+// dueActionSchedulesQuery matches unpaused schedules with a next run.
+func dueActionSchedulesQuery(before int64) bson.D {
+	return bson.D{
+		{"paused", false},
+		{"next-run", bson.D{{"$gt", 0}, {"$lte", before}}},
+	}
+}
+
+// NextActionScheduleRun returns the earliest time that an unpaused
+// action schedule in any model will run, or the zero time if none
+// will.
+func (st *State) NextActionScheduleRun() (time.Time, error) {
+	schedules, closer := st.db().GetRawCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	query := dueActionSchedulesQuery(int64(1<<63 - 1))
+	err := schedules.Find(query).Sort("next-run").One(&doc)
+	if err == mgo.ErrNotFound {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "cannot get next action schedule")
+	}
+	return unixNanoTime(doc.NextRun), nil
+}
+
+// DueActionSchedules returns the unpaused action schedules in all
+// models that should have run by the given time, earliest first.
+func (st *State) DueActionSchedules(now time.Time) ([]ActionScheduleKey, error) {
+	schedules, closer := st.db().GetRawCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	query := dueActionSchedulesQuery(now.UnixNano())
+	if err := schedules.Find(query).Sort("next-run").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get due action schedules")
+	}
+	result := make([]ActionScheduleKey, len(docs))
+	for i, doc := range docs {
+		result[i] = ActionScheduleKey{ModelUUID: doc.ModelUUID, Id: doc.Id}
+	}
+	return result, nil
+}
@@ -0,0 +1,331 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	application *state.Application
+	units       []*state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	// Recurring schedules run on whole seconds.
+	s.InitialTime = time.Date(2018, 10, 17, 12, 0, 0, 0, time.UTC)
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", ch)
+	for i := 0; i < 2; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(ch.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, args state.ActionScheduleArgs) *state.ActionSchedule {
+	if args.Name == "" {
+		args.Name = "snapshot"
+	}
+	if args.Schedule == "" && args.At.IsZero() {
+		args.Schedule = "@every 1h"
+	}
+	sched, err := s.Model.AddActionSchedule(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return sched
+}
+
+func (s *ActionScheduleSuite) TestAddRecurring(c *gc.C) {
+	now := s.Clock.Now()
+	sched := s.addSchedule(c, state.ActionScheduleArgs{
+		Receiver:   "dummy/0",
+		Name:       "snapshot",
+		Parameters: map[string]interface{}{"outfile": "out.bz2"},
+		Schedule:   "@every 1h",
+		CreatedBy:  "bob",
+	})
+	c.Check(sched.Id(), gc.Equals, "0")
+	c.Check(sched.Receiver(), gc.Equals, "dummy/0")
+	c.Check(sched.Name(), gc.Equals, "snapshot")
+	c.Check(sched.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.bz2"})
+	c.Check(sched.Schedule(), gc.Equals, "@every 1h")
+	c.Check(sched.Paused(), jc.IsFalse)
+	c.Check(sched.NextRun().Equal(now.Add(time.Hour)), jc.IsTrue)
+	c.Check(sched.LastRun().IsZero(), jc.IsTrue)
+	c.Check(sched.LastError(), gc.Equals, "")
+	c.Check(sched.Created().Equal(now), jc.IsTrue)
+	c.Check(sched.CreatedBy(), gc.Equals, "bob")
+
+	fetched, err := s.Model.ActionSchedule(sched.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fetched, jc.DeepEquals, sched)
+}
+
+func (s *ActionScheduleSuite) TestAddOnce(c *gc.C) {
+	at := s.Clock.Now().Add(time.Minute)
+	sched := s.addSchedule(c, state.ActionScheduleArgs{
+		Receiver: "dummy/leader",
+		At:       at,
+	})
+	c.Check(sched.Schedule(), gc.Equals, "")
+	c.Check(sched.NextRun().Equal(at), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestAddInvalid(c *gc.C) {
+	now := s.Clock.Now()
+	for i, test := range []struct {
+		args state.ActionScheduleArgs
+		err  string
+	}{{
+		args: state.ActionScheduleArgs{Receiver: "dummy", Schedule: "@every 1h"},
+		err:  "cannot add action schedule: empty action name not valid",
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "dummy", Name: "snapshot"},
+		err:  "cannot add action schedule: exactly one of a recurring schedule and a time must be given",
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "dummy", Name: "snapshot", Schedule: "@every 1h", At: now.Add(time.Hour)},
+		err:  "cannot add action schedule: exactly one of a recurring schedule and a time must be given",
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "dummy", Name: "snapshot", Schedule: "every day"},
+		err:  `cannot add action schedule: schedule "every day": expected 5 fields, got 2`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "dummy", Name: "snapshot", At: now.Add(-time.Minute)},
+		err:  `cannot add action schedule: time .* is in the past`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "dummy/9", Name: "snapshot", Schedule: "@every 1h"},
+		err:  `cannot add action schedule: unit "dummy/9" not found`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "missing", Name: "snapshot", Schedule: "@every 1h"},
+		err:  `cannot add action schedule: application "missing" not found`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "-bad-", Name: "snapshot", Schedule: "@every 1h"},
+		err:  `cannot add action schedule: action receiver "-bad-" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: "dummy", Name: "missing", Schedule: "@every 1h"},
+		err:  `cannot add action schedule: action "missing" not defined for application "dummy"`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Receiver:   "dummy",
+			Name:       "snapshot",
+			Parameters: map[string]interface{}{"outfile": 5},
+			Schedule:   "@every 1h",
+		},
+		err: `cannot add action schedule: invalid parameters for action "snapshot": \(root\)\.outfile : must be of type string, given 5`,
+	}} {
+		c.Logf("test %d: %s", i, test.err)
+		_, err := s.Model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	schedules, err := s.Model.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestActionSchedules(c *gc.C) {
+	for i := 0; i < 11; i++ {
+		s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy"})
+	}
+	schedules, err := s.Model.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 11)
+	c.Check(schedules[2].Id(), gc.Equals, "2")
+	c.Check(schedules[10].Id(), gc.Equals, "10")
+}
+
+func (s *ActionScheduleSuite) TestActionScheduleNotFound(c *gc.C) {
+	_, err := s.Model.ActionSchedule("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `action schedule "42" not found`)
+}
+
+func (s *ActionScheduleSuite) TestPauseResume(c *gc.C) {
+	sched := s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy"})
+	err := sched.Pause()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sched.Paused(), jc.IsTrue)
+
+	next, err := s.State.NextActionScheduleRun()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(next.IsZero(), jc.IsTrue)
+
+	// Runs missed while paused are skipped.
+	s.Clock.Advance(90 * time.Minute)
+	err = sched.Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	fetched, err := s.Model.ActionSchedule(sched.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fetched.Paused(), jc.IsFalse)
+	c.Check(fetched.NextRun().Equal(s.Clock.Now().Add(time.Hour)), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestRemove(c *gc.C) {
+	sched := s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy"})
+	err := sched.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.ActionSchedule(sched.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	err = sched.Remove()
+	c.Check(err, gc.ErrorMatches, `cannot remove action schedule "0": action schedule "0" not found`)
+	err = sched.Pause()
+	c.Check(err, gc.ErrorMatches, `cannot pause action schedule "0": action schedule "0" not found`)
+}
+
+func (s *ActionScheduleSuite) TestRunApplication(c *gc.C) {
+	sched := s.addSchedule(c, state.ActionScheduleArgs{
+		Receiver:   "dummy",
+		Parameters: map[string]interface{}{"outfile": "out.bz2"},
+	})
+	now := s.Clock.Now().Add(time.Hour)
+	actions, err := sched.Run(now)
+	c.Assert(err, jc.ErrorIsNil)
+	var receivers []string
+	for _, action := range actions {
+		receivers = append(receivers, action.Receiver())
+		c.Check(action.Name(), gc.Equals, "snapshot")
+		c.Check(action.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.bz2"})
+	}
+	c.Check(receivers, jc.SameContents, []string{"dummy/0", "dummy/1"})
+
+	fetched, err := s.Model.ActionSchedule(sched.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fetched.LastRun().Equal(now), jc.IsTrue)
+	c.Check(fetched.NextRun().Equal(now.Add(time.Hour)), jc.IsTrue)
+	c.Check(fetched.LastError(), gc.Equals, "")
+}
+
+func (s *ActionScheduleSuite) TestRunOnce(c *gc.C) {
+	at := s.Clock.Now().Add(time.Minute)
+	sched := s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy/1", At: at})
+	actions, err := sched.Run(at)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Check(actions[0].Receiver(), gc.Equals, "dummy/1")
+
+	// A one-off schedule doesn't run again.
+	c.Check(sched.NextRun().IsZero(), jc.IsTrue)
+	due, err := s.State.DueActionSchedules(at.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(due, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestRunOnlyOnce(c *gc.C) {
+	sched := s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy/0"})
+	stale, err := s.Model.ActionSchedule(sched.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := s.Clock.Now().Add(time.Hour)
+	actions, err := sched.Run(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+
+	// A schedule that has already run enqueues nothing more.
+	actions, err = stale.Run(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, 0)
+	all, err := s.units[0].Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestRunPaused(c *gc.C) {
+	sched := s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy/0"})
+	stale, err := s.Model.ActionSchedule(sched.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = sched.Pause()
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := stale.Run(s.Clock.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, 0)
+	all, err := s.units[0].Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestRunRecordsFailure(c *gc.C) {
+	sched := s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy/1"})
+	err := s.units[1].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[1].Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := s.Clock.Now().Add(time.Hour)
+	_, err = sched.Run(now)
+	c.Assert(err, gc.ErrorMatches, `cannot enqueue action "snapshot": unit "dummy/1" not found`)
+
+	// The schedule still moves on to its next run.
+	fetched, err := s.Model.ActionSchedule(sched.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fetched.LastError(), gc.Equals, `unit "dummy/1" not found`)
+	c.Check(fetched.NextRun().Equal(now.Add(time.Hour)), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestDueActionSchedules(c *gc.C) {
+	now := s.Clock.Now()
+	s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy", Schedule: "@every 2h"})
+	s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy", Schedule: "@every 1h"})
+	paused := s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy", Schedule: "@every 1m"})
+	err := paused.Pause()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Schedules in other models are included.
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	otherModel, err := otherState.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	app := factory.NewFactory(otherState, s.StatePool).MakeApplication(c, nil)
+	otherSched, err := otherModel.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: app.Name(),
+		Name:     "juju-run",
+		Parameters: map[string]interface{}{
+			"command": "ls",
+			"timeout": 0,
+		},
+		At: now.Add(90 * time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	next, err := s.State.NextActionScheduleRun()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(next.Equal(now.Add(time.Hour)), jc.IsTrue)
+
+	due, err := s.State.DueActionSchedules(now.Add(2 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(due, jc.DeepEquals, []state.ActionScheduleKey{
+		{ModelUUID: s.State.ModelUUID(), Id: "1"},
+		{ModelUUID: otherState.ModelUUID(), Id: otherSched.Id()},
+		{ModelUUID: s.State.ModelUUID(), Id: "0"},
+	})
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.State.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	sched := s.addSchedule(c, state.ActionScheduleArgs{Receiver: "dummy"})
+	wc.AssertOneChange()
+	err := sched.Pause()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	err = sched.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -18,6 +18,14 @@ var (
 	txnLogSizeTests = 1000000
 )
 
+// The capped collection holding the audit records written by every
+// controller machine defaults to 100MB. It's tweaked in export_test.go
+// to 1MB, as for the transaction log.
+var (
+	auditLogSize      = 100000000
+	auditLogSizeTests = 1000000
+)
+
 // allCollections should be the single source of truth for information about
 // any collection we use. It's broken up into 4 main sections:
 //
@@ -137,6 +145,10 @@ func allCollections() collectionSchema {
 		// migration minions.
 		migrationsMinionSyncC: {global: true},
 
+		// This collection holds the models queued for migration in
+		// batches started by a single migrate command.
+		migrationBatchesC: {global: true},
+
 		// This collection holds user information that's not specific to any
 		// one model.
 		usersC: {
@@ -154,6 +166,24 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection holds the audit records written by all the
+		// controller machines, so the audit log can be queried through
+		// any of them. The records are also written to each machine's
+		// audit log file.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			explicitCreate: &mgo.CollectionInfo{
+				Capped:   true,
+				MaxBytes: auditLogSize,
+			},
+			indexes: []mgo.Index{{
+				Key: []string{"when"},
+			}, {
+				Key: []string{"conversation-id"},
+			}},
+		},
+
 		// This collection is used as a unique key restraint. The _id field is
 		// a concatenation of multiple fields that form a compound index,
 		// allowing us to ensure users cannot have the same name for two
@@ -399,6 +429,24 @@ func allCollections() collectionSchema {
 		},
 		actionNotificationsC: {},
 
+		// This collection holds the schedules on which the controller
+		// enqueues actions. It is queried across all models by the
+		// controller worker that runs them.
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"paused", "next-run"},
+			}},
+		},
+
+		// This collection holds actions being enqueued across the units
+		// of an application. It is queried across all models by the
+		// controller worker that advances them.
+		actionRolloutsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"status"},
+			}},
+		},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -437,6 +485,8 @@ func allCollections() collectionSchema {
 		generationsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "completed"},
+			}, {
+				Key: []string{"promotion.status"},
 			}},
 		},
 
@@ -527,6 +577,8 @@ func allCollections() collectionSchema {
 // inspection.
 const (
 	actionNotificationsC       = "actionnotifications"
+	actionRolloutsC            = "actionrollouts"
+	actionSchedulesC           = "actionschedules"
 	actionresultsC             = "actionresults"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
@@ -565,6 +617,7 @@ const (
 	metricsC                   = "metrics"
 	metricsManagerC            = "metricsmanager"
 	minUnitsC                  = "minunits"
+	migrationBatchesC          = "migrations.batches"
 	migrationsActiveC          = "migrations.active"
 	migrationsC                = "migrations"
 	migrationsMinionSyncC      = "migrations.minionsync"
@@ -602,6 +655,7 @@ const (
 	ipAddressesC               = "ip.addresses"
 	toolsmetadataC             = "toolsmetadata"
 	txnLogC                    = "txns.log"
+	auditLogC                  = "auditlog"
 	txnsC                      = "txns"
 	unitsC                     = "units"
 	upgradeInfoC               = "upgradeInfo"
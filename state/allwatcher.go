@@ -5,9 +5,13 @@ package state
 
 import (
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -91,6 +95,18 @@ func makeAllWatcherCollectionInfo(collNames ...string) map[string]allWatcherStat
 		case openedPortsC:
 			collection.docType = reflect.TypeOf(backingOpenedPorts{})
 			collection.subsidiary = true
+		case instanceDataC:
+			collection.docType = reflect.TypeOf(backingInstanceData{})
+			collection.subsidiary = true
+		case linkLayerDevicesC:
+			collection.docType = reflect.TypeOf(backingLinkLayerDevice{})
+			collection.subsidiary = true
+		case ipAddressesC:
+			collection.docType = reflect.TypeOf(backingIPAddress{})
+			collection.subsidiary = true
+		case charmsC:
+			collection.docType = reflect.TypeOf(backingCharm{})
+			collection.subsidiary = true
 		case remoteApplicationsC:
 			collection.docType = reflect.TypeOf(backingRemoteApplication{})
 		case applicationOffersC:
@@ -261,14 +277,31 @@ func (m *backingMachine) updated(st *State, store *multiwatcherStore, id string)
 		if err != nil {
 			return errors.Annotatef(err, "retrieve machine and agent status for %q", m.Id)
 		}
+		// We're adding the entry for the first time,
+		// so fetch the associated child documents.
+		c, err := readConstraints(st, machineGlobalKey(m.Id))
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "retrieve constraints for machine %q", m.Id)
+		}
+		info.Constraints = c
+		if machine, ok := entity.(*Machine); ok {
+			info.NetworkInterfaces, err = machineNetworkInterfaces(machine)
+			if err != nil {
+				return errors.Annotatef(err, "retrieve network interfaces for machine %q", m.Id)
+			}
+		}
 	} else {
-		// The entry already exists, so preserve the current status and
-		// instance data.
+		// The entry already exists, so preserve the current status,
+		// instance data and child documents.
 		oldInfo := oldInfo.(*multiwatcher.MachineInfo)
 		info.AgentStatus = oldInfo.AgentStatus
 		info.InstanceStatus = oldInfo.InstanceStatus
 		info.InstanceId = oldInfo.InstanceId
+		info.DisplayName = oldInfo.DisplayName
 		info.HardwareCharacteristics = oldInfo.HardwareCharacteristics
+		info.CharmProfiles = oldInfo.CharmProfiles
+		info.Constraints = oldInfo.Constraints
+		info.NetworkInterfaces = oldInfo.NetworkInterfaces
 	}
 	// try to update agent version
 	err = m.updateAgentVersion(entity, info)
@@ -277,12 +310,11 @@ func (m *backingMachine) updated(st *State, store *multiwatcherStore, id string)
 	}
 
 	// If the machine is been provisioned, fetch the instance id as required,
-	// and set instance id and hardware characteristics.
+	// and set the instance details.
 	if m.Nonce != "" && info.InstanceId == "" {
 		instanceData, err := getInstanceData(st, m.Id)
 		if err == nil {
-			info.InstanceId = string(instanceData.InstanceId)
-			info.HardwareCharacteristics = hardwareCharacteristics(instanceData)
+			setMachineInstanceData(info, instanceData)
 		} else if !errors.IsNotFound(err) {
 			return err
 		}
@@ -291,6 +323,83 @@ func (m *backingMachine) updated(st *State, store *multiwatcherStore, id string)
 	return nil
 }
 
+// setMachineInstanceData sets the details of the machine's provider
+// instance on the machine info.
+func setMachineInstanceData(info *multiwatcher.MachineInfo, data instanceData) {
+	info.InstanceId = string(data.InstanceId)
+	info.DisplayName = data.DisplayName
+	info.HardwareCharacteristics = hardwareCharacteristics(data)
+	info.CharmProfiles = data.CharmProfiles
+}
+
+// machineNetworkInterfaces returns the network interfaces of the machine
+// that have addresses assigned to them, sorted by device name. Loopback
+// devices and addresses are ignored.
+func machineNetworkInterfaces(m *Machine) ([]multiwatcher.NetworkInterface, error) {
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	deviceAddresses := make(map[string][]*Address)
+	for _, addr := range addresses {
+		if addr.LoopbackConfigMethod() {
+			continue
+		}
+		deviceAddresses[addr.DeviceName()] = append(deviceAddresses[addr.DeviceName()], addr)
+	}
+	if len(deviceAddresses) == 0 {
+		return nil, nil
+	}
+	devices, err := m.AllLinkLayerDevices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	subnetSpaces := make(map[string]string)
+	var interfaces []multiwatcher.NetworkInterface
+	for _, dev := range devices {
+		devAddresses := deviceAddresses[dev.Name()]
+		if dev.IsLoopbackDevice() || len(devAddresses) == 0 {
+			continue
+		}
+		iface := multiwatcher.NetworkInterface{
+			DeviceName: dev.Name(),
+			MACAddress: dev.MACAddress(),
+			IsUp:       dev.IsUp(),
+		}
+		spaces := make(set.Strings)
+		for _, addr := range devAddresses {
+			iface.IPAddresses = append(iface.IPAddresses, addr.Value())
+			iface.DNSNameservers = append(iface.DNSNameservers, addr.DNSServers()...)
+			if gateway := addr.GatewayAddress(); gateway != "" {
+				iface.Gateways = append(iface.Gateways, gateway)
+			}
+			cidr := addr.SubnetCIDR()
+			spaceName, ok := subnetSpaces[cidr]
+			if !ok {
+				subnet, err := m.st.Subnet(cidr)
+				if err == nil {
+					spaceName = subnet.SpaceName()
+				} else if !errors.IsNotFound(err) {
+					return nil, errors.Trace(err)
+				}
+				subnetSpaces[cidr] = spaceName
+			}
+			if spaceName != "" {
+				spaces.Add(spaceName)
+			}
+		}
+		if !spaces.IsEmpty() {
+			iface.Spaces = spaces.SortedValues()
+		}
+		interfaces = append(interfaces, iface)
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].DeviceName < interfaces[j].DeviceName
+	})
+	return interfaces, nil
+}
+
 func (m *backingMachine) removed(store *multiwatcherStore, modelUUID, id string, _ *State) error {
 	store.Remove(multiwatcher.EntityId{
 		Kind:      "machine",
@@ -377,8 +486,14 @@ func (u *backingUnit) updated(st *State, store *multiwatcherStore, id string) er
 		Name:        u.Name,
 		Application: u.Application,
 		Series:      u.Series,
+		Life:        multiwatcher.Life(u.Life.String()),
 		MachineId:   u.MachineId,
 		Subordinate: u.Principal != "",
+		Principal:   u.Principal,
+		Resolved:    string(u.Resolved),
+	}
+	if len(u.Subordinates) > 0 {
+		info.Subordinates = u.Subordinates
 	}
 	if u.CharmURL != nil {
 		info.CharmURL = u.CharmURL.String()
@@ -405,12 +520,16 @@ func (u *backingUnit) updated(st *State, store *multiwatcherStore, id string) er
 		}
 		info.PortRanges = toMultiwatcherPortRanges(portRanges)
 		info.Ports = toMultiwatcherPorts(compatiblePorts)
+		if info.WorkloadVersion, err = unit.WorkloadVersion(); err != nil {
+			return errors.Annotatef(err, "retrieve workload version for %q", u.Name)
+		}
 	} else {
 		// The entry already exists, so preserve the current status and ports.
 		oldInfo := oldInfo.(*multiwatcher.UnitInfo)
 		// Unit and workload status.
 		info.AgentStatus = oldInfo.AgentStatus
 		info.WorkloadStatus = oldInfo.WorkloadStatus
+		info.WorkloadVersion = oldInfo.WorkloadVersion
 		info.Ports = oldInfo.Ports
 		info.PortRanges = oldInfo.PortRanges
 	}
@@ -466,13 +585,17 @@ func (app *backingApplication) updated(st *State, store *multiwatcherStore, id s
 		return errors.Errorf("charm url is nil")
 	}
 	info := &multiwatcher.ApplicationInfo{
-		ModelUUID:   st.ModelUUID(),
-		Name:        app.Name,
-		Exposed:     app.Exposed,
-		CharmURL:    app.CharmURL.String(),
-		Life:        multiwatcher.Life(app.Life.String()),
-		MinUnits:    app.MinUnits,
-		Subordinate: app.Subordinate,
+		ModelUUID:            st.ModelUUID(),
+		Name:                 app.Name,
+		Exposed:              app.Exposed,
+		CharmURL:             app.CharmURL.String(),
+		Life:                 multiwatcher.Life(app.Life.String()),
+		MinUnits:             app.MinUnits,
+		Subordinate:          app.Subordinate,
+		Series:               app.Series,
+		HasMetricCredentials: len(app.MetricCredentials) > 0,
+		CharmModifiedVersion: app.CharmModifiedVersion,
+		ForceCharm:           app.ForceCharm,
 	}
 	oldInfo := store.Get(info.EntityId())
 	needConfig := false
@@ -487,7 +610,9 @@ func (app *backingApplication) updated(st *State, store *multiwatcherStore, id s
 		}
 		info.Constraints = c
 		needConfig = true
-		applicationStatus, err := getStatus(st.db(), key, "application")
+		// The application status is derived from its units
+		// until it has been set.
+		applicationStatus, err := newApplication(st, (*applicationDoc)(app)).Status()
 		if err != nil {
 			return errors.Annotatef(err, "reading application status for key %s", key)
 		}
@@ -515,11 +640,18 @@ func (app *backingApplication) updated(st *State, store *multiwatcherStore, id s
 		// The entry already exists, so preserve the current status.
 		oldInfo := oldInfo.(*multiwatcher.ApplicationInfo)
 		info.Constraints = oldInfo.Constraints
+		info.Status = oldInfo.Status
 		info.WorkloadVersion = oldInfo.WorkloadVersion
 		if info.CharmURL == oldInfo.CharmURL {
 			// The charm URL remains the same - we can continue to
-			// use the same config settings.
+			// use the same config settings, charm details and
+			// endpoint bindings.
 			info.Config = oldInfo.Config
+			info.EndpointBindings = oldInfo.EndpointBindings
+			info.CharmVersion = oldInfo.CharmVersion
+			info.CharmLXDProfile = oldInfo.CharmLXDProfile
+			info.MetricsPlanRequired = oldInfo.MetricsPlanRequired
+			info.LatestCharmURL = oldInfo.LatestCharmURL
 		} else {
 			// The charm URL has changed - we need to fetch the
 			// settings from the new charm's settings doc.
@@ -532,11 +664,43 @@ func (app *backingApplication) updated(st *State, store *multiwatcherStore, id s
 			return errors.Annotatef(err, "application %q", app.Name)
 		}
 		info.Config = doc.Settings
+		if err := app.updateCharmDetails(st, info); err != nil {
+			return errors.Annotatef(err, "application %q", app.Name)
+		}
 	}
 	store.Update(info)
 	return nil
 }
 
+// updateCharmDetails sets the details of the application's charm, the
+// endpoint bindings that go with it and the latest known store revision
+// of the charm on the application info.
+func (app *backingApplication) updateCharmDetails(st *State, info *multiwatcher.ApplicationInfo) error {
+	ch, err := st.Charm(app.CharmURL)
+	if err == nil {
+		info.CharmVersion = ch.Version()
+		info.CharmLXDProfile = multiwatcher.NewProfile(ch.LXDProfile())
+		metrics := ch.Metrics()
+		info.MetricsPlanRequired = metrics != nil && metrics.Plan != nil && metrics.Plan.Required
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	bindings, _, err := readEndpointBindings(st, applicationGlobalKey(app.Name))
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	info.EndpointBindings = bindings
+	if app.CharmURL.Schema == "cs" {
+		latest, err := st.LatestPlaceholderCharm(app.CharmURL)
+		if err == nil {
+			info.LatestCharmURL = latest.String()
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func (app *backingApplication) removed(store *multiwatcherStore, modelUUID, id string, _ *State) error {
 	store.Remove(multiwatcher.EntityId{
 		Kind:      "application",
@@ -746,8 +910,25 @@ func (r *backingRelation) updated(st *State, store *multiwatcherStore, id string
 		ModelUUID: st.ModelUUID(),
 		Key:       r.Key,
 		Id:        r.Id,
+		Life:      multiwatcher.Life(r.Life.String()),
 		Endpoints: eps,
 	}
+	if oldInfo := store.Get(info.EntityId()); oldInfo != nil {
+		// The entry already exists, so preserve the current status.
+		info.Status = oldInfo.(*multiwatcher.RelationInfo).Status
+	} else {
+		relationStatus, err := getStatus(st.db(), relationGlobalScope(r.Id), "relation")
+		if err == nil {
+			info.Status = multiwatcher.StatusInfo{
+				Current: relationStatus.Status,
+				Message: relationStatus.Message,
+				Data:    normaliseStatusData(relationStatus.Data),
+				Since:   relationStatus.Since,
+			}
+		} else if !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "reading status for relation %q", r.Key)
+		}
+	}
 	store.Update(info)
 	return nil
 }
@@ -833,6 +1014,9 @@ func (s *backingStatus) toStatusInfo() multiwatcher.StatusInfo {
 }
 
 func (s *backingStatus) updated(st *State, store *multiwatcherStore, id string) error {
+	if strings.HasPrefix(id, "r#") {
+		return s.updatedRelationStatus(st, store, id)
+	}
 	parentID, ok := backingEntityIdForGlobalKey(st.ModelUUID(), id)
 	if !ok {
 		return nil
@@ -843,6 +1027,9 @@ func (s *backingStatus) updated(st *State, store *multiwatcherStore, id string)
 		// The parent info doesn't exist. Ignore the status until it does.
 		return nil
 	case *multiwatcher.UnitInfo:
+		if strings.HasSuffix(id, "#sat#workload-version") {
+			return s.updatedWorkloadVersion(st, store, info)
+		}
 		newInfo := *info
 		// Get the unit's current recorded status from state.
 		// It's needed to reset the unit status when a unit comes off error.
@@ -934,6 +1121,57 @@ func (s *backingStatus) updatedUnitStatus(st *State, store *multiwatcherStore, i
 	return nil
 }
 
+// updatedWorkloadVersion records the workload version of the unit,
+// which is stored as a status value, on the unit and its application.
+func (s *backingStatus) updatedWorkloadVersion(st *State, store *multiwatcherStore, info *multiwatcher.UnitInfo) error {
+	newInfo := *info
+	newInfo.WorkloadVersion = s.StatusInfo
+	store.Update(&newInfo)
+	if s.StatusInfo == "" {
+		return nil
+	}
+	applicationId := (&multiwatcher.ApplicationInfo{
+		ModelUUID: st.ModelUUID(),
+		Name:      info.Application,
+	}).EntityId()
+	applicationInfo, ok := store.Get(applicationId).(*multiwatcher.ApplicationInfo)
+	if !ok {
+		return nil
+	}
+	newApplicationInfo := *applicationInfo
+	newApplicationInfo.WorkloadVersion = s.StatusInfo
+	store.Update(&newApplicationInfo)
+	return nil
+}
+
+// updatedRelationStatus records the status of the relation with the
+// given global key. Relation statuses are keyed on the relation id
+// rather than the relation key, so the relation is looked up first.
+func (s *backingStatus) updatedRelationStatus(st *State, store *multiwatcherStore, id string) error {
+	relationId, err := strconv.Atoi(strings.TrimPrefix(id, "r#"))
+	if err != nil {
+		return nil
+	}
+	relation, err := st.Relation(relationId)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	relationInfo, ok := store.Get((&multiwatcher.RelationInfo{
+		ModelUUID: st.ModelUUID(),
+		Key:       relation.String(),
+	}).EntityId()).(*multiwatcher.RelationInfo)
+	if !ok {
+		// The relation info doesn't exist. Ignore the status until it does.
+		return nil
+	}
+	newInfo := *relationInfo
+	newInfo.Status = s.toStatusInfo()
+	store.Update(&newInfo)
+	return nil
+}
+
 func (s *backingStatus) removed(*multiwatcherStore, string, string, *State) error {
 	// If the status is removed, the parent will follow not long after,
 	// so do nothing.
@@ -956,9 +1194,13 @@ func (c *backingConstraints) updated(st *State, store *multiwatcherStore, id str
 	case nil:
 		// The parent info doesn't exist. Ignore the status until it does.
 		return nil
-	case *multiwatcher.UnitInfo, *multiwatcher.MachineInfo:
-		// We don't (yet) publish unit or machine constraints.
+	case *multiwatcher.UnitInfo:
+		// We don't (yet) publish unit constraints.
 		return nil
+	case *multiwatcher.MachineInfo:
+		newInfo := *info
+		newInfo.Constraints = constraintsDoc(*c).value()
+		info0 = &newInfo
 	case *multiwatcher.ModelInfo:
 		newInfo := *info
 		newInfo.Constraints = constraintsDoc(*c).value()
@@ -1195,6 +1437,143 @@ func backingEntityIdForOpenedPortsKey(modelUUID, key string) (multiwatcher.Entit
 	return backingEntityIdForGlobalKey(modelUUID, machineGlobalKey(parts[1]))
 }
 
+type backingInstanceData instanceData
+
+func (d *backingInstanceData) updated(st *State, store *multiwatcherStore, id string) error {
+	info, ok := store.Get((&multiwatcher.MachineInfo{
+		ModelUUID: st.ModelUUID(),
+		Id:        id,
+	}).EntityId()).(*multiwatcher.MachineInfo)
+	if !ok {
+		// The machine info doesn't exist. Ignore the instance data until it does.
+		return nil
+	}
+	newInfo := *info
+	setMachineInstanceData(&newInfo, instanceData(*d))
+	store.Update(&newInfo)
+	return nil
+}
+
+func (d *backingInstanceData) removed(*multiwatcherStore, string, string, *State) error {
+	// The instance data is removed along with the machine.
+	return nil
+}
+
+func (d *backingInstanceData) mongoId() string {
+	panic("cannot find mongo id from instance data document")
+}
+
+type backingLinkLayerDevice linkLayerDeviceDoc
+
+func (d *backingLinkLayerDevice) updated(st *State, store *multiwatcherStore, id string) error {
+	return updateMachineNetworkInterfaces(st, store, id)
+}
+
+func (d *backingLinkLayerDevice) removed(store *multiwatcherStore, modelUUID, id string, st *State) error {
+	if st == nil {
+		return nil
+	}
+	return updateMachineNetworkInterfaces(st, store, id)
+}
+
+func (d *backingLinkLayerDevice) mongoId() string {
+	panic("cannot find mongo id from link layer device document")
+}
+
+type backingIPAddress ipAddressDoc
+
+func (a *backingIPAddress) updated(st *State, store *multiwatcherStore, id string) error {
+	return updateMachineNetworkInterfaces(st, store, id)
+}
+
+func (a *backingIPAddress) removed(store *multiwatcherStore, modelUUID, id string, st *State) error {
+	if st == nil {
+		return nil
+	}
+	return updateMachineNetworkInterfaces(st, store, id)
+}
+
+func (a *backingIPAddress) mongoId() string {
+	panic("cannot find mongo id from IP address document")
+}
+
+// updateMachineNetworkInterfaces refreshes the network interfaces of the
+// machine that owns the link layer device or IP address with the given
+// global key.
+func updateMachineNetworkInterfaces(st *State, store *multiwatcherStore, key string) error {
+	parts := strings.SplitN(key, "#", 3)
+	if len(parts) != 3 || parts[0] != "m" {
+		return nil
+	}
+	info, ok := store.Get((&multiwatcher.MachineInfo{
+		ModelUUID: st.ModelUUID(),
+		Id:        parts[1],
+	}).EntityId()).(*multiwatcher.MachineInfo)
+	if !ok {
+		// The machine info doesn't exist. Ignore the change until it does.
+		return nil
+	}
+	machine, err := st.Machine(info.Id)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	newInfo := *info
+	newInfo.NetworkInterfaces, err = machineNetworkInterfaces(machine)
+	if err != nil {
+		return errors.Annotatef(err, "retrieve network interfaces for machine %q", info.Id)
+	}
+	store.Update(&newInfo)
+	return nil
+}
+
+type backingCharm charmDoc
+
+// updated records the latest store revision of a charm on the
+// applications using it, when the charm revision updater adds a
+// placeholder for a newer revision.
+func (ch *backingCharm) updated(st *State, store *multiwatcherStore, id string) error {
+	if !ch.Placeholder || ch.URL == nil {
+		return nil
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	baseURL := ch.URL.WithRevision(-1)
+	for _, app := range applications {
+		curl, _ := app.CharmURL()
+		if curl == nil || *curl.WithRevision(-1) != *baseURL {
+			continue
+		}
+		info, ok := store.Get((&multiwatcher.ApplicationInfo{
+			ModelUUID: st.ModelUUID(),
+			Name:      app.Name(),
+		}).EntityId()).(*multiwatcher.ApplicationInfo)
+		if !ok {
+			continue
+		}
+		if latest, err := charm.ParseURL(info.LatestCharmURL); err == nil && latest.Revision >= ch.URL.Revision {
+			continue
+		}
+		newInfo := *info
+		newInfo.LatestCharmURL = ch.URL.String()
+		store.Update(&newInfo)
+	}
+	return nil
+}
+
+func (ch *backingCharm) removed(*multiwatcherStore, string, string, *State) error {
+	// Placeholders are only removed once a newer revision is known,
+	// so there is nothing to do.
+	return nil
+}
+
+func (ch *backingCharm) mongoId() string {
+	panic("cannot find mongo id from charm document")
+}
+
 // backingEntityIdForGlobalKey returns the entity id for the given global key.
 // It returns false if the key is not recognized.
 func backingEntityIdForGlobalKey(modelUUID, key string) (multiwatcher.EntityId, bool) {
@@ -1215,6 +1594,7 @@ func backingEntityIdForGlobalKey(modelUUID, key string) (multiwatcher.EntityId,
 		}).EntityId(), true
 	case 'u':
 		id = strings.TrimSuffix(id, "#charm")
+		id = strings.TrimSuffix(id, "#sat#workload-version")
 		return (&multiwatcher.UnitInfo{
 			ModelUUID: modelUUID,
 			Name:      id,
@@ -1266,6 +1646,10 @@ func newAllWatcherStateBacking(st *State, params WatchParams) Backing {
 		constraintsC,
 		settingsC,
 		openedPortsC,
+		instanceDataC,
+		linkLayerDevicesC,
+		ipAddressesC,
+		charmsC,
 		actionsC,
 		blocksC,
 		remoteApplicationsC,
@@ -1352,6 +1736,10 @@ func NewAllModelWatcherStateBacking(st *State, pool *StatePool) Backing {
 		constraintsC,
 		settingsC,
 		openedPortsC,
+		instanceDataC,
+		linkLayerDevicesC,
+		ipAddressesC,
+		charmsC,
 		remoteApplicationsC,
 	)
 	return &allModelWatcherStateBacking{
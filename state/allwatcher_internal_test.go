@@ -139,6 +139,8 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int, inclu
 			Data:    map[string]interface{}{},
 			Since:   &now,
 		},
+		Series:           "quantal",
+		EndpointBindings: applicationEndpointBindings(c, wordpress),
 	})
 	pairs := map[string]string{"x": "12", "y": "99"}
 	err = model.SetAnnotations(wordpress, pairs)
@@ -163,6 +165,8 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int, inclu
 			Data:    map[string]interface{}{},
 			Since:   &now,
 		},
+		Series:           "quantal",
+		EndpointBindings: applicationEndpointBindings(c, logging),
 	})
 
 	eps, err := st.InferEndpoints("logging", "wordpress")
@@ -173,9 +177,15 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int, inclu
 		ModelUUID: modelUUID,
 		Key:       "logging:logging-directory wordpress:logging-dir",
 		Id:        rel.Id(),
+		Life:      multiwatcher.Life("alive"),
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 1, Scope: "container"}},
 			{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
+		Status: multiwatcher.StatusInfo{
+			Current: status.Joining,
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 	})
 
 	for i := 0; i < units; i++ {
@@ -187,11 +197,12 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int, inclu
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(m.Tag().String(), gc.Equals, fmt.Sprintf("machine-%d", i+1))
 
-		add(&multiwatcher.UnitInfo{
+		wordpressInfo := &multiwatcher.UnitInfo{
 			ModelUUID:   modelUUID,
 			Name:        fmt.Sprintf("wordpress/%d", i),
 			Application: wordpress.Name(),
 			Series:      m.Series(),
+			Life:        multiwatcher.Life("alive"),
 			MachineId:   m.Id(),
 			Ports:       []multiwatcher.Port{},
 			Subordinate: false,
@@ -207,7 +218,8 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int, inclu
 				Data:    map[string]interface{}{},
 				Since:   &now,
 			},
-		})
+		}
+		add(wordpressInfo)
 		pairs := map[string]string{"name": fmt.Sprintf("bar %d", i)}
 		err = model.SetAnnotations(wu, pairs)
 		c.Assert(err, jc.ErrorIsNil)
@@ -271,13 +283,16 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int, inclu
 		deployer, ok = lu.DeployerTag()
 		c.Assert(ok, jc.IsTrue)
 		c.Assert(deployer, gc.Equals, names.NewUnitTag(fmt.Sprintf("wordpress/%d", i)))
+		wordpressInfo.Subordinates = []string{lu.Name()}
 		add(&multiwatcher.UnitInfo{
 			ModelUUID:   modelUUID,
 			Name:        fmt.Sprintf("logging/%d", i),
 			Application: "logging",
 			Series:      "quantal",
+			Life:        multiwatcher.Life("alive"),
 			Ports:       []multiwatcher.Port{},
 			Subordinate: true,
+			Principal:   fmt.Sprintf("wordpress/%d", i),
 			WorkloadStatus: multiwatcher.StatusInfo{
 				Current: "waiting",
 				Message: "waiting for machine",
@@ -312,6 +327,8 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int, inclu
 			Data:    map[string]interface{}{},
 			Since:   &now,
 		},
+		Series:           "quantal",
+		EndpointBindings: applicationEndpointBindings(c, mysql),
 	})
 
 	// Set up a remote application related to the offer.
@@ -340,9 +357,15 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int, inclu
 		ModelUUID: modelUUID,
 		Key:       rel.Tag().Id(),
 		Id:        rel.Id(),
+		Life:      multiwatcher.Life("alive"),
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress2", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
+		Status: multiwatcher.StatusInfo{
+			Current: status.Joining,
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 	})
 
 	_, applicationOfferInfo, rel2 := addTestingApplicationOffer(
@@ -352,9 +375,15 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int, inclu
 		ModelUUID: modelUUID,
 		Key:       rel2.Tag().Id(),
 		Id:        rel2.Id(),
+		Life:      multiwatcher.Life("alive"),
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
+		Status: multiwatcher.StatusInfo{
+			Current: status.Joining,
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 	})
 	if includeOffers {
 		add(&applicationOfferInfo)
@@ -409,6 +438,14 @@ func addTestingRemoteApplication(
 	}
 }
 
+// applicationEndpointBindings returns the endpoint bindings of the
+// application, as recorded in the application info.
+func applicationEndpointBindings(c *gc.C, app *Application) map[string]string {
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	return bindings
+}
+
 func addTestingApplicationOffer(
 	c *gc.C, st *State, owner names.UserTag, offerName, applicationName, charmName string, endpoints []string,
 ) (*crossmodel.ApplicationOffer, multiwatcher.ApplicationOfferInfo, *Relation) {
@@ -742,6 +779,7 @@ func (s *allWatcherStateSuite) TestClosingPorts(c *gc.C) {
 			Name:           "wordpress/0",
 			Application:    "wordpress",
 			Series:         "quantal",
+			Life:           multiwatcher.Life("alive"),
 			MachineId:      "0",
 			PublicAddress:  "1.2.3.4",
 			PrivateAddress: "4.3.2.1",
@@ -779,6 +817,7 @@ func (s *allWatcherStateSuite) TestClosingPorts(c *gc.C) {
 			Name:           "wordpress/0",
 			Application:    "wordpress",
 			Series:         "quantal",
+			Life:           multiwatcher.Life("alive"),
 			MachineId:      "0",
 			PublicAddress:  "1.2.3.4",
 			PrivateAddress: "4.3.2.1",
@@ -1055,6 +1094,8 @@ func (s *allWatcherStateSuite) TestStateWatcher(c *gc.C) {
 				Data:    map[string]interface{}{},
 				Since:   &wpTime,
 			},
+			Series:           "quantal",
+			EndpointBindings: applicationEndpointBindings(c, wordpress),
 		},
 	}, {
 		Entity: &multiwatcher.UnitInfo{
@@ -1062,6 +1103,7 @@ func (s *allWatcherStateSuite) TestStateWatcher(c *gc.C) {
 			Name:        "wordpress/0",
 			Application: "wordpress",
 			Series:      "quantal",
+			Life:        multiwatcher.Life("alive"),
 			MachineId:   "2",
 			WorkloadStatus: multiwatcher.StatusInfo{
 				Current: "waiting",
@@ -1944,6 +1986,9 @@ func (s *allModelWatcherStateSuite) TestStateWatcher(c *gc.C) {
 				Data:    map[string]interface{}{},
 				Since:   &later,
 			},
+
+			Series:           "quantal",
+			EndpointBindings: applicationEndpointBindings(c, wordpress),
 		},
 	}, {
 		Entity: &multiwatcher.UnitInfo{
@@ -1951,6 +1996,7 @@ func (s *allModelWatcherStateSuite) TestStateWatcher(c *gc.C) {
 			Name:        "wordpress/0",
 			Application: "wordpress",
 			Series:      "quantal",
+			Life:        multiwatcher.Life("alive"),
 			MachineId:   "1",
 			WorkloadStatus: multiwatcher.StatusInfo{
 				Current: "waiting",
@@ -2225,6 +2271,32 @@ func testChangeMachines(c *gc.C, runChangeTests func(*gc.C, []changeTestFunc)) {
 						SupportedContainersKnown: true,
 					}}}
 		},
+		func(c *gc.C, st *State) changeTestCase {
+			m, err := st.AddMachine("trusty", JobHostUnits)
+			c.Assert(err, jc.ErrorIsNil)
+			err = m.SetProvisioned("i-0", "fancy-name", "bootstrap_nonce", nil)
+			c.Assert(err, jc.ErrorIsNil)
+			return changeTestCase{
+				about: "instance data is updated if the machine is in the store",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.MachineInfo{
+						ModelUUID: st.ModelUUID(),
+						Id:        "0",
+					},
+				},
+				change: watcher.Change{
+					C:  "instanceData",
+					Id: st.docID("0"),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.MachineInfo{
+						ModelUUID:               st.ModelUUID(),
+						Id:                      "0",
+						InstanceId:              "i-0",
+						DisplayName:             "fancy-name",
+						HardwareCharacteristics: &instance.HardwareCharacteristics{},
+					}}}
+		},
 		func(c *gc.C, st *State) changeTestCase {
 			now := st.clock().Now()
 			return changeTestCase{
@@ -2327,6 +2399,7 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 			c.Assert(err, jc.ErrorIsNil)
 			_, err = st.AddRelation(eps...)
 			c.Assert(err, jc.ErrorIsNil)
+			now := st.clock().Now()
 
 			return changeTestCase{
 				about: "relation is added if it's in backing but not in Store",
@@ -2338,9 +2411,59 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 					&multiwatcher.RelationInfo{
 						ModelUUID: st.ModelUUID(),
 						Key:       "logging:logging-directory wordpress:logging-dir",
+						Life:      multiwatcher.Life("alive"),
 						Endpoints: []multiwatcher.Endpoint{
 							{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 1, Scope: "container"}},
 							{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
+						Status: multiwatcher.StatusInfo{
+							Current: status.Joining,
+							Data:    map[string]interface{}{},
+							Since:   &now,
+						},
+					}}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
+			AddTestingApplication(c, st, "logging", AddTestingCharm(c, st, "logging"))
+			eps, err := st.InferEndpoints("logging", "wordpress")
+			c.Assert(err, jc.ErrorIsNil)
+			rel, err := st.AddRelation(eps...)
+			c.Assert(err, jc.ErrorIsNil)
+			now := st.clock().Now()
+			err = rel.SetStatus(status.StatusInfo{
+				Status:  status.Suspended,
+				Message: "for a while",
+				Since:   &now,
+			})
+			c.Assert(err, jc.ErrorIsNil)
+
+			return changeTestCase{
+				about: "relation status is updated if the relation is in the store",
+				initialContents: []multiwatcher.EntityInfo{&multiwatcher.RelationInfo{
+					ModelUUID: st.ModelUUID(),
+					Key:       "logging:logging-directory wordpress:logging-dir",
+					Id:        rel.Id(),
+					Status: multiwatcher.StatusInfo{
+						Current: status.Joining,
+						Data:    map[string]interface{}{},
+						Since:   &now,
+					},
+				}},
+				change: watcher.Change{
+					C:  "statuses",
+					Id: st.docID(fmt.Sprintf("r#%d", rel.Id())),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.RelationInfo{
+						ModelUUID: st.ModelUUID(),
+						Key:       "logging:logging-directory wordpress:logging-dir",
+						Id:        rel.Id(),
+						Status: multiwatcher.StatusInfo{
+							Current: status.Suspended,
+							Message: "for a while",
+							Data:    map[string]interface{}{},
+							Since:   &now,
+						},
 					}}}
 		},
 	}
@@ -2401,6 +2524,8 @@ func testChangeApplications(c *gc.C, owner names.UserTag, runChangeTests func(*g
 							Data:    map[string]interface{}{},
 							Since:   &now,
 						},
+						Series:           "quantal",
+						EndpointBindings: applicationEndpointBindings(c, wordpress),
 					}}}
 		},
 		func(c *gc.C, st *State) changeTestCase {
@@ -2430,6 +2555,7 @@ func testChangeApplications(c *gc.C, owner names.UserTag, runChangeTests func(*g
 						Life:        multiwatcher.Life("alive"),
 						Constraints: constraints.MustParse("mem=99M"),
 						Config:      charm.Settings{"blog-title": "boring"},
+						Series:      "quantal",
 					}}}
 		},
 		func(c *gc.C, st *State) changeTestCase {
@@ -2533,6 +2659,46 @@ func testChangeApplications(c *gc.C, owner names.UserTag, runChangeTests func(*g
 				},
 			}
 		},
+		func(c *gc.C, st *State) changeTestCase {
+			app := AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
+			unit, err := app.AddUnit(AddUnitParams{})
+			c.Assert(err, jc.ErrorIsNil)
+			err = unit.SetWorkloadVersion("42.47")
+			c.Assert(err, jc.ErrorIsNil)
+			return changeTestCase{
+				about: "workload version is recorded on the unit when set",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.UnitInfo{
+						ModelUUID:   st.ModelUUID(),
+						Name:        "wordpress/0",
+						Application: "wordpress",
+					},
+					&multiwatcher.ApplicationInfo{
+						ModelUUID: st.ModelUUID(),
+						Name:      "wordpress",
+						CharmURL:  "local:quantal/quantal-wordpress-3",
+					},
+				},
+				change: watcher.Change{
+					C:  "statuses",
+					Id: st.docID("u#" + unit.Name() + "#sat#workload-version"),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.UnitInfo{
+						ModelUUID:       st.ModelUUID(),
+						Name:            "wordpress/0",
+						Application:     "wordpress",
+						WorkloadVersion: "42.47",
+					},
+					&multiwatcher.ApplicationInfo{
+						ModelUUID:       st.ModelUUID(),
+						Name:            "wordpress",
+						CharmURL:        "local:quantal/quantal-wordpress-3",
+						WorkloadVersion: "42.47",
+					},
+				},
+			}
+		},
 		func(c *gc.C, st *State) changeTestCase {
 			app := AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
 			setApplicationConfigAttr(c, app, "blog-title", "boring")
@@ -2553,11 +2719,13 @@ func testChangeApplications(c *gc.C, owner names.UserTag, runChangeTests func(*g
 				},
 				expectContents: []multiwatcher.EntityInfo{
 					&multiwatcher.ApplicationInfo{
-						ModelUUID: st.ModelUUID(),
-						Name:      "wordpress",
-						CharmURL:  "local:quantal/quantal-wordpress-3",
-						Life:      multiwatcher.Life("alive"),
-						Config:    charm.Settings{"blog-title": "boring"},
+						ModelUUID:        st.ModelUUID(),
+						Name:             "wordpress",
+						CharmURL:         "local:quantal/quantal-wordpress-3",
+						Life:             multiwatcher.Life("alive"),
+						Config:           charm.Settings{"blog-title": "boring"},
+						Series:           "quantal",
+						EndpointBindings: applicationEndpointBindings(c, app),
 					}}}
 		},
 		// Settings.
@@ -2823,6 +2991,7 @@ func testChangeUnits(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C, []
 						Name:        "wordpress/0",
 						Application: "wordpress",
 						Series:      "quantal",
+						Life:        multiwatcher.Life("alive"),
 						MachineId:   "0",
 						Ports: []multiwatcher.Port{
 							{"tcp", 5555},
@@ -2895,6 +3064,7 @@ func testChangeUnits(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C, []
 						Name:        "wordpress/0",
 						Application: "wordpress",
 						Series:      "quantal",
+						Life:        multiwatcher.Life("alive"),
 						MachineId:   "0",
 						Ports:       []multiwatcher.Port{{"udp", 17070}},
 						PortRanges:  []multiwatcher.PortRange{{17070, 17070, "udp"}},
@@ -2982,6 +3152,7 @@ func testChangeUnits(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C, []
 						Name:        "wordpress/0",
 						Application: "wordpress",
 						Series:      "quantal",
+						Life:        multiwatcher.Life("alive"),
 						MachineId:   "0",
 						WorkloadStatus: multiwatcher.StatusInfo{
 							Current: "waiting",
@@ -3038,6 +3209,7 @@ func testChangeUnits(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C, []
 						Name:           "wordpress/0",
 						Application:    "wordpress",
 						Series:         "quantal",
+						Life:           multiwatcher.Life("alive"),
 						PublicAddress:  "public",
 						PrivateAddress: "private",
 						MachineId:      "0",
@@ -3434,6 +3606,7 @@ func testChangeUnitsNonNilPorts(c *gc.C, owner names.UserTag, runChangeTests fun
 						Name:        "wordpress/0",
 						Application: "wordpress",
 						Series:      "quantal",
+						Life:        multiwatcher.Life("alive"),
 						MachineId:   "0",
 						Ports:       []multiwatcher.Port{},
 						PortRanges:  []multiwatcher.PortRange{},
@@ -3467,6 +3640,7 @@ func testChangeUnitsNonNilPorts(c *gc.C, owner names.UserTag, runChangeTests fun
 						Name:           "wordpress/0",
 						Application:    "wordpress",
 						Series:         "quantal",
+						Life:           multiwatcher.Life("alive"),
 						MachineId:      "0",
 						PublicAddress:  "1.2.3.4",
 						PrivateAddress: "4.3.2.1",
@@ -3502,6 +3676,7 @@ func testChangeUnitsNonNilPorts(c *gc.C, owner names.UserTag, runChangeTests fun
 						Name:           "wordpress/0",
 						Application:    "wordpress",
 						Series:         "quantal",
+						Life:           multiwatcher.Life("alive"),
 						MachineId:      "0",
 						PublicAddress:  "1.2.3.4",
 						PrivateAddress: "4.3.2.1",
@@ -3537,6 +3712,7 @@ func testChangeUnitsNonNilPorts(c *gc.C, owner names.UserTag, runChangeTests fun
 						Name:        "wordpress/0",
 						Application: "wordpress",
 						Series:      "quantal",
+						Life:        multiwatcher.Life("alive"),
 						Ports:       []multiwatcher.Port{},
 						PortRanges:  []multiwatcher.PortRange{},
 						WorkloadStatus: multiwatcher.StatusInfo{
@@ -2876,6 +2876,23 @@ func (a *Application) UpdateCloudService(providerId string, addreses []network.A
 	return a.st.db().RunTransaction(ops)
 }
 
+// SetServiceHostnames records the external hostnames, or addresses,
+// at which the cloud's load balancers expose the application's cloud
+// service.
+func (a *Application) SetServiceHostnames(hostnames []string) error {
+	ops := []txn.Op{{
+		C:      cloudServicesC,
+		Id:     a.globalKey(),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"hostnames", hostnames}}}},
+	}}
+	err := a.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("cloud service for application %v", a.Name())
+	}
+	return errors.Trace(err)
+}
+
 // ServiceInfo returns information about this application's cloud service.
 // This is only used for CAAS models.
 func (a *Application) ServiceInfo() (CloudService, error) {
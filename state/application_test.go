@@ -3850,6 +3850,26 @@ func (s *CAASApplicationSuite) TestServiceInfoEmptyProviderId(c *gc.C) {
 	}
 }
 
+func (s *CAASApplicationSuite) TestSetServiceHostnames(c *gc.C) {
+	err := s.app.UpdateCloudService("id", []network.Address{{Value: "10.0.0.1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetServiceHostnames([]string{"gitlab.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Updating the service details keeps the hostnames.
+	err = s.app.UpdateCloudService("id", []network.Address{{Value: "10.0.0.2"}})
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.app.ServiceInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Addresses(), jc.DeepEquals, []network.Address{{Value: "10.0.0.2"}})
+	c.Assert(info.Hostnames(), jc.DeepEquals, []string{"gitlab.example.com"})
+}
+
+func (s *CAASApplicationSuite) TestSetServiceHostnamesNoService(c *gc.C) {
+	err := s.app.SetServiceHostnames([]string{"gitlab.example.com"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *CAASApplicationSuite) TestRemoveUnitDeletesServiceInfo(c *gc.C) {
 	err := s.app.UpdateCloudService("id", []network.Address{{Value: "10.0.0.1"}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,204 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+// auditRecordDoc holds an audit record written by one of the
+// controller machines.
+type auditRecordDoc struct {
+	Id bson.ObjectId `bson:"_id"`
+
+	// When is the time the record was written, used to select the
+	// records read for a query.
+	When time.Time `bson:"when"`
+
+	// ConversationID identifies the conversation the record belongs
+	// to, so the calls made in the selected conversations can be
+	// read.
+	ConversationID string `bson:"conversation-id"`
+
+	// Conversation, Who and ModelUUID are only set on the record
+	// starting a conversation, and are used to select the
+	// conversations read for a query.
+	Conversation bool   `bson:"conversation,omitempty"`
+	Who          string `bson:"who,omitempty"`
+	ModelUUID    string `bson:"model-uuid,omitempty"`
+
+	// Record holds the JSON encoded auditlog.Record, as it is
+	// written to the audit log file.
+	Record string `bson:"record"`
+}
+
+// AuditLog returns an audit log that writes records to the audit log
+// collection shared by all the controller machines, from where they can
+// be queried through any controller using AuditConversations.
+func (st *State) AuditLog() auditlog.AuditLog {
+	return &auditLogStore{st: st}
+}
+
+type auditLogStore struct {
+	st *State
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (s *auditLogStore) AddConversation(c auditlog.Conversation) error {
+	return errors.Trace(s.add(c.When, c.ConversationID, auditlog.Record{Conversation: &c}))
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (s *auditLogStore) AddRequest(r auditlog.Request) error {
+	return errors.Trace(s.add(r.When, r.ConversationID, auditlog.Record{Request: &r}))
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (s *auditLogStore) AddResponse(r auditlog.ResponseErrors) error {
+	return errors.Trace(s.add(r.When, r.ConversationID, auditlog.Record{Errors: &r}))
+}
+
+// Close implements auditlog.AuditLog.
+func (s *auditLogStore) Close() error {
+	return nil
+}
+
+func (s *auditLogStore) add(when, conversationID string, record auditlog.Record) error {
+	t, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		return errors.Annotate(err, "parsing audit record time")
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc := auditRecordDoc{
+		Id:             bson.NewObjectId(),
+		When:           t.UTC(),
+		ConversationID: conversationID,
+		Record:         string(data),
+	}
+	if c := record.Conversation; c != nil {
+		doc.Conversation = true
+		doc.Who = c.Who
+		doc.ModelUUID = c.ModelUUID
+	}
+	coll, closer := s.st.db().GetRawCollection(auditLogC)
+	defer closer()
+	return errors.Trace(coll.Insert(doc))
+}
+
+// AuditConversations returns the conversations recorded in the shared
+// audit log that are selected by the filter, oldest first.
+func (st *State) AuditConversations(filter auditlog.Filter) ([]auditlog.ConversationLog, error) {
+	coll, closer := st.db().GetRawCollection(auditLogC)
+	defer closer()
+
+	query := bson.D{{"conversation", true}}
+	if filter.Who != "" {
+		query = append(query, bson.DocElem{"who", filter.Who})
+	}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	when := bson.D{}
+	if !filter.From.IsZero() {
+		when = append(when, bson.DocElem{"$gte", filter.From.UTC()})
+	}
+	if !filter.To.IsZero() {
+		when = append(when, bson.DocElem{"$lte", filter.To.UTC()})
+	}
+	if len(when) > 0 {
+		query = append(query, bson.DocElem{"when", when})
+	}
+
+	if filter.Limit <= 0 {
+		conversations, err := readAuditRecords(coll.Find(query).Sort("when", "_id").Iter())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return auditConversationCalls(coll, conversations, filter)
+	}
+
+	// Only the most recent conversations are wanted, so read them
+	// newest first, a page at a time, until enough have been
+	// selected. The methods called and errors returned aren't known
+	// until the calls are read, so a page may select fewer
+	// conversations than it holds.
+	var result []auditlog.ConversationLog
+	for skip := 0; len(result) < filter.Limit; skip += filter.Limit {
+		iter := coll.Find(query).Sort("-when", "-_id").Skip(skip).Limit(filter.Limit).Iter()
+		conversations, err := readAuditRecords(iter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(conversations) == 0 {
+			break
+		}
+		for i, j := 0, len(conversations)-1; i < j; i, j = i+1, j-1 {
+			conversations[i], conversations[j] = conversations[j], conversations[i]
+		}
+		pageFilter := filter
+		pageFilter.Limit = filter.Limit - len(result)
+		selected, err := auditConversationCalls(coll, conversations, pageFilter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(selected, result...)
+	}
+	return result, nil
+}
+
+// auditConversationCalls reads the calls made in the conversations,
+// given oldest first, and returns the conversations selected by the
+// filter.
+func auditConversationCalls(
+	coll *mgo.Collection, conversations []auditlog.Record, filter auditlog.Filter,
+) ([]auditlog.ConversationLog, error) {
+	if len(conversations) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(conversations))
+	for i, record := range conversations {
+		ids[i] = record.Conversation.ConversationID
+	}
+	// Each controller writes its records in order, so sorting by
+	// time and then id keeps the calls of every conversation in the
+	// order they were made.
+	iter := coll.Find(bson.D{
+		{"conversation-id", bson.D{{"$in", ids}}},
+		{"conversation", bson.D{{"$ne", true}}},
+	}).Sort("when", "_id").Iter()
+	calls, err := readAuditRecords(iter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.SelectConversations(append(conversations, calls...), filter), nil
+}
+
+// readAuditRecords decodes the audit records read by iter, skipping
+// any that can't be read.
+func readAuditRecords(iter *mgo.Iter) ([]auditlog.Record, error) {
+	var records []auditlog.Record
+	var doc auditRecordDoc
+	for iter.Next(&doc) {
+		var record auditlog.Record
+		if err := json.Unmarshal([]byte(doc.Record), &record); err != nil {
+			logger.Debugf("skipping unreadable audit record %q: %v", doc.Id.Hex(), err)
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading audit records")
+	}
+	return records, nil
+}
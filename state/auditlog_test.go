@@ -0,0 +1,133 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type AuditLogSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) addConversation(c *gc.C, id, who, when string, withError bool) {
+	s.addModelConversation(c, id, who, "", when, withError)
+}
+
+func (s *AuditLogSuite) addModelConversation(c *gc.C, id, who, modelUUID, when string, withError bool) {
+	log := s.State.AuditLog()
+	err := log.AddConversation(auditlog.Conversation{
+		Who:            who,
+		What:           "juju status",
+		When:           when,
+		ModelUUID:      modelUUID,
+		ConversationID: id,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.AddRequest(auditlog.Request{
+		ConversationID: id,
+		RequestID:      1,
+		When:           when,
+		Facade:         "Client",
+		Method:         "FullStatus",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	if withError {
+		err = log.AddResponse(auditlog.ResponseErrors{
+			ConversationID: id,
+			RequestID:      1,
+			When:           when,
+			Errors:         []*auditlog.Error{{Message: "boom"}},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *AuditLogSuite) TestAuditConversations(c *gc.C) {
+	s.addConversation(c, "a1", "bob", "2018-10-01T10:00:00Z", false)
+	s.addConversation(c, "b2", "mary", "2018-10-01T11:00:00Z", true)
+
+	conversations, err := s.State.AuditConversations(auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 2)
+	c.Check(conversations[0].ConversationID, gc.Equals, "a1")
+	c.Check(conversations[0].Calls, gc.HasLen, 1)
+	c.Check(conversations[0].Calls[0].Method, gc.Equals, "FullStatus")
+	c.Check(conversations[1].ConversationID, gc.Equals, "b2")
+	c.Check(conversations[1].Calls[0].Errors, jc.DeepEquals, []*auditlog.Error{{Message: "boom"}})
+}
+
+func (s *AuditLogSuite) TestAuditConversationsFiltered(c *gc.C) {
+	s.addConversation(c, "a1", "bob", "2018-10-01T10:00:00Z", false)
+	s.addConversation(c, "b2", "mary", "2018-10-01T11:00:00Z", true)
+	s.addConversation(c, "c3", "bob", "2018-10-01T12:00:00Z", false)
+
+	conversations, err := s.State.AuditConversations(auditlog.Filter{
+		From: time.Date(2018, 10, 1, 11, 0, 0, 0, time.UTC),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 2)
+	c.Check(conversations[0].ConversationID, gc.Equals, "b2")
+	c.Check(conversations[1].ConversationID, gc.Equals, "c3")
+
+	conversations, err = s.State.AuditConversations(auditlog.Filter{Who: "bob", Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 1)
+	c.Check(conversations[0].ConversationID, gc.Equals, "c3")
+
+	conversations, err = s.State.AuditConversations(auditlog.Filter{ErrorsOnly: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 1)
+	c.Check(conversations[0].ConversationID, gc.Equals, "b2")
+}
+
+func (s *AuditLogSuite) TestAuditConversationsSelectedByModelAndTime(c *gc.C) {
+	s.addModelConversation(c, "a1", "bob", "model-1", "2018-10-01T10:00:00Z", false)
+	s.addModelConversation(c, "b2", "bob", "model-2", "2018-10-01T11:00:00Z", false)
+	s.addModelConversation(c, "c3", "bob", "model-1", "2018-10-01T12:00:00Z", false)
+
+	conversations, err := s.State.AuditConversations(auditlog.Filter{ModelUUID: "model-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 2)
+	c.Check(conversations[0].ConversationID, gc.Equals, "a1")
+	c.Check(conversations[0].Calls, gc.HasLen, 1)
+	c.Check(conversations[1].ConversationID, gc.Equals, "c3")
+
+	conversations, err = s.State.AuditConversations(auditlog.Filter{
+		To: time.Date(2018, 10, 1, 11, 0, 0, 0, time.UTC),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 2)
+	c.Check(conversations[0].ConversationID, gc.Equals, "a1")
+	c.Check(conversations[1].ConversationID, gc.Equals, "b2")
+}
+
+func (s *AuditLogSuite) TestAuditConversationsLimitKeepsMostRecent(c *gc.C) {
+	s.addConversation(c, "a1", "bob", "2018-10-01T10:00:00Z", true)
+	s.addConversation(c, "b2", "bob", "2018-10-01T11:00:00Z", false)
+	s.addConversation(c, "c3", "bob", "2018-10-01T12:00:00Z", true)
+	s.addConversation(c, "d4", "bob", "2018-10-01T13:00:00Z", false)
+	s.addConversation(c, "e5", "bob", "2018-10-01T14:00:00Z", false)
+
+	conversations, err := s.State.AuditConversations(auditlog.Filter{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 2)
+	c.Check(conversations[0].ConversationID, gc.Equals, "d4")
+	c.Check(conversations[1].ConversationID, gc.Equals, "e5")
+	c.Check(conversations[1].Calls, gc.HasLen, 1)
+
+	// The matching conversations are found beyond the first page.
+	conversations, err = s.State.AuditConversations(auditlog.Filter{ErrorsOnly: true, Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 2)
+	c.Check(conversations[0].ConversationID, gc.Equals, "a1")
+	c.Check(conversations[1].ConversationID, gc.Equals, "c3")
+}
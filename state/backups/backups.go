@@ -87,8 +87,9 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error)
+	// the provided metadata. If encryption is not nil, the
+	// archive is encrypted with it.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, encryption *Encryption) (string, error)
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -122,7 +123,13 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, encryption *Encryption) (string, error) {
+	if encryption != nil {
+		if err := encryption.Validate(); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
@@ -147,12 +154,13 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 		return "", errors.Annotate(err, "while preparing for DB dump")
 	}
 
-	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload}
+	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload, encryption}
 	result, err := runCreate(&args)
 	if err != nil {
 		return "", errors.Annotate(err, "while creating backup archive")
 	}
 	defer result.archiveFile.Close()
+	meta.Encrypted = encryption != nil
 
 	// Finalize the metadata.
 	err = finishMeta(meta, result)
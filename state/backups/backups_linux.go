@@ -76,6 +76,10 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	defer backupReader.Close()
 
+	if meta.Encrypted {
+		return nil, errors.Errorf("backup %q is encrypted and must be decrypted before it can be restored", backupId)
+	}
+
 	workspace, err := NewArchiveWorkspaceReader(backupReader)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
@@ -62,7 +62,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
 
-	_, err := s.api.Create(meta, &paths, &dbInfo, true, true)
+	_, err := s.api.Create(meta, &paths, &dbInfo, true, true, nil)
 	c.Check(err, gc.ErrorMatches, expected)
 }
 
@@ -118,7 +118,7 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	resultFilename, err := s.api.Create(meta, &paths, &dbInfo, keepCopy, noDownload)
+	resultFilename, err := s.api.Create(meta, &paths, &dbInfo, keepCopy, noDownload, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(resultFilename, gc.Equals, path.Join(backupDir, backups.TempFilename))
 
@@ -165,6 +165,35 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	}
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	received, testCreate := backups.NewTestCreate(nil)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+
+	paths := backups.Paths{BackupDir: c.MkDir(), DataDir: c.MkDir()}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt}
+	meta := backupstesting.NewMetadataStarted()
+	encryption := &backups.Encryption{Passphrase: "secret"}
+	_, err := s.api.Create(meta, &paths, &dbInfo, false, true, encryption)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(backups.ExposeCreateEncryption(received), gc.Equals, encryption)
+	c.Check(meta.Encrypted, jc.IsTrue)
+}
+
+func (s *backupsSuite) TestCreateInvalidEncryption(c *gc.C) {
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt}
+	meta := backupstesting.NewMetadataStarted()
+	_, err := s.api.Create(meta, &paths, &dbInfo, false, true, &backups.Encryption{})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
 	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
 		return nil, errors.New("failed!")
@@ -33,6 +33,7 @@ type createArgs struct {
 	db             DBDumper
 	metadataReader io.Reader
 	noDownload     bool
+	encryption     *Encryption
 }
 
 type createResult struct {
@@ -70,6 +71,11 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err := builder.buildAll(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if args.encryption != nil {
+		if err := builder.encryptArchive(*args.encryption); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	// Get the result.
 	result, err := builder.result()
@@ -349,6 +355,43 @@ func (b *builder) buildAll() error {
 	return nil
 }
 
+// encryptArchive replaces the archive file with an encrypted copy and
+// updates the checksum to match. The unencrypted archive is removed
+// straight away rather than being left for cleanUp.
+func (b *builder) encryptArchive(enc Encryption) error {
+	if err := b.closeArchiveFile(); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("encrypting archive file %q", b.filename)
+
+	plain, err := os.Open(b.filename)
+	if err != nil {
+		return errors.Annotate(err, "while opening archive file")
+	}
+	defer plain.Close()
+
+	filename := b.filename + EncryptedFilenameSuffix
+	encrypted, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Annotate(err, "while creating encrypted archive file")
+	}
+	hasher := hash.NewHashingWriter(encrypted, sha1.New())
+	if err := EncryptArchive(hasher, plain, enc); err != nil {
+		encrypted.Close()
+		return errors.Trace(err)
+	}
+	if err := encrypted.Close(); err != nil {
+		return errors.Annotate(err, "while closing encrypted archive file")
+	}
+	if err := os.Remove(b.filename); err != nil {
+		return errors.Annotate(err, "while removing unencrypted archive file")
+	}
+
+	b.filename = filename
+	b.checksum = hasher.Base64Sum()
+	return nil
+}
+
 // result returns a "create" result relative to the current state of the
 // builder.  create() uses this method to get the final backup result
 // from the builder it used.
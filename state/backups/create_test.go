@@ -6,6 +6,7 @@ package backups_test
 import (
 	"os"
 	"path"
+	"path/filepath"
 	"runtime"
 
 	jc "github.com/juju/testing/checkers"
@@ -58,6 +59,42 @@ func (s *createSuite) TestLegacy(c *gc.C) {
 	s.checkArchive(c, file, expected)
 }
 
+func (s *createSuite) TestEncrypted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	backupDir := c.MkDir()
+	_, testFiles, expected := s.createTestFiles(c)
+
+	dumper := &TestDBDumper{}
+	args := backups.NewTestCreateArgs(backupDir, testFiles, dumper, metadataFile, false)
+	backups.SetTestCreateEncryption(args, &backups.Encryption{Passphrase: "secret"})
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile, size, checksum, filename := backups.ExposeCreateResult(result)
+	c.Check(filename, gc.Equals, filepath.Join(filepath.Dir(filename), backups.TempFilename+backups.EncryptedFilenameSuffix))
+	_, err = os.Stat(filepath.Join(filepath.Dir(filename), backups.TempFilename))
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+
+	// The size and checksum are those of the encrypted archive.
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+
+	decrypted, err := os.Create(filepath.Join(c.MkDir(), backups.TempFilename))
+	c.Assert(err, jc.ErrorIsNil)
+	defer decrypted.Close()
+	err = backups.DecryptArchive(decrypted, file, backups.Decryption{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	resetFile(c, decrypted)
+	s.checkArchive(c, decrypted, expected)
+}
+
 func (s *createSuite) TestMetadataFileMissing(c *gc.C) {
 	var backupDir string
 	var testFiles []string
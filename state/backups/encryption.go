@@ -0,0 +1,195 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+// EncryptedFilenameSuffix is appended to the name of a backup archive
+// file once it has been encrypted.
+const EncryptedFilenameSuffix = ".gpg"
+
+// encryptionConfig is used for every encrypted archive. The archive
+// is already gzipped, so it isn't compressed again.
+var encryptionConfig = &packet.Config{
+	DefaultHash:            crypto.SHA256,
+	DefaultCipher:          packet.CipherAES256,
+	DefaultCompressionAlgo: packet.CompressionNone,
+}
+
+// Encryption holds the key material used to encrypt a backup archive.
+// Exactly one of PublicKey and Passphrase must be set.
+type Encryption struct {
+	// PublicKey is an ASCII-armored OpenPGP public key. The archive
+	// is encrypted to every key it contains.
+	PublicKey string
+
+	// Passphrase is used to derive a symmetric key for the archive.
+	Passphrase string
+}
+
+// Validate checks that exactly one kind of key is given and that a
+// public key can be parsed.
+func (e Encryption) Validate() error {
+	if e.PublicKey == "" && e.Passphrase == "" {
+		return errors.NotValidf("encryption without public key or passphrase")
+	}
+	if e.PublicKey != "" && e.Passphrase != "" {
+		return errors.NotValidf("encryption with both public key and passphrase")
+	}
+	if e.PublicKey != "" {
+		if _, err := readPublicKeys(e.PublicKey); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func readPublicKeys(armored string) (openpgp.EntityList, error) {
+	keys, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return nil, errors.NewNotValid(err, "cannot read public key")
+	}
+	if len(keys) == 0 {
+		return nil, errors.NotValidf("empty public key")
+	}
+	return keys, nil
+}
+
+// EncryptArchive writes an encrypted copy of the archive read from r
+// to w. The result is an OpenPGP message protected against
+// modification, so that DecryptArchive can refuse a tampered archive.
+func EncryptArchive(w io.Writer, r io.Reader, enc Encryption) error {
+	if err := enc.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	hints := &openpgp.FileHints{IsBinary: true}
+	var plaintext io.WriteCloser
+	var err error
+	if enc.PublicKey != "" {
+		keys, _ := readPublicKeys(enc.PublicKey)
+		plaintext, err = openpgp.Encrypt(w, keys, nil, hints, encryptionConfig)
+	} else {
+		plaintext, err = openpgp.SymmetricallyEncrypt(w, []byte(enc.Passphrase), hints, encryptionConfig)
+	}
+	if err != nil {
+		return errors.Annotate(err, "while starting encryption")
+	}
+	if _, err := io.Copy(plaintext, r); err != nil {
+		plaintext.Close()
+		return errors.Annotate(err, "while encrypting archive")
+	}
+	return errors.Annotate(plaintext.Close(), "while finishing encryption")
+}
+
+// Decryption holds the key material used to decrypt a backup archive.
+type Decryption struct {
+	// PrivateKey is an ASCII-armored OpenPGP private key matching the
+	// public key the archive was encrypted with.
+	PrivateKey string
+
+	// Passphrase is the passphrase the archive was encrypted with or,
+	// if PrivateKey is set, the passphrase protecting that key.
+	Passphrase string
+}
+
+// IsEncryptedArchive reports whether the archive read from r was
+// written by EncryptArchive rather than being a plain gzipped tarball.
+// The reader is left at the start of the archive.
+func IsEncryptedArchive(r io.ReadSeeker) (bool, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return false, errors.Annotate(err, "while reading archive header")
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return false, errors.Trace(err)
+	}
+	// Gzip streams always start with these magic bytes.
+	return header[0] != 0x1f || header[1] != 0x8b, nil
+}
+
+// DecryptArchive writes the decrypted contents of the archive read
+// from r to w. The archive's integrity is only confirmed once it has
+// been read to the end, so if an error is returned whatever has been
+// written to w must be discarded.
+func DecryptArchive(w io.Writer, r io.ReadSeeker, dec Decryption) error {
+	if dec.PrivateKey == "" && dec.Passphrase == "" {
+		return errors.NotValidf("decryption without private key or passphrase")
+	}
+	if err := checkIntegrityProtected(r); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+
+	var keys openpgp.EntityList
+	if dec.PrivateKey != "" {
+		var err error
+		keys, err = openpgp.ReadArmoredKeyRing(strings.NewReader(dec.PrivateKey))
+		if err != nil {
+			return errors.NewNotValid(err, "cannot read private key")
+		}
+	}
+	// The prompt is called again whenever the key or passphrase it
+	// supplied didn't work, so only try once.
+	prompted := false
+	prompt := func(candidates []openpgp.Key, symmetric bool) ([]byte, error) {
+		if prompted || dec.Passphrase == "" {
+			return nil, errors.New("cannot decrypt archive with the given key or passphrase")
+		}
+		prompted = true
+		if symmetric {
+			return []byte(dec.Passphrase), nil
+		}
+		for _, k := range candidates {
+			if k.PrivateKey != nil && k.PrivateKey.Encrypted {
+				if err := k.PrivateKey.Decrypt([]byte(dec.Passphrase)); err != nil {
+					return nil, errors.Annotate(err, "cannot unlock private key")
+				}
+			}
+		}
+		return nil, nil
+	}
+	md, err := openpgp.ReadMessage(r, keys, prompt, encryptionConfig)
+	if err != nil {
+		return errors.Annotate(err, "while decrypting archive")
+	}
+	// Reading to the end checks the modification detection code.
+	if _, err := io.Copy(w, md.UnverifiedBody); err != nil {
+		return errors.Annotate(err, "archive failed integrity check")
+	}
+	return nil
+}
+
+// checkIntegrityProtected makes sure the encrypted data in the archive
+// read from r carries a modification detection code. Without one a
+// modified archive could not be told apart from the original.
+func checkIntegrityProtected(r io.Reader) error {
+	packets := packet.NewReader(r)
+	for {
+		p, err := packets.Next()
+		if err != nil {
+			return errors.Annotate(err, "while reading encrypted archive")
+		}
+		switch p := p.(type) {
+		case *packet.EncryptedKey, *packet.SymmetricKeyEncrypted:
+			continue
+		case *packet.SymmetricallyEncrypted:
+			if !p.MDC {
+				return errors.New("archive is not integrity protected")
+			}
+			return nil
+		default:
+			return errors.Errorf("unexpected %T in encrypted archive", p)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type encryptionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+// archive starts with the gzip magic bytes so it looks like a real
+// backup archive.
+var archive = append([]byte{0x1f, 0x8b}, bytes.Repeat([]byte("<compressed tarball>"), 1000)...)
+
+func (s *encryptionSuite) encrypt(c *gc.C, enc backups.Encryption) []byte {
+	var encrypted bytes.Buffer
+	err := backups.EncryptArchive(&encrypted, bytes.NewReader(archive), enc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(encrypted.Bytes(), gc.Not(jc.DeepEquals), archive)
+	return encrypted.Bytes()
+}
+
+func (s *encryptionSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		enc backups.Encryption
+		err string
+	}{{
+		enc: backups.Encryption{},
+		err: "encryption without public key or passphrase not valid",
+	}, {
+		enc: backups.Encryption{PublicKey: "key", Passphrase: "secret"},
+		err: "encryption with both public key and passphrase not valid",
+	}, {
+		enc: backups.Encryption{PublicKey: "key"},
+		err: "cannot read public key: .*",
+	}} {
+		c.Logf("test %d", i)
+		err := test.enc.Validate()
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *encryptionSuite) TestPassphrase(c *gc.C) {
+	encrypted := s.encrypt(c, backups.Encryption{Passphrase: "secret"})
+
+	var decrypted bytes.Buffer
+	err := backups.DecryptArchive(&decrypted, bytes.NewReader(encrypted), backups.Decryption{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decrypted.Bytes(), jc.DeepEquals, archive)
+}
+
+func (s *encryptionSuite) TestWrongPassphrase(c *gc.C) {
+	encrypted := s.encrypt(c, backups.Encryption{Passphrase: "secret"})
+
+	var decrypted bytes.Buffer
+	err := backups.DecryptArchive(&decrypted, bytes.NewReader(encrypted), backups.Decryption{Passphrase: "guess"})
+	c.Check(err, gc.ErrorMatches, "while decrypting archive: cannot decrypt archive with the given key or passphrase")
+}
+
+func (s *encryptionSuite) TestPublicKey(c *gc.C) {
+	publicKey, privateKey := backupstesting.NewEncryptionKeys(c)
+	encrypted := s.encrypt(c, backups.Encryption{PublicKey: publicKey})
+
+	var decrypted bytes.Buffer
+	err := backups.DecryptArchive(&decrypted, bytes.NewReader(encrypted), backups.Decryption{PrivateKey: privateKey})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decrypted.Bytes(), jc.DeepEquals, archive)
+}
+
+func (s *encryptionSuite) TestWrongPrivateKey(c *gc.C) {
+	publicKey, _ := backupstesting.NewEncryptionKeys(c)
+	_, otherPrivateKey := backupstesting.NewEncryptionKeys(c)
+	encrypted := s.encrypt(c, backups.Encryption{PublicKey: publicKey})
+
+	var decrypted bytes.Buffer
+	err := backups.DecryptArchive(&decrypted, bytes.NewReader(encrypted), backups.Decryption{PrivateKey: otherPrivateKey})
+	c.Check(err, gc.ErrorMatches, "while decrypting archive: .*incorrect key")
+}
+
+func (s *encryptionSuite) TestTampered(c *gc.C) {
+	encrypted := s.encrypt(c, backups.Encryption{Passphrase: "secret"})
+	encrypted[len(encrypted)/2] ^= 1
+
+	var decrypted bytes.Buffer
+	err := backups.DecryptArchive(&decrypted, bytes.NewReader(encrypted), backups.Decryption{Passphrase: "secret"})
+	c.Check(err, gc.ErrorMatches, "archive failed integrity check: .*")
+}
+
+func (s *encryptionSuite) TestDecryptPlainArchive(c *gc.C) {
+	var decrypted bytes.Buffer
+	err := backups.DecryptArchive(&decrypted, bytes.NewReader(archive), backups.Decryption{Passphrase: "secret"})
+	c.Check(err, gc.ErrorMatches, "while reading encrypted archive: .*")
+}
+
+func (s *encryptionSuite) TestDecryptWithoutKey(c *gc.C) {
+	encrypted := s.encrypt(c, backups.Encryption{Passphrase: "secret"})
+
+	var decrypted bytes.Buffer
+	err := backups.DecryptArchive(&decrypted, bytes.NewReader(encrypted), backups.Decryption{})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *encryptionSuite) TestIsEncryptedArchive(c *gc.C) {
+	encrypted := s.encrypt(c, backups.Encryption{Passphrase: "secret"})
+
+	isEncrypted, err := backups.IsEncryptedArchive(bytes.NewReader(encrypted))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(isEncrypted, jc.IsTrue)
+
+	isEncrypted, err = backups.IsEncryptedArchive(bytes.NewReader(archive))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(isEncrypted, jc.IsFalse)
+}
@@ -34,6 +34,34 @@ var (
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
 var _ filestorage.RawFileStorage = (*backupBlobStorage)(nil)
+var _ filestorage.RawFileStorage = (*targetFileStorage)(nil)
+
+var (
+	SignV4           = signV4
+	NewTargetStorage = &newTargetStorage
+)
+
+// NewLocalStorage returns storage for backup archives in the directory.
+func NewLocalStorage(dir string) (filestorage.RawFileStorage, error) {
+	return newLocalStorage(dir)
+}
+
+// NewS3Storage returns storage for backup archives in an object store,
+// signing requests as if made at the given time.
+func NewS3Storage(endpoint, bucket string, now time.Time) (filestorage.RawFileStorage, error) {
+	stor, err := newS3Storage(s3Config{
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		Bucket:    bucket,
+		AccessKey: "access-key",
+		SecretKey: "secret-key",
+	})
+	if err != nil {
+		return nil, err
+	}
+	stor.now = func() time.Time { return now }
+	return stor, nil
+}
 
 func getBackupDBWrapper(st *state.State) *storageDBWrapper {
 	db := st.MongoSession().DB(storageDBName)
@@ -105,6 +133,17 @@ func NewTestCreateArgs(backupDir string, filesToBackUp []string, db DBDumper, me
 	return &args
 }
 
+// SetTestCreateEncryption sets the encryption in a create() args value.
+func SetTestCreateEncryption(args *createArgs, enc *Encryption) *createArgs {
+	args.encryption = enc
+	return args
+}
+
+// ExposeCreateEncryption extracts the encryption in a create() args value.
+func ExposeCreateEncryption(args *createArgs) *Encryption {
+	return args.encryption
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) (string, []string, DBDumper) {
 	return args.backupDir, args.filesToBackUp, args.db
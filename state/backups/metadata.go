@@ -68,6 +68,18 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Encrypted records whether the archive was encrypted when it
+	// was created.
+	Encrypted bool
+
+	// StorageTarget identifies the backup storage target the archive
+	// is stored in (see ControllerStorageTarget and friends).
+	StorageTarget string
+
+	// Failure records why creating the backup failed. Failed backups
+	// have metadata but no archive.
+	Failure string
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -162,6 +174,7 @@ type flatMetadata struct {
 	Started     time.Time
 	Finished    time.Time
 	Notes       string
+	Encrypted   bool
 	Environment string
 	Machine     string
 	Hostname    string
@@ -185,6 +198,7 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 
 		Started:      m.Started,
 		Notes:        m.Notes,
+		Encrypted:    m.Encrypted,
 		Environment:  m.Origin.Model,
 		Machine:      m.Origin.Machine,
 		Hostname:     m.Origin.Hostname,
@@ -234,6 +248,7 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		meta.Finished = &flat.Finished
 	}
 	meta.Notes = flat.Notes
+	meta.Encrypted = flat.Encrypted
 	meta.Origin = Origin{
 		Model:    flat.Environment,
 		Machine:  flat.Machine,
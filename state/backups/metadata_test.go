@@ -56,6 +56,7 @@ func (s *metadataSuite) TestAsJSONBuffer(c *gc.C) {
 		`"Started":"2014-09-09T11:59:34Z",`+
 		`"Finished":"2014-09-09T12:00:34Z",`+
 		`"Notes":"",`+
+		`"Encrypted":false,`+
 		`"Environment":"asdf-zxcv-qwe",`+
 		`"Machine":"0",`+
 		`"Hostname":"myhost",`+
@@ -76,6 +77,7 @@ func (s *metadataSuite) TestNewMetadataJSONReader(c *gc.C) {
 		`"Started":"2014-09-09T11:59:34Z",` +
 		`"Finished":"2014-09-09T12:00:34Z",` +
 		`"Notes":"",` +
+		`"Encrypted":true,` +
 		`"Environment":"asdf-zxcv-qwe",` +
 		`"Machine":"0",` +
 		`"Hostname":"myhost",` +
@@ -92,6 +94,7 @@ func (s *metadataSuite) TestNewMetadataJSONReader(c *gc.C) {
 	c.Check(meta.Started.Unix(), gc.Equals, int64(1410263974))
 	c.Check(meta.Finished.Unix(), gc.Equals, int64(1410264034))
 	c.Check(meta.Notes, gc.Equals, "")
+	c.Check(meta.Encrypted, jc.IsTrue)
 	c.Check(meta.Origin.Model, gc.Equals, "asdf-zxcv-qwe")
 	c.Check(meta.Origin.Machine, gc.Equals, "0")
 	c.Check(meta.Origin.Hostname, gc.Equals, "myhost")
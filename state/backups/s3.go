@@ -0,0 +1,248 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+const (
+	// s3KeyPrefix is prepended to backup IDs to make object keys.
+	s3KeyPrefix = "juju-backups/"
+
+	// s3UnsignedPayload is used in place of the payload hash when
+	// signing requests, so archives needn't be read twice to upload
+	// them.
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// s3EmptyPayload is the SHA256 hash of an empty request body.
+	s3EmptyPayload = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	amzDateFormat = "20060102T150405Z"
+)
+
+// s3Config holds the settings for an S3-compatible object store.
+type s3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// s3Storage keeps backup archives as objects in a bucket of an
+// S3-compatible object store. Requests use path-style addressing and
+// AWS signature version 4, which work with AWS and with most other
+// S3 implementations.
+type s3Storage struct {
+	config   s3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+func newS3Storage(cfg s3Config) (*s3Storage, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.NotValidf("empty backup s3 bucket")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid backup s3 endpoint")
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, errors.NotValidf("backup s3 endpoint %q", cfg.Endpoint)
+	}
+	return &s3Storage{
+		config:   cfg,
+		endpoint: endpoint,
+		client:   utils.GetValidatingHTTPClient(),
+		now:      time.Now,
+	}, nil
+}
+
+// objectURL returns the URL of the object holding the identified
+// archive.
+func (s *s3Storage) objectURL(id string) *url.URL {
+	u := *s.endpoint
+	u.Path = path.Join("/", u.Path, s.config.Bucket, s3KeyPrefix+id)
+	u.RawPath = ""
+	u.RawQuery = ""
+	return &u
+}
+
+// do sends a signed request for the identified archive's object.
+func (s *s3Storage) do(method, id string, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequest(method, s.objectURL(id).String(), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	payloadHash := s3EmptyPayload
+	if body != nil {
+		req.ContentLength = size
+		payloadHash = s3UnsignedPayload
+	}
+	signV4(req, payloadHash, s.now(), s.config.Region, s.config.AccessKey, s.config.SecretKey)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot %s backup archive %q", strings.ToLower(method), id)
+	}
+	return resp, nil
+}
+
+// s3Error returns an error describing an unexpected response, which
+// is closed.
+func s3Error(resp *http.Response, id string) error {
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.NotFoundf("backup archive %q", id)
+	}
+	// S3 error responses are small XML documents.
+	message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return errors.Errorf("backup archive %q: object store returned %s: %s",
+		id, resp.Status, strings.TrimSpace(string(message)))
+}
+
+// File returns the identified file from storage.
+func (s *s3Storage) File(id string) (io.ReadCloser, error) {
+	resp, err := s.do("GET", id, nil, 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, s3Error(resp, id)
+	}
+	return resp.Body, nil
+}
+
+// AddFile adds the file to storage.
+func (s *s3Storage) AddFile(id string, file io.Reader, size int64) error {
+	// S3 silently replaces existing objects, so check first.
+	resp, err := s.do("HEAD", id, nil, 0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return errors.AlreadyExistsf("backup archive %q", id)
+	case http.StatusNotFound:
+	default:
+		return s3Error(resp, id)
+	}
+
+	resp, err = s.do("PUT", id, file, size)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp, id)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *s3Storage) RemoveFile(id string) error {
+	resp, err := s.do("DELETE", id, nil, 0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s3Error(resp, id)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Close implements filestorage.RawFileStorage.
+func (s *s3Storage) Close() error {
+	return nil
+}
+
+// signV4 adds AWS signature version 4 authentication to the request,
+// signing the host and every header already set. See
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func signV4(req *http.Request, payloadHash string, now time.Time, region, accessKey, secretKey string) {
+	now = now.UTC()
+	amzDate := now.Format(amzDateFormat)
+	date := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		if strings.EqualFold(name, "Authorization") {
+			continue
+		}
+		var trimmed []string
+		for _, v := range values {
+			trimmed = append(trimmed, strings.Join(strings.Fields(v), " "))
+		}
+		headers[strings.ToLower(name)] = strings.Join(trimmed, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, headers[name])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		strings.Replace(req.URL.Query().Encode(), "+", "%20", -1),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -44,9 +44,19 @@ type storageMetaDoc struct {
 
 	// backup
 
-	Started  int64  `bson:"started,minsize"`
-	Finished int64  `bson:"finished,minsize"`
-	Notes    string `bson:"notes,omitempty"`
+	Started   int64  `bson:"started,minsize"`
+	Finished  int64  `bson:"finished,minsize"`
+	Notes     string `bson:"notes,omitempty"`
+	Encrypted bool   `bson:"encrypted,omitempty"`
+
+	// Target is the backup storage target the archive was stored
+	// in. Archives stored before targets existed have none, and are
+	// in the controller's blobstore.
+	Target string `bson:"target,omitempty"`
+
+	// Failure is set when creating the backup failed, in which
+	// case no archive was stored.
+	Failure string `bson:"failure,omitempty"`
 
 	// origin
 
@@ -70,6 +80,14 @@ func (doc *storageMetaDoc) isFileInfoComplete() bool {
 	return true
 }
 
+// storageTarget returns the backup storage target holding the archive.
+func (doc *storageMetaDoc) storageTarget() string {
+	if doc.Target == "" {
+		return ControllerStorageTarget
+	}
+	return doc.Target
+}
+
 func (doc *storageMetaDoc) validate() error {
 	if doc.ID == "" {
 		return errors.New("missing ID")
@@ -124,6 +142,9 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Encrypted = doc.Encrypted
+	meta.StorageTarget = doc.storageTarget()
+	meta.Failure = doc.Failure
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +196,9 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Encrypted = meta.Encrypted
+	doc.Target = meta.StorageTarget
+	doc.Failure = meta.Failure
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -373,6 +397,21 @@ func setStorageStoredTime(dbWrap *storageDBWrapper, id string, stored time.Time)
 	return nil
 }
 
+// setStorageTarget updates the backup metadata associated with "id"
+// to record the backup storage target its archive was stored in.  If
+// "id" does not match any stored records, an error satisfying
+// juju/errors.IsNotFound() is returned.
+func setStorageTarget(dbWrap *storageDBWrapper, id, target string) error {
+	op := dbWrap.txnOpUpdate(id, bson.DocElem{"target", target})
+	if err := dbWrap.runTransaction([]txn.Op{op}); err != nil {
+		if errors.Cause(err) == txn.ErrAborted {
+			return errors.NotFoundf("backup metadata %q", id)
+		}
+		return errors.Annotate(err, "while running transaction")
+	}
+	return nil
+}
+
 //---------------------------
 // metadata storage
 
@@ -547,17 +586,24 @@ type DB interface {
 
 	// StateServingInfo is the secrets of the controller.
 	StateServingInfo() (state.StateServingInfo, error)
+
+	// BackupS3SecretKey is the secret key used by the "s3" backup
+	// storage target.
+	BackupS3SecretKey() (string, error)
 }
 
 // NewStorage returns a new FileStorage to use for storing backup
-// archives (and metadata).
+// archives (and metadata). Archives are stored in the backup storage
+// target set in the controller config, while their metadata is always
+// kept in the controller database.
 func NewStorage(st DB) filestorage.FileStorage {
 	modelUUID := st.ModelTag().Id()
 	db := st.MongoSession().DB(storageDBName)
 	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
 	defer dbWrap.Close()
 
-	files := newFileStorage(dbWrap, backupStorageRoot)
+	blobs := newFileStorage(dbWrap, backupStorageRoot)
+	files := newTargetFileStorage(dbWrap, blobs, st.ControllerConfig, st.BackupS3SecretKey)
 	docs := newMetadataStorage(dbWrap)
 	return filestorage.NewFileStorage(docs, files)
 }
@@ -36,6 +36,7 @@ func (s *storageSuite) checkMeta(c *gc.C, meta, expected *backups.Metadata, id s
 		c.Check(meta.ID(), gc.Equals, id)
 	}
 	c.Check(meta.Notes, gc.Equals, expected.Notes)
+	c.Check(meta.Encrypted, gc.Equals, expected.Encrypted)
 	c.Check(meta.Started.Unix(), gc.Equals, expected.Started.Unix())
 	c.Check(meta.Checksum(), gc.Equals, expected.Checksum())
 	c.Check(meta.ChecksumFormat(), gc.Equals, expected.ChecksumFormat())
@@ -71,6 +72,18 @@ func (s *storageSuite) TestGetBackupMetadataFound(c *gc.C) {
 	s.checkMeta(c, meta, original, id)
 }
 
+func (s *storageSuite) TestGetBackupMetadataEncrypted(c *gc.C) {
+	original := s.metadata(c)
+	original.Encrypted = true
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkMeta(c, meta, original, id)
+}
+
 func (s *storageSuite) TestGetBackupMetadataNotFound(c *gc.C) {
 	_, err := backups.GetBackupMetadata(s.State, "spam")
 
@@ -0,0 +1,254 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/controller"
+)
+
+const (
+	// ControllerStorageTarget keeps backup archives in the
+	// controller's own blobstore.
+	ControllerStorageTarget = "controller"
+
+	// LocalStorageTarget keeps backup archives in a directory on the
+	// controller machine.
+	LocalStorageTarget = "local"
+
+	// S3StorageTarget keeps backup archives in an S3-compatible object
+	// store.
+	S3StorageTarget = "s3"
+)
+
+// newTargetStorage returns the raw file storage for a backup storage
+// target other than the controller's blobstore, set up using the
+// controller config and, for the "s3" target, the secret key stored
+// apart from it.
+var newTargetStorage = func(
+	target string, cfg controller.Config, s3SecretKey func() (string, error),
+) (filestorage.RawFileStorage, error) {
+	switch target {
+	case LocalStorageTarget:
+		return newLocalStorage(cfg.BackupStorageDirectory())
+	case S3StorageTarget:
+		secretKey, err := s3SecretKey()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newS3Storage(s3Config{
+			Endpoint:  cfg.BackupS3Endpoint(),
+			Region:    cfg.BackupS3Region(),
+			Bucket:    cfg.BackupS3Bucket(),
+			AccessKey: cfg.BackupS3AccessKey(),
+			SecretKey: secretKey,
+		})
+	}
+	return nil, errors.NotSupportedf("backup storage target %q", target)
+}
+
+// targetFileStorage stores each archive in the backup storage target
+// configured for the controller at the time it is added, and records
+// that target in the archive's metadata. Archives are always read from
+// and removed from the target recorded for them, so changing the
+// configured target doesn't lose track of existing backups.
+type targetFileStorage struct {
+	dbWrap           *storageDBWrapper
+	blobs            filestorage.RawFileStorage
+	controllerConfig func() (controller.Config, error)
+	s3SecretKey      func() (string, error)
+}
+
+func newTargetFileStorage(
+	dbWrap *storageDBWrapper,
+	blobs filestorage.RawFileStorage,
+	controllerConfig func() (controller.Config, error),
+	s3SecretKey func() (string, error),
+) *targetFileStorage {
+	return &targetFileStorage{
+		dbWrap:           dbWrap.Copy(),
+		blobs:            blobs,
+		controllerConfig: controllerConfig,
+		s3SecretKey:      s3SecretKey,
+	}
+}
+
+// open returns the storage for the named target. The caller must
+// close it once done.
+func (s *targetFileStorage) open(target string, cfg controller.Config) (filestorage.RawFileStorage, error) {
+	if target == ControllerStorageTarget {
+		return unclosedStorage{s.blobs}, nil
+	}
+	stor, err := newTargetStorage(target, cfg, s.s3SecretKey)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot open %s backup storage", target)
+	}
+	return stor, nil
+}
+
+// openStored returns the storage holding the identified archive, and
+// the archive's metadata.
+func (s *targetFileStorage) openStored(id string) (filestorage.RawFileStorage, *storageMetaDoc, error) {
+	dbWrap := s.dbWrap.Copy()
+	defer dbWrap.Close()
+
+	doc, err := getStorageMetadata(dbWrap, id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	target := doc.storageTarget()
+	if target == ControllerStorageTarget {
+		stor, err := s.open(target, nil)
+		return stor, doc, errors.Trace(err)
+	}
+	cfg, err := s.controllerConfig()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot get controller config")
+	}
+	stor, err := s.open(target, cfg)
+	return stor, doc, errors.Trace(err)
+}
+
+// File returns the identified file from the target it was stored in.
+func (s *targetFileStorage) File(id string) (io.ReadCloser, error) {
+	stor, doc, err := s.openStored(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer stor.Close()
+	file, err := stor.File(id)
+	if errors.IsNotFound(err) && doc.storageTarget() == LocalStorageTarget {
+		return nil, errors.Annotatef(err, "archive is stored locally on controller machine %q", doc.Machine)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to the currently configured target.
+func (s *targetFileStorage) AddFile(id string, file io.Reader, size int64) error {
+	cfg, err := s.controllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot get controller config")
+	}
+	target := cfg.BackupStorage()
+	stor, err := s.open(target, cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stor.Close()
+	if err := stor.AddFile(id, file, size); err != nil {
+		return errors.Annotatef(err, "while storing archive in %s backup storage", target)
+	}
+
+	dbWrap := s.dbWrap.Copy()
+	defer dbWrap.Close()
+	return errors.Trace(setStorageTarget(dbWrap, id, target))
+}
+
+// RemoveFile removes the identified file from the target it was
+// stored in.
+func (s *targetFileStorage) RemoveFile(id string) error {
+	stor, _, err := s.openStored(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stor.Close()
+	return errors.Trace(stor.RemoveFile(id))
+}
+
+// Close closes the storage.
+func (s *targetFileStorage) Close() error {
+	err := s.blobs.Close()
+	s.dbWrap.Close()
+	return errors.Trace(err)
+}
+
+// unclosedStorage wraps the blobstore storage, which is shared, so
+// that closing it after a single operation has no effect.
+type unclosedStorage struct {
+	filestorage.RawFileStorage
+}
+
+// Close implements filestorage.RawFileStorage.
+func (unclosedStorage) Close() error {
+	return nil
+}
+
+// localStorage keeps backup archives as files in a directory on the
+// controller machine. Only the machine that created a backup can read
+// it back, so local storage can't be used by HA controllers.
+type localStorage struct {
+	dir string
+}
+
+func newLocalStorage(dir string) (*localStorage, error) {
+	if dir == "" {
+		return nil, errors.NotValidf("empty backup storage directory")
+	}
+	// Archives contain the controller's secrets.
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Annotate(err, "cannot create backup storage directory")
+	}
+	return &localStorage{dir: dir}, nil
+}
+
+func (s *localStorage) path(id string) string {
+	return filepath.Join(s.dir, id)
+}
+
+// File returns the identified file from storage.
+func (s *localStorage) File(id string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to storage. The archive is written to a
+// temporary file first, so that an interrupted write never leaves a
+// truncated archive behind.
+func (s *localStorage) AddFile(id string, file io.Reader, size int64) error {
+	path := s.path(id)
+	if _, err := os.Stat(path); err == nil {
+		return errors.AlreadyExistsf("backup archive %q", id)
+	}
+	tmp, err := ioutil.TempFile(s.dir, "."+id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, file)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Annotate(err, "while writing archive")
+	}
+	if written != size {
+		return errors.Errorf("wrote %d bytes of archive, expected %d", written, size)
+	}
+	return errors.Trace(os.Rename(tmp.Name(), path))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *localStorage) RemoveFile(id string) error {
+	err := os.Remove(s.path(id))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("backup archive %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close implements filestorage.RawFileStorage.
+func (s *localStorage) Close() error {
+	return nil
+}
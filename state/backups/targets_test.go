@@ -0,0 +1,221 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type localStorageSuite struct {
+	testing.BaseSuite
+	dir  string
+	stor filestorage.RawFileStorage
+}
+
+var _ = gc.Suite(&localStorageSuite{})
+
+func (s *localStorageSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "backups")
+	var err error
+	s.stor, err = backups.NewLocalStorage(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *localStorageSuite) TestAddFile(c *gc.C) {
+	err := s.stor.AddFile("some-id", bytes.NewBufferString("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, "some-id"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+	info, err := os.Stat(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0700))
+}
+
+func (s *localStorageSuite) TestAddFileAlreadyExists(c *gc.C) {
+	err := s.stor.AddFile("some-id", bytes.NewBufferString("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.stor.AddFile("some-id", bytes.NewBufferString("<archive>"), 9)
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *localStorageSuite) TestAddFileShort(c *gc.C) {
+	err := s.stor.AddFile("some-id", bytes.NewBufferString("<arch"), 9)
+	c.Check(err, gc.ErrorMatches, "wrote 5 bytes of archive, expected 9")
+
+	entries, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+func (s *localStorageSuite) TestFile(c *gc.C) {
+	err := s.stor.AddFile("some-id", bytes.NewBufferString("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	file, err := s.stor.File("some-id")
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	data, err := ioutil.ReadAll(file)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+}
+
+func (s *localStorageSuite) TestFileNotFound(c *gc.C) {
+	_, err := s.stor.File("some-id")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *localStorageSuite) TestRemoveFile(c *gc.C) {
+	err := s.stor.AddFile("some-id", bytes.NewBufferString("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.stor.RemoveFile("some-id")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.stor.RemoveFile("some-id")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type targetStorageSuite struct {
+	statetesting.StateSuite
+	dir string
+	db  backups.DB
+}
+
+var _ = gc.Suite(&targetStorageSuite{})
+
+func (s *targetStorageSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.db = struct {
+		*state.State
+		*state.Model
+	}{s.State, s.Model}
+}
+
+func (s *targetStorageSuite) useTarget(c *gc.C, target string) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupStorage:          target,
+		controller.BackupStorageDirectory: s.dir,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *targetStorageSuite) add(c *gc.C, stor filestorage.FileStorage) string {
+	meta, err := backups.NewMetadataState(s.db, "0", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	meta.Raw.Size = 9
+	id, err := stor.Add(meta, bytes.NewBufferString("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	return id
+}
+
+func (s *targetStorageSuite) checkArchive(c *gc.C, stor filestorage.FileStorage, id, target string) {
+	meta, file, err := stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	c.Check(meta.(*backups.Metadata).StorageTarget, gc.Equals, target)
+	data, err := ioutil.ReadAll(file)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+}
+
+func (s *targetStorageSuite) TestControllerTarget(c *gc.C) {
+	stor := backups.NewStorage(s.db)
+	defer stor.Close()
+
+	id := s.add(c, stor)
+	s.checkArchive(c, stor, id, backups.ControllerStorageTarget)
+	entries, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+func (s *targetStorageSuite) TestLocalTarget(c *gc.C) {
+	s.useTarget(c, backups.LocalStorageTarget)
+	stor := backups.NewStorage(s.db)
+	defer stor.Close()
+
+	id := s.add(c, stor)
+	s.checkArchive(c, stor, id, backups.LocalStorageTarget)
+	c.Check(filepath.Join(s.dir, id), jc.IsNonEmptyFile)
+
+	err := stor.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(filepath.Join(s.dir, id), jc.DoesNotExist)
+}
+
+func (s *targetStorageSuite) TestTargetChanged(c *gc.C) {
+	s.useTarget(c, backups.LocalStorageTarget)
+	stor := backups.NewStorage(s.db)
+	defer stor.Close()
+	localID := s.add(c, stor)
+
+	// Archives are still found after the target is changed.
+	s.useTarget(c, backups.ControllerStorageTarget)
+	s.checkArchive(c, stor, localID, backups.LocalStorageTarget)
+}
+
+func (s *targetStorageSuite) TestTargetFailure(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupStorage:     backups.S3StorageTarget,
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3Bucket:    "juju",
+		controller.BackupS3AccessKey: "access-key",
+		controller.BackupS3SecretKey: "secret-key",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(backups.NewTargetStorage, func(target string, _ controller.Config, _ func() (string, error)) (filestorage.RawFileStorage, error) {
+		return nil, errors.Errorf("no %s here", target)
+	})
+	stor := backups.NewStorage(s.db)
+	defer stor.Close()
+
+	meta, err := backups.NewMetadataState(s.db, "0", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	meta.Raw.Size = 9
+	_, err = stor.Add(meta, bytes.NewBufferString("<archive>"))
+	c.Check(err, gc.ErrorMatches, ".*cannot open s3 backup storage: no s3 here")
+
+	// The metadata isn't kept if the archive can't be stored.
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(list, gc.HasLen, 0)
+}
+
+func (s *targetStorageSuite) TestFailedBackup(c *gc.C) {
+	stor := backups.NewStorage(s.db)
+	defer stor.Close()
+
+	meta, err := backups.NewMetadataState(s.db, "0", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	meta.Failure = "disk full"
+	id, err := stor.Add(meta, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stored, err := stor.Metadata(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stored.(*backups.Metadata).Failure, gc.Equals, "disk full")
+	c.Check(stored.Stored(), gc.IsNil)
+	_, _, err = stor.Get(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	err = stor.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
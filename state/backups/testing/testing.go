@@ -4,12 +4,15 @@
 package testing
 
 import (
+	"bytes"
 	"crypto/sha1"
 	"encoding/base64"
 	"io"
 	"os"
 
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
 	gc "gopkg.in/check.v1"
 )
 
@@ -20,3 +23,30 @@ func SHA1SumFile(c *gc.C, file *os.File) string {
 	c.Assert(err, jc.ErrorIsNil)
 	return base64.StdEncoding.EncodeToString(shahash.Sum(nil))
 }
+
+// NewEncryptionKeys returns a new ASCII-armored OpenPGP key pair for
+// encrypting and decrypting backup archives.
+func NewEncryptionKeys(c *gc.C) (publicKey, privateKey string) {
+	entity, err := openpgp.NewEntity("juju-backup", "", "backup@example.com", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	// Keys are only usable for encryption if they prefer a hash
+	// that's compiled in.
+	for _, id := range entity.Identities {
+		id.SelfSignature.PreferredHash = []uint8{8} // SHA256
+	}
+
+	var private bytes.Buffer
+	w, err := armor.Encode(&private, openpgp.PrivateKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	// SerializePrivate signs the identities again, so do it first.
+	c.Assert(entity.SerializePrivate(w, nil), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	var public bytes.Buffer
+	w, err = armor.Encode(&public, openpgp.PublicKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Serialize(w), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	return public.String(), private.String()
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	jujucontroller "github.com/juju/juju/controller"
+)
+
+// backupS3SecretsKey is the key for the controllers document holding
+// the secret key used by the "s3" backup storage target. It is kept
+// apart from the controller settings, which any controller user can
+// read.
+const backupS3SecretsKey = "backupS3Secrets"
+
+type backupS3SecretsDoc struct {
+	SecretKey string `bson:"secret-key"`
+}
+
+// BackupS3SecretKey returns the secret key used to authenticate with
+// the object store of the "s3" backup storage target, or "" if none
+// has been set.
+func (st *State) BackupS3SecretKey() (string, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc backupS3SecretsDoc
+	err := controllers.FindId(backupS3SecretsKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Annotate(err, "cannot get backup s3 secret key")
+	}
+	return doc.SecretKey, nil
+}
+
+// setBackupS3SecretKeyOps returns the operations that store the secret
+// key used by the "s3" backup storage target.
+func (st *State) setBackupS3SecretKeyOps(secretKey string) ([]txn.Op, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	n, err := controllers.FindId(backupS3SecretsKey).Count()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n == 0 {
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     backupS3SecretsKey,
+			Assert: txn.DocMissing,
+			Insert: &backupS3SecretsDoc{SecretKey: secretKey},
+		}}, nil
+	}
+	return []txn.Op{{
+		C:      controllersC,
+		Id:     backupS3SecretsKey,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"secret-key", secretKey}}}},
+	}}, nil
+}
+
+// splitBackupSecrets returns a copy of the controller config attributes
+// without the backup s3 secret key, and the key if it was present.
+func splitBackupSecrets(attrs map[string]interface{}) (map[string]interface{}, string, bool) {
+	secretKey, ok := attrs[jujucontroller.BackupS3SecretKey]
+	if !ok {
+		return attrs, "", false
+	}
+	result := make(map[string]interface{}, len(attrs)-1)
+	for k, v := range attrs {
+		if k != jujucontroller.BackupS3SecretKey {
+			result[k] = v
+		}
+	}
+	s, _ := secretKey.(string)
+	return result, s, true
+}
+
+// checkBackupStorage checks that the backup storage target configured
+// in cfg can be used by this controller.
+func (st *State) checkBackupStorage(cfg jujucontroller.Config, secretKey string) error {
+	switch cfg.BackupStorage() {
+	case "local":
+		// Archives in local storage can only be read by the
+		// controller machine that created them.
+		info, err := st.ControllerInfo()
+		if err != nil {
+			return errors.Annotate(err, "cannot get controller info")
+		}
+		if len(info.MachineIds) > 1 {
+			return errors.New("local backup storage cannot be used by a controller with more than one machine")
+		}
+	case "s3":
+		if secretKey == "" {
+			return errors.Errorf("%s must be set for s3 backup storage", jujucontroller.BackupS3SecretKey)
+		}
+	}
+	return nil
+}
@@ -19,6 +19,10 @@ type CloudService interface {
 
 	// Addresses returns the service addresses.
 	Addresses() []network.Address
+
+	// Hostnames returns the external hostnames, or addresses, at
+	// which the cloud's load balancers expose the service.
+	Hostnames() []string
 }
 
 // cloudService is an implementation of CloudService.
@@ -34,6 +38,7 @@ type cloudServiceDoc struct {
 
 	ProviderId string    `bson:"provider-id"`
 	Addresses  []address `bson:"addresses"`
+	Hostnames  []string  `bson:"hostnames,omitempty"`
 }
 
 // Id implements CloudService.
@@ -51,6 +56,11 @@ func (c *cloudService) Addresses() []network.Address {
 	return networkAddresses(c.doc.Addresses)
 }
 
+// Hostnames implements CloudService.
+func (c *cloudService) Hostnames() []string {
+	return c.doc.Hostnames
+}
+
 func (a *Application) cloudService() (*cloudServiceDoc, error) {
 	coll, closer := a.st.db().GetCollection(cloudServicesC)
 	defer closer()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/txn"
 
 	jujucontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/network"
@@ -97,6 +98,27 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 		return errors.Trace(err)
 	}
 
+	// The backup s3 secret key is not stored with the rest of the
+	// controller config.
+	updateAttrs, secretKey, setSecretKey := splitBackupSecrets(updateAttrs)
+	var secretOps []txn.Op
+	for _, r := range removeAttrs {
+		if r == jujucontroller.BackupS3SecretKey {
+			secretKey, setSecretKey = "", true
+		}
+	}
+	if setSecretKey {
+		var err error
+		if secretOps, err = st.setBackupS3SecretKeyOps(secretKey); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		var err error
+		if secretKey, err = st.BackupS3SecretKey(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	settings, err := readSettings(st.db(), controllersC, controllerSettingsGlobalKey)
 	if err != nil {
 		return errors.Annotatef(err, "controller %q", st.ControllerUUID())
@@ -108,7 +130,7 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 
 	// Ensure the resulting config is still valid.
 	newValues := settings.Map()
-	_, err = jujucontroller.NewConfig(
+	newConfig, err := jujucontroller.NewConfig(
 		newValues[jujucontroller.ControllerUUIDKey].(string),
 		newValues[jujucontroller.CACertKey].(string),
 		newValues,
@@ -116,8 +138,15 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := st.checkBackupStorage(newConfig, secretKey); err != nil {
+		return errors.Trace(err)
+	}
 
 	_, ops := settings.settingsUpdateOps()
+	ops = append(ops, secretOps...)
+	if len(ops) == 0 {
+		return nil
+	}
 	return errors.Trace(settings.write(ops))
 }
 
@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
@@ -36,6 +37,19 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.JujuHASpace,
 		controller.JujuManagementSpace,
 		controller.AuditLogExcludeMethods,
+		controller.AuditLogSyslogForward,
+		controller.AuditLogWebhookURL,
+		controller.AuditLogBufferSize,
+		controller.BackupSchedule,
+		controller.BackupKeepDaily,
+		controller.BackupKeepWeekly,
+		controller.BackupStorage,
+		controller.BackupStorageDirectory,
+		controller.BackupS3Endpoint,
+		controller.BackupS3Region,
+		controller.BackupS3Bucket,
+		controller.BackupS3AccessKey,
+		controller.BackupS3SecretKey,
 		controller.MaxPruneTxnBatchSize,
 		controller.MaxPruneTxnPasses,
 		controller.PruneTxnQueryCount,
@@ -117,6 +131,50 @@ func (s *ControllerSuite) TestUpdateControllerConfigRemoveYieldsDefaults(c *gc.C
 	c.Assert(newCfg.AuditLogCaptureArgs(), gc.Equals, false)
 }
 
+func (s *ControllerSuite) TestUpdateControllerConfigBackupS3SecretKey(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3Bucket:    "juju",
+		controller.BackupS3AccessKey: "access-key",
+		controller.BackupS3SecretKey: "secret-key",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The secret key is stored apart from the controller config.
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupStorage(), gc.Equals, "s3")
+	_, ok := cfg[controller.BackupS3SecretKey]
+	c.Check(ok, jc.IsFalse)
+	secretKey, err := s.State.BackupS3SecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secretKey, gc.Equals, "secret-key")
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupS3SecretKey: "new-secret-key",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	secretKey, err = s.State.BackupS3SecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secretKey, gc.Equals, "new-secret-key")
+
+	// The s3 target can't be used without it.
+	err = s.State.UpdateControllerConfig(nil, []string{controller.BackupS3SecretKey})
+	c.Assert(err, gc.ErrorMatches, "backup-s3-secret-key must be set for s3 backup storage")
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigLocalBackupStorageHA(c *gc.C) {
+	_, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupStorage:          "local",
+		controller.BackupStorageDirectory: "/var/lib/juju/backups",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "local backup storage cannot be used by a controller with more than one machine")
+}
+
 func (s *ControllerSuite) TestUpdateControllerConfigRejectsDisallowedUpdates(c *gc.C) {
 	// Sanity check.
 	c.Assert(controller.AllowedUpdateConfigAttributes.Contains(controller.APIPort), jc.IsFalse)
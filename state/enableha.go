@@ -11,6 +11,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 )
@@ -104,6 +105,17 @@ func (st *State) EnableHA(
 	if numControllers > replicaset.MaxPeers {
 		return ControllersChanges{}, errors.Errorf("controller count is too large (allowed %d)", replicaset.MaxPeers)
 	}
+	if numControllers != 1 {
+		// Archives in local backup storage can only be read by
+		// the controller machine that created them.
+		cfg, err := st.ControllerConfig()
+		if err != nil {
+			return ControllersChanges{}, errors.Trace(err)
+		}
+		if cfg.BackupStorage() == "local" {
+			return ControllersChanges{}, errors.Errorf("cannot enable HA with local backup storage: change %s first", controller.BackupStorage)
+		}
+	}
 	var change ControllersChanges
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		currentInfo, err := st.ControllerInfo()
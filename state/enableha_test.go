@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -252,6 +253,17 @@ func (s *EnableHASuite) TestEnableHAGoesToNextOdd(c *gc.C) {
 	s.assertControllerInfo(c, []string{"2", "3", "4", "5", "6"}, []string{"2", "3", "4", "5", "6"}, nil)
 }
 
+func (s *EnableHASuite) TestEnableHALocalBackupStorage(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupStorage:          "local",
+		controller.BackupStorageDirectory: "/var/lib/juju/backups",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, gc.ErrorMatches, "cannot enable HA with local backup storage: change backup-storage first")
+}
+
 func (s *EnableHASuite) TestEnableHAConcurrentSame(c *gc.C) {
 	defer state.SetBeforeHooks(c, s.State, func() {
 		changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
@@ -47,6 +47,9 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+
+	MaxActionMessages      = maxActionMessages
+	MaxActionMessageLength = maxActionMessageLength
 )
 
 var (
@@ -296,6 +299,7 @@ func addCharm(c *gc.C, st *State, series string, ch charm.Charm) *Charm {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	auditLogSize = auditLogSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
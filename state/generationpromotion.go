@@ -0,0 +1,355 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// GenerationPromotionStatus describes the progress of the automatic
+// promotion of a generation.
+type GenerationPromotionStatus string
+
+const (
+	// PromotionRunning means units are still being moved into the
+	// generation.
+	PromotionRunning GenerationPromotionStatus = "running"
+
+	// PromotionCompleted means every unit was moved into the
+	// generation and it was made current.
+	PromotionCompleted GenerationPromotionStatus = "completed"
+
+	// PromotionRolledBack means a unit in the generation became
+	// unhealthy, so every unit was moved back to the current
+	// generation and the generation was cancelled.
+	PromotionRolledBack GenerationPromotionStatus = "rolled-back"
+)
+
+// GenerationPromotionPolicy describes how the controller moves units
+// into a generation automatically.
+type GenerationPromotionPolicy struct {
+	// Percent is the percentage of each application's units that is
+	// moved into the generation in each batch.
+	Percent int
+
+	// SoakTime is how long each batch must stay healthy before the
+	// next batch is moved.
+	SoakTime time.Duration
+
+	// RequireActive, if true, requires the workloads of the units in
+	// the generation to be active by the end of each soak time. Units
+	// in error are never healthy.
+	RequireActive bool
+}
+
+// Validate returns an error if the policy is not valid.
+func (p GenerationPromotionPolicy) Validate() error {
+	if p.Percent < 1 || p.Percent > 100 {
+		return errors.NotValidf("promotion percentage %d", p.Percent)
+	}
+	if p.SoakTime < 0 {
+		return errors.NotValidf("negative soak time")
+	}
+	return nil
+}
+
+// GenerationPromotion describes the automatic promotion of a
+// generation.
+type GenerationPromotion struct {
+	GenerationPromotionPolicy
+
+	Status GenerationPromotionStatus
+
+	// BatchStarted is when the latest batch of units was moved into
+	// the generation, or zero if none has been moved yet.
+	BatchStarted time.Time
+
+	// Message describes why the promotion was rolled back.
+	Message string
+}
+
+// generationPromotionDoc is stored on the generation doc while the
+// controller promotes it.
+type generationPromotionDoc struct {
+	Percent       int                       `bson:"percent"`
+	SoakTime      int64                     `bson:"soak-time"`
+	RequireActive bool                      `bson:"require-active"`
+	Status        GenerationPromotionStatus `bson:"status"`
+	BatchStarted  int64                     `bson:"batch-started"`
+	Message       string                    `bson:"message,omitempty"`
+}
+
+// Promotion returns the automatic promotion of the generation, and
+// whether one was started.
+func (g *Generation) Promotion() (GenerationPromotion, bool) {
+	doc := g.doc.Promotion
+	if doc == nil {
+		return GenerationPromotion{}, false
+	}
+	promotion := GenerationPromotion{
+		GenerationPromotionPolicy: GenerationPromotionPolicy{
+			Percent:       doc.Percent,
+			SoakTime:      time.Duration(doc.SoakTime),
+			RequireActive: doc.RequireActive,
+		},
+		Status:  doc.Status,
+		Message: doc.Message,
+	}
+	if doc.BatchStarted != 0 {
+		promotion.BatchStarted = time.Unix(0, doc.BatchStarted).UTC()
+	}
+	return promotion, true
+}
+
+// StartPromotion asks the controller to move the units of the
+// applications with changes in the generation into it in batches,
+// according to the policy, and to make the generation current once
+// they are all in it. If a unit in the generation becomes unhealthy,
+// the controller moves every unit back and cancels the generation.
+func (g *Generation) StartPromotion(policy GenerationPromotionPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Annotate(err, "cannot start generation promotion")
+	}
+	doc := &generationPromotionDoc{
+		Percent:       policy.Percent,
+		SoakTime:      int64(policy.SoakTime),
+		RequireActive: policy.RequireActive,
+		Status:        PromotionRunning,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if g.IsCompleted() {
+			return nil, errors.New("generation has been completed")
+		}
+		if g.doc.Promotion != nil {
+			return nil, errors.New("generation is already being promoted")
+		}
+		if len(g.doc.AssignedUnits) == 0 {
+			return nil, errors.New("generation has no changes to promote")
+		}
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.Id,
+			Assert: bson.D{{"completed", 0}, {"promotion", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"promotion", doc}}}},
+		}}, nil
+	}
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot start generation promotion")
+	}
+	g.doc.Promotion = doc
+	return nil
+}
+
+// AdvancePromotion checks the health of the units in the generation.
+// If any is unhealthy, every unit is moved back to the current
+// generation and the generation is cancelled. Otherwise, once the
+// latest batch has soaked, the next batch of units is moved into the
+// generation, or the generation is made current if every unit is in
+// it. Only one agent may advance a given promotion.
+func (g *Generation) AdvancePromotion() error {
+	doc := g.doc.Promotion
+	if doc == nil || doc.Status != PromotionRunning {
+		return nil
+	}
+	if g.IsCompleted() {
+		// The generation was completed by other means, or by us
+		// before the promotion could be marked completed.
+		return errors.Trace(g.setPromotionStatus(PromotionCompleted))
+	}
+	now := g.st.clock().Now()
+	soaked := now.Sub(time.Unix(0, doc.BatchStarted)) >= time.Duration(doc.SoakTime)
+
+	unhealthy, err := g.unhealthyUnits(doc.RequireActive && soaked)
+	if err != nil {
+		return errors.Annotate(err, "cannot advance generation promotion")
+	}
+	if len(unhealthy) > 0 {
+		message := fmt.Sprintf("units unhealthy: %s", strings.Join(unhealthy, ", "))
+		return errors.Annotate(g.rollBackPromotion(message), "cannot roll back generation promotion")
+	}
+	if doc.BatchStarted != 0 && !soaked {
+		return nil
+	}
+
+	batch, err := g.nextPromotionBatch(doc.Percent)
+	if err != nil {
+		return errors.Annotate(err, "cannot advance generation promotion")
+	}
+	if len(batch) == 0 {
+		if err := g.MakeCurrent(); err != nil {
+			return errors.Annotate(err, "cannot complete generation promotion")
+		}
+		return errors.Trace(g.setPromotionStatus(PromotionCompleted))
+	}
+
+	ops := []txn.Op{{
+		C:      generationsC,
+		Id:     g.doc.Id,
+		Assert: bson.D{{"completed", 0}, {"promotion.status", PromotionRunning}},
+		Update: bson.D{{"$set", bson.D{{"promotion.batch-started", now.UnixNano()}}}},
+	}}
+	for _, unitName := range batch {
+		appName, _ := names.UnitApplication(unitName)
+		ops = append(ops, assignGenerationUnitTxnOps(g.doc.Id, appName, unitName)...)
+	}
+	if err := g.st.db().RunTransaction(ops); err != nil {
+		err = onAbort(err, errors.New("generation changed concurrently"))
+		return errors.Annotate(err, "cannot advance generation promotion")
+	}
+	logger.Infof("generation %q promotion moved %s into the next generation", g.doc.Id, strings.Join(batch, ", "))
+	return errors.Trace(g.Refresh())
+}
+
+// unhealthyUnits returns the names of the units in the generation that
+// are in error, or that don't have an active workload if requireActive
+// is true. Units that have been removed are ignored.
+func (g *Generation) unhealthyUnits(requireActive bool) ([]string, error) {
+	var unhealthy []string
+	for _, unitNames := range g.doc.AssignedUnits {
+		for _, unitName := range unitNames {
+			unit, err := g.st.Unit(unitName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			agentStatus, err := unit.AgentStatus()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			workloadStatus, err := unit.Status()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			switch {
+			case agentStatus.Status == status.Error, workloadStatus.Status == status.Error:
+			case requireActive && workloadStatus.Status != status.Active:
+			default:
+				continue
+			}
+			unhealthy = append(unhealthy, unitName)
+		}
+	}
+	naturalsort.Sort(unhealthy)
+	return unhealthy, nil
+}
+
+// nextPromotionBatch returns the units to move into the generation
+// next: the given percentage of each application's units, rounded up,
+// from those not yet in it.
+func (g *Generation) nextPromotionBatch(percent int) ([]string, error) {
+	appNames := make([]string, 0, len(g.doc.AssignedUnits))
+	for appName := range g.doc.AssignedUnits {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+
+	var batch []string
+	for _, appName := range appNames {
+		unitNames, err := appUnitNames(g.st, appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		naturalsort.Sort(unitNames)
+		size := (len(unitNames)*percent + 99) / 100
+		assigned := set.NewStrings(g.doc.AssignedUnits[appName]...)
+		for _, unitName := range unitNames {
+			if size == 0 {
+				break
+			}
+			if assigned.Contains(unitName) {
+				continue
+			}
+			batch = append(batch, unitName)
+			size--
+		}
+	}
+	return batch, nil
+}
+
+// rollBackPromotion moves every unit in the generation back to the
+// current generation, records why, and cancels the generation.
+func (g *Generation) rollBackPromotion(message string) error {
+	unassign := bson.D{{"promotion.status", PromotionRolledBack}, {"promotion.message", message}}
+	for appName := range g.doc.AssignedUnits {
+		unassign = append(unassign, bson.DocElem{Name: fmt.Sprintf("assigned-units.%s", appName), Value: []string{}})
+	}
+	ops := []txn.Op{{
+		C:      generationsC,
+		Id:     g.doc.Id,
+		Assert: bson.D{{"completed", 0}, {"promotion.status", PromotionRunning}},
+		Update: bson.D{{"$set", unassign}},
+	}}
+	if err := g.st.db().RunTransaction(ops); err != nil {
+		return errors.Trace(onAbort(err, errors.New("generation changed concurrently")))
+	}
+	logger.Warningf("generation %q promotion rolled back: %s", g.doc.Id, message)
+	if err := g.Refresh(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(g.MakeCurrent())
+}
+
+func (g *Generation) setPromotionStatus(promotionStatus GenerationPromotionStatus) error {
+	ops := []txn.Op{{
+		C:      generationsC,
+		Id:     g.doc.Id,
+		Assert: bson.D{{"promotion.status", PromotionRunning}},
+		Update: bson.D{{"$set", bson.D{{"promotion.status", promotionStatus}}}},
+	}}
+	if err := g.st.db().RunTransaction(ops); err != nil {
+		return errors.Trace(onAbort(err, errors.New("generation changed concurrently")))
+	}
+	return errors.Trace(g.Refresh())
+}
+
+// GenerationKey identifies a generation across all models.
+type GenerationKey struct {
+	ModelUUID string
+	Id        string
+}
+
+// WatchGenerations returns a NotifyWatcher that triggers when the
+// generations of any model change. It is intended to be used by the
+// controller worker that promotes them.
+func (st *State) WatchGenerations() NotifyWatcher {
+	return newNotifyCollWatcher(st, generationsC, nil)
+}
+
+// PromotingGenerations returns the generations in all models that are
+// being promoted by the controller.
+func (st *State) PromotingGenerations() ([]GenerationKey, error) {
+	generations, closer := st.db().GetRawCollection(generationsC)
+	defer closer()
+
+	// Completed generations are included so that a promotion whose
+	// generation was completed elsewhere can be marked completed too.
+	var docs []generationDoc
+	query := bson.D{{"promotion.status", PromotionRunning}}
+	if err := generations.Find(query).Select(bson.D{{"model-uuid", 1}, {"generation-id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get promoting generations")
+	}
+	result := make([]GenerationKey, len(docs))
+	for i, doc := range docs {
+		result[i] = GenerationKey{ModelUUID: doc.ModelUUID, Id: doc.Id}
+	}
+	return result, nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type generationPromotionSuite struct {
+	ConnSuite
+	clock *testclock.Clock
+	gen   *state.Generation
+}
+
+var _ = gc.Suite(&generationPromotionSuite{})
+
+func (s *generationPromotionSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(testing.NonZeroTime())
+	c.Assert(s.State.SetClockForTesting(s.clock), jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "riak")
+	riak := s.AddTestingApplication(c, "riak", ch)
+	for i := 0; i < 4; i++ {
+		_, err := riak.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		s.setWorkloadStatus(c, fmt.Sprintf("riak/%d", i), status.Active)
+	}
+	c.Assert(s.Model.AddGeneration(), jc.ErrorIsNil)
+
+	gen, err := s.Model.NextGeneration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.AssignApplication("riak"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	s.gen = gen
+}
+
+func (s *generationPromotionSuite) setWorkloadStatus(c *gc.C, unitName string, workloadStatus status.Status) {
+	unit, err := s.State.Unit(unitName)
+	c.Assert(err, jc.ErrorIsNil)
+	now := s.clock.Now()
+	err = unit.SetStatus(status.StatusInfo{Status: workloadStatus, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *generationPromotionSuite) start(c *gc.C, policy state.GenerationPromotionPolicy) {
+	c.Assert(s.gen.StartPromotion(policy), jc.ErrorIsNil)
+}
+
+func (s *generationPromotionSuite) advance(c *gc.C) {
+	c.Assert(s.gen.AdvancePromotion(), jc.ErrorIsNil)
+	c.Assert(s.gen.Refresh(), jc.ErrorIsNil)
+}
+
+func (s *generationPromotionSuite) promotionStatus(c *gc.C) state.GenerationPromotionStatus {
+	promotion, ok := s.gen.Promotion()
+	c.Assert(ok, jc.IsTrue)
+	return promotion.Status
+}
+
+func (s *generationPromotionSuite) TestStartPromotion(c *gc.C) {
+	_, ok := s.gen.Promotion()
+	c.Check(ok, jc.IsFalse)
+
+	policy := state.GenerationPromotionPolicy{Percent: 25, SoakTime: time.Minute, RequireActive: true}
+	s.start(c, policy)
+	c.Assert(s.gen.Refresh(), jc.ErrorIsNil)
+	promotion, ok := s.gen.Promotion()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(promotion, jc.DeepEquals, state.GenerationPromotion{
+		GenerationPromotionPolicy: policy,
+		Status:                    state.PromotionRunning,
+	})
+
+	err := s.gen.StartPromotion(policy)
+	c.Check(err, gc.ErrorMatches, "cannot start generation promotion: generation is already being promoted")
+}
+
+func (s *generationPromotionSuite) TestStartPromotionInvalid(c *gc.C) {
+	for _, test := range []struct {
+		policy state.GenerationPromotionPolicy
+		err    string
+	}{{
+		policy: state.GenerationPromotionPolicy{},
+		err:    "cannot start generation promotion: promotion percentage 0 not valid",
+	}, {
+		policy: state.GenerationPromotionPolicy{Percent: 101},
+		err:    "cannot start generation promotion: promotion percentage 101 not valid",
+	}, {
+		policy: state.GenerationPromotionPolicy{Percent: 50, SoakTime: -time.Second},
+		err:    "cannot start generation promotion: negative soak time not valid",
+	}} {
+		c.Check(s.gen.StartPromotion(test.policy), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *generationPromotionSuite) TestStartPromotionNoChanges(c *gc.C) {
+	c.Assert(s.gen.MakeCurrent(), jc.ErrorIsNil)
+	c.Assert(s.Model.AddGeneration(), jc.ErrorIsNil)
+	gen, err := s.Model.NextGeneration()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = gen.StartPromotion(state.GenerationPromotionPolicy{Percent: 50})
+	c.Check(err, gc.ErrorMatches, "cannot start generation promotion: generation has no changes to promote")
+}
+
+func (s *generationPromotionSuite) TestAdvancePromotionBatches(c *gc.C) {
+	s.start(c, state.GenerationPromotionPolicy{Percent: 50, SoakTime: time.Minute})
+
+	s.advance(c)
+	c.Check(s.gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1"})
+	promotion, _ := s.gen.Promotion()
+	c.Check(promotion.BatchStarted.Equal(s.clock.Now()), jc.IsTrue)
+
+	// The batch hasn't soaked yet.
+	s.clock.Advance(30 * time.Second)
+	s.advance(c)
+	c.Check(s.gen.AssignedUnits()["riak"], gc.HasLen, 2)
+
+	s.clock.Advance(30 * time.Second)
+	s.advance(c)
+	c.Check(s.gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1", "riak/2", "riak/3"})
+	c.Check(s.gen.IsCompleted(), jc.IsFalse)
+
+	s.clock.Advance(time.Minute)
+	s.advance(c)
+	c.Check(s.gen.IsCompleted(), jc.IsTrue)
+	c.Check(s.promotionStatus(c), gc.Equals, state.PromotionCompleted)
+}
+
+func (s *generationPromotionSuite) TestAdvancePromotionRollsBackOnError(c *gc.C) {
+	s.start(c, state.GenerationPromotionPolicy{Percent: 25, SoakTime: time.Minute})
+	s.advance(c)
+	c.Check(s.gen.AssignedUnits()["riak"], jc.DeepEquals, []string{"riak/0"})
+
+	// Units not in the generation don't affect its health.
+	s.setWorkloadStatus(c, "riak/3", status.Error)
+	s.advance(c)
+	c.Check(s.promotionStatus(c), gc.Equals, state.PromotionRunning)
+
+	s.setWorkloadStatus(c, "riak/0", status.Error)
+	s.advance(c)
+	c.Check(s.gen.AssignedUnits()["riak"], gc.HasLen, 0)
+	c.Check(s.gen.IsCompleted(), jc.IsTrue)
+	promotion, _ := s.gen.Promotion()
+	c.Check(promotion.Status, gc.Equals, state.PromotionRolledBack)
+	c.Check(promotion.Message, gc.Equals, "units unhealthy: riak/0")
+}
+
+func (s *generationPromotionSuite) TestAdvancePromotionSkipsRemovedUnits(c *gc.C) {
+	s.start(c, state.GenerationPromotionPolicy{Percent: 50, SoakTime: time.Minute, RequireActive: true})
+	s.advance(c)
+	c.Check(s.gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1"})
+
+	unit, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Destroy(), jc.ErrorIsNil)
+	_, err = s.State.Unit("riak/0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.clock.Advance(time.Minute)
+	s.advance(c)
+	c.Check(s.promotionStatus(c), gc.Equals, state.PromotionRunning)
+	c.Check(s.gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1", "riak/2", "riak/3"})
+}
+
+func (s *generationPromotionSuite) TestAdvancePromotionRequireActive(c *gc.C) {
+	s.start(c, state.GenerationPromotionPolicy{Percent: 25, SoakTime: time.Minute, RequireActive: true})
+	s.advance(c)
+
+	// The workload may settle during the soak time.
+	s.setWorkloadStatus(c, "riak/0", status.Maintenance)
+	s.advance(c)
+	c.Check(s.promotionStatus(c), gc.Equals, state.PromotionRunning)
+
+	s.clock.Advance(time.Minute)
+	s.advance(c)
+	c.Check(s.promotionStatus(c), gc.Equals, state.PromotionRolledBack)
+	c.Check(s.gen.IsCompleted(), jc.IsTrue)
+}
+
+func (s *generationPromotionSuite) TestAdvancePromotionCompletedElsewhere(c *gc.C) {
+	s.start(c, state.GenerationPromotionPolicy{Percent: 100})
+	c.Assert(s.gen.AssignAllUnits("riak"), jc.ErrorIsNil)
+	c.Assert(s.gen.Refresh(), jc.ErrorIsNil)
+	completed, err := s.gen.AutoComplete()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completed, jc.IsTrue)
+	c.Assert(s.gen.Refresh(), jc.ErrorIsNil)
+
+	s.advance(c)
+	c.Check(s.promotionStatus(c), gc.Equals, state.PromotionCompleted)
+}
+
+func (s *generationPromotionSuite) TestPromotingGenerations(c *gc.C) {
+	promoting, err := s.State.PromotingGenerations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(promoting, gc.HasLen, 0)
+
+	s.start(c, state.GenerationPromotionPolicy{Percent: 100})
+	promoting, err = s.State.PromotingGenerations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(promoting, jc.DeepEquals, []state.GenerationKey{
+		{ModelUUID: s.State.ModelUUID(), Id: s.gen.Id()},
+	})
+}
+
+func (s *generationPromotionSuite) TestWatchGenerations(c *gc.C) {
+	w := s.State.WatchGenerations()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.start(c, state.GenerationPromotionPolicy{Percent: 50})
+	wc.AssertOneChange()
+	s.advance(c)
+	wc.AssertOneChange()
+}
+
+func (s *generationPromotionSuite) TestGeneration(c *gc.C) {
+	gen, err := s.Model.Generation(s.gen.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(gen.Id(), gc.Equals, s.gen.Id())
+
+	_, err = s.Model.Generation("42")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *generationPromotionSuite) TestLatestGeneration(c *gc.C) {
+	gen, err := s.Model.LatestGeneration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(gen.Id(), gc.Equals, s.gen.Id())
+
+	// The promotion of a completed generation can still be shown.
+	s.start(c, state.GenerationPromotionPolicy{Percent: 100})
+	s.advance(c)
+	s.advance(c)
+	c.Assert(s.gen.IsCompleted(), jc.IsTrue)
+	gen, err = s.Model.LatestGeneration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(gen.Id(), gc.Equals, s.gen.Id())
+	promotion, ok := gen.Promotion()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(promotion.Status, gc.Equals, state.PromotionCompleted)
+}
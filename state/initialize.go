@@ -198,6 +198,10 @@ func Initialize(args InitializeParams) (_ *Controller, err error) {
 		return nil, err
 	}
 
+	// The backup s3 secret key is not stored with the rest of the
+	// controller config.
+	controllerConfig, backupS3SecretKey, _ := splitBackupSecrets(args.ControllerConfig)
+
 	dateCreated := st.nowToTheSecond()
 	ops := createInitialUserOps(
 		args.ControllerConfig.ControllerUUID(),
@@ -255,8 +259,14 @@ func Initialize(args InitializeParams) (_ *Controller, err error) {
 			Assert: txn.DocMissing,
 			Insert: &hostedModelCountDoc{},
 		},
-		createSettingsOp(controllersC, controllerSettingsGlobalKey, args.ControllerConfig),
+		createSettingsOp(controllersC, controllerSettingsGlobalKey, controllerConfig),
 		createSettingsOp(globalSettingsC, controllerInheritedSettingsGlobalKey, args.ControllerInheritedConfig),
+		txn.Op{
+			C:      controllersC,
+			Id:     backupS3SecretsKey,
+			Assert: txn.DocMissing,
+			Insert: &backupS3SecretsDoc{SecretKey: backupS3SecretKey},
+		},
 	)
 	for k, v := range args.Cloud.RegionConfig {
 		// Create an entry keyed on cloudname#<key>, value for each region in
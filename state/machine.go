@@ -2125,12 +2125,7 @@ func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action
 		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
 	}
 
-	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
-	if err != nil {
-		return nil, err
-	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
+	payloadWithDefaults, err := actions.ValidateParams(name, spec, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -2637,7 +2637,7 @@ func (s *MachineSuite) TestMachineValidActions(c *gc.C) {
 	}{
 		{
 			actionName: "juju-run",
-			errString:  `validation failed: (root) : "command" property is missing and required, given {}; (root) : "timeout" property is missing and required, given {}`,
+			errString:  `invalid parameters for action "juju-run": (root) : "command" property is missing and required, given {}; (root) : "timeout" property is missing and required, given {}`,
 		},
 		{
 			actionName:      "juju-run",
@@ -1346,6 +1346,9 @@ func (e *exporter) readAllCloudServices() (map[string]*cloudServiceDoc, error) {
 	return result, nil
 }
 
+// cloudService doesn't export the load balancer hostnames, which the
+// unit provisioner records again once the service is deployed in the
+// target model.
 func (e *exporter) cloudService(doc *cloudServiceDoc) *description.CloudServiceArgs {
 	return &description.CloudServiceArgs{
 		ProviderId: doc.ProviderId,
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -33,43 +34,44 @@ import (
 	"github.com/juju/juju/tools"
 )
 
-// Import the database agnostic model representation into the database.
-func (ctrl *Controller) Import(model description.Model) (_ *Model, _ *State, err error) {
+// importModelArgs checks that the model can be imported, without
+// writing to the database, and returns the arguments to create it
+// with. If the model's cloud credential needs to be added, it is
+// returned too.
+func (ctrl *Controller) importModelArgs(model description.Model) (ModelArgs, *cloud.Credential, error) {
 	st := ctrl.pool.SystemState()
 	modelUUID := model.Tag().Id()
-	logger := loggo.GetLogger("juju.state.import-model")
-	logger.Debugf("import starting for model %s", modelUUID)
 
 	// At this stage, attempting to import a model with the same
 	// UUID as an existing model will error.
 	if modelExists, err := st.ModelExists(modelUUID); err != nil {
-		return nil, nil, errors.Trace(err)
+		return ModelArgs{}, nil, errors.Trace(err)
 	} else if modelExists {
 		// We have an existing matching model.
-		return nil, nil, errors.AlreadyExistsf("model %s", modelUUID)
+		return ModelArgs{}, nil, errors.AlreadyExistsf("model %s", modelUUID)
 	}
 
 	if len(model.RemoteApplications()) != 0 {
 		// Cross-model relations are currently limited to models on
 		// the same controller, while migration is for getting the
 		// model to a new controller.
-		return nil, nil, errors.New("can't import models with remote applications")
+		return ModelArgs{}, nil, errors.New("can't import models with remote applications")
 	}
 
 	// Unfortunately a version was released that exports v4 models
 	// with the Type field blank. Treat this as IAAS.
 	modelType := ModelTypeIAAS
 	if model.Type() != "" {
+		var err error
 		modelType, err = ParseModelType(model.Type())
 		if err != nil {
-			return nil, nil, errors.Trace(err)
+			return ModelArgs{}, nil, errors.Trace(err)
 		}
 	}
 
-	// Create the model.
 	cfg, err := config.New(config.NoDefaults, model.Config())
 	if err != nil {
-		return nil, nil, errors.Trace(err)
+		return ModelArgs{}, nil, errors.Trace(err)
 	}
 	args := ModelArgs{
 		Type:                    modelType,
@@ -81,42 +83,311 @@ func (ctrl *Controller) Import(model description.Model) (_ *Model, _ *State, err
 		EnvironVersion:          model.EnvironVersion(),
 		StorageProviderRegistry: storage.StaticProviderRegistry{},
 	}
-	if creds := model.CloudCredential(); creds != nil {
-		// Need to add credential or make sure an existing credential
-		// matches.
-		// TODO: there really should be a way to create a cloud credential
-		// tag in the names package from the cloud, owner and name.
-		credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
-		if !names.IsValidCloudCredential(credID) {
-			return nil, nil, errors.Errorf("model credentials id not valid: %q", credID)
-		}
-		credTag := names.NewCloudCredentialTag(credID)
-
-		existingCreds, err := st.CloudCredential(credTag)
-
-		if errors.IsNotFound(err) {
-			credential := cloud.NewCredential(
-				cloud.AuthType(creds.AuthType()),
-				creds.Attributes())
-			if err := st.UpdateCloudCredential(credTag, credential); err != nil {
-				return nil, nil, errors.Trace(err)
+	creds := model.CloudCredential()
+	if creds == nil {
+		return args, nil, nil
+	}
+
+	// Need to add credential or make sure an existing credential
+	// matches.
+	// TODO: there really should be a way to create a cloud credential
+	// tag in the names package from the cloud, owner and name.
+	credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+	if !names.IsValidCloudCredential(credID) {
+		return ModelArgs{}, nil, errors.Errorf("model credentials id not valid: %q", credID)
+	}
+	credTag := names.NewCloudCredentialTag(credID)
+	args.CloudCredential = credTag
+
+	existingCreds, err := st.CloudCredential(credTag)
+	if errors.IsNotFound(err) {
+		credential := cloud.NewCredential(
+			cloud.AuthType(creds.AuthType()),
+			creds.Attributes())
+		return args, &credential, nil
+	} else if err != nil {
+		return ModelArgs{}, nil, errors.Trace(err)
+	}
+	// ensure existing creds match
+	if existingCreds.AuthType != creds.AuthType() {
+		return ModelArgs{}, nil, errors.Errorf("credential auth type mismatch: %q != %q", existingCreds.AuthType, creds.AuthType())
+	}
+	if !reflect.DeepEqual(existingCreds.Attributes, creds.Attributes()) {
+		return ModelArgs{}, nil, errors.Errorf("credential attribute mismatch: %v != %v", existingCreds.Attributes, creds.Attributes())
+	}
+	if existingCreds.Revoked {
+		return ModelArgs{}, nil, errors.Errorf("credential %q is revoked", credID)
+	}
+	return args, nil, nil
+}
+
+// CheckImport checks that the model description could be imported into
+// this controller, without writing anything to the database. It covers
+// the checks made before the model is created by Import, and those
+// made when creating it: the model's cloud, region and owner must exist,
+// and no other model of the owner (or for CAAS models, the cloud) may
+// have the same name. The machines, applications, units, relations and
+// storage are checked as they would be when imported, along with the
+// entities they refer to.
+func (ctrl *Controller) CheckImport(model description.Model) error {
+	if err := model.Validate(); err != nil {
+		return errors.Annotate(err, "invalid model description")
+	}
+	args, _, err := ctrl.importModelArgs(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := args.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	st := ctrl.pool.SystemState()
+	modelCloud, err := st.Cloud(args.CloudName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if args.Type == ModelTypeIAAS {
+		region := args.CloudRegion
+		if region == "" && len(modelCloud.Regions) == 1 {
+			region = modelCloud.Regions[0].Name
+		}
+		if _, err := validateCloudRegion(modelCloud, region); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if args.Owner.IsLocal() {
+		if _, err := st.User(args.Owner); err != nil {
+			return errors.Annotate(err, "cannot create model")
+		}
+	}
+
+	name := args.Config.Name()
+	qualifierTerm := bson.DocElem{"owner", args.Owner.Id()}
+	qualifierMessage := args.Owner.Id()
+	if args.Type == ModelTypeCAAS {
+		qualifierTerm = bson.DocElem{"cloud", args.CloudName}
+		qualifierMessage = fmt.Sprintf("cloud %v", args.CloudName)
+	}
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+	modelCount, err := models.Find(bson.D{qualifierTerm, {"name", name}}).Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelCount > 0 {
+		return errors.AlreadyExistsf("model %q for %s", name, qualifierMessage)
+	}
+	return errors.Trace(newImportChecker(model, args.Type).check())
+}
+
+// importChecker checks the entities in a model description as the
+// importer would, without writing to the database.
+type importChecker struct {
+	model     description.Model
+	modelType ModelType
+	machines  set.Strings
+	units     map[string]description.Unit
+}
+
+func newImportChecker(model description.Model, modelType ModelType) *importChecker {
+	return &importChecker{
+		model:     model,
+		modelType: modelType,
+		machines:  set.NewStrings(),
+		units:     make(map[string]description.Unit),
+	}
+}
+
+func (c *importChecker) check() error {
+	for _, m := range c.model.Machines() {
+		if err := c.machine(m); err != nil {
+			return errors.Annotatef(err, "machines: %s", m.Id())
+		}
+	}
+	// The units of every application are gathered first, as
+	// subordinates and relations refer to units of other
+	// applications.
+	for _, a := range c.model.Applications() {
+		for _, u := range a.Units() {
+			c.units[u.Name()] = u
+		}
+	}
+	for _, a := range c.model.Applications() {
+		if err := c.application(a); err != nil {
+			return errors.Annotatef(err, "applications: %s", a.Name())
+		}
+	}
+	for _, r := range c.model.Relations() {
+		if err := c.relation(r); err != nil {
+			return errors.Annotatef(err, "relations: %s", r.Key())
+		}
+	}
+	return errors.Annotate(c.storage(), "storage")
+}
+
+func (c *importChecker) machine(m description.Machine) error {
+	if c.machines.Contains(m.Id()) {
+		return errors.AlreadyExistsf("machine %s", m.Id())
+	}
+	c.machines.Add(m.Id())
+	if m.Status() == nil {
+		return errors.NotValidf("missing status")
+	}
+	if inst := m.Instance(); inst == nil || inst.Status() == nil {
+		return errors.NotValidf("missing instance status")
+	}
+	if _, err := (&importer{}).makeMachineJobs(m.Jobs()); err != nil {
+		return errors.Trace(err)
+	}
+	for _, container := range m.Containers() {
+		if err := c.machine(container); err != nil {
+			return errors.Annotate(err, container.Id())
+		}
+	}
+	return nil
+}
+
+func (c *importChecker) application(a description.Application) error {
+	if _, err := charm.ParseURL(a.CharmURL()); err != nil {
+		return errors.Trace(err)
+	}
+	if a.Status() == nil {
+		return errors.NotValidf("missing status")
+	}
+	if c.modelType == ModelTypeCAAS {
+		if a.OperatorStatus() == nil {
+			return errors.NotValidf("missing operator status")
+		}
+	} else if a.PodSpec() != "" {
+		return errors.NotSupportedf("adding pod spec to IAAS model")
+	}
+	for _, u := range a.Units() {
+		if err := c.unit(a, u); err != nil {
+			return errors.Annotate(err, u.Name())
+		}
+	}
+	return nil
+}
+
+func (c *importChecker) unit(a description.Application, u description.Unit) error {
+	if !names.IsValidUnit(u.Name()) || names.UnitApplication(u.Name()) != a.Name() {
+		return errors.NotValidf("unit name %q", u.Name())
+	}
+	if u.AgentStatus() == nil {
+		return errors.NotValidf("missing agent status")
+	}
+	if u.WorkloadStatus() == nil {
+		return errors.NotValidf("missing workload status")
+	}
+	if principal := u.Principal().Id(); principal != "" {
+		if _, ok := c.units[principal]; !ok {
+			return errors.NotFoundf("principal unit %q", principal)
+		}
+	} else if c.modelType == ModelTypeIAAS && !c.machines.Contains(u.Machine().Id()) {
+		return errors.NotFoundf("machine %q", u.Machine().Id())
+	}
+	for _, sub := range u.Subordinates() {
+		if _, ok := c.units[sub.Id()]; !ok {
+			return errors.NotFoundf("subordinate unit %q", sub.Id())
+		}
+	}
+	return nil
+}
+
+func (c *importChecker) relation(rel description.Relation) error {
+	for _, ep := range rel.Endpoints() {
+		app := ep.ApplicationName()
+		if !c.hasApplication(app) {
+			return errors.NotFoundf("application %q", app)
+		}
+		// The settings of each unit in scope are imported along
+		// with the relation.
+		for unitName := range ep.AllSettings() {
+			if u, ok := c.units[unitName]; !ok || names.UnitApplication(u.Name()) != app {
+				return errors.NotFoundf("unit %q", unitName)
 			}
-		} else if err != nil {
-			return nil, nil, errors.Trace(err)
-		} else {
-			// ensure existing creds match
-			if existingCreds.AuthType != creds.AuthType() {
-				return nil, nil, errors.Errorf("credential auth type mismatch: %q != %q", existingCreds.AuthType, creds.AuthType())
+		}
+	}
+	return nil
+}
+
+func (c *importChecker) hasApplication(name string) bool {
+	for _, a := range c.model.Applications() {
+		if a.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *importChecker) storage() error {
+	storageTags := set.NewStrings()
+	for _, storage := range c.model.Storages() {
+		storageTags.Add(storage.Tag().Id())
+		if parseStorageKind(storage.Kind()) == StorageKindUnknown {
+			return errors.Errorf("storage kind %q is unknown", storage.Kind())
+		}
+		if _, err := storage.Owner(); err != nil {
+			return errors.Annotatef(err, "storage %s owner", storage.Tag().Id())
+		}
+		for _, unit := range storage.Attachments() {
+			if _, ok := c.units[unit.Id()]; !ok {
+				return errors.NotFoundf("storage %s attached to unit %q", storage.Tag().Id(), unit.Id())
+			}
+		}
+	}
+	checkHost := func(host names.Tag) error {
+		switch host := host.(type) {
+		case names.MachineTag:
+			if !c.machines.Contains(host.Id()) {
+				return errors.NotFoundf("machine %q", host.Id())
 			}
-			if !reflect.DeepEqual(existingCreds.Attributes, creds.Attributes()) {
-				return nil, nil, errors.Errorf("credential attribute mismatch: %v != %v", existingCreds.Attributes, creds.Attributes())
+		case names.UnitTag:
+			if _, ok := c.units[host.Id()]; !ok {
+				return errors.NotFoundf("unit %q", host.Id())
 			}
-			if existingCreds.Revoked {
-				return nil, nil, errors.Errorf("credential %q is revoked", credID)
+		}
+		return nil
+	}
+	for _, volume := range c.model.Volumes() {
+		if tag := volume.Storage(); tag.Id() != "" && !storageTags.Contains(tag.Id()) {
+			return errors.NotFoundf("volume %s storage %q", volume.Tag().Id(), tag.Id())
+		}
+		for _, attachment := range volume.Attachments() {
+			if err := checkHost(attachment.Host()); err != nil {
+				return errors.Annotatef(err, "volume %s", volume.Tag().Id())
+			}
+		}
+	}
+	for _, filesystem := range c.model.Filesystems() {
+		if tag := filesystem.Storage(); tag.Id() != "" && !storageTags.Contains(tag.Id()) {
+			return errors.NotFoundf("filesystem %s storage %q", filesystem.Tag().Id(), tag.Id())
+		}
+		for _, attachment := range filesystem.Attachments() {
+			if err := checkHost(attachment.Host()); err != nil {
+				return errors.Annotatef(err, "filesystem %s", filesystem.Tag().Id())
 			}
 		}
+	}
+	return nil
+}
 
-		args.CloudCredential = credTag
+// Import the database agnostic model representation into the database.
+func (ctrl *Controller) Import(model description.Model) (_ *Model, _ *State, err error) {
+	st := ctrl.pool.SystemState()
+	modelUUID := model.Tag().Id()
+	logger := loggo.GetLogger("juju.state.import-model")
+	logger.Debugf("import starting for model %s", modelUUID)
+
+	args, credential, err := ctrl.importModelArgs(model)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if credential != nil {
+		// The credential doesn't exist yet.
+		if err := st.UpdateCloudCredential(args.CloudCredential, *credential); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
 	}
 	dbModel, newSt, err := ctrl.NewModel(args)
 	if err != nil {
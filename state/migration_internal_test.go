@@ -115,6 +115,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		metricsC,
 		// Backup and restore information is not migrated.
 		restoreInfoC,
+		// The audit log is controller global, and not migrated.
+		auditLogC,
 		// reference counts are implementation details that should be
 		// reconstructed on the other side.
 		refcountsC,
@@ -138,6 +140,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		migrationsStatusC,
 		migrationsActiveC,
 		migrationsMinionSyncC,
+		migrationBatchesC,
 
 		// The container ref document is primarily there to keep track
 		// of a particular machine's containers. The migration format
@@ -166,6 +169,12 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
+		// Action schedules and running action rollouts aren't
+		// migrated; the migration prechecks refuse models with
+		// either. Finished rollouts are dropped, as the actions
+		// they enqueued are migrated.
+		actionSchedulesC,
+		actionRolloutsC,
 
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
@@ -716,6 +725,9 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Progress messages aren't migrated; the action's
+		// results are.
+		"Logs",
 	)
 	migrated := set.NewStrings(
 		"DocId",
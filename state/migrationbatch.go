@@ -0,0 +1,250 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// migrationBatchDoc tracks the models migrated together by a single
+// migrate command, of which only a limited number are migrated at a
+// time. These are written into migrationBatchesC.
+type migrationBatchDoc struct {
+	Id string `bson:"_id"`
+
+	// MaxConcurrent holds the maximum number of the batch's
+	// migrations which may be in progress at once.
+	MaxConcurrent int `bson:"max-concurrent"`
+
+	// Queued holds the UUIDs of the models whose migrations haven't
+	// been started yet, in the order they'll be started.
+	Queued []string `bson:"queued"`
+
+	// Running holds the UUIDs of the models whose migrations have
+	// been started and haven't finished yet.
+	Running []string `bson:"running"`
+
+	// Failed holds the reasons the migrations of some models
+	// couldn't be started, keyed by model UUID.
+	Failed map[string]string `bson:"failed,omitempty"`
+}
+
+// MigrationBatch represents a set of models migrated to the same
+// target controller, with a limit on how many of the migrations may
+// be in progress at once. The migrations of queued models are started
+// as the batch's earlier migrations finish.
+type MigrationBatch struct {
+	pool *StatePool
+	doc  migrationBatchDoc
+}
+
+// Id returns the batch's unique identifier.
+func (b *MigrationBatch) Id() string {
+	return b.doc.Id
+}
+
+// MaxConcurrent returns the maximum number of the batch's migrations
+// which may be in progress at once.
+func (b *MigrationBatch) MaxConcurrent() int {
+	return b.doc.MaxConcurrent
+}
+
+// Queued returns the UUIDs of the models whose migrations haven't
+// been started yet.
+func (b *MigrationBatch) Queued() []string {
+	return b.doc.Queued
+}
+
+// Running returns the UUIDs of the models whose migrations are in
+// progress.
+func (b *MigrationBatch) Running() []string {
+	return b.doc.Running
+}
+
+// Failed returns the reasons the migrations of some of the batch's
+// models couldn't be started, keyed by model UUID.
+func (b *MigrationBatch) Failed() map[string]string {
+	return b.doc.Failed
+}
+
+// Refresh reloads the batch's details from the database.
+func (b *MigrationBatch) Refresh() error {
+	doc, err := getMigrationBatchDoc(b.pool.SystemState(), b.doc.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	b.doc = doc
+	return nil
+}
+
+// StartQueued starts the migrations of as many of the batch's queued
+// models as its concurrency limit allows, returning the migrations
+// started. When a model's migration can't be started the reason is
+// recorded, and the next queued model is tried instead.
+func (b *MigrationBatch) StartQueued(spec MigrationSpec) ([]ModelMigration, error) {
+	spec.Batch = b.doc.Id
+	var started []ModelMigration
+	for {
+		modelUUID, err := b.claimQueued()
+		if err != nil {
+			return started, errors.Trace(err)
+		}
+		if modelUUID == "" {
+			return started, nil
+		}
+		mig, err := b.startMigration(modelUUID, spec)
+		if err != nil {
+			logger.Warningf("cannot start migration of model %s in batch %s: %v", modelUUID, b.doc.Id, err)
+			if err := b.setFailed(modelUUID, err.Error()); err != nil {
+				return started, errors.Trace(err)
+			}
+			continue
+		}
+		started = append(started, mig)
+	}
+}
+
+// Finished records that the identified model's migration is no longer
+// in progress, making room for the next queued migration to start.
+func (b *MigrationBatch) Finished(modelUUID string) error {
+	ops := []txn.Op{{
+		C:      migrationBatchesC,
+		Id:     b.doc.Id,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pull", bson.D{{"running", modelUUID}}}},
+	}}
+	if err := b.pool.SystemState().db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "finishing migration of model %s in batch %s", modelUUID, b.doc.Id)
+	}
+	return errors.Trace(b.Refresh())
+}
+
+// claimQueued moves the first queued model to the running list, if the
+// concurrency limit allows it, and returns its UUID. It returns "" if
+// there is nothing to start.
+func (b *MigrationBatch) claimQueued() (string, error) {
+	var modelUUID string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := b.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if len(b.doc.Queued) == 0 || len(b.doc.Running) >= b.doc.MaxConcurrent {
+			modelUUID = ""
+			return nil, jujutxn.ErrNoOperations
+		}
+		modelUUID = b.doc.Queued[0]
+		return []txn.Op{{
+			C:  migrationBatchesC,
+			Id: b.doc.Id,
+			Assert: bson.D{
+				{"queued.0", modelUUID},
+				{fmt.Sprintf("running.%d", b.doc.MaxConcurrent-1), bson.D{{"$exists", false}}},
+			},
+			Update: bson.D{
+				{"$pop", bson.D{{"queued", -1}}},
+				{"$push", bson.D{{"running", modelUUID}}},
+			},
+		}}, nil
+	}
+	if err := b.pool.SystemState().db().Run(buildTxn); err != nil {
+		return "", errors.Annotatef(err, "claiming queued migration in batch %s", b.doc.Id)
+	}
+	if modelUUID != "" {
+		b.doc.Queued = b.doc.Queued[1:]
+		b.doc.Running = append(b.doc.Running, modelUUID)
+	}
+	return modelUUID, nil
+}
+
+func (b *MigrationBatch) startMigration(modelUUID string, spec MigrationSpec) (ModelMigration, error) {
+	st, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+	mig, err := st.CreateMigration(spec)
+	return mig, errors.Trace(err)
+}
+
+// setFailed records why the identified model's migration couldn't be
+// started.
+func (b *MigrationBatch) setFailed(modelUUID, reason string) error {
+	ops := []txn.Op{{
+		C:      migrationBatchesC,
+		Id:     b.doc.Id,
+		Assert: txn.DocExists,
+		Update: bson.D{
+			{"$pull", bson.D{{"running", modelUUID}}},
+			{"$set", bson.D{{"failed." + modelUUID, reason}}},
+		},
+	}}
+	if err := b.pool.SystemState().db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "recording failed migration of model %s in batch %s", modelUUID, b.doc.Id)
+	}
+	return errors.Trace(b.Refresh())
+}
+
+// AddMigrationBatch records a batch of models to be migrated, at most
+// maxConcurrent at a time. No migrations are started; see
+// MigrationBatch.StartQueued.
+func (ctlr *Controller) AddMigrationBatch(modelUUIDs []string, maxConcurrent int) (*MigrationBatch, error) {
+	if len(modelUUIDs) == 0 {
+		return nil, errors.NotValidf("empty migration batch")
+	}
+	if maxConcurrent < 1 {
+		return nil, errors.NotValidf("max concurrent migrations %d", maxConcurrent)
+	}
+	st := ctlr.pool.SystemState()
+	seq, err := sequence(st, "migrationbatch")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := migrationBatchDoc{
+		Id:            strconv.Itoa(seq),
+		MaxConcurrent: maxConcurrent,
+		Queued:        modelUUIDs,
+		Running:       []string{},
+	}
+	ops := []txn.Op{{
+		C:      migrationBatchesC,
+		Id:     doc.Id,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(err, "adding migration batch")
+	}
+	return &MigrationBatch{pool: ctlr.pool, doc: doc}, nil
+}
+
+// MigrationBatch returns the migration batch with the given id.
+func (ctlr *Controller) MigrationBatch(id string) (*MigrationBatch, error) {
+	doc, err := getMigrationBatchDoc(ctlr.pool.SystemState(), id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MigrationBatch{pool: ctlr.pool, doc: doc}, nil
+}
+
+func getMigrationBatchDoc(st *State, id string) (migrationBatchDoc, error) {
+	coll, closer := st.db().GetCollection(migrationBatchesC)
+	defer closer()
+	var doc migrationBatchDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return doc, errors.NotFoundf("migration batch %q", id)
+	} else if err != nil {
+		return doc, errors.Annotatef(err, "reading migration batch %q", id)
+	}
+	return doc, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+)
+
+type MigrationBatchSuite struct {
+	ConnSuite
+	modelUUIDs []string
+	spec       state.MigrationSpec
+}
+
+var _ = gc.Suite(new(MigrationBatchSuite))
+
+func (s *MigrationBatchSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	s.modelUUIDs = nil
+	for i := 0; i < 3; i++ {
+		st := s.Factory.MakeModel(c, nil)
+		s.modelUUIDs = append(s.modelUUIDs, st.ModelUUID())
+		st.Close()
+	}
+	s.spec = state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5555"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("user"),
+			Password:      "password",
+		},
+	}
+}
+
+func (s *MigrationBatchSuite) TestAddMigrationBatch(c *gc.C) {
+	batch, err := s.Controller.AddMigrationBatch(s.modelUUIDs, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(batch.MaxConcurrent(), gc.Equals, 2)
+	c.Check(batch.Queued(), jc.DeepEquals, s.modelUUIDs)
+	c.Check(batch.Running(), gc.HasLen, 0)
+
+	loaded, err := s.Controller.MigrationBatch(batch.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loaded.Queued(), jc.DeepEquals, s.modelUUIDs)
+}
+
+func (s *MigrationBatchSuite) TestAddMigrationBatchInvalid(c *gc.C) {
+	_, err := s.Controller.AddMigrationBatch(nil, 2)
+	c.Check(err, gc.ErrorMatches, "empty migration batch not valid")
+	_, err = s.Controller.AddMigrationBatch(s.modelUUIDs, 0)
+	c.Check(err, gc.ErrorMatches, "max concurrent migrations 0 not valid")
+}
+
+func (s *MigrationBatchSuite) TestMigrationBatchNotFound(c *gc.C) {
+	_, err := s.Controller.MigrationBatch("42")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MigrationBatchSuite) TestStartQueuedRespectsLimit(c *gc.C) {
+	batch, err := s.Controller.AddMigrationBatch(s.modelUUIDs, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	started, err := batch.StartQueued(s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(started, gc.HasLen, 2)
+	for i, mig := range started {
+		c.Check(mig.ModelUUID(), gc.Equals, s.modelUUIDs[i])
+		c.Check(mig.Batch(), gc.Equals, batch.Id())
+	}
+	c.Check(batch.Queued(), jc.DeepEquals, s.modelUUIDs[2:])
+	c.Check(batch.Running(), jc.DeepEquals, s.modelUUIDs[:2])
+
+	// Nothing more is started until a migration finishes.
+	started, err = batch.StartQueued(s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(started, gc.HasLen, 0)
+
+	err = batch.Finished(s.modelUUIDs[0])
+	c.Assert(err, jc.ErrorIsNil)
+	started, err = batch.StartQueued(s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(started, gc.HasLen, 1)
+	c.Check(started[0].ModelUUID(), gc.Equals, s.modelUUIDs[2])
+	c.Check(batch.Queued(), gc.HasLen, 0)
+	c.Check(batch.Running(), jc.DeepEquals, s.modelUUIDs[1:])
+}
+
+func (s *MigrationBatchSuite) TestStartQueuedRecordsFailures(c *gc.C) {
+	// The first model is already being migrated, so its migration
+	// can't be started by the batch.
+	st, err := s.StatePool.Get(s.modelUUIDs[0])
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.CreateMigration(s.spec)
+	st.Release()
+	c.Assert(err, jc.ErrorIsNil)
+
+	batch, err := s.Controller.AddMigrationBatch(s.modelUUIDs, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	started, err := batch.StartQueued(s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(started, gc.HasLen, 1)
+	c.Check(started[0].ModelUUID(), gc.Equals, s.modelUUIDs[1])
+	c.Check(batch.Running(), jc.DeepEquals, s.modelUUIDs[1:2])
+	c.Check(batch.Failed(), jc.DeepEquals, map[string]string{
+		s.modelUUIDs[0]: "failed to create migration: already in progress",
+	})
+}
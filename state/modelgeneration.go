@@ -5,16 +5,21 @@ package state
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/model"
 )
 
 // generationDoc represents the state of a model generation in MongoDB.
@@ -32,9 +37,27 @@ type generationDoc struct {
 	// generation, but no units currently set to be in it.
 	AssignedUnits map[string][]string `bson:"assigned-units"`
 
+	// Config holds the charm config changes made in this generation,
+	// keyed by application name. The setting names are escaped for
+	// storage. A nil value resets the setting to its default.
+	Config map[string]map[string]interface{} `bson:"config,omitempty"`
+
+	// CharmURLs holds the URLs of the charms that applications are
+	// upgraded to in this generation, keyed by application name.
+	CharmURLs map[string]string `bson:"charm-urls,omitempty"`
+
+	// Promotion, if set, describes how the controller moves units into
+	// this generation automatically.
+	Promotion *generationPromotionDoc `bson:"promotion,omitempty"`
+
 	// Completed, if set, indicates when this generation was completed and
 	// effectively became the current model generation.
 	Completed int64 `bson:"completed"`
+
+	// Unrealised is set when the generation is completed with charm or
+	// charm config changes to apply to its applications, and cleared
+	// once they have been applied.
+	Unrealised bool `bson:"unrealised,omitempty"`
 }
 
 // Generation represents the state of a model generation.
@@ -59,6 +82,27 @@ func (g *Generation) AssignedUnits() map[string][]string {
 	return g.doc.AssignedUnits
 }
 
+// CharmConfig returns the charm config changes made in this
+// generation, keyed by application name. A nil value resets the
+// setting to its default.
+func (g *Generation) CharmConfig() map[string]charm.Settings {
+	result := make(map[string]charm.Settings, len(g.doc.Config))
+	for appName, escaped := range g.doc.Config {
+		settings := make(charm.Settings, len(escaped))
+		for key, value := range escaped {
+			settings[unescapeReplacer.Replace(key)] = value
+		}
+		result[appName] = settings
+	}
+	return result
+}
+
+// CharmURLs returns the URLs of the charms that applications are
+// upgraded to in this generation, keyed by application name.
+func (g *Generation) CharmURLs() map[string]string {
+	return g.doc.CharmURLs
+}
+
 // IsCompleted returns true if the generation has been completed;
 // i.e it has a completion time-stamp.
 func (g *Generation) IsCompleted() bool {
@@ -106,6 +150,192 @@ func assignGenerationAppTxnOps(id, appName string) []txn.Op {
 	}
 }
 
+// UpdateCharmConfig records changes to the application's charm config
+// in this generation. They are applied to the application when the
+// generation completes.
+// Values set to nil reset the setting to its default; unknown and
+// invalid values return an error.
+func (g *Generation) UpdateCharmConfig(appName string, changes charm.Settings) error {
+	app, err := g.st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changes, err = ch.Config().ValidateSettings(changes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if g.IsCompleted() {
+			return nil, errors.New("generation has been completed")
+		}
+		if len(changes) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		updates := bson.D{}
+		for key, value := range changes {
+			updates = append(updates, bson.DocElem{
+				fmt.Sprintf("config.%s.%s", appName, escapeReplacer.Replace(key)), value,
+			})
+		}
+		if _, ok := g.doc.AssignedUnits[appName]; !ok {
+			updates = append(updates, bson.DocElem{fmt.Sprintf("assigned-units.%s", appName), []string{}})
+		}
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.Id,
+			Assert: bson.D{{"completed", 0}},
+			Update: bson.D{{"$set", updates}},
+		}}, nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// SetCharmURL records that the application is upgraded to the charm
+// with the given URL in this generation. The application is upgraded
+// when the generation completes.
+func (g *Generation) SetCharmURL(appName string, curl *charm.URL) error {
+	if _, err := g.st.Charm(curl); err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if g.IsCompleted() {
+			return nil, errors.New("generation has been completed")
+		}
+		if g.doc.CharmURLs[appName] == curl.String() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		updates := bson.D{{fmt.Sprintf("charm-urls.%s", appName), curl.String()}}
+		if _, ok := g.doc.AssignedUnits[appName]; !ok {
+			updates = append(updates, bson.DocElem{fmt.Sprintf("assigned-units.%s", appName), []string{}})
+		}
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.Id,
+			Assert: bson.D{{"completed", 0}},
+			Update: bson.D{{"$set", updates}},
+		}}, nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// GenerationConfigChange describes a charm config setting whose value
+// differs between the current and next generations.
+type GenerationConfigChange struct {
+	Key     string
+	Current interface{}
+	Next    interface{}
+}
+
+// GenerationApplicationDiff describes how an application with changes
+// in a generation differs between the current and next generations.
+type GenerationApplicationDiff struct {
+	Application string
+
+	// CurrentCharmURL is the URL of the application's charm.
+	CurrentCharmURL string
+
+	// NextCharmURL is the URL of the charm that the application is
+	// upgraded to in the generation, or empty if it isn't.
+	NextCharmURL string
+
+	// Config holds the charm config settings that differ, sorted
+	// by key.
+	Config []GenerationConfigChange
+
+	// Units holds the generation that each of the application's units
+	// is on.
+	Units map[string]model.GenerationVersion
+}
+
+// Diff returns how each application with changes in this generation
+// differs between the current and next generations, sorted by
+// application name. Units on the current generation realise the
+// differences when the generation is completed.
+func (g *Generation) Diff() ([]GenerationApplicationDiff, error) {
+	appNames := make([]string, 0, len(g.doc.AssignedUnits))
+	for appName := range g.doc.AssignedUnits {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+
+	nextConfig := g.CharmConfig()
+	diffs := make([]GenerationApplicationDiff, len(appNames))
+	for i, appName := range appNames {
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		curl, _ := app.CharmURL()
+		diff := GenerationApplicationDiff{
+			Application:     appName,
+			CurrentCharmURL: curl.String(),
+			Units:           make(map[string]model.GenerationVersion),
+		}
+		if next, ok := g.doc.CharmURLs[appName]; ok && next != diff.CurrentCharmURL {
+			diff.NextCharmURL = next
+		}
+
+		if changes := nextConfig[appName]; len(changes) > 0 {
+			current, err := app.CharmConfig()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ch, _, err := app.Charm()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			defaults := ch.Config().DefaultSettings()
+			for key, next := range changes {
+				if next == nil {
+					next = defaults[key]
+				}
+				if reflect.DeepEqual(current[key], next) {
+					continue
+				}
+				diff.Config = append(diff.Config, GenerationConfigChange{
+					Key:     key,
+					Current: current[key],
+					Next:    next,
+				})
+			}
+			sort.Slice(diff.Config, func(i, j int) bool {
+				return diff.Config[i].Key < diff.Config[j].Key
+			})
+		}
+
+		unitNames, err := appUnitNames(g.st, appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		assigned := set.NewStrings(g.doc.AssignedUnits[appName]...)
+		for _, unitName := range unitNames {
+			if assigned.Contains(unitName) {
+				diff.Units[unitName] = model.GenerationNext
+			} else {
+				diff.Units[unitName] = model.GenerationCurrent
+			}
+		}
+		diffs[i] = diff
+	}
+	return diffs, nil
+}
+
 // AssignAllUnits indicates that all units of the given application,
 // not already added to this generation will be.
 func (g *Generation) AssignAllUnits(appName string) error {
@@ -200,7 +430,8 @@ func assignGenerationUnitTxnOps(id, appName, unitName string) []txn.Op {
 
 // AutoComplete marks the generation as completed if there are no applications
 // with changes in this generation that do not have all units advanced to the
-// generation. It then becomes the "current" generation, and true is returned.
+// generation. It then becomes the "current" generation, its charm and charm
+// config changes are applied to the applications, and true is returned.
 // If the criteria above are not met, the generation is not completed and
 // false is returned.
 func (g *Generation) AutoComplete() (bool, error) {
@@ -212,7 +443,9 @@ func (g *Generation) AutoComplete() (bool, error) {
 // with changes in this generation that do not have all units on the same
 // generation, which can be either "current" of "next".
 // This the operation invoked by an operator "cancelling" a generation.
-// It then becomes the "current" generation.
+// It then becomes the "current" generation. The charm and charm config
+// changes of applications whose units were all advanced are applied;
+// those of applications with no units advanced are discarded.
 func (g *Generation) MakeCurrent() error {
 	_, err := g.complete(true)
 	return errors.Trace(err)
@@ -243,13 +476,21 @@ func (g *Generation) complete(allowEmpty bool) (bool, error) {
 		// here, however ensuring that no new applications are added to
 		// AssignedUnits, is non trivial.  Therefore just check the txn-revno
 		// instead.
+		// The changes are applied to the applications after the
+		// generation is completed, so it is marked as unrealised until
+		// they have been; the controller retries applying them until
+		// they are.
+		set := bson.D{{"completed", time.Unix()}}
+		if g.hasChangesToRealise() {
+			set = append(set, bson.DocElem{"unrealised", true})
+		}
 		ops := []txn.Op{
 			{
 				C:      generationsC,
 				Id:     g.doc.Id,
 				Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
 				Update: bson.D{
-					{"$set", bson.D{{"completed", time.Unix()}}},
+					{"$set", set},
 				},
 			},
 		}
@@ -257,16 +498,115 @@ func (g *Generation) complete(allowEmpty bool) (bool, error) {
 	}
 
 	err := g.st.db().Run(buildTxn)
-	completed := true
 	if err != nil {
 		// if we are auto-completing, and criteria are not met, just return
 		// false. This error is not relevant to MakeCurrent (cancel).
 		if errors.Cause(err) == errGenerationNoAutoComplete && !allowEmpty {
 			err = nil
 		}
-		completed = false
+		return false, errors.Trace(err)
 	}
-	return completed, errors.Trace(err)
+	if err := g.Refresh(); err != nil {
+		return true, errors.Trace(err)
+	}
+	if err := g.Realise(); err != nil {
+		return true, errors.Annotate(err, "generation completed, but applying its changes failed")
+	}
+	return true, nil
+}
+
+// hasChangesToRealise returns true if the generation has charm or
+// charm config changes for an application with units advanced to it.
+func (g *Generation) hasChangesToRealise() bool {
+	for appName, units := range g.doc.AssignedUnits {
+		if len(units) == 0 {
+			continue
+		}
+		if _, ok := g.doc.Config[appName]; ok {
+			return true
+		}
+		if _, ok := g.doc.CharmURLs[appName]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Realise applies the charm and charm config changes recorded in the
+// completed generation to the applications whose units were advanced
+// to it, if they have yet to be applied, and records that they have
+// been. It is safe to call more than once.
+func (g *Generation) Realise() error {
+	if !g.doc.Unrealised {
+		return nil
+	}
+	if err := g.realise(); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      generationsC,
+		Id:     g.doc.Id,
+		Assert: bson.D{{"unrealised", true}},
+		Update: bson.D{{"$unset", bson.D{{"unrealised", 1}}}},
+	}}
+	// If the transaction aborts, the changes have already been
+	// recorded as applied by someone else.
+	if err := onAbort(g.st.db().RunTransaction(ops), nil); err != nil {
+		return errors.Annotatef(err, "recording generation %q changes applied", g.doc.Id)
+	}
+	g.doc.Unrealised = false
+	return nil
+}
+
+// realise applies the charm and charm config changes recorded in the
+// completed generation to the applications whose units were advanced
+// to it. Changes that have already been applied are applied again
+// harmlessly.
+func (g *Generation) realise() error {
+	config := g.CharmConfig()
+	for appName, units := range g.doc.AssignedUnits {
+		changes, hasConfig := config[appName]
+		url, hasCharm := g.doc.CharmURLs[appName]
+		if len(units) == 0 || (!hasConfig && !hasCharm) {
+			continue
+		}
+		app, err := g.st.Application(appName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if curl, _ := app.CharmURL(); hasCharm && curl.String() != url {
+			if err := g.realiseCharm(app, url); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if hasConfig {
+			if err := app.UpdateCharmConfig(changes); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// realiseCharm upgrades the application to the charm with the given URL.
+func (g *Generation) realiseCharm(app *Application, url string) error {
+	curl, err := charm.ParseURL(url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, err := g.st.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := app.SetCharmProfile(url); err != nil {
+		return errors.Annotate(err, "setting charm profile")
+	}
+	return errors.Trace(app.SetCharm(SetCharmConfig{
+		Charm:   ch,
+		Channel: app.Channel(),
+	}))
 }
 
 // checkCanMakeCurrent assesses the generation to determine whether it can be
@@ -423,6 +763,63 @@ func (st *State) NextGeneration() (*Generation, error) {
 	return newGeneration(st, doc), nil
 }
 
+// Generation returns the model's generation with the given ID,
+// whether or not it is completed.
+func (m *Model) Generation(id string) (*Generation, error) {
+	gen, err := m.st.Generation(id)
+	return gen, errors.Trace(err)
+}
+
+// Generation returns the generation with the given ID
+// for the current model, whether or not it is completed.
+func (st *State) Generation(id string) (*Generation, error) {
+	col, closer := st.db().GetCollection(generationsC)
+	defer closer()
+
+	doc := &generationDoc{}
+	err := col.FindId(id).One(doc)
+	switch err {
+	case nil:
+		return newGeneration(st, doc), nil
+	case mgo.ErrNotFound:
+		return nil, errors.NotFoundf("generation %q", id)
+	default:
+		return nil, errors.Annotatef(err, "retrieving generation %q", id)
+	}
+}
+
+// LatestGeneration returns the model's "next" generation if one
+// exists, or else its most recently completed generation.
+func (m *Model) LatestGeneration() (*Generation, error) {
+	gen, err := m.st.LatestGeneration()
+	return gen, errors.Trace(err)
+}
+
+// LatestGeneration returns the "next" generation for the current
+// model if one exists, or else its most recently completed generation.
+func (st *State) LatestGeneration() (*Generation, error) {
+	gen, err := st.NextGeneration()
+	if !errors.IsNotFound(err) {
+		return gen, errors.Trace(err)
+	}
+
+	col, closer := st.db().GetCollection(generationsC)
+	defer closer()
+
+	doc := &generationDoc{}
+	err = col.Find(nil).Sort("-completed").One(doc)
+	switch err {
+	case nil:
+		return newGeneration(st, doc), nil
+	case mgo.ErrNotFound:
+		mod, _ := st.modelName()
+		return nil, errors.NotFoundf("generation for %q", mod)
+	default:
+		mod, _ := st.modelName()
+		return nil, errors.Annotatef(err, "retrieving latest generation for %q", mod)
+	}
+}
+
 func (st *State) getNextGenerationDoc() (*generationDoc, error) {
 	col, closer := st.db().GetCollection(generationsC)
 	defer closer()
@@ -443,6 +840,24 @@ func (st *State) getNextGenerationDoc() (*generationDoc, error) {
 	}
 }
 
+// UnrealisedGenerations returns the completed generations in all
+// models whose charm and charm config changes have yet to be applied.
+func (st *State) UnrealisedGenerations() ([]GenerationKey, error) {
+	generations, closer := st.db().GetRawCollection(generationsC)
+	defer closer()
+
+	var docs []generationDoc
+	query := bson.D{{"unrealised", true}}
+	if err := generations.Find(query).Select(bson.D{{"model-uuid", 1}, {"generation-id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get unrealised generations")
+	}
+	result := make([]GenerationKey, len(docs))
+	for i, doc := range docs {
+		result[i] = GenerationKey{ModelUUID: doc.ModelUUID, Id: doc.Id}
+	}
+	return result, nil
+}
+
 func newGeneration(st *State, doc *generationDoc) *Generation {
 	return &Generation{
 		st:  st,
@@ -9,7 +9,9 @@ import (
 	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -263,6 +265,164 @@ func (s *generationSuite) TestAppNoUnitsAutoCompleteErrorMakeCurrentSuccess(c *g
 	c.Assert(gen.MakeCurrent(), jc.ErrorIsNil)
 }
 
+func (s *generationSuite) setupDiff(c *gc.C) *state.Generation {
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy", ch)
+	for i := 0; i < 2; i++ {
+		_, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(s.Model.AddGeneration(), jc.ErrorIsNil)
+
+	gen, err := s.Model.NextGeneration()
+	c.Assert(err, jc.ErrorIsNil)
+	return gen
+}
+
+func (s *generationSuite) TestUpdateCharmConfig(c *gc.C) {
+	gen := s.setupDiff(c)
+
+	c.Assert(gen.UpdateCharmConfig("dummy", charm.Settings{"title": "Next"}), jc.ErrorIsNil)
+	c.Assert(gen.UpdateCharmConfig("dummy", charm.Settings{"username": nil}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.CharmConfig(), jc.DeepEquals, map[string]charm.Settings{
+		"dummy": {"title": "Next", "username": nil},
+	})
+	c.Check(gen.AssignedUnits(), jc.DeepEquals, map[string][]string{"dummy": {}})
+
+	// The application's config is unchanged.
+	app, err := s.State.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["title"], gc.Equals, "My Title")
+}
+
+func (s *generationSuite) TestUpdateCharmConfigInvalid(c *gc.C) {
+	gen := s.setupDiff(c)
+
+	err := gen.UpdateCharmConfig("dummy", charm.Settings{"unknown": "x"})
+	c.Assert(err, gc.ErrorMatches, `unknown option "unknown"`)
+}
+
+func (s *generationSuite) TestUpdateCharmConfigGenCompletedError(c *gc.C) {
+	s.setupClockForComplete(c)
+	gen := s.setupDiff(c)
+	c.Assert(gen.MakeCurrent(), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	err := gen.UpdateCharmConfig("dummy", charm.Settings{"title": "Next"})
+	c.Assert(err, gc.ErrorMatches, "generation has been completed")
+}
+
+func (s *generationSuite) TestAutoCompleteAppliesChanges(c *gc.C) {
+	s.setupClockForComplete(c)
+	gen := s.setupDiff(c)
+	next := s.AddConfigCharm(c, "dummy", "options: {title: {type: string}}", 2)
+
+	c.Assert(gen.UpdateCharmConfig("dummy", charm.Settings{"title": "Next"}), jc.ErrorIsNil)
+	c.Assert(gen.SetCharmURL("dummy", next.URL()), jc.ErrorIsNil)
+	c.Assert(gen.AssignAllUnits("dummy"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	completed, err := gen.AutoComplete()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(completed, jc.IsTrue)
+
+	app, err := s.State.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl, jc.DeepEquals, next.URL())
+	cfg, err := app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["title"], gc.Equals, "Next")
+}
+
+func (s *generationSuite) TestMakeCurrentDiscardsChangesWithoutUnits(c *gc.C) {
+	s.setupClockForComplete(c)
+	gen := s.setupDiff(c)
+
+	c.Assert(gen.UpdateCharmConfig("dummy", charm.Settings{"title": "Next"}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.MakeCurrent(), jc.ErrorIsNil)
+
+	app, err := s.State.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := app.CharmConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["title"], gc.Equals, "My Title")
+}
+
+func (s *generationSuite) TestAutoCompleteRecordsChangesApplied(c *gc.C) {
+	s.setupClockForComplete(c)
+	gen := s.setupDiff(c)
+	c.Assert(gen.UpdateCharmConfig("dummy", charm.Settings{"title": "Next"}), jc.ErrorIsNil)
+	c.Assert(gen.AssignAllUnits("dummy"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	completed, err := gen.AutoComplete()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(completed, jc.IsTrue)
+	unrealised, err := s.State.UnrealisedGenerations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unrealised, gc.HasLen, 0)
+}
+
+func (s *generationSuite) TestAutoCompleteRetriesFailedChanges(c *gc.C) {
+	s.setupClockForComplete(c)
+	gen := s.setupDiff(c)
+	// The new charm doesn't have the setting changed in the generation,
+	// so applying the config change fails once the charm is upgraded.
+	next := s.AddConfigCharm(c, "dummy", "options: {outlook: {type: string}}", 2)
+	c.Assert(gen.UpdateCharmConfig("dummy", charm.Settings{"title": "Next"}), jc.ErrorIsNil)
+	c.Assert(gen.SetCharmURL("dummy", next.URL()), jc.ErrorIsNil)
+	c.Assert(gen.AssignAllUnits("dummy"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	completed, err := gen.AutoComplete()
+	c.Assert(err, gc.ErrorMatches, "generation completed, but applying its changes failed: .*")
+	c.Check(completed, jc.IsTrue)
+
+	// The generation is recorded as having changes yet to be applied,
+	// and applying them is tried again.
+	unrealised, err := s.State.UnrealisedGenerations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unrealised, jc.DeepEquals, []state.GenerationKey{{ModelUUID: s.State.ModelUUID(), Id: gen.Id()}})
+	err = gen.Realise()
+	c.Check(err, gc.ErrorMatches, ".*unknown option \"title\".*")
+}
+
+func (s *generationSuite) TestDiff(c *gc.C) {
+	gen := s.setupDiff(c)
+	next := s.AddConfigCharm(c, "dummy", "options: {title: {type: string}}", 2)
+
+	c.Assert(gen.UpdateCharmConfig("dummy", charm.Settings{
+		"title":       "Next",
+		"skill-level": 5,
+		// Resetting to the default is not a change.
+		"username": nil,
+	}), jc.ErrorIsNil)
+	c.Assert(gen.SetCharmURL("dummy", next.URL()), jc.ErrorIsNil)
+	c.Assert(gen.AssignUnit("dummy/1"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	diff, err := gen.Diff()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(diff, jc.DeepEquals, []state.GenerationApplicationDiff{{
+		Application:     "dummy",
+		CurrentCharmURL: "local:quantal/quantal-dummy-1",
+		NextCharmURL:    next.URL().String(),
+		Config: []state.GenerationConfigChange{
+			{Key: "skill-level", Current: nil, Next: int64(5)},
+			{Key: "title", Current: "My Title", Next: "Next"},
+		},
+		Units: map[string]model.GenerationVersion{
+			"dummy/0": model.GenerationCurrent,
+			"dummy/1": model.GenerationNext,
+		},
+	}})
+}
+
 func (s *generationSuite) TestHasNextGeneration(c *gc.C) {
 	has, err := s.Model.HasNextGeneration()
 	c.Assert(err, jc.ErrorIsNil)
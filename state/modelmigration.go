@@ -62,6 +62,10 @@ type ModelMigration interface {
 	// InitiatedBy returns username the initiated the migration.
 	InitiatedBy() string
 
+	// Batch returns the id of the migration batch the migration was
+	// started for, or "" if it wasn't part of a batch.
+	Batch() string
+
 	// TargetInfo returns the details required to connect to the
 	// migration's target controller.
 	TargetInfo() (*migration.TargetInfo, error)
@@ -151,6 +155,10 @@ type modelMigDoc struct {
 	// TargetMacaroons holds the macaroons to use with TargetAuthTag
 	// when authenticating.
 	TargetMacaroons string `bson:"target-macaroons,omitempty"`
+
+	// Batch holds the id of the migration batch the migration was
+	// started for, if any.
+	Batch string `bson:"batch,omitempty"`
 }
 
 // modelMigStatusDoc tracks the progress of a migration attempt for a
@@ -254,6 +262,11 @@ func (mig *modelMigration) InitiatedBy() string {
 	return mig.doc.InitiatedBy
 }
 
+// Batch implements ModelMigration.
+func (mig *modelMigration) Batch() string {
+	return mig.doc.Batch
+}
+
 // TargetInfo implements ModelMigration.
 func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	authTag, err := names.ParseUserTag(mig.doc.TargetAuthTag)
@@ -609,6 +622,10 @@ func (mig *modelMigration) Refresh() error {
 type MigrationSpec struct {
 	InitiatedBy names.UserTag
 	TargetInfo  migration.TargetInfo
+
+	// Batch holds the id of the migration batch the migration is
+	// started for, if any.
+	Batch string
 }
 
 // Validate returns an error if the MigrationSpec contains bad
@@ -682,6 +699,7 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			TargetAuthTag:    spec.TargetInfo.AuthTag.String(),
 			TargetPassword:   spec.TargetInfo.Password,
 			TargetMacaroons:  macsJSON,
+			Batch:            spec.Batch,
 		}
 
 		statusDoc = modelMigStatusDoc{
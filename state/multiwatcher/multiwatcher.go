@@ -125,12 +125,25 @@ type Address struct {
 	SpaceProviderId string `json:"space-provider-id,omitempty"`
 }
 
+// NetworkInterface describes a network interface of a machine that
+// has addresses assigned to it.
+type NetworkInterface struct {
+	DeviceName     string   `json:"device-name"`
+	MACAddress     string   `json:"mac-address"`
+	IsUp           bool     `json:"is-up"`
+	IPAddresses    []string `json:"ip-addresses"`
+	Gateways       []string `json:"gateways,omitempty"`
+	DNSNameservers []string `json:"dns-nameservers,omitempty"`
+	Spaces         []string `json:"spaces,omitempty"`
+}
+
 // MachineInfo holds the information about a machine
 // that is tracked by multiwatcherStore.
 type MachineInfo struct {
 	ModelUUID                string                            `json:"model-uuid"`
 	Id                       string                            `json:"id"`
 	InstanceId               string                            `json:"instance-id"`
+	DisplayName              string                            `json:"display-name,omitempty"`
 	AgentStatus              StatusInfo                        `json:"agent-status"`
 	InstanceStatus           StatusInfo                        `json:"instance-status"`
 	Life                     Life                              `json:"life"`
@@ -143,6 +156,9 @@ type MachineInfo struct {
 	Addresses                []Address                         `json:"addresses"`
 	HasVote                  bool                              `json:"has-vote"`
 	WantsVote                bool                              `json:"wants-vote"`
+	Constraints              constraints.Value                 `json:"constraints"`
+	CharmProfiles            []string                          `json:"charm-profiles,omitempty"`
+	NetworkInterfaces        []NetworkInterface                `json:"network-interfaces,omitempty"`
 }
 
 // EntityId returns a unique identifier for a machine across
@@ -181,18 +197,47 @@ func NewStatusInfo(s status.StatusInfo, err error) StatusInfo {
 // ApplicationInfo holds the information about an application that is tracked
 // by multiwatcherStore.
 type ApplicationInfo struct {
-	ModelUUID       string                 `json:"model-uuid"`
-	Name            string                 `json:"name"`
-	Exposed         bool                   `json:"exposed"`
-	CharmURL        string                 `json:"charm-url"`
-	OwnerTag        string                 `json:"owner-tag"`
-	Life            Life                   `json:"life"`
-	MinUnits        int                    `json:"min-units"`
-	Constraints     constraints.Value      `json:"constraints"`
-	Config          map[string]interface{} `json:"config,omitempty"`
-	Subordinate     bool                   `json:"subordinate"`
-	Status          StatusInfo             `json:"status"`
-	WorkloadVersion string                 `json:"workload-version"`
+	ModelUUID            string                 `json:"model-uuid"`
+	Name                 string                 `json:"name"`
+	Exposed              bool                   `json:"exposed"`
+	CharmURL             string                 `json:"charm-url"`
+	OwnerTag             string                 `json:"owner-tag"`
+	Life                 Life                   `json:"life"`
+	MinUnits             int                    `json:"min-units"`
+	Constraints          constraints.Value      `json:"constraints"`
+	Config               map[string]interface{} `json:"config,omitempty"`
+	Subordinate          bool                   `json:"subordinate"`
+	Status               StatusInfo             `json:"status"`
+	WorkloadVersion      string                 `json:"workload-version"`
+	Series               string                 `json:"series"`
+	EndpointBindings     map[string]string      `json:"endpoint-bindings,omitempty"`
+	CharmVersion         string                 `json:"charm-version,omitempty"`
+	CharmLXDProfile      *Profile               `json:"charm-lxd-profile,omitempty"`
+	MetricsPlanRequired  bool                   `json:"metrics-plan-required,omitempty"`
+	HasMetricCredentials bool                   `json:"has-metric-credentials,omitempty"`
+	LatestCharmURL       string                 `json:"latest-charm-url,omitempty"`
+	CharmModifiedVersion int                    `json:"charm-modified-version,omitempty"`
+	ForceCharm           bool                   `json:"force-charm,omitempty"`
+}
+
+// Profile is a mirror struct for charm.LXDProfile.
+type Profile struct {
+	Config      map[string]string            `json:"config,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	Devices     map[string]map[string]string `json:"devices,omitempty"`
+}
+
+// NewProfile creates a new local Profile structure from the
+// charm.LXDProfile structure.
+func NewProfile(profile *charm.LXDProfile) *Profile {
+	if profile == nil || profile.Empty() {
+		return nil
+	}
+	return &Profile{
+		Config:      profile.Config,
+		Description: profile.Description,
+		Devices:     profile.Devices,
+	}
 }
 
 // EntityId returns a unique identifier for an application across
@@ -266,6 +311,7 @@ type UnitInfo struct {
 	Name           string      `json:"name"`
 	Application    string      `json:"application"`
 	Series         string      `json:"series"`
+	Life           Life        `json:"life"`
 	CharmURL       string      `json:"charm-url"`
 	PublicAddress  string      `json:"public-address"`
 	PrivateAddress string      `json:"private-address"`
@@ -273,9 +319,15 @@ type UnitInfo struct {
 	Ports          []Port      `json:"ports"`
 	PortRanges     []PortRange `json:"port-ranges"`
 	Subordinate    bool        `json:"subordinate"`
+	Principal      string      `json:"principal,omitempty"`
+	Subordinates   []string    `json:"subordinates,omitempty"`
 	// Workload and agent state are modelled separately.
 	WorkloadStatus StatusInfo `json:"workload-status"`
 	AgentStatus    StatusInfo `json:"agent-status"`
+	// WorkloadVersion is the version of the workload the unit reports.
+	WorkloadVersion string `json:"workload-version,omitempty"`
+	// Resolved is the resolved mode requested for a unit in error.
+	Resolved string `json:"resolved,omitempty"`
 }
 
 // EntityId returns a unique identifier for a unit across
@@ -320,7 +372,9 @@ type RelationInfo struct {
 	ModelUUID string     `json:"model-uuid"`
 	Key       string     `json:"key"`
 	Id        int        `json:"id"`
+	Life      Life       `json:"life"`
 	Endpoints []Endpoint `json:"endpoints"`
+	Status    StatusInfo `json:"status"`
 }
 
 // CharmRelation is a mirror struct for charm.Relation.
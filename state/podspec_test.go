@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/errors"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing/factory"
@@ -105,4 +106,9 @@ func (s *PodSpecSuite) TestWatchPodSpec(c *gc.C) {
 	err = s.Model.SetPodSpec(s.application.ApplicationTag(), "spec2")
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertOneChange()
+
+	// Application config changes, such as trusting the application.
+	err = s.application.UpdateApplicationConfig(application.ConfigAttributes{"title": "value"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
 }
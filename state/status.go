@@ -123,6 +123,11 @@ func (m *ModelStatus) MachineInstance(machineID string) (status.StatusInfo, erro
 	return m.getStatus(machineGlobalInstanceKey(machineID), "instance")
 }
 
+// Relation returns the status of the relation with the specified id.
+func (m *ModelStatus) Relation(id int) (status.StatusInfo, error) {
+	return m.getStatus(relationGlobalScope(id), "relation")
+}
+
 // FullUnitWorkloadVersion returns the full status info for the workload
 // version of a unit. This is used for selecting the workload version for
 // an application.
@@ -2727,8 +2727,9 @@ func (u *Unit) UnassignFromMachine() (err error) {
 type ActionSpecsByName map[string]charm.ActionSpec
 
 // AddAction adds a new Action of type name and using arguments payload to
-// this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
-// mutates payload.
+// this Unit, and returns its ID.  The payload is validated against the
+// action's schema, and the schema's defaults are inserted, as described
+// on actions.ValidateParams.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
@@ -2746,12 +2747,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 			return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
 		}
 	}
-	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
-	if err != nil {
-		return nil, err
-	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
+	payloadWithDefaults, err := actions.ValidateParams(name, spec, payload)
 	if err != nil {
 		return nil, err
 	}
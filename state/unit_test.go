@@ -2133,19 +2133,19 @@ snapshot:
 		},
 		{
 			actionName: "juju-run",
-			errString:  `validation failed: \(root\) : "command" property is missing and required, given \{\}; \(root\) : "timeout" property is missing and required, given \{\}`,
+			errString:  `invalid parameters for action "juju-run": \(root\) : "command" property is missing and required, given \{\}; \(root\) : "timeout" property is missing and required, given \{\}`,
 		},
 		{
 			actionName:   "juju-run",
 			givenPayload: map[string]interface{}{"command": "allyourbasearebelongtous"},
-			errString:    `validation failed: \(root\) : "timeout" property is missing and required, given \{"command":"allyourbasearebelongtous"\}`,
+			errString:    `invalid parameters for action "juju-run": \(root\) : "timeout" property is missing and required, given \{"command":"allyourbasearebelongtous"\}`,
 		},
 		{
 			actionName:   "juju-run",
 			givenPayload: map[string]interface{}{"timeout": 5 * time.Second},
 			// Note: in Go 1.8 the representation of large numbers in JSON changed
 			// to use integer rather than exponential notation, hence the pattern.
-			errString: `validation failed: \(root\) : "command" property is missing and required, given \{"timeout":5.*\}`,
+			errString: `invalid parameters for action "juju-run": \(root\) : "command" property is missing and required, given \{"timeout":5.*\}`,
 		},
 		{
 			actionName:      "juju-run",
@@ -5,6 +5,7 @@ package state
 
 import (
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -3070,6 +3071,107 @@ func (m *Model) WatchActionResultsFilteredBy(receivers ...ActionReceiver) String
 	return newActionStatusWatcher(m.st, receivers, []ActionStatus{ActionCompleted, ActionCancelled, ActionFailed}...)
 }
 
+// WatchActionLogs starts and returns a StringsWatcher that notifies of
+// progress messages logged by the action with the given id. Each
+// change is a json encoded ActionMessage; the first event holds all
+// messages logged so far.
+func (m *Model) WatchActionLogs(actionId string) StringsWatcher {
+	return newActionLogsWatcher(m.st, actionId)
+}
+
+// actionLogsWatcher notifies of progress messages logged by an action.
+type actionLogsWatcher struct {
+	commonWatcher
+	actionId string
+	sent     int
+	out      chan []string
+}
+
+var _ Watcher = (*actionLogsWatcher)(nil)
+
+func newActionLogsWatcher(backend modelBackend, actionId string) StringsWatcher {
+	w := &actionLogsWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		actionId:      actionId,
+		out:           make(chan []string),
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the event channel for the actionLogsWatcher.
+func (w *actionLogsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+// messages returns the json encoded messages logged since those
+// already sent.
+func (w *actionLogsWatcher) messages() ([]string, error) {
+	actions, closer := w.db.GetCollection(actionsC)
+	defer closer()
+
+	var doc struct {
+		Logs []ActionMessage `bson:"messages"`
+	}
+	err := actions.FindId(w.actionId).Select(bson.D{{"messages", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action %q", w.actionId)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var changes []string
+	for _, msg := range doc.Logs[w.sent:] {
+		msg.Timestamp = msg.Timestamp.UTC()
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changes = append(changes, string(data))
+	}
+	w.sent = len(doc.Logs)
+	return changes, nil
+}
+
+func (w *actionLogsWatcher) loop() error {
+	in := make(chan watcher.Change)
+	docID := w.backend.docID(w.actionId)
+	w.watcher.Watch(actionsC, docID, in)
+	defer w.watcher.Unwatch(actionsC, docID, in)
+
+	changes, err := w.messages()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case _, ok := <-in:
+			if !ok {
+				return tomb.ErrDying
+			}
+			more, err := w.messages()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if len(more) > 0 {
+				changes = append(changes, more...)
+				out = w.out
+			}
+		case out <- changes:
+			changes = nil
+			out = nil
+		}
+	}
+}
+
 // openedPortsWatcher notifies of changes in the openedPorts
 // collection
 type openedPortsWatcher struct {
@@ -3754,11 +3856,16 @@ func (w *externalControllersWatcher) loop() error {
 }
 
 // WatchPodSpec returns a watcher observing changes that affect the
-// pod spec for an application or unit.
+// pod spec for an application or unit. Changes to the application's
+// config are included, as it holds whether the application is trusted
+// with the privileges the pod spec asks for.
 func (m *CAASModel) WatchPodSpec(appTag names.ApplicationTag) (NotifyWatcher, error) {
 	docKeys := []docKey{{
 		podSpecsC,
 		m.st.docID(applicationGlobalKey(appTag.Id())),
+	}, {
+		settingsC,
+		m.st.docID(applicationConfigKey(appTag.Id())),
 	}}
 	return newDocWatcher(m.st, docKeys), nil
 }
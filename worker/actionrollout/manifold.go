@@ -0,0 +1,106 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run an action
+// rollout worker in a dependency.Engine.
+type ManifoldConfig struct {
+	StateName string
+
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run an action
+// rollout worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	worker, err := config.NewWorker(Config{
+		Backend: &stateBackend{
+			State: statePool.SystemState(),
+			pool:  statePool,
+		},
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	go func() {
+		worker.Wait()
+		stTracker.Done()
+	}()
+	return worker, nil
+}
+
+// stateBackend implements Backend, finding the rollouts of all models
+// through the controller's state, and advancing each in the state of
+// its own model.
+type stateBackend struct {
+	*state.State
+	pool *state.StatePool
+}
+
+// AdvanceActionRollout is part of the Backend interface.
+func (b *stateBackend) AdvanceActionRollout(key state.ActionRolloutKey) error {
+	model, ph, err := b.pool.GetModel(key.ModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer ph.Release()
+
+	rollout, err := model.ActionRollout(key.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := rollout.Advance(); err != nil {
+		return errors.Trace(err)
+	}
+	if rollout.Status() != state.RolloutRunning {
+		logger.Infof("action rollout %q of %q on %s in model %s %s",
+			key.Id, rollout.Name(), rollout.Application(), key.ModelUUID, rollout.Status())
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionrollout")
+
+// Backend provides the action rollouts of all the models on the
+// controller. (Primary implementation wraps a state pool.)
+type Backend interface {
+	// WatchActionRollouts notifies of changes to any rollout.
+	WatchActionRollouts() state.NotifyWatcher
+
+	// WatchAllActions notifies of changes to any action, so that
+	// rollouts can move on as their actions finish.
+	WatchAllActions() state.NotifyWatcher
+
+	// RunningActionRollouts returns the rollouts that have yet to
+	// finish.
+	RunningActionRollouts() ([]state.ActionRolloutKey, error)
+
+	// AdvanceActionRollout enqueues as many of the rollout's actions
+	// as its limits allow, and records whether it has finished.
+	AdvanceActionRollout(key state.ActionRolloutKey) error
+}
+
+// Config holds the dependencies of an action rollout worker.
+type Config struct {
+	Backend Backend
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that advances the action rollouts of all
+// models as their actions finish. This worker must not be run in more
+// than one agent concurrently.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &rolloutWorker{
+		config: config,
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type rolloutWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *rolloutWorker) loop() error {
+	rolloutsWatcher := w.config.Backend.WatchActionRollouts()
+	defer worker.Stop(rolloutsWatcher)
+	actionsWatcher := w.config.Backend.WatchAllActions()
+	defer worker.Stop(actionsWatcher)
+
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+
+		case _, ok := <-rolloutsWatcher.Changes():
+			if !ok {
+				return errors.New("action rollouts watcher closed")
+			}
+		case _, ok := <-actionsWatcher.Changes():
+			if !ok {
+				return errors.New("actions watcher closed")
+			}
+		}
+		if err := w.advanceAll(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// advanceAll advances every running rollout. Failing to advance one
+// rollout doesn't prevent the others advancing; it is tried again on
+// the next change.
+func (w *rolloutWorker) advanceAll() error {
+	running, err := w.config.Backend.RunningActionRollouts()
+	if err != nil {
+		return errors.Annotate(err, "cannot get running action rollouts")
+	}
+	for _, key := range running {
+		if err := w.config.Backend.AdvanceActionRollout(key); err != nil {
+			logger.Errorf("advancing action rollout %q in model %s: %v", key.Id, key.ModelUUID, err)
+		}
+	}
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *rolloutWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *rolloutWorker) Wait() error {
+	return w.tomb.Wait()
+}
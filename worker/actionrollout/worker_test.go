@@ -0,0 +1,157 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionrollout_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionrollout"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	rolloutChanges chan struct{}
+	actionChanges  chan struct{}
+	backend        *fakeBackend
+	config         actionrollout.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.rolloutChanges = make(chan struct{}, 1)
+	s.actionChanges = make(chan struct{}, 1)
+	s.backend = &fakeBackend{
+		rolloutsWatcher: watchertest.NewNotifyWatcher(s.rolloutChanges),
+		actionsWatcher:  watchertest.NewNotifyWatcher(s.actionChanges),
+		advanced:        make(chan state.ActionRolloutKey, 10),
+	}
+	s.config = actionrollout.Config{
+		Backend: s.backend,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	s.config.Backend = nil
+	_, err := actionrollout.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := actionrollout.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *workerSuite) waitAdvanced(c *gc.C) state.ActionRolloutKey {
+	select {
+	case key := <-s.backend.advanced:
+		return key
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("rollout not advanced")
+	}
+	return state.ActionRolloutKey{}
+}
+
+func (s *workerSuite) assertNotAdvanced(c *gc.C) {
+	select {
+	case key := <-s.backend.advanced:
+		c.Fatalf("rollout %v advanced unexpectedly", key)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestAdvancesOnRolloutChange(c *gc.C) {
+	key := state.ActionRolloutKey{ModelUUID: "model-1", Id: "0"}
+	s.backend.setRunning(key)
+	s.startWorker(c)
+	s.assertNotAdvanced(c)
+
+	s.rolloutChanges <- struct{}{}
+	c.Assert(s.waitAdvanced(c), gc.Equals, key)
+	s.assertNotAdvanced(c)
+}
+
+func (s *workerSuite) TestAdvancesOnActionChange(c *gc.C) {
+	key := state.ActionRolloutKey{ModelUUID: "model-1", Id: "0"}
+	s.backend.setRunning(key)
+	s.startWorker(c)
+
+	s.actionChanges <- struct{}{}
+	c.Assert(s.waitAdvanced(c), gc.Equals, key)
+
+	// Finished rollouts aren't advanced.
+	s.backend.setRunning()
+	s.actionChanges <- struct{}{}
+	s.assertNotAdvanced(c)
+}
+
+func (s *workerSuite) TestFailureDoesNotStopOthers(c *gc.C) {
+	failing := state.ActionRolloutKey{ModelUUID: "model-1", Id: "0"}
+	other := state.ActionRolloutKey{ModelUUID: "model-2", Id: "0"}
+	s.backend.setRunning(failing, other)
+	s.backend.failing = failing
+	s.startWorker(c)
+
+	s.rolloutChanges <- struct{}{}
+	c.Assert(s.waitAdvanced(c), gc.Equals, failing)
+	c.Assert(s.waitAdvanced(c), gc.Equals, other)
+
+	// The failed rollout is tried again on the next change.
+	s.actionChanges <- struct{}{}
+	c.Assert(s.waitAdvanced(c), gc.Equals, failing)
+	c.Assert(s.waitAdvanced(c), gc.Equals, other)
+}
+
+// fakeBackend holds the keys of running rollouts, and records when
+// each is advanced. Advancing the failing rollout returns an error.
+type fakeBackend struct {
+	mu              sync.Mutex
+	rolloutsWatcher *watchertest.NotifyWatcher
+	actionsWatcher  *watchertest.NotifyWatcher
+	running         []state.ActionRolloutKey
+	failing         state.ActionRolloutKey
+	advanced        chan state.ActionRolloutKey
+}
+
+func (b *fakeBackend) setRunning(keys ...state.ActionRolloutKey) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.running = keys
+}
+
+func (b *fakeBackend) WatchActionRollouts() state.NotifyWatcher {
+	return b.rolloutsWatcher
+}
+
+func (b *fakeBackend) WatchAllActions() state.NotifyWatcher {
+	return b.actionsWatcher
+}
+
+func (b *fakeBackend) RunningActionRollouts() ([]state.ActionRolloutKey, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]state.ActionRolloutKey(nil), b.running...), nil
+}
+
+func (b *fakeBackend) AdvanceActionRollout(key state.ActionRolloutKey) error {
+	b.advanced <- key
+	if key == b.failing {
+		return errors.New("boom")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run an action
+// scheduler worker in a dependency.Engine.
+type ManifoldConfig struct {
+	ClockName string
+	StateName string
+
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run an action
+// scheduler worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.ClockName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	worker, err := config.NewWorker(Config{
+		Backend: &stateBackend{
+			State: statePool.SystemState(),
+			pool:  statePool,
+		},
+		Clock: clock,
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	go func() {
+		worker.Wait()
+		stTracker.Done()
+	}()
+	return worker, nil
+}
+
+// stateBackend implements Backend, finding the schedules of all
+// models through the controller's state, and running each in the
+// state of its own model.
+type stateBackend struct {
+	*state.State
+	pool *state.StatePool
+}
+
+// RunActionSchedule is part of the Backend interface.
+func (b *stateBackend) RunActionSchedule(key state.ActionScheduleKey, now time.Time) error {
+	model, ph, err := b.pool.GetModel(key.ModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer ph.Release()
+
+	sched, err := model.ActionSchedule(key.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	actions, err := sched.Run(now)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("enqueued %d %q actions on %s for schedule %q in model %s",
+		len(actions), sched.Name(), sched.Receiver(), key.Id, key.ModelUUID)
+	return nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// RetryDelay is how long the worker waits before running a schedule
+// again when it could not be run and recorded.
+const RetryDelay = time.Minute
+
+// Backend provides the action schedules of all the models on the
+// controller. (Primary implementation wraps a state pool.)
+type Backend interface {
+	// WatchActionSchedules notifies of changes to any schedule.
+	WatchActionSchedules() state.NotifyWatcher
+
+	// NextActionScheduleRun returns the earliest time an unpaused
+	// schedule should run, or the zero time if none will.
+	NextActionScheduleRun() (time.Time, error)
+
+	// DueActionSchedules returns the unpaused schedules that should
+	// have run by the given time.
+	DueActionSchedules(now time.Time) ([]state.ActionScheduleKey, error)
+
+	// RunActionSchedule enqueues the schedule's action, and records
+	// when the schedule should next run.
+	RunActionSchedule(key state.ActionScheduleKey, now time.Time) error
+}
+
+// Config holds the dependencies of an action scheduler worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that enqueues the actions of all models'
+// action schedules when they are due. This worker must not be run in
+// more than one agent concurrently.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &schedulerWorker{
+		config: config,
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type schedulerWorker struct {
+	tomb    tomb.Tomb
+	mu      sync.Mutex
+	config  Config
+	current report
+}
+
+type report struct {
+	nextRun   time.Time
+	lastRun   time.Time
+	lastError string
+}
+
+// Report is part of the dependency.Reporter interface.
+func (w *schedulerWorker) Report() map[string]interface{} {
+	w.mu.Lock()
+	report := w.current
+	w.mu.Unlock()
+
+	result := make(map[string]interface{})
+	if !report.nextRun.IsZero() {
+		result["next-run"] = report.nextRun.Round(time.Second)
+	}
+	if !report.lastRun.IsZero() {
+		result["last-run"] = report.lastRun.Round(time.Second)
+	}
+	if report.lastError != "" {
+		result["last-error"] = report.lastError
+	}
+	return result
+}
+
+func (w *schedulerWorker) loop() error {
+	schedulesWatcher := w.config.Backend.WatchActionSchedules()
+	defer worker.Stop(schedulesWatcher)
+
+	var (
+		timer clock.Timer
+		next  <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	// reschedule sets the timer for the next schedule run. After
+	// running the due schedules, any that are still due couldn't be
+	// recorded as run; they are retried after a delay.
+	reschedule := func(afterRun bool) error {
+		if timer != nil {
+			timer.Stop()
+			timer, next = nil, nil
+		}
+		nextRun, err := w.config.Backend.NextActionScheduleRun()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.mu.Lock()
+		w.current.nextRun = nextRun
+		w.mu.Unlock()
+		if nextRun.IsZero() {
+			return nil
+		}
+		delay := nextRun.Sub(w.config.Clock.Now())
+		if delay <= 0 {
+			delay = 0
+			if afterRun {
+				delay = RetryDelay
+			}
+		}
+		timer = w.config.Clock.NewTimer(delay)
+		next = timer.Chan()
+		return nil
+	}
+
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+
+		case _, ok := <-schedulesWatcher.Changes():
+			if !ok {
+				return errors.New("action schedules watcher closed")
+			}
+			if err := reschedule(false); err != nil {
+				return errors.Trace(err)
+			}
+
+		case <-next:
+			if err := w.runDue(); err != nil {
+				return errors.Trace(err)
+			}
+			if err := reschedule(true); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// runDue runs every schedule that is due. Failing to run one schedule
+// doesn't prevent the others running.
+func (w *schedulerWorker) runDue() error {
+	now := w.config.Clock.Now()
+	due, err := w.config.Backend.DueActionSchedules(now)
+	if err != nil {
+		return errors.Annotate(err, "cannot get due action schedules")
+	}
+	var lastError string
+	for _, key := range due {
+		if err := w.config.Backend.RunActionSchedule(key, now); err != nil {
+			logger.Errorf("running action schedule %q in model %s: %v", key.Id, key.ModelUUID, err)
+			lastError = err.Error()
+			continue
+		}
+		logger.Debugf("ran action schedule %q in model %s", key.Id, key.ModelUUID)
+	}
+	w.mu.Lock()
+	w.current.lastRun = now
+	w.current.lastError = lastError
+	w.mu.Unlock()
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *schedulerWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *schedulerWorker) Wait() error {
+	return w.tomb.Wait()
+}
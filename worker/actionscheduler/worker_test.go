@@ -0,0 +1,213 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	changes chan struct{}
+	backend *fakeBackend
+	config  actionscheduler.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2018, 10, 17, 12, 0, 0, 0, time.UTC))
+	s.changes = make(chan struct{}, 1)
+	s.backend = &fakeBackend{
+		watcher: watchertest.NewNotifyWatcher(s.changes),
+		ran:     make(chan state.ActionScheduleKey, 10),
+	}
+	s.config = actionscheduler.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	s.config.Backend = nil
+	_, err := actionscheduler.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := actionscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.changes <- struct{}{}
+}
+
+func (s *workerSuite) waitRan(c *gc.C) state.ActionScheduleKey {
+	select {
+	case key := <-s.backend.ran:
+		return key
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("schedule not run")
+	}
+	return state.ActionScheduleKey{}
+}
+
+func (s *workerSuite) assertNotRun(c *gc.C) {
+	select {
+	case key := <-s.backend.ran:
+		c.Fatalf("schedule %v run unexpectedly", key)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestRunsDueSchedules(c *gc.C) {
+	key := state.ActionScheduleKey{ModelUUID: "model-1", Id: "0"}
+	s.backend.add(key, s.clock.Now().Add(time.Hour), time.Hour)
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Hour-time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotRun(c)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitRan(c), gc.Equals, key)
+
+	// And again an hour later.
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitRan(c), gc.Equals, key)
+}
+
+func (s *workerSuite) TestNoSchedules(c *gc.C) {
+	s.startWorker(c)
+	err := s.clock.WaitAdvance(48*time.Hour, coretesting.ShortWait, 1)
+	c.Assert(err, gc.ErrorMatches, "got 0 timers added .*")
+	s.assertNotRun(c)
+}
+
+func (s *workerSuite) TestScheduleAdded(c *gc.C) {
+	s.startWorker(c)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.ShortWait, 1)
+	c.Assert(err, gc.ErrorMatches, "got 0 timers added .*")
+
+	key := state.ActionScheduleKey{ModelUUID: "model-1", Id: "0"}
+	s.backend.add(key, s.clock.Now().Add(time.Minute), 0)
+	s.changes <- struct{}{}
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitRan(c), gc.Equals, key)
+
+	// A one-off schedule doesn't run again.
+	err = s.clock.WaitAdvance(48*time.Hour, coretesting.ShortWait, 1)
+	c.Assert(err, gc.ErrorMatches, "got 0 timers added .*")
+}
+
+func (s *workerSuite) TestFailureDoesNotStopOthers(c *gc.C) {
+	failing := state.ActionScheduleKey{ModelUUID: "model-1", Id: "0"}
+	other := state.ActionScheduleKey{ModelUUID: "model-2", Id: "0"}
+	s.backend.add(failing, s.clock.Now().Add(time.Minute), 0)
+	s.backend.add(other, s.clock.Now().Add(time.Minute), 0)
+	s.backend.failing = failing
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitRan(c), gc.Equals, failing)
+	c.Assert(s.waitRan(c), gc.Equals, other)
+
+	// The failed schedule is still due, and is retried after a delay.
+	err = s.clock.WaitAdvance(actionscheduler.RetryDelay-time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotRun(c)
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitRan(c), gc.Equals, failing)
+}
+
+type fakeSchedule struct {
+	key      state.ActionScheduleKey
+	next     time.Time
+	interval time.Duration
+}
+
+// fakeBackend holds schedules that run once, or repeatedly at an
+// interval. Running the failing schedule leaves it due.
+type fakeBackend struct {
+	mu        sync.Mutex
+	watcher   *watchertest.NotifyWatcher
+	schedules []*fakeSchedule
+	failing   state.ActionScheduleKey
+	ran       chan state.ActionScheduleKey
+}
+
+func (b *fakeBackend) add(key state.ActionScheduleKey, next time.Time, interval time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.schedules = append(b.schedules, &fakeSchedule{key: key, next: next, interval: interval})
+}
+
+func (b *fakeBackend) WatchActionSchedules() state.NotifyWatcher {
+	return b.watcher
+}
+
+func (b *fakeBackend) NextActionScheduleRun() (time.Time, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var next time.Time
+	for _, s := range b.schedules {
+		if !s.next.IsZero() && (next.IsZero() || s.next.Before(next)) {
+			next = s.next
+		}
+	}
+	return next, nil
+}
+
+func (b *fakeBackend) DueActionSchedules(now time.Time) ([]state.ActionScheduleKey, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var due []state.ActionScheduleKey
+	for _, s := range b.schedules {
+		if !s.next.IsZero() && !s.next.After(now) {
+			due = append(due, s.key)
+		}
+	}
+	return due, nil
+}
+
+func (b *fakeBackend) RunActionSchedule(key state.ActionScheduleKey, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer func() { b.ran <- key }()
+	if key == b.failing {
+		return errors.New("boom")
+	}
+	for _, s := range b.schedules {
+		if s.key != key {
+			continue
+		}
+		if s.interval == 0 {
+			s.next = time.Time{}
+		} else {
+			s.next = now.Add(s.interval)
+		}
+	}
+	return nil
+}
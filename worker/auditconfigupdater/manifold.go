@@ -10,6 +10,9 @@ import (
 
 	jujuagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/common"
 	workerstate "github.com/juju/juju/worker/state"
 )
@@ -73,14 +76,19 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 		}
 	}()
 
-	logDir := agent.CurrentConfig().LogDir()
-
 	st := statePool.SystemState()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	source := stateConfigSource{State: st, model: model}
 
-	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+	factory := sinkFactory{
+		agentConfig: agent.CurrentConfig(),
+		st:          st,
 	}
-	auditConfig, err := initialConfig(st)
+	logFactory := factory.newLog
+	auditConfig, err := configFromSource(source)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -88,7 +96,7 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 		auditConfig.Target = logFactory(auditConfig)
 	}
 
-	w, err := config.NewWorker(st, auditConfig, logFactory)
+	w, err := config.NewWorker(source, auditConfig, logFactory)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -115,17 +123,38 @@ func output(in worker.Worker, out interface{}) error {
 	return nil
 }
 
-func initialConfig(source ConfigSource) (auditlog.Config, error) {
-	cfg, err := source.ControllerConfig()
+// stateConfigSource is the ConfigSource for a controller agent. The
+// syslog forwarding config comes from the controller model, and is nil
+// if no syslog host is set there.
+type stateConfigSource struct {
+	*state.State
+	model *state.Model
+}
+
+// WatchSyslogConfig is part of ConfigSource.
+func (s stateConfigSource) WatchSyslogConfig() state.NotifyWatcher {
+	return s.model.WatchForModelConfigChanges()
+}
+
+// SyslogConfig is part of ConfigSource.
+func (s stateConfigSource) SyslogConfig() (*syslog.RawConfig, error) {
+	modelConfig, err := s.model.ModelConfig()
 	if err != nil {
-		return auditlog.Config{}, errors.Trace(err)
+		return nil, errors.Trace(err)
+	}
+	syslogConfig, ok := modelConfig.LogFwdSyslog()
+	if !ok || syslogConfig.Host == "" {
+		// There's no syslog server to forward to.
+		return nil, nil
 	}
-	result := auditlog.Config{
-		Enabled:        cfg.AuditingEnabled(),
-		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
-		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
-		MaxBackups:     cfg.AuditLogMaxBackups(),
-		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+	return syslogConfig, nil
+}
+
+// SyslogFilter is part of ConfigSource.
+func (s stateConfigSource) SyslogFilter() (logfwd.Filter, error) {
+	modelConfig, err := s.model.ModelConfig()
+	if err != nil {
+		return logfwd.Filter{}, errors.Trace(err)
 	}
-	return result, nil
+	return modelConfig.LogFwdFilter(), nil
 }
@@ -112,7 +112,8 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 
 	args := s.stub.Calls()[0].Args
 	c.Assert(args, gc.HasLen, 3)
-	c.Assert(args[0], gc.Equals, s.State)
+	var source auditconfigupdater.ConfigSource
+	c.Assert(args[0], gc.Implements, &source)
 
 	auditConfig := args[1].(auditlog.Config)
 	target := auditConfig.Target
@@ -126,6 +127,7 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 		ExcludeMethods: set.NewStrings("This.Method"),
 		MaxSizeMB:      10,
 		MaxBackups:     10,
+		BufferSize:     1000,
 	})
 
 	c.Assert(args[2], gc.NotNil)
@@ -144,7 +146,8 @@ func (s *manifoldSuite) TestStartWithAuditingDisabled(c *gc.C) {
 
 	args := s.stub.Calls()[0].Args
 	c.Assert(args, gc.HasLen, 3)
-	c.Assert(args[0], gc.Equals, s.State)
+	var source auditconfigupdater.ConfigSource
+	c.Assert(args[0], gc.Implements, &source)
 
 	auditConfig := args[1].(auditlog.Config)
 	c.Assert(auditConfig.Target, gc.IsNil)
@@ -163,7 +166,8 @@ func (s *manifoldSuite) TestOutput(c *gc.C) {
 
 	args := s.stub.Calls()[0].Args
 	c.Assert(args, gc.HasLen, 3)
-	c.Assert(args[0], gc.Equals, s.State)
+	var source auditconfigupdater.ConfigSource
+	c.Assert(args[0], gc.Implements, &source)
 
 	auditConfig := args[1].(auditlog.Config)
 
@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditconfigupdater
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// flushTimeout is how long a sink that is being replaced or shut down
+// is given to write the records it holds before they are dropped.
+const flushTimeout = 10 * time.Second
+
+// sinkFactory creates the audit log for a controller agent: the local
+// audit log file, the audit log collection shared by the controllers,
+// along with any remote sinks enabled in the config.
+type sinkFactory struct {
+	agentConfig agent.Config
+	st          *state.State
+}
+
+// newLog is an AuditLogFactory. The shared collection and each remote
+// sink get their own buffer, so a slow or unreachable sink never blocks
+// API calls, or the other sinks. A sink that can't be set up is logged
+// and skipped rather than preventing the local audit log from being
+// written.
+func (f sinkFactory) newLog(cfg auditlog.Config) auditlog.AuditLog {
+	logs := []auditlog.AuditLog{
+		auditlog.NewLogFile(f.agentConfig.LogDir(), cfg.MaxSizeMB, cfg.MaxBackups),
+	}
+	bufferSize := cfg.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = controller.DefaultAuditLogBufferSize
+	}
+	// The records are written to the database so that the audit
+	// log can be queried through any of the controller machines.
+	logs = append(logs, auditlog.NewBufferedLog(f.st.AuditLog(), bufferSize, flushTimeout))
+	if cfg.SyslogForward {
+		syslogLog, err := f.newSyslogLog(cfg.SyslogConfig, cfg.SyslogFilter)
+		if err != nil {
+			logger.Errorf("not forwarding audit records to syslog: %v", err)
+		} else {
+			logs = append(logs, auditlog.NewBufferedLog(syslogLog, bufferSize, flushTimeout))
+		}
+	}
+	if cfg.WebhookURL != "" {
+		webhookLog := auditlog.NewWebhookLog(cfg.WebhookURL, nil)
+		logs = append(logs, auditlog.NewBufferedLog(webhookLog, bufferSize, flushTimeout))
+	}
+	return auditlog.NewMultiLog(logs...)
+}
+
+// newSyslogLog connects to the syslog server configured for the
+// controller model's log forwarding. Only the records matched by the
+// filter are sent.
+func (f sinkFactory) newSyslogLog(syslogConfig *syslog.RawConfig, filter logfwd.Filter) (auditlog.AuditLog, error) {
+	if syslogConfig == nil || syslogConfig.Host == "" {
+		return nil, errors.NotFoundf("syslog-host in controller model config")
+	}
+	tag, ok := f.agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected machine agent, got %s", f.agentConfig.Tag())
+	}
+	client, err := syslog.Open(*syslogConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to syslog")
+	}
+	origin := logfwd.OriginForMachineAgent(tag, f.st.ControllerUUID(), f.st.ModelUUID(), jujuversion.Current)
+	return auditlog.NewSyslogLog(filteredSender{client, filter}, origin), nil
+}
+
+// filteredSender sends only the records matched by its filter.
+type filteredSender struct {
+	auditlog.SyslogSender
+	filter logfwd.Filter
+}
+
+// Send is part of auditlog.SyslogSender.
+func (s filteredSender) Send(records []logfwd.Record) error {
+	if matched := s.filter.FilterRecords(records); len(matched) > 0 {
+		return errors.Trace(s.SyslogSender.Send(matched))
+	}
+	return nil
+}
@@ -4,23 +4,33 @@
 package auditconfigupdater
 
 import (
+	"reflect"
 	"sync"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/state"
 )
 
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
 // ConfigSource lets us get notifications of changes to controller
-// configuration, and then get the changed config. (Primary
-// implementation is State.)
+// configuration, and to the syslog forwarding config of the controller
+// model, and then get the changed config. (Primary implementation
+// wraps the controller's State.)
 type ConfigSource interface {
 	WatchControllerConfig() state.NotifyWatcher
 	ControllerConfig() (controller.Config, error)
+	WatchSyslogConfig() state.NotifyWatcher
+	SyslogConfig() (*syslog.RawConfig, error)
+	SyslogFilter() (logfwd.Filter, error)
 }
 
 // AuditLogFactory is a function that will return an audit log given
@@ -63,40 +73,50 @@ func (u *updater) Wait() error {
 }
 
 func (u *updater) loop() error {
-	watcher := u.source.WatchControllerConfig()
-	if err := u.catacomb.Add(watcher); err != nil {
+	controllerWatcher := u.source.WatchControllerConfig()
+	if err := u.catacomb.Add(controllerWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	syslogWatcher := u.source.WatchSyslogConfig()
+	if err := u.catacomb.Add(syslogWatcher); err != nil {
 		return errors.Trace(err)
 	}
 	for {
 		select {
 		case <-u.catacomb.Dying():
 			return u.catacomb.ErrDying()
-		case _, ok := <-watcher.Changes():
+		case _, ok := <-controllerWatcher.Changes():
+			if !ok {
+				return errors.Errorf("controller config watcher channel closed")
+			}
+		case _, ok := <-syslogWatcher.Changes():
 			if !ok {
-				return errors.Errorf("watcher channel closed")
+				return errors.Errorf("syslog config watcher channel closed")
 			}
-			newConfig, err := u.newConfig()
-			if err != nil {
-				return errors.Annotatef(err, "getting new config")
+		}
+		newConfig, err := u.newConfig()
+		if err != nil {
+			return errors.Annotatef(err, "getting new config")
+		}
+		oldTarget := u.update(newConfig)
+		if oldTarget != nil && oldTarget != newConfig.Target {
+			// The remote sinks have changed, so the existing
+			// target is closed now that the new one is in use.
+			if err := oldTarget.Close(); err != nil {
+				logger.Warningf("closing audit log: %v", err)
 			}
-			u.update(newConfig)
 		}
 	}
 }
 
 func (u *updater) newConfig() (auditlog.Config, error) {
-	cfg, err := u.source.ControllerConfig()
+	result, err := configFromSource(u.source)
 	if err != nil {
 		return auditlog.Config{}, errors.Trace(err)
 	}
-	result := auditlog.Config{
-		Enabled:        cfg.AuditingEnabled(),
-		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
-		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
-		MaxBackups:     cfg.AuditLogMaxBackups(),
-		ExcludeMethods: cfg.AuditLogExcludeMethods(),
-	}
-	if result.Enabled && u.current.Target == nil {
+	if result.Enabled && (u.current.Target == nil || sinksChanged(u.current, result)) {
+		// The existing target, if any, is closed by the
+		// caller once the new config has been published.
 		result.Target = u.logFactory(result)
 	} else {
 		// Keep the existing target to avoid file handle leaks from
@@ -107,10 +127,81 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 	return result, nil
 }
 
-func (u *updater) update(newConfig auditlog.Config) {
+// configFromSource returns the audit logging configuration from the
+// controller config and the controller model's log forwarding config,
+// without a target.
+func configFromSource(source ConfigSource) (auditlog.Config, error) {
+	cfg, err := source.ControllerConfig()
+	if err != nil {
+		return auditlog.Config{}, errors.Trace(err)
+	}
+	result := configFromController(cfg)
+	if result.SyslogConfig, err = source.SyslogConfig(); err != nil {
+		return auditlog.Config{}, errors.Trace(err)
+	}
+	filter, err := source.SyslogFilter()
+	if err != nil {
+		return auditlog.Config{}, errors.Trace(err)
+	}
+	switch {
+	case result.SyslogForward:
+		// The controller config asks for every record to be
+		// forwarded.
+		result.SyslogFilter = logfwd.Filter{IncludeAudit: true}
+	case filter.IncludeAudit && result.SyslogConfig != nil && result.SyslogConfig.Enabled:
+		// The controller model forwards its logs along with the
+		// audit records, which go through the same filter.
+		result.SyslogForward = true
+		result.SyslogFilter = filter
+	}
+	return result, nil
+}
+
+// configFromController returns the audit logging configuration in the
+// controller config, without a target.
+func configFromController(cfg controller.Config) auditlog.Config {
+	return auditlog.Config{
+		Enabled:        cfg.AuditingEnabled(),
+		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
+		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
+		MaxBackups:     cfg.AuditLogMaxBackups(),
+		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		SyslogForward:  cfg.AuditLogSyslogForward(),
+		WebhookURL:     cfg.AuditLogWebhookURL(),
+		BufferSize:     cfg.AuditLogBufferSize(),
+	}
+}
+
+// sinksChanged returns whether the remote sinks records should be
+// written to differ between the configs. For syslog, that's the server
+// address and TLS settings, and the filter applied to the records; the
+// facility is always "user". The buffer size only takes effect when the
+// sinks are next created.
+func sinksChanged(old, new auditlog.Config) bool {
+	if old.SyslogForward != new.SyslogForward || old.WebhookURL != new.WebhookURL {
+		return true
+	}
+	if !new.SyslogForward {
+		return false
+	}
+	return !syslogConfigEqual(old.SyslogConfig, new.SyslogConfig) ||
+		!reflect.DeepEqual(old.SyslogFilter, new.SyslogFilter)
+}
+
+func syslogConfigEqual(a, b *syslog.RawConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// update publishes the new config, returning the previous target.
+func (u *updater) update(newConfig auditlog.Config) auditlog.AuditLog {
 	u.mu.Lock()
 	defer u.mu.Unlock()
+	oldTarget := u.current.Target
 	u.current = newConfig
+	return oldTarget
 }
 
 // CurrentConfig returns the updater's up-to-date audit config.
@@ -6,6 +6,7 @@ package auditconfigupdater_test
 import (
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/testing"
@@ -17,6 +18,8 @@ import (
 	apitesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher/watchertest"
 	jujutesting "github.com/juju/juju/testing"
@@ -188,6 +191,177 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestChangingSinksReplacesTarget(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  oldTarget,
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-webhook-url"] = "https://siem.example.com/audit"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.WebhookURL != ""
+	})
+
+	c.Assert(newConfig.WebhookURL, gc.Equals, "https://siem.example.com/audit")
+	c.Assert(newConfig.Target, gc.Equals, auditlog.AuditLog(newTarget))
+	c.Assert(calls, gc.HasLen, 1)
+	oldTarget.CheckCallNames(c, "Close")
+}
+
+func (s *updaterSuite) TestChangingSyslogServerReplacesTarget(c *gc.C) {
+	syslogChanged := make(chan struct{}, 1)
+	oldTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled:       true,
+		SyslogForward: true,
+		SyslogConfig:  &syslog.RawConfig{Host: "10.0.0.1:6514"},
+		Target:        oldTarget,
+	}
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-syslog-forward"] = true
+	source := configSource{
+		watcher:       watchertest.NewNotifyWatcher(make(chan struct{})),
+		cfg:           cfg,
+		syslogWatcher: watchertest.NewNotifyWatcher(syslogChanged),
+		syslogConfig:  &syslog.RawConfig{Host: "10.0.0.1:6514"},
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	source.setSyslogConfig(&syslog.RawConfig{Host: "10.0.0.2:6514"})
+	syslogChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Target == auditlog.AuditLog(newTarget)
+	})
+	c.Assert(newConfig.SyslogConfig, jc.DeepEquals, &syslog.RawConfig{Host: "10.0.0.2:6514"})
+	c.Assert(calls, gc.HasLen, 1)
+	oldTarget.CheckCallNames(c, "Close")
+}
+
+func (s *updaterSuite) TestForwardingAuditRecordsFromModelConfig(c *gc.C) {
+	syslogChanged := make(chan struct{}, 1)
+	oldTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  oldTarget,
+	}
+	syslogConfig := &syslog.RawConfig{Enabled: true, Host: "10.0.0.1:6514"}
+	source := configSource{
+		watcher:       watchertest.NewNotifyWatcher(make(chan struct{})),
+		cfg:           makeControllerConfig(true, false),
+		syslogWatcher: watchertest.NewNotifyWatcher(syslogChanged),
+		syslogConfig:  syslogConfig,
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	filter := logfwd.Filter{
+		ExcludeEntity: []string{"machine-1"},
+		IncludeAudit:  true,
+	}
+	source.setSyslogFilter(filter)
+	syslogChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Target == auditlog.AuditLog(newTarget)
+	})
+	c.Assert(newConfig.SyslogForward, jc.IsTrue)
+	c.Assert(newConfig.SyslogConfig, jc.DeepEquals, syslogConfig)
+	c.Assert(newConfig.SyslogFilter, jc.DeepEquals, filter)
+	c.Assert(calls, gc.HasLen, 1)
+	oldTarget.CheckCallNames(c, "Close")
+}
+
+func (s *updaterSuite) TestReplacedTargetClosedAfterSwap(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	closed := make(chan auditlog.AuditLog, 1)
+	var w worker.Worker
+	oldTarget := &closeRecordingLog{onClose: func() {
+		// Record the target in use when the old one is closed.
+		closed <- getWorkerConfig(c, w).Target
+	}}
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  oldTarget,
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+	newTarget := &apitesting.FakeAuditLog{}
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		return newTarget
+	}
+
+	var err error
+	w, err = auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-syslog-forward"] = true
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	select {
+	case target := <-closed:
+		c.Assert(target, gc.Equals, auditlog.AuditLog(newTarget))
+	case <-time.After(jujutesting.LongWait):
+		c.Fatalf("timed out waiting for old target to be closed")
+	}
+}
+
+// closeRecordingLog calls onClose when it is closed.
+type closeRecordingLog struct {
+	apitesting.FakeAuditLog
+	onClose func()
+}
+
+func (l *closeRecordingLog) Close() error {
+	l.onClose()
+	return l.FakeAuditLog.Close()
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
@@ -209,10 +383,13 @@ func getWorkerConfig(c *gc.C, w worker.Worker) auditlog.Config {
 }
 
 type configSource struct {
-	mu      sync.Mutex
-	stub    testing.Stub
-	watcher *watchertest.NotifyWatcher
-	cfg     controller.Config
+	mu            sync.Mutex
+	stub          testing.Stub
+	watcher       *watchertest.NotifyWatcher
+	cfg           controller.Config
+	syslogWatcher *watchertest.NotifyWatcher
+	syslogConfig  *syslog.RawConfig
+	syslogFilter  logfwd.Filter
 }
 
 func (s *configSource) WatchControllerConfig() state.NotifyWatcher {
@@ -220,6 +397,40 @@ func (s *configSource) WatchControllerConfig() state.NotifyWatcher {
 	return s.watcher
 }
 
+func (s *configSource) WatchSyslogConfig() state.NotifyWatcher {
+	s.stub.AddCall("WatchSyslogConfig")
+	if s.syslogWatcher == nil {
+		return watchertest.NewNotifyWatcher(make(chan struct{}))
+	}
+	return s.syslogWatcher
+}
+
+func (s *configSource) SyslogConfig() (*syslog.RawConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stub.AddCall("SyslogConfig")
+	return s.syslogConfig, nil
+}
+
+func (s *configSource) SyslogFilter() (logfwd.Filter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stub.AddCall("SyslogFilter")
+	return s.syslogFilter, nil
+}
+
+func (s *configSource) setSyslogFilter(filter logfwd.Filter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syslogFilter = filter
+}
+
+func (s *configSource) setSyslogConfig(cfg *syslog.RawConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syslogConfig = cfg
+}
+
 func (s *configSource) ControllerConfig() (controller.Config, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -0,0 +1,115 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// stateBackups creates backups of the controller machine the agent is
+// running on, in the same way as the Backups facade.
+type stateBackups struct {
+	st          *state.State
+	agentConfig agent.Config
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create(notes string) (*backups.Metadata, error) {
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("no mongo info found in agent config")
+	}
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	meta, err := b.newMetadata(notes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	modelConfig, err := b.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	if _, err := backups.NewBackups(stor).Create(meta, &paths, dbInfo, true, true, nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// RecordFailure is part of the Backups interface.
+func (b *stateBackups) RecordFailure(notes string, failure error) error {
+	meta, err := b.newMetadata(notes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta.Failure = failure.Error()
+	finished := time.Now().UTC()
+	meta.Finished = &finished
+
+	// Only the metadata is stored, as there is no archive.
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	_, err = stor.Add(meta, nil)
+	return errors.Trace(err)
+}
+
+// newMetadata returns the metadata for a backup of the controller
+// machine the agent is running on.
+func (b *stateBackups) newMetadata(notes string) (*backups.Metadata, error) {
+	machineID := b.agentConfig.Tag().Id()
+	machine, err := b.st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.st, machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = notes
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	result, err := backups.NewBackups(stor).List()
+	return result, errors.Trace(err)
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	return errors.Trace(backups.NewBackups(stor).Remove(id))
+}
@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/juju/state/backups"
+)
+
+// expired returns the IDs of the scheduled backups that fall outside
+// the retention policy. The most recent scheduled backup of each of the
+// last keepDaily days, and of each of the last keepWeekly weeks, is
+// kept. Failed backups do not count towards either, and are kept only
+// while they are newer than the oldest backup kept. Backups not created
+// by the scheduler are never expired.
+func expired(all []*backups.Metadata, keepDaily, keepWeekly int) []string {
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if strings.HasPrefix(meta.Notes, NotesPrefix) {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	// Newest first, so the first backup seen in each
+	// day or week is the one to keep.
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+
+	type week struct{ year, week int }
+	keep := make(map[string]bool)
+	days := make(map[string]bool)
+	weeks := make(map[week]bool)
+	var oldest time.Time
+	for _, meta := range scheduled {
+		if meta.Failure != "" {
+			continue
+		}
+		started := meta.Started.UTC()
+		day := started.Format("2006-01-02")
+		if !days[day] && len(days) < keepDaily {
+			days[day] = true
+			keep[meta.ID()] = true
+		}
+		var w week
+		w.year, w.week = started.ISOWeek()
+		if !weeks[w] && len(weeks) < keepWeekly {
+			weeks[w] = true
+			keep[meta.ID()] = true
+		}
+		if keep[meta.ID()] {
+			oldest = meta.Started
+		}
+	}
+	for _, meta := range scheduled {
+		if meta.Failure != "" && (oldest.IsZero() || meta.Started.After(oldest)) {
+			keep[meta.ID()] = true
+		}
+	}
+
+	var result []string
+	for _, meta := range scheduled {
+		if !keep[meta.ID()] {
+			result = append(result, meta.ID())
+		}
+	}
+	return result
+}
@@ -0,0 +1,317 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/schedule"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// NotesPrefix starts the notes of every backup created by the
+// scheduler. Only backups with notes starting with it are
+// subject to the retention policy.
+const NotesPrefix = "scheduled backup"
+
+// ConfigSource lets us get notifications of changes to controller
+// configuration, and then get the changed config. (Primary
+// implementation is State.)
+type ConfigSource interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+}
+
+// Backups creates, lists and removes backups of the controller, and
+// records the backups that could not be created.
+type Backups interface {
+	Create(notes string) (*backups.Metadata, error)
+	RecordFailure(notes string, failure error) error
+	List() ([]*backups.Metadata, error)
+	Remove(id string) error
+}
+
+// StatusSetter gets and sets the status used to warn about failed
+// backups. (Primary implementation is the controller model.)
+type StatusSetter interface {
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+}
+
+// failurePrefix starts the status message set when a scheduled
+// backup fails. The model remains available, so the message is only
+// a warning.
+const failurePrefix = "scheduled backup failed"
+
+// Config holds the dependencies of a backup scheduler worker.
+type Config struct {
+	ConfigSource ConfigSource
+	Backups      Backups
+	Status       StatusSetter
+	Clock        clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.ConfigSource == nil {
+		return errors.NotValidf("nil ConfigSource")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Status == nil {
+		return errors.NotValidf("nil Status")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that creates backups of the controller on
+// the schedule given in controller config, removing old scheduled
+// backups according to the configured retention policy. This worker
+// must not be run in more than one agent concurrently.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &schedulerWorker{
+		config: config,
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type schedulerWorker struct {
+	tomb    tomb.Tomb
+	mu      sync.Mutex
+	config  Config
+	current report
+}
+
+type report struct {
+	schedule   string
+	keepDaily  int
+	keepWeekly int
+	lastBackup time.Time
+	lastID     string
+	lastError  string
+	nextBackup time.Time
+}
+
+// Report is part of the dependency.Reporter interface.
+func (w *schedulerWorker) Report() map[string]interface{} {
+	w.mu.Lock()
+	report := w.current
+	w.mu.Unlock()
+
+	result := map[string]interface{}{
+		"keep-daily":  report.keepDaily,
+		"keep-weekly": report.keepWeekly,
+	}
+	if report.schedule != "" {
+		result["schedule"] = report.schedule
+	}
+	if !report.lastBackup.IsZero() {
+		result["last-backup"] = report.lastBackup.Round(time.Second)
+	}
+	if report.lastID != "" {
+		result["last-backup-id"] = report.lastID
+	}
+	if report.lastError != "" {
+		result["last-error"] = report.lastError
+	}
+	if !report.nextBackup.IsZero() {
+		result["next-backup"] = report.nextBackup.Round(time.Second)
+	}
+	return result
+}
+
+func (w *schedulerWorker) loop() error {
+	controllerConfigWatcher := w.config.ConfigSource.WatchControllerConfig()
+	defer worker.Stop(controllerConfigWatcher)
+
+	var (
+		sched schedule.Schedule
+		timer clock.Timer
+		next  <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	reschedule := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, next = nil, nil
+		}
+		var nextBackup time.Time
+		if sched != nil {
+			now := w.config.Clock.Now()
+			nextBackup = sched.Next(now)
+			timer = w.config.Clock.NewTimer(nextBackup.Sub(now))
+			next = timer.Chan()
+		}
+		w.mu.Lock()
+		w.current.nextBackup = nextBackup
+		w.mu.Unlock()
+	}
+
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+
+		case _, ok := <-controllerConfigWatcher.Changes():
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			controllerConfig, err := w.config.ConfigSource.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			spec := controllerConfig.BackupSchedule()
+			w.mu.Lock()
+			changed := spec != w.current.schedule
+			w.current.schedule = spec
+			w.current.keepDaily = controllerConfig.BackupKeepDaily()
+			w.current.keepWeekly = controllerConfig.BackupKeepWeekly()
+			w.mu.Unlock()
+			if !changed && (sched != nil || spec == "") {
+				continue
+			}
+			sched = nil
+			if spec != "" {
+				// The schedule was validated when the config was set.
+				if sched, err = schedule.Parse(spec); err != nil {
+					return errors.Trace(err)
+				}
+				logger.Infof("creating backups on schedule %q", spec)
+			} else {
+				logger.Infof("scheduled backups disabled")
+			}
+			reschedule()
+
+		case <-next:
+			if err := w.backup(); err != nil {
+				logger.Errorf("%s: %v", failurePrefix, err)
+				if err := w.warnFailure(err); err != nil {
+					return errors.Annotate(err, "cannot report backup failure")
+				}
+			} else if err := w.clearFailure(); err != nil {
+				return errors.Annotate(err, "cannot clear backup failure")
+			}
+			reschedule()
+		}
+	}
+}
+
+// warnFailure adds a warning about the failed backup to the status
+// message. Other statuses, such as a migration's busy status, are left
+// alone; the failure is still recorded in the backup metadata.
+func (w *schedulerWorker) warnFailure(failure error) error {
+	info, err := w.config.Status.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.Status != status.Available {
+		return nil
+	}
+	now := w.config.Clock.Now()
+	return errors.Trace(w.config.Status.SetStatus(status.StatusInfo{
+		Status:  status.Available,
+		Message: fmt.Sprintf("%s: %v", failurePrefix, failure),
+		Data:    info.Data,
+		Since:   &now,
+	}))
+}
+
+// clearFailure removes the warning about a failed backup from the
+// status message, unless something else has set the status since.
+func (w *schedulerWorker) clearFailure() error {
+	info, err := w.config.Status.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.Status != status.Available || !strings.HasPrefix(info.Message, failurePrefix) {
+		return nil
+	}
+	now := w.config.Clock.Now()
+	return errors.Trace(w.config.Status.SetStatus(status.StatusInfo{
+		Status: status.Available,
+		Data:   info.Data,
+		Since:  &now,
+	}))
+}
+
+// backup creates a backup, then removes old scheduled backups
+// according to the retention policy.
+func (w *schedulerWorker) backup() error {
+	now := w.config.Clock.Now()
+	w.mu.Lock()
+	w.current.lastBackup = now
+	spec := w.current.schedule
+	keepDaily, keepWeekly := w.current.keepDaily, w.current.keepWeekly
+	w.mu.Unlock()
+
+	notes := fmt.Sprintf("%s (%s)", NotesPrefix, spec)
+	meta, err := w.config.Backups.Create(notes)
+	w.mu.Lock()
+	if err != nil {
+		w.current.lastError = err.Error()
+	} else {
+		w.current.lastID = meta.ID()
+		w.current.lastError = ""
+	}
+	w.mu.Unlock()
+	if err != nil {
+		if err := w.config.Backups.RecordFailure(notes, err); err != nil {
+			logger.Warningf("cannot record failed backup: %v", err)
+		}
+		return errors.Annotate(err, "creating backup")
+	}
+	logger.Infof("created scheduled backup %q", meta.ID())
+
+	if keepDaily == 0 && keepWeekly == 0 {
+		return nil
+	}
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Annotate(err, "listing backups")
+	}
+	for _, id := range expired(all, keepDaily, keepWeekly) {
+		logger.Infof("removing expired scheduled backup %q", id)
+		if err := w.config.Backups.Remove(id); err != nil {
+			return errors.Annotatef(err, "removing backup %q", id)
+		}
+	}
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *schedulerWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *schedulerWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,304 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock         *testclock.Clock
+	configChanged chan struct{}
+	source        *configSource
+	backups       *fakeBackups
+	status        *fakeStatus
+	config        backupscheduler.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2018, 10, 17, 12, 0, 0, 0, time.UTC))
+	s.configChanged = make(chan struct{}, 1)
+	s.source = &configSource{
+		watcher: watchertest.NewNotifyWatcher(s.configChanged),
+		cfg: controller.Config{
+			controller.BackupSchedule:   "0 3 * * *",
+			controller.BackupKeepDaily:  1,
+			controller.BackupKeepWeekly: 0,
+		},
+	}
+	s.backups = &fakeBackups{created: make(chan string, 10)}
+	s.status = &fakeStatus{
+		set: make(chan status.StatusInfo, 10),
+		current: status.StatusInfo{
+			Status: status.Available,
+		},
+	}
+	s.config = backupscheduler.Config{
+		ConfigSource: s.source,
+		Backups:      s.backups,
+		Status:       s.status,
+		Clock:        s.clock,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	s.config.Backups = nil
+	_, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil Backups not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.configChanged <- struct{}{}
+}
+
+func (s *workerSuite) waitCreated(c *gc.C) string {
+	select {
+	case notes := <-s.backups.created:
+		return notes
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("backup not created")
+	}
+	return ""
+}
+
+func (s *workerSuite) waitStatus(c *gc.C) status.StatusInfo {
+	select {
+	case info := <-s.status.set:
+		return info
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("status not set")
+	}
+	return status.StatusInfo{}
+}
+
+func (s *workerSuite) TestCreatesBackupOnSchedule(c *gc.C) {
+	s.startWorker(c)
+
+	// The first backup is due at 03:00 tomorrow.
+	err := s.clock.WaitAdvance(15*time.Hour-time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-s.backups.created:
+		c.Fatalf("backup created early")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.waitCreated(c), gc.Equals, "scheduled backup (0 3 * * *)")
+
+	// And the next one a day later.
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreated(c)
+
+	// Only one daily backup is kept.
+	err = s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backups.CheckCallNames(c, "Create", "List", "Create", "List", "Remove")
+	s.backups.CheckCall(c, 4, "Remove", "backup-0")
+}
+
+func (s *workerSuite) TestDisabled(c *gc.C) {
+	s.source.setConfig(controller.Config{})
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(48*time.Hour, coretesting.ShortWait, 1)
+	c.Assert(err, gc.ErrorMatches, "got 0 timers added .*")
+	s.backups.CheckNoCalls(c)
+}
+
+func (s *workerSuite) TestFailureSetsStatusWarning(c *gc.C) {
+	s.backups.SetErrors(errors.New("disk full"))
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(15*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreated(c)
+	info := s.waitStatus(c)
+	c.Assert(info.Status, gc.Equals, status.Available)
+	c.Assert(info.Message, gc.Equals, "scheduled backup failed: creating backup: disk full")
+	s.backups.CheckCall(c, 1, "RecordFailure", "scheduled backup (0 3 * * *)", "disk full")
+
+	// The next successful backup clears the warning.
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreated(c)
+	info = s.waitStatus(c)
+	c.Assert(info.Status, gc.Equals, status.Available)
+	c.Assert(info.Message, gc.Equals, "")
+}
+
+func (s *workerSuite) TestFailureLeavesOtherStatus(c *gc.C) {
+	s.status.current = status.StatusInfo{
+		Status:  status.Busy,
+		Message: "migrating",
+	}
+	s.backups.SetErrors(errors.New("disk full"))
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(15*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCreated(c)
+
+	// The failure is only recorded in the backup metadata.
+	err = s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backups.CheckCall(c, 1, "RecordFailure", "scheduled backup (0 3 * * *)", "disk full")
+	select {
+	case info := <-s.status.set:
+		c.Fatalf("status unexpectedly set to %+v", info)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestExpired(c *gc.C) {
+	backup := func(id string, started time.Time, notes string) *backups.Metadata {
+		meta := backups.NewMetadata()
+		meta.SetID(id)
+		meta.Started = started
+		meta.Notes = notes
+		return meta
+	}
+	failed := func(id string, started time.Time) *backups.Metadata {
+		meta := backup(id, started, "scheduled backup")
+		meta.Failure = "disk full"
+		return meta
+	}
+	day := func(d, h int) time.Time {
+		return time.Date(2018, 10, d, h, 0, 0, 0, time.UTC)
+	}
+	all := []*backups.Metadata{
+		backup("manual", day(1, 3), "before upgrade"),
+		backup("oct-01", day(1, 3), "scheduled backup"),
+		backup("oct-08", day(8, 3), "scheduled backup"),
+		backup("oct-14", day(14, 3), "scheduled backup"),
+		backup("oct-15", day(15, 3), "scheduled backup"),
+		backup("oct-16-early", day(16, 3), "scheduled backup"),
+		backup("oct-16", day(16, 15), "scheduled backup"),
+		backup("oct-17", day(17, 3), "scheduled backup"),
+		failed("oct-07-failed", day(7, 3)),
+		failed("oct-16-failed", day(16, 18)),
+		failed("oct-18-failed", day(18, 3)),
+	}
+	// Failed backups are kept while newer than the oldest backup kept.
+	c.Check(backupscheduler.Expired(all, 2, 0), jc.SameContents, []string{
+		"oct-15", "oct-16-early", "oct-14", "oct-08", "oct-01", "oct-07-failed",
+	})
+	// Weeks start on Monday, October 15th.
+	c.Check(backupscheduler.Expired(all, 0, 3), jc.SameContents, []string{
+		"oct-16-early", "oct-16", "oct-15", "oct-08",
+	})
+	c.Check(backupscheduler.Expired(all, 2, 3), jc.SameContents, []string{
+		"oct-16-early", "oct-15", "oct-08",
+	})
+}
+
+type configSource struct {
+	mu      sync.Mutex
+	watcher *watchertest.NotifyWatcher
+	cfg     controller.Config
+}
+
+func (s *configSource) WatchControllerConfig() state.NotifyWatcher {
+	return s.watcher
+}
+
+func (s *configSource) ControllerConfig() (controller.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg, nil
+}
+
+func (s *configSource) setConfig(cfg controller.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+}
+
+type fakeBackups struct {
+	testing.Stub
+	mu      sync.Mutex
+	created chan string
+	backups []*backups.Metadata
+}
+
+func (f *fakeBackups) Create(notes string) (*backups.Metadata, error) {
+	f.MethodCall(f, "Create", notes)
+	defer func() { f.created <- notes }()
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	meta := backups.NewMetadata()
+	meta.SetID(fmt.Sprintf("backup-%d", len(f.backups)))
+	meta.Started = time.Date(2018, 10, 17+len(f.backups), 3, 0, 0, 0, time.UTC)
+	meta.Notes = notes
+	f.backups = append(f.backups, meta)
+	return meta, nil
+}
+
+func (f *fakeBackups) RecordFailure(notes string, failure error) error {
+	f.MethodCall(f, "RecordFailure", notes, failure.Error())
+	return f.NextErr()
+}
+
+func (f *fakeBackups) List() ([]*backups.Metadata, error) {
+	f.MethodCall(f, "List")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.backups, f.NextErr()
+}
+
+func (f *fakeBackups) Remove(id string) error {
+	f.MethodCall(f, "Remove", id)
+	return f.NextErr()
+}
+
+type fakeStatus struct {
+	mu      sync.Mutex
+	set     chan status.StatusInfo
+	current status.StatusInfo
+}
+
+func (f *fakeStatus) Status() (status.StatusInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.current, nil
+}
+
+func (f *fakeStatus) SetStatus(info status.StatusInfo) error {
+	f.mu.Lock()
+	f.current = info
+	f.mu.Unlock()
+	f.set <- info
+	return nil
+}
@@ -4,6 +4,8 @@
 package caasunitprovisioner
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -12,6 +14,7 @@ import (
 	"github.com/juju/juju/api/caasunitprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -73,12 +76,13 @@ func (w *deploymentWorker) loop() error {
 		cw       watcher.NotifyWatcher
 		specChan watcher.NotifyChannel
 
-		currentScale int
-		currentSpec  string
+		currentScale   int
+		currentSpec    string
+		currentConfig  application.ConfigAttributes
+		currentService *params.UpdateApplicationServiceArg
 	)
 
 	gotSpecNotify := false
-	serviceUpdated := false
 	scale := 0
 	for {
 		select {
@@ -135,17 +139,22 @@ func (w *deploymentWorker) loop() error {
 		}
 		specStr := info.PodSpec
 
-		if scale == currentScale && specStr == currentSpec {
+		// The application config is watched with the pod spec, as it
+		// holds whether the application is trusted with the privileges
+		// the spec asks for.
+		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		configChanged := !reflect.DeepEqual(appConfig, currentConfig)
+		if scale == currentScale && specStr == currentSpec && !configChanged {
 			continue
 		}
 
 		currentScale = scale
 		currentSpec = specStr
+		currentConfig = appConfig
 
-		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
-		if err != nil {
-			return errors.Trace(err)
-		}
 		spec, err := w.broker.Provider().ParsePodSpec(specStr)
 		if err != nil {
 			return errors.Annotate(err, "cannot parse pod spec")
@@ -165,26 +174,40 @@ func (w *deploymentWorker) loop() error {
 			Filesystems:  info.Filesystems,
 			Devices:      info.Devices,
 		}
+		trustErr := spec.ValidateTrust(appConfig.GetBool(caas.JujuTrustKey, false))
 		err = w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, serviceParams, currentScale, appConfig)
-		if err != nil {
+		if err != nil && trustErr != nil {
+			// The broker has revoked the privileges the application
+			// is no longer trusted with, and set its status. Wait for
+			// it to be trusted again or its pod spec to change.
+			logger.Warningf("cannot update deployment for %s: %v", w.application, err)
+			continue
+		} else if err != nil {
 			return errors.Trace(err)
 		}
 		logger.Debugf("created/updated deployment for %s for %v units", w.application, currentScale)
-		if !serviceUpdated && !spec.OmitServiceFrontend {
+		// The ingress exposing the service is updated with the
+		// application config, so the service details, including the
+		// load balancer hostnames, are fetched again when it changes.
+		if (currentService == nil || configChanged) && !spec.OmitServiceFrontend {
 			// TODO(caas) - add a service watcher
 			service, err := w.broker.Service(w.application)
 			if err != nil && !errors.IsNotFound(err) {
 				return errors.Annotate(err, "cannot get new service details")
 			}
-			err = w.applicationUpdater.UpdateApplicationService(params.UpdateApplicationServiceArg{
+			serviceArg := params.UpdateApplicationServiceArg{
 				ApplicationTag: names.NewApplicationTag(w.application).String(),
 				ProviderId:     service.Id,
 				Addresses:      params.FromNetworkAddresses(service.Addresses...),
-			})
-			if err != nil {
+				Hostnames:      service.Hostnames,
+			}
+			if currentService != nil && reflect.DeepEqual(serviceArg, *currentService) {
+				continue
+			}
+			if err := w.applicationUpdater.UpdateApplicationService(serviceArg); err != nil {
 				return errors.Trace(err)
 			}
-			serviceUpdated = true
+			currentService = &serviceArg
 		}
 	}
 }
@@ -39,9 +39,10 @@ type fakeClient struct {
 type mockServiceBroker struct {
 	testing.Stub
 	caas.ContainerEnvironProvider
-	ensured chan<- struct{}
-	deleted chan<- struct{}
-	podSpec *caas.PodSpec
+	ensured   chan<- struct{}
+	deleted   chan<- struct{}
+	podSpec   *caas.PodSpec
+	hostnames []string
 }
 
 func (m *mockServiceBroker) Provider() caas.ContainerEnvironProvider {
@@ -66,7 +67,11 @@ func (m *mockServiceBroker) EnsureCustomResourceDefinition(appName string, podSp
 
 func (m *mockServiceBroker) Service(appName string) (*caas.Service, error) {
 	m.MethodCall(m, "Service", appName)
-	return &caas.Service{Id: "id", Addresses: []network.Address{{Value: "10.0.0.1"}}}, m.NextErr()
+	return &caas.Service{
+		Id:        "id",
+		Addresses: []network.Address{{Value: "10.0.0.1"}},
+		Hostnames: m.hostnames,
+	}, m.NextErr()
 }
 
 func (m *mockServiceBroker) DeleteService(appName string) error {
@@ -145,6 +150,7 @@ type mockApplicationGetter struct {
 	watcher      *watchertest.MockStringsWatcher
 	scaleWatcher *watchertest.MockNotifyWatcher
 	scale        int
+	config       application.ConfigAttributes
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -157,6 +163,9 @@ func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, err
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
+	if a.config != nil {
+		return a.config, a.NextErr()
+	}
 	return application.ConfigAttributes{
 		"juju-external-hostname": "exthost",
 	}, a.NextErr()
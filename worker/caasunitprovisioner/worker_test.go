@@ -367,6 +367,74 @@ containers:
 		"gitlab", expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestApplicationConfigChange(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+	config := application.ConfigAttributes{
+		"juju-external-hostname": "exthost",
+		"trust":                  true,
+	}
+	s.applicationGetter.config = config
+	s.serviceBroker.hostnames = []string{"gitlab.example.com"}
+	s.applicationUpdater.ResetCalls()
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	// The ingress load balancer hostnames are recorded.
+	select {
+	case <-s.serviceUpdated:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be updated")
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureService", "Service")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService", "gitlab", expectedServiceParams, 1, config)
+	s.applicationUpdater.CheckCallNames(c, "UpdateApplicationService")
+	s.applicationUpdater.CheckCall(c, 0, "UpdateApplicationService", params.UpdateApplicationServiceArg{
+		ApplicationTag: "application-gitlab",
+		ProviderId:     "id",
+		Addresses:      []params.Address{{Value: "10.0.0.1"}},
+		Hostnames:      []string{"gitlab.example.com"},
+	})
+}
+
+func (s *WorkerSuite) TestUntrustedPodSpecNotFatal(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+	untrustedSpec := parsedSpec
+	untrustedSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		Global: true,
+		Rules: []caas.PolicyRule{{
+			Resources: []string{"pods"},
+			Verbs:     []string{"get"},
+		}},
+	}
+	s.serviceBroker.podSpec = &untrustedSpec
+	s.serviceBroker.SetErrors(errors.New("global service account for application without trust not valid"))
+	s.podSpecGetter.setProvisioningInfo(apicaasunitprovisioner.ProvisioningInfo{
+		PodSpec: "untrusted",
+		Tags:    map[string]string{"foo": "bar"},
+	})
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	// The worker waits for the application to be trusted.
+	workertest.CheckAlive(c, w)
+}
+
 func (s *WorkerSuite) TestNewPodSpecChangeCrd(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
@@ -0,0 +1,137 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package generationpromoter
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a generation
+// promoter worker in a dependency.Engine.
+type ManifoldConfig struct {
+	ClockName string
+	StateName string
+
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a generation
+// promoter worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.ClockName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	worker, err := config.NewWorker(Config{
+		Backend: &stateBackend{
+			State: statePool.SystemState(),
+			pool:  statePool,
+		},
+		Clock:        clock,
+		PollInterval: DefaultPollInterval,
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	go func() {
+		worker.Wait()
+		stTracker.Done()
+	}()
+	return worker, nil
+}
+
+// stateBackend implements Backend, finding the generations being
+// promoted or realised in all models through the controller's state,
+// and advancing or realising each in the state of its own model.
+type stateBackend struct {
+	*state.State
+	pool *state.StatePool
+}
+
+// AdvanceGenerationPromotion is part of the Backend interface.
+func (b *stateBackend) AdvanceGenerationPromotion(key state.GenerationKey) error {
+	model, ph, err := b.pool.GetModel(key.ModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer ph.Release()
+
+	gen, err := model.Generation(key.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := gen.AdvancePromotion(); err != nil {
+		return errors.Trace(err)
+	}
+	if promotion, _ := gen.Promotion(); promotion.Status != state.PromotionRunning {
+		logger.Infof("generation %q promotion in model %s %s", key.Id, key.ModelUUID, promotion.Status)
+	}
+	return nil
+}
+
+// RealiseGeneration is part of the Backend interface.
+func (b *stateBackend) RealiseGeneration(key state.GenerationKey) error {
+	model, ph, err := b.pool.GetModel(key.ModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer ph.Release()
+
+	gen, err := model.Generation(key.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := gen.Realise(); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("generation %q changes in model %s applied", key.Id, key.ModelUUID)
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package generationpromoter
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.worker.generationpromoter")
+
+// DefaultPollInterval is how often the promotions are advanced when
+// no generation has changed, so that batches move on once they have
+// soaked and unhealthy units are noticed. Unit status changes are not
+// watched, as that would wake the worker on every status change in
+// every model on the controller.
+const DefaultPollInterval = 30 * time.Second
+
+// Backend provides the generations being promoted in all the models on
+// the controller. (Primary implementation wraps a state pool.)
+type Backend interface {
+	// WatchGenerations notifies of changes to any generation.
+	WatchGenerations() state.NotifyWatcher
+
+	// PromotingGenerations returns the generations whose promotion
+	// has yet to finish.
+	PromotingGenerations() ([]state.GenerationKey, error)
+
+	// AdvanceGenerationPromotion rolls the promotion back if any unit
+	// in the generation is unhealthy, or else moves the next batch of
+	// units into the generation once the last has soaked.
+	AdvanceGenerationPromotion(key state.GenerationKey) error
+
+	// UnrealisedGenerations returns the completed generations whose
+	// changes have yet to be applied to their applications.
+	UnrealisedGenerations() ([]state.GenerationKey, error)
+
+	// RealiseGeneration applies the changes of a completed generation
+	// to its applications.
+	RealiseGeneration(key state.GenerationKey) error
+}
+
+// Config holds the dependencies of a generation promoter worker.
+type Config struct {
+	Backend      Backend
+	Clock        clock.Clock
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that advances the automatic promotions of
+// the generations of all models, and applies the changes of completed
+// generations whose changes failed to be applied when they completed. This worker must not be run in more
+// than one agent concurrently.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &promoterWorker{
+		config: config,
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type promoterWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *promoterWorker) loop() error {
+	generationsWatcher := w.config.Backend.WatchGenerations()
+	defer worker.Stop(generationsWatcher)
+
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+
+		case _, ok := <-generationsWatcher.Changes():
+			if !ok {
+				return errors.New("generations watcher closed")
+			}
+		case <-w.config.Clock.After(w.config.PollInterval):
+		}
+		if err := w.advanceAll(); err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.realiseAll(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// advanceAll advances every running promotion. Failing to advance one
+// promotion doesn't prevent the others advancing; it is tried again on
+// the next change or poll.
+func (w *promoterWorker) advanceAll() error {
+	promoting, err := w.config.Backend.PromotingGenerations()
+	if err != nil {
+		return errors.Annotate(err, "cannot get promoting generations")
+	}
+	for _, key := range promoting {
+		if err := w.config.Backend.AdvanceGenerationPromotion(key); err != nil {
+			logger.Errorf("advancing generation %q promotion in model %s: %v", key.Id, key.ModelUUID, err)
+		}
+	}
+	return nil
+}
+
+// realiseAll applies the changes of every completed generation whose
+// changes have yet to be applied. As with promotions, a failure is
+// logged and tried again on the next change or poll.
+func (w *promoterWorker) realiseAll() error {
+	unrealised, err := w.config.Backend.UnrealisedGenerations()
+	if err != nil {
+		return errors.Annotate(err, "cannot get unrealised generations")
+	}
+	for _, key := range unrealised {
+		if err := w.config.Backend.RealiseGeneration(key); err != nil {
+			logger.Errorf("applying generation %q changes in model %s: %v", key.Id, key.ModelUUID, err)
+		}
+	}
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *promoterWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *promoterWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,229 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package generationpromoter_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/generationpromoter"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	generationChanges chan struct{}
+	clock             *testclock.Clock
+	backend           *fakeBackend
+	config            generationpromoter.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.generationChanges = make(chan struct{}, 1)
+	s.clock = testclock.NewClock(time.Now())
+	s.backend = &fakeBackend{
+		generationsWatcher: watchertest.NewNotifyWatcher(s.generationChanges),
+		advanced:           make(chan state.GenerationKey, 10),
+		realised:           make(chan state.GenerationKey, 10),
+	}
+	s.config = generationpromoter.Config{
+		Backend:      s.backend,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	for _, test := range []struct {
+		mutate func(*generationpromoter.Config)
+		err    string
+	}{{
+		mutate: func(config *generationpromoter.Config) { config.Backend = nil },
+		err:    "nil Backend not valid",
+	}, {
+		mutate: func(config *generationpromoter.Config) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		mutate: func(config *generationpromoter.Config) { config.PollInterval = 0 },
+		err:    "non-positive PollInterval not valid",
+	}} {
+		config := s.config
+		test.mutate(&config)
+		_, err := generationpromoter.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := generationpromoter.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *workerSuite) waitAdvanced(c *gc.C) state.GenerationKey {
+	select {
+	case key := <-s.backend.advanced:
+		return key
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("promotion not advanced")
+	}
+	return state.GenerationKey{}
+}
+
+func (s *workerSuite) assertNotAdvanced(c *gc.C) {
+	select {
+	case key := <-s.backend.advanced:
+		c.Fatalf("promotion %v advanced unexpectedly", key)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestAdvancesOnGenerationChange(c *gc.C) {
+	key := state.GenerationKey{ModelUUID: "model-1", Id: "1"}
+	s.backend.setPromoting(key)
+	s.startWorker(c)
+	s.assertNotAdvanced(c)
+
+	s.generationChanges <- struct{}{}
+	c.Assert(s.waitAdvanced(c), gc.Equals, key)
+	s.assertNotAdvanced(c)
+}
+
+func (s *workerSuite) TestFinishedPromotionsNotAdvanced(c *gc.C) {
+	key := state.GenerationKey{ModelUUID: "model-1", Id: "1"}
+	s.backend.setPromoting(key)
+	s.startWorker(c)
+
+	s.generationChanges <- struct{}{}
+	c.Assert(s.waitAdvanced(c), gc.Equals, key)
+
+	s.backend.setPromoting()
+	s.generationChanges <- struct{}{}
+	s.assertNotAdvanced(c)
+}
+
+func (s *workerSuite) TestAdvancesOnPoll(c *gc.C) {
+	key := state.GenerationKey{ModelUUID: "model-1", Id: "1"}
+	s.backend.setPromoting(key)
+	s.startWorker(c)
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.waitAdvanced(c), gc.Equals, key)
+}
+
+func (s *workerSuite) TestFailureDoesNotStopOthers(c *gc.C) {
+	failing := state.GenerationKey{ModelUUID: "model-1", Id: "1"}
+	other := state.GenerationKey{ModelUUID: "model-2", Id: "1"}
+	s.backend.setPromoting(failing, other)
+	s.backend.failing = failing
+	s.startWorker(c)
+
+	s.generationChanges <- struct{}{}
+	c.Assert(s.waitAdvanced(c), gc.Equals, failing)
+	c.Assert(s.waitAdvanced(c), gc.Equals, other)
+
+	// The failed promotion is tried again on the next change.
+	s.generationChanges <- struct{}{}
+	c.Assert(s.waitAdvanced(c), gc.Equals, failing)
+	c.Assert(s.waitAdvanced(c), gc.Equals, other)
+}
+
+func (s *workerSuite) TestRealisesUnrealised(c *gc.C) {
+	failing := state.GenerationKey{ModelUUID: "model-1", Id: "1"}
+	other := state.GenerationKey{ModelUUID: "model-2", Id: "1"}
+	s.backend.setUnrealised(failing, other)
+	s.backend.failing = failing
+	s.startWorker(c)
+
+	s.generationChanges <- struct{}{}
+	c.Assert(s.waitRealised(c), gc.Equals, failing)
+	c.Assert(s.waitRealised(c), gc.Equals, other)
+
+	// The failed generation is tried again on the next poll.
+	s.backend.setUnrealised(failing)
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.waitRealised(c), gc.Equals, failing)
+	s.assertNotAdvanced(c)
+}
+
+func (s *workerSuite) waitRealised(c *gc.C) state.GenerationKey {
+	select {
+	case key := <-s.backend.realised:
+		return key
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("generation not realised")
+	}
+	return state.GenerationKey{}
+}
+
+// fakeBackend holds the keys of generations being promoted or
+// realised, and records when each is advanced or realised. Advancing
+// or realising the failing generation returns an error.
+type fakeBackend struct {
+	mu                 sync.Mutex
+	generationsWatcher *watchertest.NotifyWatcher
+	promoting          []state.GenerationKey
+	unrealised         []state.GenerationKey
+	failing            state.GenerationKey
+	advanced           chan state.GenerationKey
+	realised           chan state.GenerationKey
+}
+
+func (b *fakeBackend) setUnrealised(keys ...state.GenerationKey) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.unrealised = keys
+}
+
+func (b *fakeBackend) setPromoting(keys ...state.GenerationKey) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.promoting = keys
+}
+
+func (b *fakeBackend) WatchGenerations() state.NotifyWatcher {
+	return b.generationsWatcher
+}
+
+func (b *fakeBackend) PromotingGenerations() ([]state.GenerationKey, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]state.GenerationKey(nil), b.promoting...), nil
+}
+
+func (b *fakeBackend) AdvanceGenerationPromotion(key state.GenerationKey) error {
+	b.advanced <- key
+	if key == b.failing {
+		return errors.New("boom")
+	}
+	return nil
+}
+
+func (b *fakeBackend) UnrealisedGenerations() ([]state.GenerationKey, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]state.GenerationKey(nil), b.unrealised...), nil
+}
+
+func (b *fakeBackend) RealiseGeneration(key state.GenerationKey) error {
+	b.realised <- key
+	if key == b.failing {
+		return errors.New("boom")
+	}
+	return nil
+}
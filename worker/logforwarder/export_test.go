@@ -0,0 +1,14 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"gopkg.in/juju/worker.v1"
+)
+
+// NewOrchestratorForController exposes newOrchestratorForController
+// for testing.
+func NewOrchestratorForController(args OrchestratorArgs) (worker.Worker, error) {
+	return newOrchestratorForController(args)
+}
@@ -71,6 +71,10 @@ type OpenLogForwarderArgs struct {
 	// will be wrapped.
 	OpenSink LogSinkFn
 
+	// OpenHTTPSink, if set, is used instead of OpenSink to open the
+	// underlying log sink, using the HTTP log forward configuration.
+	OpenHTTPSink HTTPLogSinkFn
+
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
 	OpenLogStream LogStreamFn
@@ -91,20 +95,20 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	sinkArgs, enabled, err := lf.trackingSinkArgs()
 	if err != nil {
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
-		logger.Infof("config change - log forwarding not enabled")
+	if !enabled {
+		logger.Infof("config change - log forwarding to %s not enabled", lf.args.Name)
 		return nil, closeExisting()
 	}
 	// If the config is not valid, we don't want to exit with an error
 	// and bounce the worker; we'll just log the issue and wait for another
 	// config change to come through.
 	// We'll continue sending using the current sink.
-	if err := cfg.Validate(); err != nil {
+	if err := sinkArgs.validate(); err != nil {
 		logger.Errorf("invalid log forward config change: %v", err)
 		return currentSender, nil
 	}
@@ -113,12 +117,7 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	if err := closeExisting(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	sink, err := OpenTrackingSink(TrackingSinkArgs{
-		Name:     lf.args.Name,
-		Config:   cfg,
-		Caller:   lf.args.Caller,
-		OpenSink: lf.args.OpenSink,
-	})
+	sink, err := OpenTrackingSink(sinkArgs)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -126,6 +125,38 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	return sink, nil
 }
 
+// trackingSinkArgs reads the current config for the forwarder's sink,
+// returning the args to open it and whether forwarding is enabled.
+func (lf *LogForwarder) trackingSinkArgs() (TrackingSinkArgs, bool, error) {
+	args := TrackingSinkArgs{
+		Name:         lf.args.Name,
+		Caller:       lf.args.Caller,
+		OpenSink:     lf.args.OpenSink,
+		OpenHTTPSink: lf.args.OpenHTTPSink,
+	}
+	filter, err := lf.args.LogForwardConfig.LogForwardFilter()
+	if err != nil {
+		return args, false, errors.Trace(err)
+	}
+	args.Filter = filter
+	if lf.args.OpenHTTPSink != nil {
+		cfg, ok, err := lf.args.LogForwardConfig.HTTPLogForwardConfig()
+		if err != nil {
+			return args, false, errors.Trace(err)
+		}
+		args.HTTPConfig = cfg
+		return args, ok && cfg.Enabled, nil
+	}
+	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	if err != nil {
+		return args, false, errors.Trace(err)
+	}
+	args.Config = cfg
+	// Forwarding may be enabled for an HTTP sink alone, in
+	// which case no syslog host is configured.
+	return args, ok && cfg.Enabled && cfg.Host != "", nil
+}
+
 // waitForEnabled returns true if streaming is enabled.
 // Otherwise if blocks and waits for enabled to be true.
 func (lf *LogForwarder) waitForEnabled() (bool, error) {
@@ -145,7 +176,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %s", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
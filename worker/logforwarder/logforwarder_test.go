@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
@@ -113,6 +114,59 @@ func (s *LogForwarderSuite) TestOne(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestHTTPSink(c *gc.C) {
+	s.stream.addRecords(c, s.rec)
+	api := &mockLogForwardConfig{
+		enabled: true,
+		url:     "https://logs.example.com/ingest",
+	}
+	args := s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender)
+	args.OpenSink = func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+		c.Fatalf("unexpected syslog sink opened")
+		return nil, nil
+	}
+	args.OpenHTTPSink = func(cfg *httpjson.RawConfig) (*logforwarder.LogSink, error) {
+		s.sender.host = cfg.URL
+		return &logforwarder.LogSink{s.sender}, nil
+	}
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+	expected := s.rec
+	expected.Message = "send to https://logs.example.com/ingest"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{expected}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestFilter(c *gc.C) {
+	debugRec := s.rec
+	debugRec.Level = loggo.DEBUG
+	infoRec := s.rec
+	infoRec.ID = 11
+
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+		filter:  logfwd.Filter{MinLevel: loggo.INFO},
+	}
+	s.stream.addRecords(c, debugRec, infoRec)
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{infoRec}}},
+		{"Close", nil},
+	})
+}
+
 func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	rec0 := s.rec
 	rec1 := s.rec
@@ -209,6 +263,8 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 type mockLogForwardConfig struct {
 	enabled bool
 	host    string
+	url     string
+	filter  logfwd.Filter
 	changes chan struct{}
 }
 
@@ -258,6 +314,20 @@ func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, erro
 	}, true, nil
 }
 
+func (c *mockLogForwardConfig) HTTPLogForwardConfig() (*httpjson.RawConfig, bool, error) {
+	if c.url == "" {
+		return nil, false, nil
+	}
+	return &httpjson.RawConfig{
+		Enabled: c.enabled,
+		URL:     c.url,
+	}, true, nil
+}
+
+func (c *mockLogForwardConfig) LogForwardFilter() (logfwd.Filter, error) {
+	return c.filter, nil
+}
+
 type stubStream struct {
 	stub     *testing.Stub
 	nextRecs chan logfwd.Record
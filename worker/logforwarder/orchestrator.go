@@ -5,12 +5,32 @@ package logforwarder
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/api/base"
 )
 
+// orchestrator runs a LogForwarder for each log sink. If any of the
+// forwarders stops, they are all stopped along with the orchestrator,
+// so that the dependency engine restarts them.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
+}
+
+func (o *orchestrator) loop() error {
+	<-o.catacomb.Dying()
+	return o.catacomb.ErrDying()
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -38,21 +58,38 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
+	// Each sink gets its own forwarder, so that the records sent to
+	// each are tracked separately.
 	if len(args.Sinks) == 0 {
 		return nil, nil
 	}
-	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
+	var forwarders []worker.Worker
+	for _, sink := range args.Sinks {
+		lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
+			ControllerUUID:   args.ControllerUUID,
+			LogForwardConfig: args.LogForwardConfig,
+			Caller:           args.Caller,
+			Name:             sink.Name,
+			OpenSink:         sink.OpenFn,
+			OpenHTTPSink:     sink.OpenHTTPFn,
+			OpenLogStream:    args.OpenLogStream,
+		})
+		if err != nil {
+			for _, forwarder := range forwarders {
+				worker.Stop(forwarder)
+			}
+			return nil, errors.Annotatef(err, "opening log forwarder for %q", sink.Name)
+		}
+		forwarders = append(forwarders, lf)
 	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
+	o := &orchestrator{}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
+		Init: forwarders,
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
 }
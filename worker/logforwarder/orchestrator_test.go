@@ -0,0 +1,38 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+func (s *LogForwarderSuite) TestOrchestratorStopsWhenAForwarderStops(c *gc.C) {
+	failure := errors.New("<failure>")
+	failing := newStubStream()
+	failing.stub.SetErrors(failure)
+	streams := map[string]*stubStream{
+		"good": s.stream,
+		"bad":  failing,
+	}
+
+	w, err := logforwarder.NewOrchestratorForController(logforwarder.OrchestratorArgs{
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		Sinks:          []logforwarder.LogSinkSpec{{Name: "good"}, {Name: "bad"}},
+		OpenLogForwarder: func(args logforwarder.OpenLogForwarderArgs) (*logforwarder.LogForwarder, error) {
+			return logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, streams[args.Name], newStubSender()))
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	// The failed forwarder stops the orchestrator, and with it the
+	// other forwarder, so the dependency engine can restart them.
+	err = workertest.CheckKilled(c, w)
+	c.Check(errors.Cause(err), gc.Equals, failure)
+}
@@ -5,6 +5,8 @@ package logforwarder
 
 import (
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -16,19 +18,35 @@ type LogForwardConfig interface {
 
 	// LogForwardConfig returns the current log forward configuration.
 	LogForwardConfig() (*syslog.RawConfig, bool, error)
+
+	// HTTPLogForwardConfig returns the current HTTP log forward
+	// configuration.
+	HTTPLogForwardConfig() (*httpjson.RawConfig, bool, error)
+
+	// LogForwardFilter returns the filter applied to log records
+	// before they are forwarded.
+	LogForwardFilter() (logfwd.Filter, error)
 }
 
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
 
-	// OpenFn is a function that opens a log sink.
+	// OpenFn is a function that opens a syslog log sink.
 	OpenFn LogSinkFn
+
+	// OpenHTTPFn, if set, is used instead of OpenFn to open a log
+	// sink configured by the HTTP log forward configuration.
+	OpenHTTPFn HTTPLogSinkFn
 }
 
 // LogSinkFn is a function that opens a log sink.
 type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
 
+// HTTPLogSinkFn is a function that opens a log sink which forwards
+// to an HTTP endpoint.
+type HTTPLogSinkFn func(cfg *httpjson.RawConfig) (*LogSink, error)
+
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
 	SendCloser
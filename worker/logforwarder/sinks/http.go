@@ -0,0 +1,26 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTP returns a sink used to post log messages to be forwarded
+// to an HTTP endpoint as newline-delimited JSON.
+func OpenHTTP(cfg *httpjson.RawConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := httpjson.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type HTTPSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HTTPSuite{})
+
+func (s *HTTPSuite) TestOpenHTTP(c *gc.C) {
+	sink, err := sinks.OpenHTTP(&httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com/ingest",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.SendCloser, gc.FitsTypeOf, &httpjson.Client{})
+}
+
+func (s *HTTPSuite) TestOpenHTTPNotEnabled(c *gc.C) {
+	_, err := sinks.OpenHTTP(&httpjson.RawConfig{
+		URL: "https://logs.example.com/ingest",
+	})
+	c.Assert(err, gc.ErrorMatches, "log forwarding not enabled")
+}
+
+func (s *HTTPSuite) TestOpenHTTPInvalid(c *gc.C) {
+	_, err := sinks.OpenHTTP(&httpjson.RawConfig{
+		Enabled: true,
+		URL:     "ftp://logs.example.com",
+	})
+	c.Assert(err, gc.ErrorMatches, `URL scheme "ftp" not valid`)
+}
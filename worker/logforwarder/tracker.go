@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -18,6 +19,14 @@ type TrackingSinkArgs struct {
 	// Config is the logging config that will be used.
 	Config *syslog.RawConfig
 
+	// HTTPConfig is the HTTP logging config that will be used
+	// if OpenHTTPSink is set.
+	HTTPConfig *httpjson.RawConfig
+
+	// Filter determines which records are sent to the sink. Records
+	// which are filtered out are still tracked as sent.
+	Filter logfwd.Filter
+
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
@@ -27,12 +36,33 @@ type TrackingSinkArgs struct {
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
+
+	// OpenHTTPSink, if set, is used instead of OpenSink to open the
+	// underlying log sink.
+	OpenHTTPSink HTTPLogSinkFn
+}
+
+// validate ensures that the config for the sink to be opened is valid.
+func (args TrackingSinkArgs) validate() error {
+	if err := args.Filter.Validate(); err != nil {
+		return errors.Annotate(err, "validating filter")
+	}
+	if args.OpenHTTPSink != nil {
+		return errors.Trace(args.HTTPConfig.Validate())
+	}
+	return errors.Trace(args.Config.Validate())
 }
 
 // OpenTrackingSink opens a log record sender to use with a worker.
 // The sender also tracks records that were successfully sent.
 func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
-	sink, err := args.OpenSink(args.Config)
+	var sink *LogSink
+	var err error
+	if args.OpenHTTPSink != nil {
+		sink, err = args.OpenHTTPSink(args.HTTPConfig)
+	} else {
+		sink, err = args.OpenSink(args.Config)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -40,6 +70,7 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 	return &LogSink{
 		&trackingSender{
 			SendCloser: sink,
+			filter:     args.Filter,
 			tracker:    newLastSentTracker(args.Name, args.Caller),
 		},
 	}, nil
@@ -47,14 +78,19 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 
 type trackingSender struct {
 	SendCloser
+	filter  logfwd.Filter
 	tracker *lastSentTracker
 }
 
 // Send implements Sender.
 func (s *trackingSender) Send(records []logfwd.Record) error {
-	if err := s.SendCloser.Send(records); err != nil {
-		return errors.Trace(err)
+	if matched := s.filter.FilterRecords(records); len(matched) > 0 {
+		if err := s.SendCloser.Send(matched); err != nil {
+			return errors.Trace(err)
+		}
 	}
+	// Record the whole batch as sent, so filtered records aren't
+	// streamed again when forwarding resumes.
 	if err := s.tracker.setLastSent(records); err != nil {
 		return errors.Trace(err)
 	}
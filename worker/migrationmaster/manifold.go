@@ -12,7 +12,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
-	"github.com/juju/juju/migration"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/worker/fortress"
 )
 
@@ -79,7 +79,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		Facade:          facade,
 		Guard:           guard,
 		APIOpen:         api.Open,
-		UploadBinaries:  migration.UploadBinaries,
+		UploadBinaries:  coremigration.UploadBinaries,
 		CharmDownloader: apiClient,
 		ToolsDownloader: apiClient,
 		Clock:           config.Clock,
@@ -11,7 +11,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api"
-	"github.com/juju/juju/migration"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/migrationmaster"
 )
@@ -81,9 +81,9 @@ func validConfig() migrationmaster.Config {
 		Guard:           struct{ fortress.Guard }{},
 		Facade:          struct{ migrationmaster.Facade }{},
 		APIOpen:         func(*api.Info, api.DialOpts) (api.Connection, error) { return nil, nil },
-		UploadBinaries:  func(migration.UploadBinariesConfig) error { return nil },
-		CharmDownloader: struct{ migration.CharmDownloader }{},
-		ToolsDownloader: struct{ migration.ToolsDownloader }{},
+		UploadBinaries:  func(coremigration.UploadBinariesConfig) error { return nil },
+		CharmDownloader: struct{ coremigration.CharmDownloader }{},
+		ToolsDownloader: struct{ coremigration.ToolsDownloader }{},
 		Clock:           struct{ clock.Clock }{},
 	}
 }
@@ -96,6 +96,10 @@ type Facade interface {
 	// connection.
 	Reap() error
 
+	// StartQueuedMigrations starts the queued migrations in the
+	// batch that the finished migration was part of, if any.
+	StartQueuedMigrations() error
+
 	// WatchMinionReports returns a watcher which reports when a migration
 	// minion has made a report for the current migration phase.
 	WatchMinionReports() (watcher.NotifyWatcher, error)
@@ -117,9 +121,9 @@ type Config struct {
 	Facade          Facade
 	Guard           fortress.Guard
 	APIOpen         func(*api.Info, api.DialOpts) (api.Connection, error)
-	UploadBinaries  func(migration.UploadBinariesConfig) error
-	CharmDownloader migration.CharmDownloader
-	ToolsDownloader migration.ToolsDownloader
+	UploadBinaries  func(coremigration.UploadBinariesConfig) error
+	CharmDownloader coremigration.CharmDownloader
+	ToolsDownloader coremigration.ToolsDownloader
 	Clock           clock.Clock
 }
 
@@ -252,6 +256,9 @@ func (w *Worker) run() error {
 			return errors.Annotate(err, "failed to set phase")
 		}
 		status.Phase = phase
+		if phase.IsTerminal() {
+			w.startQueuedMigrations()
+		}
 
 		if modelHasMigrated(phase) {
 			return ErrMigrated
@@ -263,6 +270,15 @@ func (w *Worker) run() error {
 	}
 }
 
+// startQueuedMigrations lets the queued migrations in the finished
+// migration's batch start, if it was part of one. Failing to start them
+// doesn't affect this model's migration, so errors are only logged.
+func (w *Worker) startQueuedMigrations() {
+	if err := w.config.Facade.StartQueuedMigrations(); err != nil {
+		w.logger.Warningf("failed to start queued migrations: %v", err)
+	}
+}
+
 func (w *Worker) killed() bool {
 	select {
 	case <-w.catacomb.Dying():
@@ -416,7 +432,7 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 
 	w.setInfoStatus("uploading model binaries into target controller")
 	wrapper := &uploadWrapper{targetClient, modelUUID}
-	err = w.config.UploadBinaries(migration.UploadBinariesConfig{
+	err = w.config.UploadBinaries(coremigration.UploadBinariesConfig{
 		Charms:          serialized.Charms,
 		CharmDownloader: w.config.CharmDownloader,
 		CharmUploader:   wrapper,
@@ -664,7 +680,10 @@ func (w *Worker) waitForActiveMigration() (coremigration.MigrationStatus, error)
 		case params.IsCodeNotFound(err):
 			// There's never been a migration.
 		case err == nil && status.Phase.IsTerminal():
-			// No migration in progress.
+			// No migration in progress. The worker may have
+			// been stopped before the next migrations in the
+			// batch were started, so make sure they are.
+			w.startQueuedMigrations()
 			if modelHasMigrated(status.Phase) {
 				return empty, ErrMigrated
 			}
@@ -148,6 +148,7 @@ var (
 		abortCall,
 		apiCloseCall,
 		{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+		{"facade.StartQueuedMigrations", nil},
 	}
 	openDestLogStreamCall = jujutesting.StubCall{"ConnectControllerStream", []interface{}{
 		"/migrate/logtransfer",
@@ -284,6 +285,7 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 			// REAP
 			{"facade.Reap", nil},
 			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+			{"facade.StartQueuedMigrations", nil},
 		}),
 	)
 }
@@ -310,6 +312,7 @@ func (s *Suite) TestMigrationResume(c *gc.C) {
 			{"facade.SetPhase", []interface{}{coremigration.REAP}},
 			{"facade.Reap", nil},
 			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+			{"facade.StartQueuedMigrations", nil},
 		},
 	))
 }
@@ -324,6 +327,7 @@ func (s *Suite) TestPreviouslyAbortedMigration(c *gc.C) {
 	s.waitForStubCalls(c, []string{
 		"facade.Watch",
 		"facade.MigrationStatus",
+		"facade.StartQueuedMigrations",
 		"guard.Unlock",
 	})
 }
@@ -334,9 +338,19 @@ func (s *Suite) TestPreviouslyCompletedMigration(c *gc.C) {
 	s.stub.CheckCalls(c, []jujutesting.StubCall{
 		{"facade.Watch", nil},
 		{"facade.MigrationStatus", nil},
+		{"facade.StartQueuedMigrations", nil},
 	})
 }
 
+func (s *Suite) TestStartQueuedMigrationsFailure(c *gc.C) {
+	// Failing to start the rest of the batch doesn't stop the
+	// worker from noticing that the model has migrated.
+	s.facade.startQueuedErr = errors.New("boom")
+	s.facade.queueStatus(s.makeStatus(coremigration.DONE))
+	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
+	s.stub.CheckCallNames(c, "facade.Watch", "facade.MigrationStatus", "facade.StartQueuedMigrations")
+}
+
 func (s *Suite) TestWatchFailure(c *gc.C) {
 	s.facade.watchErr = errors.New("boom")
 	s.checkWorkerErr(c, "watching for migration: boom")
@@ -504,6 +518,7 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 			{"facade.SetPhase", []interface{}{coremigration.ABORT}},
 			apiOpenControllerCall,
 			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+			{"facade.StartQueuedMigrations", nil},
 		},
 	))
 }
@@ -652,6 +667,7 @@ func (s *Suite) TestSUCCESSMinionWaitFailedMachine(c *gc.C) {
 			{"facade.SetPhase", []interface{}{coremigration.REAP}},
 			{"facade.Reap", nil},
 			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+			{"facade.StartQueuedMigrations", nil},
 		},
 	))
 }
@@ -683,6 +699,7 @@ func (s *Suite) TestSUCCESSMinionWaitFailedUnit(c *gc.C) {
 			{"facade.SetPhase", []interface{}{coremigration.REAP}},
 			{"facade.Reap", nil},
 			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+			{"facade.StartQueuedMigrations", nil},
 		},
 	))
 }
@@ -724,6 +741,7 @@ func (s *Suite) TestSUCCESSMinionWaitTimeout(c *gc.C) {
 			{"facade.SetPhase", []interface{}{coremigration.REAP}},
 			{"facade.Reap", nil},
 			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+			{"facade.StartQueuedMigrations", nil},
 		},
 	))
 }
@@ -788,6 +806,7 @@ func (s *Suite) TestAPIConnectWithMacaroon(c *gc.C) {
 			abortCall,
 			apiCloseCall,
 			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+			{"facade.StartQueuedMigrations", nil},
 		},
 	))
 }
@@ -902,6 +921,7 @@ func (s *Suite) TestLogTransferSendsRecords(c *gc.C) {
 			{"facade.SetPhase", []interface{}{coremigration.REAP}},
 			{"facade.Reap", nil},
 			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+			{"facade.StartQueuedMigrations", nil},
 		},
 	))
 	c.Assert(s.connection.logStream.written, gc.DeepEquals, []params.LogRecord{
@@ -968,6 +988,7 @@ func (s *Suite) TestLogTransfer_ChecksLatestTime(c *gc.C) {
 			{"facade.SetPhase", []interface{}{coremigration.REAP}},
 			{"facade.Reap", nil},
 			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+			{"facade.StartQueuedMigrations", nil},
 		},
 	))
 }
@@ -1074,9 +1095,10 @@ type stubMasterFacade struct {
 	status         []coremigration.MigrationStatus
 	statusErr      error
 
-	prechecksErr error
-	modelInfoErr error
-	exportErr    error
+	prechecksErr   error
+	modelInfoErr   error
+	exportErr      error
+	startQueuedErr error
 
 	logMessages func(chan<- common.LogMessage)
 	streamErr   error
@@ -1208,6 +1230,11 @@ func (f *stubMasterFacade) Reap() error {
 	return nil
 }
 
+func (f *stubMasterFacade) StartQueuedMigrations() error {
+	f.stub.AddCall("facade.StartQueuedMigrations")
+	return f.startQueuedErr
+}
+
 func (f *stubMasterFacade) StreamModelLog(start time.Time) (<-chan common.LogMessage, error) {
 	f.stub.AddCall("StreamModelLog", start)
 	if f.streamErr != nil {
@@ -1315,8 +1342,8 @@ func (c *stubConnection) ConnectControllerStream(path string, attrs url.Values,
 	return c.logStream, nil
 }
 
-func makeStubUploadBinaries(stub *jujutesting.Stub) func(migration.UploadBinariesConfig) error {
-	return func(config migration.UploadBinariesConfig) error {
+func makeStubUploadBinaries(stub *jujutesting.Stub) func(coremigration.UploadBinariesConfig) error {
+	return func(config coremigration.UploadBinariesConfig) error {
 		stub.AddCall(
 			"UploadBinaries",
 			config.Charms,
@@ -1332,13 +1359,13 @@ func makeStubUploadBinaries(stub *jujutesting.Stub) func(migration.UploadBinarie
 
 // nullUploadBinaries is a UploadBinaries variant which is intended to
 // not get called.
-func nullUploadBinaries(migration.UploadBinariesConfig) error {
+func nullUploadBinaries(coremigration.UploadBinariesConfig) error {
 	panic("should not get called")
 }
 
-var fakeCharmDownloader = struct{ migration.CharmDownloader }{}
+var fakeCharmDownloader = struct{ coremigration.CharmDownloader }{}
 
-var fakeToolsDownloader = struct{ migration.ToolsDownloader }{}
+var fakeToolsDownloader = struct{ coremigration.ToolsDownloader }{}
 
 func joinCalls(allCalls ...[]jujutesting.StubCall) (out []jujutesting.StubCall) {
 	for _, calls := range allCalls {
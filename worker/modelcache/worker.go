@@ -245,18 +245,164 @@ func (c *cacheWorker) translate(d multiwatcher.Delta) interface{} {
 			c.config.Logger.Errorf("unexpected type %T", d.Entity)
 			return nil
 		}
+		var profile *cache.LXDProfile
+		if value.CharmLXDProfile != nil {
+			profile = &cache.LXDProfile{
+				Config:      value.CharmLXDProfile.Config,
+				Description: value.CharmLXDProfile.Description,
+				Devices:     value.CharmLXDProfile.Devices,
+			}
+		}
 		return cache.ApplicationChange{
+			ModelUUID:            value.ModelUUID,
+			Name:                 value.Name,
+			Exposed:              value.Exposed,
+			CharmURL:             value.CharmURL,
+			Life:                 life.Value(value.Life),
+			MinUnits:             value.MinUnits,
+			Constraints:          value.Constraints,
+			Config:               value.Config,
+			Subordinate:          value.Subordinate,
+			Status:               coreStatus(value.Status),
+			WorkloadVersion:      value.WorkloadVersion,
+			Series:               value.Series,
+			EndpointBindings:     value.EndpointBindings,
+			CharmVersion:         value.CharmVersion,
+			CharmLXDProfile:      profile,
+			MetricsPlanRequired:  value.MetricsPlanRequired,
+			HasMetricCredentials: value.HasMetricCredentials,
+			LatestCharmURL:       value.LatestCharmURL,
+			CharmModifiedVersion: value.CharmModifiedVersion,
+			ForceCharm:           value.ForceCharm,
+		}
+	case "machine":
+		if d.Removed {
+			return cache.RemoveMachine{
+				ModelUUID: id.ModelUUID,
+				Id:        id.Id,
+			}
+		}
+		value, ok := d.Entity.(*multiwatcher.MachineInfo)
+		if !ok {
+			c.config.Logger.Errorf("unexpected type %T", d.Entity)
+			return nil
+		}
+		jobs := make([]string, len(value.Jobs))
+		for i, job := range value.Jobs {
+			jobs[i] = string(job)
+		}
+		addresses := make([]cache.Address, len(value.Addresses))
+		for i, addr := range value.Addresses {
+			addresses[i] = cache.Address{
+				Value: addr.Value,
+				Type:  addr.Type,
+				Scope: addr.Scope,
+			}
+		}
+		interfaces := make([]cache.NetworkInterface, len(value.NetworkInterfaces))
+		for i, iface := range value.NetworkInterfaces {
+			interfaces[i] = cache.NetworkInterface{
+				DeviceName:     iface.DeviceName,
+				MACAddress:     iface.MACAddress,
+				IsUp:           iface.IsUp,
+				IPAddresses:    iface.IPAddresses,
+				Gateways:       iface.Gateways,
+				DNSNameservers: iface.DNSNameservers,
+				Spaces:         iface.Spaces,
+			}
+		}
+		return cache.MachineChange{
+			ModelUUID:                value.ModelUUID,
+			Id:                       value.Id,
+			InstanceId:               value.InstanceId,
+			DisplayName:              value.DisplayName,
+			AgentStatus:              coreStatus(value.AgentStatus),
+			InstanceStatus:           coreStatus(value.InstanceStatus),
+			AgentVersion:             value.AgentStatus.Version,
+			Life:                     life.Value(value.Life),
+			Series:                   value.Series,
+			SupportedContainers:      value.SupportedContainers,
+			SupportedContainersKnown: value.SupportedContainersKnown,
+			HardwareCharacteristics:  value.HardwareCharacteristics,
+			Jobs:                     jobs,
+			Addresses:                addresses,
+			HasVote:                  value.HasVote,
+			WantsVote:                value.WantsVote,
+			Constraints:              value.Constraints,
+			CharmProfiles:            value.CharmProfiles,
+			NetworkInterfaces:        interfaces,
+		}
+	case "unit":
+		if d.Removed {
+			return cache.RemoveUnit{
+				ModelUUID: id.ModelUUID,
+				Name:      id.Id,
+			}
+		}
+		value, ok := d.Entity.(*multiwatcher.UnitInfo)
+		if !ok {
+			c.config.Logger.Errorf("unexpected type %T", d.Entity)
+			return nil
+		}
+		portRanges := make([]cache.PortRange, len(value.PortRanges))
+		for i, pr := range value.PortRanges {
+			portRanges[i] = cache.PortRange{
+				FromPort: pr.FromPort,
+				ToPort:   pr.ToPort,
+				Protocol: pr.Protocol,
+			}
+		}
+		return cache.UnitChange{
 			ModelUUID:       value.ModelUUID,
 			Name:            value.Name,
-			Exposed:         value.Exposed,
+			Application:     value.Application,
+			Series:          value.Series,
 			CharmURL:        value.CharmURL,
 			Life:            life.Value(value.Life),
-			MinUnits:        value.MinUnits,
-			Constraints:     value.Constraints,
-			Config:          value.Config,
+			PublicAddress:   value.PublicAddress,
+			PrivateAddress:  value.PrivateAddress,
+			MachineId:       value.MachineId,
+			PortRanges:      portRanges,
+			Principal:       value.Principal,
+			Subordinates:    value.Subordinates,
 			Subordinate:     value.Subordinate,
-			Status:          coreStatus(value.Status),
+			WorkloadStatus:  coreStatus(value.WorkloadStatus),
+			AgentStatus:     coreStatus(value.AgentStatus),
+			AgentVersion:    value.AgentStatus.Version,
 			WorkloadVersion: value.WorkloadVersion,
+			Resolved:        value.Resolved,
+		}
+	case "relation":
+		if d.Removed {
+			return cache.RemoveRelation{
+				ModelUUID: id.ModelUUID,
+				Key:       id.Id,
+			}
+		}
+		value, ok := d.Entity.(*multiwatcher.RelationInfo)
+		if !ok {
+			c.config.Logger.Errorf("unexpected type %T", d.Entity)
+			return nil
+		}
+		endpoints := make([]cache.RelationEndpoint, len(value.Endpoints))
+		for i, ep := range value.Endpoints {
+			endpoints[i] = cache.RelationEndpoint{
+				Application: ep.ApplicationName,
+				Name:        ep.Relation.Name,
+				Role:        ep.Relation.Role,
+				Interface:   ep.Relation.Interface,
+				Optional:    ep.Relation.Optional,
+				Limit:       ep.Relation.Limit,
+				Scope:       ep.Relation.Scope,
+			}
+		}
+		return cache.RelationChange{
+			ModelUUID: value.ModelUUID,
+			Key:       value.Key,
+			Id:        value.Id,
+			Life:      life.Value(value.Life),
+			Endpoints: endpoints,
+			Status:    coreStatus(value.Status),
 		}
 	default:
 		return nil
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/juju/worker.v1/workertest"
 
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -287,6 +288,10 @@ func (s *WorkerSuite) TestAddApplication(c *gc.C) {
 	obtained, ok := change.(cache.ApplicationChange)
 	c.Assert(ok, jc.IsTrue)
 	c.Check(obtained.Name, gc.Equals, app.Name())
+	c.Check(obtained.Series, gc.Equals, app.Series())
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(obtained.EndpointBindings, jc.DeepEquals, bindings)
 
 	controller := s.getController(c, w)
 	modUUIDs := controller.ModelUUIDs()
@@ -329,6 +334,100 @@ func (s *WorkerSuite) TestRemoveApplication(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) captureMachineEvents(c *gc.C) <-chan interface{} {
+	events := make(chan interface{})
+	s.notify = func(change interface{}) {
+		send := false
+		switch change.(type) {
+		case cache.MachineChange:
+			send = true
+		case cache.RemoveMachine:
+			send = true
+		default:
+			// no-op
+		}
+		if send {
+			c.Logf("sending %#v", change)
+			select {
+			case events <- change:
+			case <-time.After(testing.LongWait):
+				c.Fatalf("change not processed by test")
+			}
+		}
+	}
+	return events
+}
+
+func (s *WorkerSuite) TestAddMachine(c *gc.C) {
+	changes := s.captureMachineEvents(c)
+	w := s.start(c)
+
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	s.State.StartSync()
+
+	change := s.nextChange(c, changes)
+	obtained, ok := change.(cache.MachineChange)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obtained.Id, gc.Equals, machine.Id())
+	c.Check(obtained.Constraints.String(), gc.Equals, "mem=4096M")
+
+	controller := s.getController(c, w)
+	mod, err := controller.Model(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	cachedMachine, err := mod.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cachedMachine.Details().Series, gc.Equals, machine.Series())
+}
+
+func (s *WorkerSuite) captureUnitEvents(c *gc.C) <-chan interface{} {
+	events := make(chan interface{})
+	s.notify = func(change interface{}) {
+		send := false
+		switch change.(type) {
+		case cache.UnitChange:
+			send = true
+		case cache.RemoveUnit:
+			send = true
+		default:
+			// no-op
+		}
+		if send {
+			c.Logf("sending %#v", change)
+			select {
+			case events <- change:
+			case <-time.After(testing.LongWait):
+				c.Fatalf("change not processed by test")
+			}
+		}
+	}
+	return events
+}
+
+func (s *WorkerSuite) TestAddUnit(c *gc.C) {
+	changes := s.captureUnitEvents(c)
+	w := s.start(c)
+
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{})
+	s.State.StartSync()
+
+	change := s.nextChange(c, changes)
+	obtained, ok := change.(cache.UnitChange)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obtained.Name, gc.Equals, unit.Name())
+	c.Check(obtained.Life, gc.Equals, life.Alive)
+
+	controller := s.getController(c, w)
+	mod, err := controller.Model(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	cachedUnit, err := mod.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cachedUnit.Application(), gc.Equals, unit.ApplicationName())
+}
+
 type noopRegisterer struct {
 	prometheus.Registerer
 }
@@ -117,8 +117,11 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 		}
 	}
 
-	params := action.Params()
-	if err := spec.ValidateParams(params); err != nil {
+	// Check the parameters the same way the controller did when the
+	// action was enqueued, in case the charm has since changed, so
+	// action-get sees the parameters with the schema's defaults.
+	params, err := actions.ValidateParams(name, spec, action.Params())
+	if err != nil {
 		return nil, charmrunner.NewBadActionError(name, err.Error())
 	}
 
@@ -1343,7 +1343,7 @@ func (s *UniterSuite) TestActionEvents(c *gc.C) {
 				name:    "snapshot",
 				results: map[string]interface{}{},
 				status:  params.ActionFailed,
-				message: `cannot run "snapshot" action: validation failed: (root).outfile : must be of type string, given 2`,
+				message: `cannot run "snapshot" action: invalid parameters for action "snapshot": (root).outfile : must be of type string, given 2`,
 			}}},
 			waitUnitAgent{status: status.Idle},
 			waitUnitAgent{